@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+
 	"UMKMGo-backend/config/db"
 	"UMKMGo-backend/config/env"
 	"UMKMGo-backend/config/log"
@@ -8,6 +10,7 @@ import (
 	"UMKMGo-backend/config/storage"
 	"UMKMGo-backend/config/vault"
 	"UMKMGo-backend/interface/http/router"
+	"UMKMGo-backend/interface/worker"
 )
 
 func init() {
@@ -47,6 +50,13 @@ func main() {
 
 	r := router.SetupRouter() // Set up the HTTP router
 
+	worker.StartSLAMonitor(context.Background(), db.DB, redis.GetRedisRepository(), env.Cfg.SLAMonitor)                            // Flag applications past their SLA
+	worker.StartDraftExpiry(context.Background(), db.DB, redis.GetRedisRepository())                                               // Discard idle application drafts
+	worker.StartDocumentRetention(context.Background(), db.DB, redis.GetRedisRepository(), storage.MinioClient, env.Cfg.Documents) // Remove files of superseded document versions
+	worker.StartCertificationReminder(context.Background(), db.DB, redis.GetRedisRepository(), env.Cfg.Certifications)             // Remind UMKMs of certificates nearing expiry
+	worker.StartKartuQRMigration(context.Background(), db.DB, redis.GetRedisRepository(), storage.MinioClient, env.Cfg.Kartu)      // Replace QR codes holding the plaintext kartu number
+	worker.StartKartuExpiry(context.Background(), db.DB, redis.GetRedisRepository(), storage.MinioClient, env.Cfg.Kartu)           // Mark kartu past their expiry date as expired
+
 	r.Listen(":" + env.Cfg.Server.Port)
 	log.Info("Starting HTTP server on port " + env.Cfg.Server.Port)
 }
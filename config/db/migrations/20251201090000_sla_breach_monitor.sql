@@ -0,0 +1,54 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TYPE application_history_action ADD VALUE IF NOT EXISTS 'sla_breached';
+ALTER TYPE application_history_action ADD VALUE IF NOT EXISTS 'escalate';
+ALTER TYPE application_history_action ADD VALUE IF NOT EXISTS 'auto_reject';
+ALTER TYPE notification_type ADD VALUE IF NOT EXISTS 'auto_rejected';
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE applications
+    ADD COLUMN is_overdue BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN overdue_at TIMESTAMPTZ,
+    ADD COLUMN escalated_at TIMESTAMPTZ;
+
+CREATE INDEX idx_applications_status_expired_at ON applications(status, expired_at) WHERE deleted_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TABLE admin_notifications (
+    id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    application_id INT REFERENCES applications(id) ON DELETE SET NULL,
+    type VARCHAR(50) NOT NULL, -- 'sla_breached', 'sla_escalated', etc
+    title VARCHAR(255) NOT NULL,
+    message TEXT NOT NULL,
+    is_read BOOLEAN DEFAULT FALSE,
+    read_at TIMESTAMPTZ,
+    metadata JSONB,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_admin_notifications_user_id ON admin_notifications(user_id);
+CREATE INDEX idx_admin_notifications_is_read ON admin_notifications(is_read);
+CREATE INDEX idx_admin_notifications_created_at ON admin_notifications(created_at DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_admin_notifications_created_at;
+DROP INDEX IF EXISTS idx_admin_notifications_is_read;
+DROP INDEX IF EXISTS idx_admin_notifications_user_id;
+DROP TABLE IF EXISTS admin_notifications;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_applications_status_expired_at;
+ALTER TABLE applications
+    DROP COLUMN IF EXISTS escalated_at,
+    DROP COLUMN IF EXISTS overdue_at,
+    DROP COLUMN IF EXISTS is_overdue;
+-- Enum values added above cannot be dropped in PostgreSQL and are left in place
+-- +goose StatementEnd
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE holidays (
+    id SERIAL PRIMARY KEY,
+    date DATE NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    is_collective_leave BOOLEAN NOT NULL DEFAULT FALSE, -- cuti bersama
+    description TEXT,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX idx_holidays_date ON holidays(date) WHERE deleted_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE slas
+    ADD COLUMN day_type VARCHAR(20) NOT NULL DEFAULT 'calendar',
+    ADD CONSTRAINT check_day_type CHECK (day_type IN ('calendar', 'working'));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE slas DROP CONSTRAINT IF EXISTS check_day_type;
+ALTER TABLE slas DROP COLUMN IF EXISTS day_type;
+DROP TABLE IF EXISTS holidays;
+-- +goose StatementEnd
@@ -0,0 +1,48 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE slas DROP CONSTRAINT IF EXISTS slas_status_key;
+
+ALTER TABLE slas
+    ADD COLUMN application_type program_type,
+    ADD COLUMN program_id INT REFERENCES programs(id) ON DELETE CASCADE,
+    ADD COLUMN effective_from TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    ADD COLUMN created_by INT REFERENCES users(id) ON DELETE SET NULL;
+
+-- The seeded defaults have always applied, so they cover every existing application
+UPDATE slas SET effective_from = '1970-01-01 00:00:00+00' WHERE application_type IS NULL AND program_id IS NULL;
+
+CREATE UNIQUE INDEX idx_slas_scope_effective_from
+    ON slas(status, COALESCE(application_type::text, ''), COALESCE(program_id, 0), effective_from)
+    WHERE deleted_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TABLE sla_change_logs (
+    id SERIAL PRIMARY KEY,
+    sla_id INT NOT NULL REFERENCES slas(id) ON DELETE CASCADE,
+    action VARCHAR(20) NOT NULL, -- 'create', 'update', 'delete'
+    old_value JSONB,
+    new_value JSONB,
+    changed_by INT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_sla_change_logs_sla_id ON sla_change_logs(sla_id);
+CREATE INDEX idx_sla_change_logs_created_at ON sla_change_logs(created_at DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS sla_change_logs;
+DROP INDEX IF EXISTS idx_slas_scope_effective_from;
+DELETE FROM slas WHERE application_type IS NOT NULL OR program_id IS NOT NULL;
+DELETE FROM slas a USING slas b WHERE a.status = b.status AND a.effective_from < b.effective_from;
+ALTER TABLE slas
+    DROP COLUMN IF EXISTS created_by,
+    DROP COLUMN IF EXISTS effective_from,
+    DROP COLUMN IF EXISTS program_id,
+    DROP COLUMN IF EXISTS application_type;
+ALTER TABLE slas ADD CONSTRAINT slas_status_key UNIQUE (status);
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE applications
+    ADD COLUMN version INT NOT NULL DEFAULT 1;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE applications
+    DROP COLUMN IF EXISTS version;
+-- +goose StatementEnd
@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS idx_applications_created_at ON applications(created_at DESC, id DESC) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_applications_submitted_at ON applications(submitted_at) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_applications_program_id ON applications(program_id) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_applications_umkm_id ON applications(umkm_id) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_application_documents_application_id ON application_documents(application_id);
+CREATE INDEX IF NOT EXISTS idx_application_histories_application_id ON application_histories(application_id);
+CREATE INDEX IF NOT EXISTS idx_umkms_province_city ON umkms(province_id, city_id);
+CREATE INDEX IF NOT EXISTS idx_umkms_kartu_type ON umkms(kartu_type);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+-- Trigram indexes keep ILIKE '%term%' searches off sequential scans
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+CREATE INDEX IF NOT EXISTS idx_umkms_business_name_trgm ON umkms USING gin (business_name gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_users_name_trgm ON users USING gin (name gin_trgm_ops);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_users_name_trgm;
+DROP INDEX IF EXISTS idx_umkms_business_name_trgm;
+DROP INDEX IF EXISTS idx_umkms_kartu_type;
+DROP INDEX IF EXISTS idx_umkms_province_city;
+DROP INDEX IF EXISTS idx_application_histories_application_id;
+DROP INDEX IF EXISTS idx_application_documents_application_id;
+DROP INDEX IF EXISTS idx_applications_umkm_id;
+DROP INDEX IF EXISTS idx_applications_program_id;
+DROP INDEX IF EXISTS idx_applications_submitted_at;
+DROP INDEX IF EXISTS idx_applications_created_at;
+-- pg_trgm is left installed, other objects may depend on it
+-- +goose StatementEnd
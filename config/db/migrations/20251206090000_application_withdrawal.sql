@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TYPE application_status ADD VALUE IF NOT EXISTS 'withdrawn';
+ALTER TYPE application_history_action ADD VALUE IF NOT EXISTS 'withdraw';
+ALTER TYPE notification_type ADD VALUE IF NOT EXISTS 'application_withdrawn';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- Enum values cannot be dropped in PostgreSQL; move withdrawn rows back to a
+-- terminal status so the values are unused
+UPDATE applications SET status = 'rejected' WHERE status = 'withdrawn';
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TYPE application_status ADD VALUE IF NOT EXISTS 'draft';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- Enum values cannot be dropped in PostgreSQL; discard unsubmitted drafts so the
+-- value is unused
+UPDATE applications SET deleted_at = NOW() WHERE status = 'draft' AND deleted_at IS NULL;
+-- +goose StatementEnd
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TYPE document_review_status AS ENUM ('pending', 'accepted', 'rejected');
+
+ALTER TABLE application_documents
+    ADD COLUMN review_status document_review_status NOT NULL DEFAULT 'pending',
+    ADD COLUMN review_reason TEXT,
+    ADD COLUMN reviewed_by INT REFERENCES users(id) ON DELETE SET NULL,
+    ADD COLUMN reviewed_at TIMESTAMP;
+
+-- Form fields the reviewer asked to change, comma separated
+ALTER TABLE applications ADD COLUMN revision_fields TEXT NOT NULL DEFAULT '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE applications DROP COLUMN IF EXISTS revision_fields;
+
+ALTER TABLE application_documents
+    DROP COLUMN IF EXISTS reviewed_at,
+    DROP COLUMN IF EXISTS reviewed_by,
+    DROP COLUMN IF EXISTS review_reason,
+    DROP COLUMN IF EXISTS review_status;
+
+DROP TYPE IF EXISTS document_review_status;
+-- +goose StatementEnd
@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE application_documents
+    ADD COLUMN version INT NOT NULL DEFAULT 1,
+    ADD COLUMN previous_id INT REFERENCES application_documents(id) ON DELETE SET NULL,
+    ADD COLUMN revision_round INT NOT NULL DEFAULT 0,
+    ADD COLUMN superseded_at TIMESTAMP,
+    ADD COLUMN purged_at TIMESTAMP;
+
+-- Current documents are the ones not superseded by a newer upload
+CREATE INDEX IF NOT EXISTS idx_application_documents_current ON application_documents(application_id, type) WHERE superseded_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_application_documents_superseded_at ON application_documents(superseded_at) WHERE superseded_at IS NOT NULL AND purged_at IS NULL;
+
+-- Number of times the application was resubmitted after a revision request
+ALTER TABLE applications ADD COLUMN revision_round INT NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE applications DROP COLUMN IF EXISTS revision_round;
+
+DROP INDEX IF EXISTS idx_application_documents_superseded_at;
+DROP INDEX IF EXISTS idx_application_documents_current;
+
+-- Only the current version of each document survives the rollback
+DELETE FROM application_documents WHERE superseded_at IS NOT NULL;
+
+ALTER TABLE application_documents
+    DROP COLUMN IF EXISTS purged_at,
+    DROP COLUMN IF EXISTS superseded_at,
+    DROP COLUMN IF EXISTS revision_round,
+    DROP COLUMN IF EXISTS previous_id,
+    DROP COLUMN IF EXISTS version;
+-- +goose StatementEnd
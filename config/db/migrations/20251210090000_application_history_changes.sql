@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Form fields corrected with the action, each with its previous and new value
+ALTER TABLE application_histories ADD COLUMN changes JSONB;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE application_histories DROP COLUMN IF EXISTS changes;
+-- +goose StatementEnd
@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Seats for approved participants and the total fund pool of funding programs;
+-- NULL means unlimited
+ALTER TABLE programs
+    ADD COLUMN capacity INT CHECK (capacity > 0),
+    ADD COLUMN fund_pool NUMERIC(15,2) CHECK (fund_pool > 0);
+
+ALTER TYPE application_status ADD VALUE IF NOT EXISTS 'waitlisted';
+ALTER TYPE application_history_action ADD VALUE IF NOT EXISTS 'waitlist';
+ALTER TYPE application_history_action ADD VALUE IF NOT EXISTS 'promote_from_waitlist';
+ALTER TYPE notification_type ADD VALUE IF NOT EXISTS 'application_waitlisted';
+
+-- Waitlist order within a program
+ALTER TABLE applications ADD COLUMN waitlisted_at TIMESTAMP;
+CREATE INDEX IF NOT EXISTS idx_applications_waitlist ON applications(program_id, status, waitlisted_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- Enum values cannot be dropped in PostgreSQL; waitlisted applications go back
+-- to the final stage so the value is unused
+UPDATE applications SET status = 'final' WHERE status = 'waitlisted';
+
+DROP INDEX IF EXISTS idx_applications_waitlist;
+ALTER TABLE applications DROP COLUMN IF EXISTS waitlisted_at;
+
+ALTER TABLE programs
+    DROP COLUMN IF EXISTS fund_pool,
+    DROP COLUMN IF EXISTS capacity;
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Structured eligibility rules of the program, checked when an application is submitted
+ALTER TABLE programs ADD COLUMN eligibility_rules JSONB;
+
+-- Outcome of the eligibility rules at the last submission, shown to reviewers
+ALTER TABLE applications ADD COLUMN eligibility JSONB;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE applications DROP COLUMN IF EXISTS eligibility;
+ALTER TABLE programs DROP COLUMN IF EXISTS eligibility_rules;
+-- +goose StatementEnd
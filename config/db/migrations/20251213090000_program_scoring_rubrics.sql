@@ -0,0 +1,47 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Criteria reviewers score applications of a program on; a score is normalized
+-- over [min_score, max_score] and weighted into the application total
+CREATE TABLE IF NOT EXISTS program_rubric_criteria (
+    id SERIAL PRIMARY KEY,
+    program_id INT NOT NULL REFERENCES programs(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    weight NUMERIC(5,2) NOT NULL CHECK (weight > 0),
+    min_score NUMERIC(7,2) NOT NULL DEFAULT 0,
+    max_score NUMERIC(7,2) NOT NULL,
+    sort_order INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW(),
+    deleted_at TIMESTAMP,
+    CHECK (max_score > min_score)
+);
+CREATE INDEX IF NOT EXISTS idx_program_rubric_criteria_program ON program_rubric_criteria(program_id);
+
+-- One score per criterion and application, kept with the reviewer who gave it
+CREATE TABLE IF NOT EXISTS application_scores (
+    id SERIAL PRIMARY KEY,
+    application_id INT NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+    criterion_id INT NOT NULL REFERENCES program_rubric_criteria(id) ON DELETE CASCADE,
+    score NUMERIC(7,2) NOT NULL,
+    notes TEXT,
+    scored_by INT NOT NULL REFERENCES users(id),
+    scored_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW(),
+    deleted_at TIMESTAMP,
+    UNIQUE (application_id, criterion_id)
+);
+
+-- Weighted total on a 0-100 scale, set once every criterion is scored
+ALTER TABLE applications ADD COLUMN score_total NUMERIC(5,2);
+CREATE INDEX IF NOT EXISTS idx_applications_ranking ON applications(program_id, score_total DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_applications_ranking;
+ALTER TABLE applications DROP COLUMN IF EXISTS score_total;
+DROP TABLE IF EXISTS application_scores;
+DROP TABLE IF EXISTS program_rubric_criteria;
+-- +goose StatementEnd
@@ -0,0 +1,71 @@
+-- +goose Up
+-- +goose StatementBegin
+-- How a funding program charges interest; NULL means annuity
+ALTER TABLE programs ADD COLUMN interest_method VARCHAR(20) CHECK (interest_method IN ('flat', 'annuity'));
+
+CREATE TYPE loan_status AS ENUM ('active', 'paid_off', 'cancelled');
+CREATE TYPE loan_installment_status AS ENUM ('unpaid', 'partial', 'paid');
+
+-- The loan opened when a funding application is approved, with the terms it
+-- was scheduled on
+CREATE TABLE IF NOT EXISTS loans (
+    id SERIAL PRIMARY KEY,
+    application_id INT NOT NULL UNIQUE REFERENCES applications(id) ON DELETE CASCADE,
+    principal NUMERIC(15,2) NOT NULL,
+    interest_rate NUMERIC(5,2) NOT NULL DEFAULT 0,
+    interest_method VARCHAR(20) NOT NULL,
+    tenure_months INT NOT NULL CHECK (tenure_months > 0),
+    total_interest NUMERIC(15,2) NOT NULL,
+    total_payable NUMERIC(15,2) NOT NULL,
+    paid_amount NUMERIC(15,2) NOT NULL DEFAULT 0,
+    status loan_status NOT NULL DEFAULT 'active',
+    start_date DATE NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW(),
+    deleted_at TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS loan_installments (
+    id SERIAL PRIMARY KEY,
+    loan_id INT NOT NULL REFERENCES loans(id) ON DELETE CASCADE,
+    number INT NOT NULL,
+    due_date DATE NOT NULL,
+    principal NUMERIC(15,2) NOT NULL,
+    interest NUMERIC(15,2) NOT NULL,
+    amount NUMERIC(15,2) NOT NULL,
+    paid_amount NUMERIC(15,2) NOT NULL DEFAULT 0,
+    status loan_installment_status NOT NULL DEFAULT 'unpaid',
+    paid_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW(),
+    deleted_at TIMESTAMP,
+    UNIQUE (loan_id, number)
+);
+-- Overdue installments are the unpaid ones past their due date
+CREATE INDEX IF NOT EXISTS idx_loan_installments_due ON loan_installments(due_date) WHERE status <> 'paid';
+
+CREATE TABLE IF NOT EXISTS loan_repayments (
+    id SERIAL PRIMARY KEY,
+    loan_id INT NOT NULL REFERENCES loans(id) ON DELETE CASCADE,
+    installment_id INT NOT NULL REFERENCES loan_installments(id) ON DELETE CASCADE,
+    amount NUMERIC(15,2) NOT NULL CHECK (amount > 0),
+    paid_at TIMESTAMP NOT NULL,
+    reference VARCHAR(100),
+    notes TEXT,
+    recorded_by INT NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW(),
+    deleted_at TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_loan_repayments_loan ON loan_repayments(loan_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS loan_repayments;
+DROP TABLE IF EXISTS loan_installments;
+DROP TABLE IF EXISTS loans;
+DROP TYPE IF EXISTS loan_installment_status;
+DROP TYPE IF EXISTS loan_status;
+ALTER TABLE programs DROP COLUMN IF EXISTS interest_method;
+-- +goose StatementEnd
@@ -0,0 +1,63 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Share of sessions a participant must attend to complete a training; NULL means all
+ALTER TABLE programs ADD COLUMN min_attendance_percent INT CHECK (min_attendance_percent BETWEEN 1 AND 100);
+
+CREATE TYPE attendance_status AS ENUM ('present', 'absent', 'excused');
+ALTER TYPE notification_type ADD VALUE IF NOT EXISTS 'certificate_issued';
+
+-- Sessions of a training program. Hybrid programs mix online and offline
+-- sessions, so a session itself is never hybrid.
+CREATE TABLE IF NOT EXISTS training_sessions (
+    id SERIAL PRIMARY KEY,
+    program_id INT NOT NULL REFERENCES programs(id) ON DELETE CASCADE,
+    title VARCHAR(255) NOT NULL,
+    mode training_type NOT NULL CHECK (mode <> 'hybrid'),
+    starts_at TIMESTAMP NOT NULL,
+    ends_at TIMESTAMP NOT NULL,
+    location VARCHAR(255),
+    meeting_url VARCHAR(255),
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW(),
+    deleted_at TIMESTAMP,
+    CHECK (ends_at > starts_at)
+);
+CREATE INDEX IF NOT EXISTS idx_training_sessions_program ON training_sessions(program_id, starts_at);
+
+CREATE TABLE IF NOT EXISTS training_attendances (
+    id SERIAL PRIMARY KEY,
+    session_id INT NOT NULL REFERENCES training_sessions(id) ON DELETE CASCADE,
+    application_id INT NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+    status attendance_status NOT NULL,
+    checked_in_at TIMESTAMP,
+    notes TEXT,
+    recorded_by INT REFERENCES users(id),
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW(),
+    deleted_at TIMESTAMP,
+    UNIQUE (session_id, application_id)
+);
+
+-- Issued once per approved training application when its participant completes
+CREATE TABLE IF NOT EXISTS training_certificates (
+    id SERIAL PRIMARY KEY,
+    application_id INT NOT NULL UNIQUE REFERENCES applications(id) ON DELETE CASCADE,
+    certificate_number VARCHAR(50) NOT NULL UNIQUE,
+    verification_code VARCHAR(32) NOT NULL UNIQUE,
+    attendance_percent NUMERIC(5,2) NOT NULL,
+    file_url VARCHAR(255),
+    issued_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW(),
+    deleted_at TIMESTAMP
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS training_certificates;
+DROP TABLE IF EXISTS training_attendances;
+DROP TABLE IF EXISTS training_sessions;
+DROP TYPE IF EXISTS attendance_status;
+ALTER TABLE programs DROP COLUMN IF EXISTS min_attendance_percent;
+-- +goose StatementEnd
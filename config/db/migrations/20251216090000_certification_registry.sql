@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TYPE notification_type ADD VALUE IF NOT EXISTS 'certification_recorded';
+ALTER TYPE notification_type ADD VALUE IF NOT EXISTS 'certification_expiring';
+
+-- The certificate a UMKM earned through an approved certification application,
+-- as issued by the program's provider
+CREATE TABLE IF NOT EXISTS certifications (
+    id SERIAL PRIMARY KEY,
+    certification_application_id INT NOT NULL UNIQUE REFERENCES certification_applications(id) ON DELETE CASCADE,
+    certificate_number VARCHAR(100) NOT NULL UNIQUE,
+    issuing_body VARCHAR(255) NOT NULL,
+    issued_date DATE NOT NULL,
+    -- NULL for certificates that do not expire
+    expiry_date DATE CHECK (expiry_date > issued_date),
+    document_url VARCHAR(255) NOT NULL,
+    verification_code VARCHAR(32) NOT NULL UNIQUE,
+    qr_code VARCHAR(255),
+    -- The last expiry reminder sent, in days before expiry
+    reminded_days INT,
+    recorded_by INT NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW(),
+    deleted_at TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_certifications_expiry ON certifications(expiry_date) WHERE expiry_date IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS certifications;
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TYPE decrypt_purpose ADD VALUE IF NOT EXISTS 'kartu_verification';
+
+-- Version of the signed token in the UMKM's kartu QR code. Rotating the QR code
+-- bumps it and revokes older tokens; 0 marks a QR code that still holds the
+-- plaintext kartu number and has to be regenerated.
+ALTER TABLE umkms ADD COLUMN IF NOT EXISTS qr_token_version INT NOT NULL DEFAULT 0;
+CREATE INDEX IF NOT EXISTS idx_umkms_legacy_qr ON umkms(id) WHERE qr_token_version = 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_umkms_legacy_qr;
+ALTER TABLE umkms DROP COLUMN IF EXISTS qr_token_version;
+-- +goose StatementEnd
@@ -0,0 +1,78 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TYPE kartu_status AS ENUM ('active', 'suspended', 'expired');
+CREATE TYPE kartu_history_action AS ENUM ('renewed', 'suspended', 'reactivated', 'upgraded', 'expired');
+CREATE TYPE kartu_renewal_status AS ENUM ('pending', 'approved', 'rejected');
+
+ALTER TYPE notification_type ADD VALUE IF NOT EXISTS 'kartu_renewed';
+ALTER TYPE notification_type ADD VALUE IF NOT EXISTS 'kartu_renewal_rejected';
+ALTER TYPE notification_type ADD VALUE IF NOT EXISTS 'kartu_suspended';
+ALTER TYPE notification_type ADD VALUE IF NOT EXISTS 'kartu_reactivated';
+ALTER TYPE notification_type ADD VALUE IF NOT EXISTS 'kartu_upgraded';
+ALTER TYPE notification_type ADD VALUE IF NOT EXISTS 'kartu_expired';
+
+ALTER TABLE umkms
+    ADD COLUMN IF NOT EXISTS kartu_status kartu_status NOT NULL DEFAULT 'active',
+    ADD COLUMN IF NOT EXISTS kartu_issued_date DATE,
+    ADD COLUMN IF NOT EXISTS kartu_expiry_date DATE;
+
+-- Existing kartu were issued at registration with the default validity of
+-- five years (KARTU_VALIDITY_MONTHS)
+UPDATE umkms
+SET kartu_issued_date = created_at::date,
+    kartu_expiry_date = (created_at + INTERVAL '60 months')::date
+WHERE kartu_issued_date IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_umkms_kartu_expiry ON umkms(kartu_expiry_date) WHERE kartu_status = 'active';
+
+-- Every change to a kartu after it was issued
+CREATE TABLE IF NOT EXISTS kartu_histories (
+    id SERIAL PRIMARY KEY,
+    umkm_id INT NOT NULL REFERENCES umkms(id) ON DELETE CASCADE,
+    action kartu_history_action NOT NULL,
+    from_type card_type,
+    to_type card_type,
+    from_status kartu_status,
+    to_status kartu_status,
+    from_expiry_date DATE,
+    to_expiry_date DATE,
+    notes TEXT,
+    -- NULL for changes made by the system, e.g. expiry
+    actioned_by INT REFERENCES users(id),
+    actioned_at TIMESTAMP DEFAULT NOW(),
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW(),
+    deleted_at TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_kartu_histories_umkm ON kartu_histories(umkm_id);
+
+-- Renewal requests made by UMKMs from the mobile app
+CREATE TABLE IF NOT EXISTS kartu_renewals (
+    id SERIAL PRIMARY KEY,
+    umkm_id INT NOT NULL REFERENCES umkms(id) ON DELETE CASCADE,
+    status kartu_renewal_status NOT NULL DEFAULT 'pending',
+    notes TEXT,
+    review_notes TEXT,
+    reviewed_by INT REFERENCES users(id),
+    reviewed_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW(),
+    deleted_at TIMESTAMP
+);
+-- At most one pending request per UMKM
+CREATE UNIQUE INDEX IF NOT EXISTS idx_kartu_renewals_pending ON kartu_renewals(umkm_id) WHERE status = 'pending' AND deleted_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS kartu_renewals;
+DROP TABLE IF EXISTS kartu_histories;
+DROP INDEX IF EXISTS idx_umkms_kartu_expiry;
+ALTER TABLE umkms
+    DROP COLUMN IF EXISTS kartu_expiry_date,
+    DROP COLUMN IF EXISTS kartu_issued_date,
+    DROP COLUMN IF EXISTS kartu_status;
+DROP TYPE IF EXISTS kartu_renewal_status;
+DROP TYPE IF EXISTS kartu_history_action;
+DROP TYPE IF EXISTS kartu_status;
+-- +goose StatementEnd
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
@@ -58,14 +59,54 @@ type (
 		KartuEncryptionKey string `env:"VAULT_KARTU_ENCRYPTION_KEY"`
 	}
 
+	SLAMonitor struct {
+		IntervalMinutes int    `env:"SLA_MONITOR_INTERVAL_MINUTES"`
+		BreachPolicy    string `env:"SLA_BREACH_POLICY"`
+	}
+
+	ReviewQueue struct {
+		ClaimLeaseMinutes  int    `env:"REVIEW_CLAIM_LEASE_MINUTES"`
+		AssignmentStrategy string `env:"REVIEW_ASSIGNMENT_STRATEGY"`
+	}
+
+	Drafts struct {
+		IdleDays int `env:"DRAFT_IDLE_DAYS"`
+	}
+
+	Documents struct {
+		RetentionDays int `env:"DOCUMENT_RETENTION_DAYS"`
+	}
+
+	Certificates struct {
+		VerifyURL string `env:"CERTIFICATE_VERIFY_URL"`
+	}
+
+	Certifications struct {
+		VerifyURL    string `env:"CERTIFICATION_VERIFY_URL"`
+		ReminderDays []int  `env:"CERTIFICATION_REMINDER_DAYS"`
+	}
+
+	Kartu struct {
+		QRSecret       string `env:"KARTU_QR_SECRET"`
+		VerifyURL      string `env:"KARTU_VERIFY_URL"`
+		ValidityMonths int    `env:"KARTU_VALIDITY_MONTHS"`
+	}
+
 	Config struct {
-		Server   Server
-		Database Database
-		Redis    Redis
-		Minio    Minio
-		ZSMTP    ZSMTP
-		Fonnte   Fonnte
-		Vault    Vault
+		Server         Server
+		Database       Database
+		Redis          Redis
+		Minio          Minio
+		ZSMTP          ZSMTP
+		Fonnte         Fonnte
+		Vault          Vault
+		SLAMonitor     SLAMonitor
+		ReviewQueue    ReviewQueue
+		Drafts         Drafts
+		Documents      Documents
+		Certificates   Certificates
+		Certifications Certifications
+		Kartu          Kartu
 	}
 )
 
@@ -198,5 +239,116 @@ func LoadNative() ([]string, error) {
 	}
 	// ! ______________________________________________________
 
+	// ! Load SLA monitor configuration ________________________
+	Cfg.SLAMonitor.IntervalMinutes = 15
+	if val, ok := os.LookupEnv("SLA_MONITOR_INTERVAL_MINUTES"); !ok {
+		missing = append(missing, "SLA_MONITOR_INTERVAL_MINUTES env is not set, defaulting to 15")
+	} else {
+		var err error
+		if Cfg.SLAMonitor.IntervalMinutes, err = strconv.Atoi(val); err != nil || Cfg.SLAMonitor.IntervalMinutes <= 0 {
+			Cfg.SLAMonitor.IntervalMinutes = 15
+			missing = append(missing, fmt.Sprintf("SLA_MONITOR_INTERVAL_MINUTES must be positive int, got %s", val))
+		}
+	}
+	if Cfg.SLAMonitor.BreachPolicy, ok = os.LookupEnv("SLA_BREACH_POLICY"); !ok {
+		Cfg.SLAMonitor.BreachPolicy = "notify"
+		missing = append(missing, "SLA_BREACH_POLICY env is not set, defaulting to notify")
+	}
+	// ! ______________________________________________________
+
+	// ! Load review queue configuration _______________________
+	Cfg.ReviewQueue.ClaimLeaseMinutes = 30
+	if val, ok := os.LookupEnv("REVIEW_CLAIM_LEASE_MINUTES"); !ok {
+		missing = append(missing, "REVIEW_CLAIM_LEASE_MINUTES env is not set, defaulting to 30")
+	} else {
+		var err error
+		if Cfg.ReviewQueue.ClaimLeaseMinutes, err = strconv.Atoi(val); err != nil || Cfg.ReviewQueue.ClaimLeaseMinutes <= 0 {
+			Cfg.ReviewQueue.ClaimLeaseMinutes = 30
+			missing = append(missing, fmt.Sprintf("REVIEW_CLAIM_LEASE_MINUTES must be positive int, got %s", val))
+		}
+	}
+	if Cfg.ReviewQueue.AssignmentStrategy, ok = os.LookupEnv("REVIEW_ASSIGNMENT_STRATEGY"); !ok {
+		Cfg.ReviewQueue.AssignmentStrategy = "least_loaded"
+		missing = append(missing, "REVIEW_ASSIGNMENT_STRATEGY env is not set, defaulting to least_loaded")
+	}
+	// ! ______________________________________________________
+
+	// ! Load application draft configuration __________________
+	Cfg.Drafts.IdleDays = 30
+	if val, ok := os.LookupEnv("DRAFT_IDLE_DAYS"); !ok {
+		missing = append(missing, "DRAFT_IDLE_DAYS env is not set, defaulting to 30")
+	} else {
+		var err error
+		if Cfg.Drafts.IdleDays, err = strconv.Atoi(val); err != nil || Cfg.Drafts.IdleDays <= 0 {
+			Cfg.Drafts.IdleDays = 30
+			missing = append(missing, fmt.Sprintf("DRAFT_IDLE_DAYS must be positive int, got %s", val))
+		}
+	}
+	// ! ______________________________________________________
+
+	// ! Load document retention configuration __________________
+	Cfg.Documents.RetentionDays = 365
+	if val, ok := os.LookupEnv("DOCUMENT_RETENTION_DAYS"); !ok {
+		missing = append(missing, "DOCUMENT_RETENTION_DAYS env is not set, defaulting to 365")
+	} else {
+		var err error
+		if Cfg.Documents.RetentionDays, err = strconv.Atoi(val); err != nil || Cfg.Documents.RetentionDays <= 0 {
+			Cfg.Documents.RetentionDays = 365
+			missing = append(missing, fmt.Sprintf("DOCUMENT_RETENTION_DAYS must be positive int, got %s", val))
+		}
+	}
+	// ! ______________________________________________________
+
+	// ! Load certificate configuration ________________________
+	if Cfg.Certificates.VerifyURL, ok = os.LookupEnv("CERTIFICATE_VERIFY_URL"); !ok {
+		missing = append(missing, "CERTIFICATE_VERIFY_URL env is not set, certificate QR codes will hold the verification code only")
+	}
+	// ! ______________________________________________________
+
+	// ! Load certification registry configuration _____________
+	if Cfg.Certifications.VerifyURL, ok = os.LookupEnv("CERTIFICATION_VERIFY_URL"); !ok {
+		missing = append(missing, "CERTIFICATION_VERIFY_URL env is not set, certification QR codes will hold the verification code only")
+	}
+	Cfg.Certifications.ReminderDays = []int{90, 30, 7}
+	if val, ok := os.LookupEnv("CERTIFICATION_REMINDER_DAYS"); !ok {
+		missing = append(missing, "CERTIFICATION_REMINDER_DAYS env is not set, defaulting to 90,30,7")
+	} else {
+		var days []int
+		for _, part := range strings.Split(val, ",") {
+			day, err := strconv.Atoi(strings.TrimSpace(part))
+			if err != nil || day <= 0 {
+				days = nil
+				break
+			}
+			days = append(days, day)
+		}
+		if len(days) == 0 {
+			missing = append(missing, fmt.Sprintf("CERTIFICATION_REMINDER_DAYS must be comma separated positive ints, got %s", val))
+		} else {
+			Cfg.Certifications.ReminderDays = days
+		}
+	}
+	// ! ______________________________________________________
+
+	// ! Load kartu QR configuration __________________________
+	if Cfg.Kartu.QRSecret, ok = os.LookupEnv("KARTU_QR_SECRET"); !ok {
+		missing = append(missing, "KARTU_QR_SECRET env is not set, kartu QR tokens will be signed with JWT_SECRET_KEY")
+		Cfg.Kartu.QRSecret = Cfg.Server.JWTSecretKey
+	}
+	if Cfg.Kartu.VerifyURL, ok = os.LookupEnv("KARTU_VERIFY_URL"); !ok {
+		missing = append(missing, "KARTU_VERIFY_URL env is not set, kartu QR codes will hold the token only")
+	}
+	Cfg.Kartu.ValidityMonths = 60
+	if val, ok := os.LookupEnv("KARTU_VALIDITY_MONTHS"); !ok {
+		missing = append(missing, "KARTU_VALIDITY_MONTHS env is not set, defaulting to 60")
+	} else {
+		var err error
+		if Cfg.Kartu.ValidityMonths, err = strconv.Atoi(val); err != nil || Cfg.Kartu.ValidityMonths <= 0 {
+			Cfg.Kartu.ValidityMonths = 60
+			missing = append(missing, fmt.Sprintf("KARTU_VALIDITY_MONTHS must be positive int, got %s", val))
+		}
+	}
+	// ! ______________________________________________________
+
 	return missing, nil
 }
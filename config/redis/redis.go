@@ -17,6 +17,7 @@ type RedisRepository interface {
 	Subscribe(ctx context.Context, channel string) *redisPackage.PubSub
 	Get(ctx context.Context, key string) (string, error)
 	Del(ctx context.Context, keys ...string) (int64, error)
+	DelIfEqual(ctx context.Context, key, value string) (bool, error)
 	Exists(ctx context.Context, keys ...string) (int64, error)
 	Incr(ctx context.Context, key string) (int64, error)
 	Expire(ctx context.Context, key string, exp time.Duration) error
@@ -99,6 +100,24 @@ func (rdb *redisInstance) Del(ctx context.Context, keys ...string) (int64, error
 	return res, nil
 }
 
+// delIfEqualScript deletes a key only while it still holds the given value,
+// checked and deleted in one step on the server.
+var delIfEqualScript = redisPackage.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+func (rdb *redisInstance) DelIfEqual(ctx context.Context, key, value string) (bool, error) {
+	deleted, err := delIfEqualScript.Run(ctx, rdb.Client, []string{key}, value).Int64()
+	if err != nil {
+		return false, fmt.Errorf("redis: %w", err)
+	}
+
+	return deleted == 1, nil
+}
+
 func (rdb *redisInstance) Exists(ctx context.Context, keys ...string) (int64, error) {
 	found, err := rdb.Client.Exists(ctx, keys...).Result()
 	if err != nil {
@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"UMKMGo-backend/internal/service"
+	"UMKMGo-backend/internal/types/dto"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type adminNotificationHandler struct {
+	adminNotificationService service.AdminNotificationService
+}
+
+func NewAdminNotificationHandler(adminNotificationService service.AdminNotificationService) *adminNotificationHandler {
+	return &adminNotificationHandler{
+		adminNotificationService: adminNotificationService,
+	}
+}
+
+func (h *adminNotificationHandler) GetNotifications(c *fiber.Ctx) error {
+	userData, ok := c.Locals("user_data").(dto.UserData)
+	if !ok {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{
+			"statusCode": 401,
+			"status":     false,
+			"message":    "Unauthorized",
+		})
+	}
+
+	notifications, err := h.adminNotificationService.GetNotifications(c.Context(), int(userData.ID))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"statusCode": 200,
+		"status":     true,
+		"message":    "Get list notifications",
+		"data":       notifications,
+	})
+}
+
+func (h *adminNotificationHandler) GetUnreadCount(c *fiber.Ctx) error {
+	userData, ok := c.Locals("user_data").(dto.UserData)
+	if !ok {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{
+			"statusCode": 401,
+			"status":     false,
+			"message":    "Unauthorized",
+		})
+	}
+
+	count, err := h.adminNotificationService.GetUnreadCount(c.Context(), int(userData.ID))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"statusCode": 200,
+		"status":     true,
+		"message":    "Get unread notification count",
+		"data":       count,
+	})
+}
+
+func (h *adminNotificationHandler) MarkAsRead(c *fiber.Ctx) error {
+	userData, ok := c.Locals("user_data").(dto.UserData)
+	if !ok {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{
+			"statusCode": 401,
+			"status":     false,
+			"message":    "Unauthorized",
+		})
+	}
+
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    "Invalid notification ID",
+		})
+	}
+
+	if err := h.adminNotificationService.MarkAsRead(c.Context(), int(userData.ID), id); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"statusCode": 200,
+		"status":     true,
+		"message":    "Notification marked as read",
+	})
+}
+
+func (h *adminNotificationHandler) MarkAllAsRead(c *fiber.Ctx) error {
+	userData, ok := c.Locals("user_data").(dto.UserData)
+	if !ok {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{
+			"statusCode": 401,
+			"status":     false,
+			"message":    "Unauthorized",
+		})
+	}
+
+	if err := h.adminNotificationService.MarkAllAsRead(c.Context(), int(userData.ID)); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"statusCode": 200,
+		"status":     true,
+		"message":    "All notifications marked as read",
+	})
+}
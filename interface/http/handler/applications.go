@@ -1,8 +1,10 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"UMKMGo-backend/internal/service"
 	"UMKMGo-backend/internal/types/dto"
@@ -21,7 +23,28 @@ func NewApplicationsHandler(applicationsService service.ApplicationsService) *ap
 }
 
 func (h *applicationsHandler) GetAllApplications(c *fiber.Ctx) error {
-	filterType := c.Query("type", "")
+	params := dto.ApplicationQueryParams{
+		Page:          c.QueryInt("page", 1),
+		Limit:         c.QueryInt("limit", 10),
+		Type:          c.Query("type"),
+		Status:        c.Query("status"),
+		ProgramID:     c.QueryInt("program_id", 0),
+		ProvinceID:    c.QueryInt("province_id", 0),
+		CityID:        c.QueryInt("city_id", 0),
+		KartuType:     c.Query("kartu_type"),
+		SubmittedFrom: c.Query("submitted_from"),
+		SubmittedTo:   c.Query("submitted_to"),
+		Search:        c.Query("search"),
+		SortBy:        c.Query("sort_by"),
+		SortOrder:     c.Query("sort_order"),
+	}
+
+	// Parse is_overdue parameter
+	if isOverdueStr := c.Query("is_overdue"); isOverdueStr != "" {
+		isOverdue := isOverdueStr == "true"
+		params.IsOverdue = &isOverdue
+	}
+
 	userIDVal := c.Locals("userID")
 	var userID int
 	if userIDVal != nil {
@@ -30,7 +53,7 @@ func (h *applicationsHandler) GetAllApplications(c *fiber.Ctx) error {
 		}
 	}
 
-	applications, err := h.applicationsService.GetAllApplications(c.Context(), userID, filterType)
+	applications, meta, err := h.applicationsService.GetAllApplications(c.Context(), userID, params)
 	if err != nil {
 		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
 			"statusCode": 400,
@@ -44,6 +67,7 @@ func (h *applicationsHandler) GetAllApplications(c *fiber.Ctx) error {
 		"status":     true,
 		"message":    "Get all applications",
 		"data":       applications,
+		"meta":       meta,
 	})
 }
 
@@ -75,6 +99,7 @@ func (h *applicationsHandler) GetApplicationByID(c *fiber.Ctx) error {
 		})
 	}
 
+	c.Set(fiber.HeaderETag, applicationETag(application.Version))
 	return c.Status(http.StatusOK).JSON(fiber.Map{
 		"statusCode": 200,
 		"status":     true,
@@ -83,6 +108,36 @@ func (h *applicationsHandler) GetApplicationByID(c *fiber.Ctx) error {
 	})
 }
 
+// GetDocumentComparison returns the originally submitted and current version of
+// each document type of an application.
+func (h *applicationsHandler) GetDocumentComparison(c *fiber.Ctx) error {
+	id := c.Params("id")
+	intID, err := strconv.Atoi(id)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    "Invalid application ID",
+		})
+	}
+
+	comparison, err := h.applicationsService.GetDocumentComparison(c.Context(), intID)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"statusCode": 200,
+		"status":     true,
+		"message":    "Get application document versions",
+		"data":       comparison,
+	})
+}
+
 func (h *applicationsHandler) ScreeningApprove(c *fiber.Ctx) error {
 	id := c.Params("id")
 	intID, err := strconv.Atoi(id)
@@ -104,7 +159,7 @@ func (h *applicationsHandler) ScreeningApprove(c *fiber.Ctx) error {
 		})
 	}
 
-	application, err := h.applicationsService.ScreeningApprove(c.Context(), int(userData.ID), intID)
+	expectedVersion, err := versionFromIfMatch(c)
 	if err != nil {
 		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
 			"statusCode": 400,
@@ -113,6 +168,17 @@ func (h *applicationsHandler) ScreeningApprove(c *fiber.Ctx) error {
 		})
 	}
 
+	application, err := h.applicationsService.ScreeningApprove(c.Context(), int(userData.ID), intID, expectedVersion)
+	if err != nil {
+		code := statusCodeFromError(err)
+		return c.Status(code).JSON(fiber.Map{
+			"statusCode": code,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	c.Set(fiber.HeaderETag, applicationETag(application.Version))
 	return c.Status(http.StatusOK).JSON(fiber.Map{
 		"statusCode": 200,
 		"status":     true,
@@ -154,15 +220,28 @@ func (h *applicationsHandler) ScreeningReject(c *fiber.Ctx) error {
 		})
 	}
 
+	// If-Match takes precedence over a version sent in the body
+	if c.Get(fiber.HeaderIfMatch) != "" {
+		if decision.Version, err = versionFromIfMatch(c); err != nil {
+			return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+				"statusCode": 400,
+				"status":     false,
+				"message":    err.Error(),
+			})
+		}
+	}
+
 	application, err := h.applicationsService.ScreeningReject(c.Context(), int(userData.ID), decision)
 	if err != nil {
-		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
-			"statusCode": 400,
+		code := statusCodeFromError(err)
+		return c.Status(code).JSON(fiber.Map{
+			"statusCode": code,
 			"status":     false,
 			"message":    err.Error(),
 		})
 	}
 
+	c.Set(fiber.HeaderETag, applicationETag(application.Version))
 	return c.Status(http.StatusOK).JSON(fiber.Map{
 		"statusCode": 200,
 		"status":     true,
@@ -204,15 +283,28 @@ func (h *applicationsHandler) ScreeningRevise(c *fiber.Ctx) error {
 		})
 	}
 
+	// If-Match takes precedence over a version sent in the body
+	if c.Get(fiber.HeaderIfMatch) != "" {
+		if decision.Version, err = versionFromIfMatch(c); err != nil {
+			return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+				"statusCode": 400,
+				"status":     false,
+				"message":    err.Error(),
+			})
+		}
+	}
+
 	application, err := h.applicationsService.ScreeningRevise(c.Context(), int(userData.ID), decision)
 	if err != nil {
-		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
-			"statusCode": 400,
+		code := statusCodeFromError(err)
+		return c.Status(code).JSON(fiber.Map{
+			"statusCode": code,
 			"status":     false,
 			"message":    err.Error(),
 		})
 	}
 
+	c.Set(fiber.HeaderETag, applicationETag(application.Version))
 	return c.Status(http.StatusOK).JSON(fiber.Map{
 		"statusCode": 200,
 		"status":     true,
@@ -221,6 +313,64 @@ func (h *applicationsHandler) ScreeningRevise(c *fiber.Ctx) error {
 	})
 }
 
+// ReviewDocument records an accepted or rejected verdict on one document of an
+// application in screening.
+func (h *applicationsHandler) ReviewDocument(c *fiber.Ctx) error {
+	intID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    "Invalid application ID",
+		})
+	}
+
+	documentID, err := strconv.Atoi(c.Params("documentId"))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    "Invalid document ID",
+		})
+	}
+
+	var request dto.DocumentReviewRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	// Get user data from context
+	userData, ok := c.Locals("user_data").(dto.UserData)
+	if !ok {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{
+			"statusCode": 401,
+			"status":     false,
+			"message":    "Unauthorized",
+		})
+	}
+
+	document, err := h.applicationsService.ReviewDocument(c.Context(), int(userData.ID), intID, documentID, request)
+	if err != nil {
+		code := statusCodeFromError(err)
+		return c.Status(code).JSON(fiber.Map{
+			"statusCode": code,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"statusCode": 200,
+		"status":     true,
+		"message":    "Document reviewed successfully",
+		"data":       document,
+	})
+}
+
 func (h *applicationsHandler) FinalApprove(c *fiber.Ctx) error {
 	id := c.Params("id")
 	intID, err := strconv.Atoi(id)
@@ -242,7 +392,7 @@ func (h *applicationsHandler) FinalApprove(c *fiber.Ctx) error {
 		})
 	}
 
-	application, err := h.applicationsService.FinalApprove(c.Context(), int(userData.ID), intID)
+	expectedVersion, err := versionFromIfMatch(c)
 	if err != nil {
 		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
 			"statusCode": 400,
@@ -251,6 +401,17 @@ func (h *applicationsHandler) FinalApprove(c *fiber.Ctx) error {
 		})
 	}
 
+	application, err := h.applicationsService.FinalApprove(c.Context(), int(userData.ID), intID, expectedVersion)
+	if err != nil {
+		code := statusCodeFromError(err)
+		return c.Status(code).JSON(fiber.Map{
+			"statusCode": code,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	c.Set(fiber.HeaderETag, applicationETag(application.Version))
 	return c.Status(http.StatusOK).JSON(fiber.Map{
 		"statusCode": 200,
 		"status":     true,
@@ -292,15 +453,28 @@ func (h *applicationsHandler) FinalReject(c *fiber.Ctx) error {
 		})
 	}
 
+	// If-Match takes precedence over a version sent in the body
+	if c.Get(fiber.HeaderIfMatch) != "" {
+		if decision.Version, err = versionFromIfMatch(c); err != nil {
+			return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+				"statusCode": 400,
+				"status":     false,
+				"message":    err.Error(),
+			})
+		}
+	}
+
 	application, err := h.applicationsService.FinalReject(c.Context(), int(userData.ID), decision)
 	if err != nil {
-		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
-			"statusCode": 400,
+		code := statusCodeFromError(err)
+		return c.Status(code).JSON(fiber.Map{
+			"statusCode": code,
 			"status":     false,
 			"message":    err.Error(),
 		})
 	}
 
+	c.Set(fiber.HeaderETag, applicationETag(application.Version))
 	return c.Status(http.StatusOK).JSON(fiber.Map{
 		"statusCode": 200,
 		"status":     true,
@@ -308,3 +482,307 @@ func (h *applicationsHandler) FinalReject(c *fiber.Ctx) error {
 		"data":       application,
 	})
 }
+
+func (h *applicationsHandler) BulkScreeningDecision(c *fiber.Ctx) error {
+	var request dto.BulkApplicationDecision
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	// Get user data from context
+	userData, ok := c.Locals("user_data").(dto.UserData)
+	if !ok {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{
+			"statusCode": 401,
+			"status":     false,
+			"message":    "Unauthorized",
+		})
+	}
+
+	report, err := h.applicationsService.BulkScreeningDecision(c.Context(), int(userData.ID), request)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"statusCode": 200,
+		"status":     true,
+		"message":    "Bulk screening decision processed",
+		"data":       report,
+	})
+}
+
+func (h *applicationsHandler) BulkFinalDecision(c *fiber.Ctx) error {
+	var request dto.BulkApplicationDecision
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	// Get user data from context
+	userData, ok := c.Locals("user_data").(dto.UserData)
+	if !ok {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{
+			"statusCode": 401,
+			"status":     false,
+			"message":    "Unauthorized",
+		})
+	}
+
+	report, err := h.applicationsService.BulkFinalDecision(c.Context(), int(userData.ID), request)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"statusCode": 200,
+		"status":     true,
+		"message":    "Bulk final decision processed",
+		"data":       report,
+	})
+}
+
+// BulkApproveTop approves the best ranked applications of a program waiting at
+// a review stage.
+func (h *applicationsHandler) BulkApproveTop(c *fiber.Ctx) error {
+	var request dto.BulkTopDecision
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	// Get user data from context
+	userData, ok := c.Locals("user_data").(dto.UserData)
+	if !ok {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{
+			"statusCode": 401,
+			"status":     false,
+			"message":    "Unauthorized",
+		})
+	}
+
+	report, err := h.applicationsService.BulkApproveTop(c.Context(), int(userData.ID), request)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"statusCode": 200,
+		"status":     true,
+		"message":    "Top ranked applications processed",
+		"data":       report,
+	})
+}
+
+func (h *applicationsHandler) GetProgramRubric(c *fiber.Ctx) error {
+	programID, err := strconv.Atoi(c.Params("programId"))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    "Invalid program ID",
+		})
+	}
+
+	rubric, err := h.applicationsService.GetProgramRubric(c.Context(), programID)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"statusCode": 200,
+		"status":     true,
+		"message":    "Get program rubric",
+		"data":       rubric,
+	})
+}
+
+func (h *applicationsHandler) SetProgramRubric(c *fiber.Ctx) error {
+	programID, err := strconv.Atoi(c.Params("programId"))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    "Invalid program ID",
+		})
+	}
+
+	var request dto.ProgramRubric
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	rubric, err := h.applicationsService.SetProgramRubric(c.Context(), programID, request)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"statusCode": 200,
+		"status":     true,
+		"message":    "Program rubric saved successfully",
+		"data":       rubric,
+	})
+}
+
+func (h *applicationsHandler) GetProgramRanking(c *fiber.Ctx) error {
+	programID, err := strconv.Atoi(c.Params("programId"))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    "Invalid program ID",
+		})
+	}
+
+	ranking, err := h.applicationsService.GetProgramRanking(c.Context(), programID)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"statusCode": 200,
+		"status":     true,
+		"message":    "Get program ranking",
+		"data":       ranking,
+	})
+}
+
+func (h *applicationsHandler) ScoreApplication(c *fiber.Ctx) error {
+	intID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    "Invalid application ID",
+		})
+	}
+
+	var request dto.ApplicationScoresRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	// Get user data from context
+	userData, ok := c.Locals("user_data").(dto.UserData)
+	if !ok {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{
+			"statusCode": 401,
+			"status":     false,
+			"message":    "Unauthorized",
+		})
+	}
+
+	scorecard, err := h.applicationsService.ScoreApplication(c.Context(), int(userData.ID), intID, request)
+	if err != nil {
+		code := statusCodeFromError(err)
+		return c.Status(code).JSON(fiber.Map{
+			"statusCode": code,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"statusCode": 200,
+		"status":     true,
+		"message":    "Application scored successfully",
+		"data":       scorecard,
+	})
+}
+
+// applicationETag renders an application version as a strong entity tag.
+func applicationETag(version int) string {
+	return strconv.Quote(strconv.Itoa(version))
+}
+
+// versionFromIfMatch reads the application version a client last saw from the
+// If-Match header. A missing header or "*" means no precondition.
+func versionFromIfMatch(c *fiber.Ctx) (int, error) {
+	header := strings.TrimSpace(c.Get(fiber.HeaderIfMatch))
+	if header == "" || header == "*" {
+		return 0, nil
+	}
+
+	version, err := strconv.Atoi(strings.Trim(strings.TrimPrefix(header, "W/"), `"`))
+	if err != nil || version <= 0 {
+		return 0, errors.New("invalid If-Match header")
+	}
+	return version, nil
+}
+
+// statusCodeFromError maps service errors to HTTP status codes; illegal workflow
+// transitions, stale versions and claims held by another reviewer are conflicts
+// with the application's current state, acting without a claim is forbidden.
+func statusCodeFromError(err error) int {
+	var transitionErr *service.InvalidTransitionError
+	if errors.As(err, &transitionErr) {
+		return http.StatusConflict
+	}
+	var conflictErr *service.ApplicationConflictError
+	if errors.As(err, &conflictErr) {
+		return http.StatusConflict
+	}
+	var claimErr *service.ReviewClaimError
+	if errors.As(err, &claimErr) {
+		if claimErr.HolderID != 0 {
+			return http.StatusConflict
+		}
+		return http.StatusForbidden
+	}
+	var incompleteErr *service.DraftIncompleteError
+	if errors.As(err, &incompleteErr) {
+		return http.StatusUnprocessableEntity
+	}
+	var closedErr *service.ProgramClosedError
+	if errors.As(err, &closedErr) {
+		return http.StatusUnprocessableEntity
+	}
+	var ineligibleErr *service.IneligibleError
+	if errors.As(err, &ineligibleErr) {
+		return http.StatusUnprocessableEntity
+	}
+	return http.StatusBadRequest
+}
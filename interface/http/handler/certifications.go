@@ -0,0 +1,172 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"UMKMGo-backend/internal/service"
+	"UMKMGo-backend/internal/types/dto"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type certificationHandler struct {
+	certificationService service.CertificationService
+}
+
+func NewCertificationHandler(certificationService service.CertificationService) *certificationHandler {
+	return &certificationHandler{
+		certificationService: certificationService,
+	}
+}
+
+func (h *certificationHandler) GetCertifications(c *fiber.Ctx) error {
+	params := dto.CertificationQueryParams{
+		Status: c.Query("status"),
+	}
+
+	certifications, err := h.certificationService.GetCertifications(c.Context(), params)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"statusCode": 200,
+		"status":     true,
+		"message":    "Get all certifications",
+		"data":       certifications,
+	})
+}
+
+func (h *certificationHandler) GetCertificationByID(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    "Invalid certification ID",
+		})
+	}
+
+	certification, err := h.certificationService.GetCertificationByID(c.Context(), id)
+	if err != nil {
+		return c.Status(http.StatusNotFound).JSON(fiber.Map{
+			"statusCode": 404,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"statusCode": 200,
+		"status":     true,
+		"message":    "Get certification by ID",
+		"data":       certification,
+	})
+}
+
+func (h *certificationHandler) RecordCertification(c *fiber.Ctx) error {
+	applicationID, err := strconv.Atoi(c.Params("applicationId"))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    "Invalid application ID",
+		})
+	}
+
+	var request dto.CertificationRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	// Get user data from context
+	userData, ok := c.Locals("user_data").(dto.UserData)
+	if !ok {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{
+			"statusCode": 401,
+			"status":     false,
+			"message":    "Unauthorized",
+		})
+	}
+
+	certification, err := h.certificationService.RecordCertification(c.Context(), int(userData.ID), applicationID, request)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusCreated).JSON(fiber.Map{
+		"statusCode": 201,
+		"status":     true,
+		"message":    "Certification recorded successfully",
+		"data":       certification,
+	})
+}
+
+func (h *certificationHandler) UpdateCertification(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    "Invalid certification ID",
+		})
+	}
+
+	var request dto.CertificationRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	certification, err := h.certificationService.UpdateCertification(c.Context(), id, request)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"statusCode": 200,
+		"status":     true,
+		"message":    "Certification updated successfully",
+		"data":       certification,
+	})
+}
+
+// VerifyCertification looks a certificate up by the code in its QR code, or by
+// its number with ?number= when no code is given.
+func (h *certificationHandler) VerifyCertification(c *fiber.Ctx) error {
+	verification, err := h.certificationService.VerifyCertification(c.Context(), c.Query("number"), c.Params("code"))
+	if err != nil {
+		return c.Status(http.StatusNotFound).JSON(fiber.Map{
+			"statusCode": 404,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"statusCode": 200,
+		"status":     true,
+		"message":    "Certification found",
+		"data":       verification,
+	})
+}
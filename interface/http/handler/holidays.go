@@ -0,0 +1,154 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"UMKMGo-backend/internal/service"
+	"UMKMGo-backend/internal/types/dto"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type holidayHandler struct {
+	holidayService service.HolidayService
+}
+
+func NewHolidayHandler(holidayService service.HolidayService) *holidayHandler {
+	return &holidayHandler{
+		holidayService: holidayService,
+	}
+}
+
+func (h *holidayHandler) GetHolidays(c *fiber.Ctx) error {
+	holidays, err := h.holidayService.GetHolidays(c.Context(), c.QueryInt("year", 0))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"statusCode": 200,
+		"status":     true,
+		"message":    "Get list holidays",
+		"data":       holidays,
+	})
+}
+
+func (h *holidayHandler) GetHolidayByID(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    "Invalid holiday ID",
+		})
+	}
+
+	holiday, err := h.holidayService.GetHolidayByID(c.Context(), id)
+	if err != nil {
+		return c.Status(http.StatusNotFound).JSON(fiber.Map{
+			"statusCode": 404,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"statusCode": 200,
+		"status":     true,
+		"message":    "Get holiday detail",
+		"data":       holiday,
+	})
+}
+
+func (h *holidayHandler) CreateHoliday(c *fiber.Ctx) error {
+	var request dto.Holiday
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	holiday, err := h.holidayService.CreateHoliday(c.Context(), request)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusCreated).JSON(fiber.Map{
+		"statusCode": 201,
+		"status":     true,
+		"message":    "Holiday created successfully",
+		"data":       holiday,
+	})
+}
+
+func (h *holidayHandler) UpdateHoliday(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    "Invalid holiday ID",
+		})
+	}
+
+	var request dto.Holiday
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	holiday, err := h.holidayService.UpdateHoliday(c.Context(), id, request)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"statusCode": 200,
+		"status":     true,
+		"message":    "Holiday updated successfully",
+		"data":       holiday,
+	})
+}
+
+func (h *holidayHandler) DeleteHoliday(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    "Invalid holiday ID",
+		})
+	}
+
+	if err := h.holidayService.DeleteHoliday(c.Context(), id); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"statusCode": 200,
+		"status":     true,
+		"message":    "Holiday deleted successfully",
+	})
+}
@@ -0,0 +1,334 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"UMKMGo-backend/internal/service"
+	"UMKMGo-backend/internal/types/dto"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type kartuHandler struct {
+	kartuService service.KartuService
+}
+
+func NewKartuHandler(kartuService service.KartuService) *kartuHandler {
+	return &kartuHandler{
+		kartuService: kartuService,
+	}
+}
+
+func (h *kartuHandler) VerifyKartu(c *fiber.Ctx) error {
+	verification, err := h.kartuService.VerifyKartu(c.Context(), c.Params("token"))
+	if err != nil {
+		return c.Status(http.StatusNotFound).JSON(fiber.Map{
+			"statusCode": 404,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"statusCode": 200,
+		"status":     true,
+		"message":    "Kartu found",
+		"data":       verification,
+	})
+}
+
+// RotateQRCode reissues the kartu QR code of a UMKM, revoking the old one.
+func (h *kartuHandler) RotateQRCode(c *fiber.Ctx) error {
+	umkmID, err := strconv.Atoi(c.Params("umkmId"))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    "Invalid UMKM ID",
+		})
+	}
+
+	qrCode, err := h.kartuService.RotateQRCode(c.Context(), umkmID)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"statusCode": 200,
+		"status":     true,
+		"message":    "QR code rotated successfully",
+		"data":       qrCode,
+	})
+}
+
+// RotateMyQRCode lets a UMKM revoke its own QR code, e.g. after losing the card.
+func (h *kartuHandler) RotateMyQRCode(c *fiber.Ctx) error {
+	// Get user data from context
+	userData, ok := c.Locals("user_data").(dto.UserData)
+	if !ok {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{
+			"statusCode": 401,
+			"status":     false,
+			"message":    "Unauthorized",
+		})
+	}
+
+	qrCode, err := h.kartuService.RotateQRCode(c.Context(), int(userData.ID))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"statusCode": 200,
+		"status":     true,
+		"message":    "QR code rotated successfully",
+		"data":       qrCode,
+	})
+}
+
+func (h *kartuHandler) GetKartu(c *fiber.Ctx) error {
+	umkmID, err := strconv.Atoi(c.Params("umkmId"))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    "Invalid UMKM ID",
+		})
+	}
+
+	kartu, err := h.kartuService.GetKartu(c.Context(), umkmID)
+	if err != nil {
+		return c.Status(http.StatusNotFound).JSON(fiber.Map{
+			"statusCode": 404,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"statusCode": 200,
+		"status":     true,
+		"message":    "Get kartu",
+		"data":       kartu,
+	})
+}
+
+// GetMyKartu shows the UMKM its own kartu, with expiry and renewal state.
+func (h *kartuHandler) GetMyKartu(c *fiber.Ctx) error {
+	// Get user data from context
+	userData, ok := c.Locals("user_data").(dto.UserData)
+	if !ok {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{
+			"statusCode": 401,
+			"status":     false,
+			"message":    "Unauthorized",
+		})
+	}
+
+	kartu, err := h.kartuService.GetKartu(c.Context(), int(userData.ID))
+	if err != nil {
+		return c.Status(http.StatusNotFound).JSON(fiber.Map{
+			"statusCode": 404,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"statusCode": 200,
+		"status":     true,
+		"message":    "Get kartu",
+		"data":       kartu,
+	})
+}
+
+func (h *kartuHandler) SuspendKartu(c *fiber.Ctx) error {
+	return h.changeKartu(c, h.kartuService.SuspendKartu, "Kartu suspended successfully")
+}
+
+func (h *kartuHandler) ReactivateKartu(c *fiber.Ctx) error {
+	return h.changeKartu(c, h.kartuService.ReactivateKartu, "Kartu reactivated successfully")
+}
+
+func (h *kartuHandler) UpgradeKartu(c *fiber.Ctx) error {
+	return h.changeKartu(c, h.kartuService.UpgradeKartu, "Kartu upgraded successfully")
+}
+
+// changeKartu handles the admin kartu actions, which share their request and
+// response shape.
+func (h *kartuHandler) changeKartu(c *fiber.Ctx, change func(ctx context.Context, userID, umkmID int, request dto.KartuActionRequest) (dto.Kartu, error), message string) error {
+	umkmID, err := strconv.Atoi(c.Params("umkmId"))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    "Invalid UMKM ID",
+		})
+	}
+
+	// Notes are optional for some actions, so an empty body is accepted
+	var request dto.KartuActionRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&request); err != nil {
+			return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+				"statusCode": 400,
+				"status":     false,
+				"message":    err.Error(),
+			})
+		}
+	}
+
+	// Get user data from context
+	userData, ok := c.Locals("user_data").(dto.UserData)
+	if !ok {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{
+			"statusCode": 401,
+			"status":     false,
+			"message":    "Unauthorized",
+		})
+	}
+
+	kartu, err := change(c.Context(), int(userData.ID), umkmID, request)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"statusCode": 200,
+		"status":     true,
+		"message":    message,
+		"data":       kartu,
+	})
+}
+
+// RequestRenewal lets a UMKM ask for its kartu to be renewed.
+func (h *kartuHandler) RequestRenewal(c *fiber.Ctx) error {
+	// Get user data from context
+	userData, ok := c.Locals("user_data").(dto.UserData)
+	if !ok {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{
+			"statusCode": 401,
+			"status":     false,
+			"message":    "Unauthorized",
+		})
+	}
+
+	var request dto.KartuRenewalRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&request); err != nil {
+			return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+				"statusCode": 400,
+				"status":     false,
+				"message":    err.Error(),
+			})
+		}
+	}
+
+	renewal, err := h.kartuService.RequestRenewal(c.Context(), int(userData.ID), request)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusCreated).JSON(fiber.Map{
+		"statusCode": 201,
+		"status":     true,
+		"message":    "Kartu renewal requested successfully",
+		"data":       renewal,
+	})
+}
+
+func (h *kartuHandler) GetRenewals(c *fiber.Ctx) error {
+	params := dto.KartuRenewalQueryParams{
+		Status: c.Query("status"),
+	}
+
+	renewals, err := h.kartuService.GetRenewals(c.Context(), params)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"statusCode": 200,
+		"status":     true,
+		"message":    "Get all kartu renewals",
+		"data":       renewals,
+	})
+}
+
+func (h *kartuHandler) ApproveRenewal(c *fiber.Ctx) error {
+	return h.reviewRenewal(c, h.kartuService.ApproveRenewal, "Kartu renewal approved successfully")
+}
+
+func (h *kartuHandler) RejectRenewal(c *fiber.Ctx) error {
+	return h.reviewRenewal(c, h.kartuService.RejectRenewal, "Kartu renewal rejected successfully")
+}
+
+func (h *kartuHandler) reviewRenewal(c *fiber.Ctx, review func(ctx context.Context, userID, renewalID int, request dto.KartuActionRequest) (dto.KartuRenewal, error), message string) error {
+	renewalID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    "Invalid renewal ID",
+		})
+	}
+
+	var request dto.KartuActionRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&request); err != nil {
+			return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+				"statusCode": 400,
+				"status":     false,
+				"message":    err.Error(),
+			})
+		}
+	}
+
+	// Get user data from context
+	userData, ok := c.Locals("user_data").(dto.UserData)
+	if !ok {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{
+			"statusCode": 401,
+			"status":     false,
+			"message":    "Unauthorized",
+		})
+	}
+
+	renewal, err := review(c.Context(), int(userData.ID), renewalID, request)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"statusCode": 200,
+		"status":     true,
+		"message":    message,
+		"data":       renewal,
+	})
+}
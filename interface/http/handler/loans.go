@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"UMKMGo-backend/internal/service"
+	"UMKMGo-backend/internal/types/dto"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type loanHandler struct {
+	loanService service.LoanService
+}
+
+func NewLoanHandler(loanService service.LoanService) *loanHandler {
+	return &loanHandler{
+		loanService: loanService,
+	}
+}
+
+func (h *loanHandler) GetLoans(c *fiber.Ctx) error {
+	params := dto.LoanQueryParams{
+		Status:  c.Query("status"),
+		Overdue: c.Query("overdue") == "true",
+	}
+
+	loans, err := h.loanService.GetLoans(c.Context(), params)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"statusCode": 200,
+		"status":     true,
+		"message":    "Get all loans",
+		"data":       loans,
+	})
+}
+
+func (h *loanHandler) GetLoanByApplicationID(c *fiber.Ctx) error {
+	applicationID, err := strconv.Atoi(c.Params("applicationId"))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    "Invalid application ID",
+		})
+	}
+
+	loan, err := h.loanService.GetLoanByApplicationID(c.Context(), applicationID)
+	if err != nil {
+		return c.Status(http.StatusNotFound).JSON(fiber.Map{
+			"statusCode": 404,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"statusCode": 200,
+		"status":     true,
+		"message":    "Get loan by application ID",
+		"data":       loan,
+	})
+}
+
+func (h *loanHandler) RecordRepayment(c *fiber.Ctx) error {
+	loanID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    "Invalid loan ID",
+		})
+	}
+
+	var request dto.LoanRepaymentRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	// Get user data from context
+	userData, ok := c.Locals("user_data").(dto.UserData)
+	if !ok {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{
+			"statusCode": 401,
+			"status":     false,
+			"message":    "Unauthorized",
+		})
+	}
+
+	loan, err := h.loanService.RecordRepayment(c.Context(), int(userData.ID), loanID, request)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusCreated).JSON(fiber.Map{
+		"statusCode": 201,
+		"status":     true,
+		"message":    "Repayment recorded successfully",
+		"data":       loan,
+	})
+}
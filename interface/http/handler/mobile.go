@@ -1,6 +1,9 @@
 package handler
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -50,7 +53,16 @@ func (h *MobileHandler) GetDashboard(c *fiber.Ctx) error {
 
 // Programs - Training
 func (h *MobileHandler) GetTrainingPrograms(c *fiber.Ctx) error {
-	programs, err := h.mobileService.GetTrainingPrograms(c.Context())
+	userData, ok := c.Locals("user_data").(dto.UserData)
+	if !ok {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{
+			"statusCode": 401,
+			"status":     false,
+			"message":    "Unauthorized",
+		})
+	}
+
+	programs, err := h.mobileService.GetTrainingPrograms(c.Context(), int(userData.ID))
 	if err != nil {
 		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
 			"statusCode": 400,
@@ -69,7 +81,16 @@ func (h *MobileHandler) GetTrainingPrograms(c *fiber.Ctx) error {
 
 // Programs - Certification
 func (h *MobileHandler) GetCertificationPrograms(c *fiber.Ctx) error {
-	programs, err := h.mobileService.GetCertificationPrograms(c.Context())
+	userData, ok := c.Locals("user_data").(dto.UserData)
+	if !ok {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{
+			"statusCode": 401,
+			"status":     false,
+			"message":    "Unauthorized",
+		})
+	}
+
+	programs, err := h.mobileService.GetCertificationPrograms(c.Context(), int(userData.ID))
 	if err != nil {
 		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
 			"statusCode": 400,
@@ -88,7 +109,16 @@ func (h *MobileHandler) GetCertificationPrograms(c *fiber.Ctx) error {
 
 // Programs - Funding
 func (h *MobileHandler) GetFundingPrograms(c *fiber.Ctx) error {
-	programs, err := h.mobileService.GetFundingPrograms(c.Context())
+	userData, ok := c.Locals("user_data").(dto.UserData)
+	if !ok {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{
+			"statusCode": 401,
+			"status":     false,
+			"message":    "Unauthorized",
+		})
+	}
+
+	programs, err := h.mobileService.GetFundingPrograms(c.Context(), int(userData.ID))
 	if err != nil {
 		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
 			"statusCode": 400,
@@ -107,6 +137,15 @@ func (h *MobileHandler) GetFundingPrograms(c *fiber.Ctx) error {
 
 // Program Detail
 func (h *MobileHandler) GetProgramDetail(c *fiber.Ctx) error {
+	userData, ok := c.Locals("user_data").(dto.UserData)
+	if !ok {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{
+			"statusCode": 401,
+			"status":     false,
+			"message":    "Unauthorized",
+		})
+	}
+
 	id := c.Params("id")
 	intID, err := strconv.Atoi(id)
 	if err != nil {
@@ -117,7 +156,7 @@ func (h *MobileHandler) GetProgramDetail(c *fiber.Ctx) error {
 		})
 	}
 
-	program, err := h.mobileService.GetProgramDetail(c.Context(), intID)
+	program, err := h.mobileService.GetProgramDetail(c.Context(), int(userData.ID), intID)
 	if err != nil {
 		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
 			"statusCode": 400,
@@ -134,6 +173,43 @@ func (h *MobileHandler) GetProgramDetail(c *fiber.Ctx) error {
 	})
 }
 
+// SimulateFunding shows the monthly installments for an amount and tenure before applying.
+func (h *MobileHandler) SimulateFunding(c *fiber.Ctx) error {
+	intID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    "Invalid program ID",
+		})
+	}
+
+	var request dto.FundingSimulationRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	simulation, err := h.mobileService.SimulateFunding(c.Context(), intID, request)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"statusCode": 200,
+		"status":     true,
+		"message":    "Funding simulation",
+		"data":       simulation,
+	})
+}
+
 // UMKM Profile
 func (h *MobileHandler) GetUMKMProfile(c *fiber.Ctx) error {
 	userData, ok := c.Locals("user_data").(dto.UserData)
@@ -290,11 +366,7 @@ func (h *MobileHandler) CreateTrainingApplication(c *fiber.Ctx) error {
 	}
 
 	if err := h.mobileService.CreateTrainingApplication(c.Context(), int(userData.ID), request); err != nil {
-		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
-			"statusCode": 400,
-			"status":     false,
-			"message":    err.Error(),
-		})
+		return applicationErrorResponse(c, err)
 	}
 
 	return c.Status(http.StatusCreated).JSON(fiber.Map{
@@ -324,11 +396,7 @@ func (h *MobileHandler) CreateCertificationApplication(c *fiber.Ctx) error {
 	}
 
 	if err := h.mobileService.CreateCertificationApplication(c.Context(), int(userData.ID), request); err != nil {
-		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
-			"statusCode": 400,
-			"status":     false,
-			"message":    err.Error(),
-		})
+		return applicationErrorResponse(c, err)
 	}
 
 	return c.Status(http.StatusCreated).JSON(fiber.Map{
@@ -358,11 +426,7 @@ func (h *MobileHandler) CreateFundingApplication(c *fiber.Ctx) error {
 	}
 
 	if err := h.mobileService.CreateFundingApplication(c.Context(), int(userData.ID), request); err != nil {
-		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
-			"statusCode": 400,
-			"status":     false,
-			"message":    err.Error(),
-		})
+		return applicationErrorResponse(c, err)
 	}
 
 	return c.Status(http.StatusCreated).JSON(fiber.Map{
@@ -447,8 +511,14 @@ func (h *MobileHandler) ReviseApplication(c *fiber.Ctx) error {
 		})
 	}
 
-	var request []dto.UploadDocumentRequest
-	if err := c.BodyParser(&request); err != nil {
+	// Older clients send only the list of replacement documents
+	var request dto.ReviseApplicationRequest
+	if body := bytes.TrimSpace(c.Body()); len(body) > 0 && body[0] == '[' {
+		err = json.Unmarshal(body, &request.Documents)
+	} else {
+		err = c.BodyParser(&request)
+	}
+	if err != nil {
 		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
 			"statusCode": 400,
 			"status":     false,
@@ -457,9 +527,58 @@ func (h *MobileHandler) ReviseApplication(c *fiber.Ctx) error {
 	}
 
 	if err := h.mobileService.ReviseApplication(c.Context(), int(userData.ID), intID, request); err != nil {
+		code := statusCodeFromError(err)
+		return c.Status(code).JSON(fiber.Map{
+			"statusCode": code,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"statusCode": 200,
+		"status":     true,
+		"message":    "Application revised successfully",
+	})
+}
+
+func (h *MobileHandler) WithdrawApplication(c *fiber.Ctx) error {
+	userData, ok := c.Locals("user_data").(dto.UserData)
+	if !ok {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{
+			"statusCode": 401,
+			"status":     false,
+			"message":    "Unauthorized",
+		})
+	}
+
+	id := c.Params("id")
+	intID, err := strconv.Atoi(id)
+	if err != nil {
 		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
 			"statusCode": 400,
 			"status":     false,
+			"message":    "Invalid application ID",
+		})
+	}
+
+	// The reason is optional, so an empty body is accepted
+	var request dto.WithdrawApplicationRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&request); err != nil {
+			return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+				"statusCode": 400,
+				"status":     false,
+				"message":    err.Error(),
+			})
+		}
+	}
+
+	if err := h.mobileService.WithdrawApplication(c.Context(), int(userData.ID), intID, request); err != nil {
+		code := statusCodeFromError(err)
+		return c.Status(code).JSON(fiber.Map{
+			"statusCode": code,
+			"status":     false,
 			"message":    err.Error(),
 		})
 	}
@@ -467,7 +586,309 @@ func (h *MobileHandler) ReviseApplication(c *fiber.Ctx) error {
 	return c.Status(http.StatusOK).JSON(fiber.Map{
 		"statusCode": 200,
 		"status":     true,
-		"message":    "Application revised successfully",
+		"message":    "Application withdrawn successfully",
+	})
+}
+
+// GetApplicationLoan shows the repayment schedule of an approved funding application.
+func (h *MobileHandler) GetApplicationLoan(c *fiber.Ctx) error {
+	userData, ok := c.Locals("user_data").(dto.UserData)
+	if !ok {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{
+			"statusCode": 401,
+			"status":     false,
+			"message":    "Unauthorized",
+		})
+	}
+
+	intID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    "Invalid application ID",
+		})
+	}
+
+	loan, err := h.mobileService.GetApplicationLoan(c.Context(), int(userData.ID), intID)
+	if err != nil {
+		return c.Status(http.StatusNotFound).JSON(fiber.Map{
+			"statusCode": 404,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"statusCode": 200,
+		"status":     true,
+		"message":    "Get application loan",
+		"data":       loan,
+	})
+}
+
+// GetMyCertificates lists the training certificates issued to the UMKM.
+func (h *MobileHandler) GetMyCertificates(c *fiber.Ctx) error {
+	userData, ok := c.Locals("user_data").(dto.UserData)
+	if !ok {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{
+			"statusCode": 401,
+			"status":     false,
+			"message":    "Unauthorized",
+		})
+	}
+
+	certificates, err := h.mobileService.GetMyCertificates(c.Context(), int(userData.ID))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"statusCode": 200,
+		"status":     true,
+		"message":    "Get my certificates",
+		"data":       certificates,
+	})
+}
+
+// GetMyCertifications lists the certificates the UMKM earned through certification programs.
+func (h *MobileHandler) GetMyCertifications(c *fiber.Ctx) error {
+	userData, ok := c.Locals("user_data").(dto.UserData)
+	if !ok {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{
+			"statusCode": 401,
+			"status":     false,
+			"message":    "Unauthorized",
+		})
+	}
+
+	certifications, err := h.mobileService.GetMyCertifications(c.Context(), int(userData.ID))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"statusCode": 200,
+		"status":     true,
+		"message":    "Get my certifications",
+		"data":       certifications,
+	})
+}
+
+// CreateApplicationDraft saves an incomplete application to be finished later.
+func (h *MobileHandler) CreateApplicationDraft(c *fiber.Ctx) error {
+	userData, ok := c.Locals("user_data").(dto.UserData)
+	if !ok {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{
+			"statusCode": 401,
+			"status":     false,
+			"message":    "Unauthorized",
+		})
+	}
+
+	var request dto.CreateApplicationDraft
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	draft, err := h.mobileService.CreateApplicationDraft(c.Context(), int(userData.ID), request)
+	if err != nil {
+		return applicationErrorResponse(c, err)
+	}
+
+	return c.Status(http.StatusCreated).JSON(fiber.Map{
+		"statusCode": 201,
+		"status":     true,
+		"message":    "Application draft created successfully",
+		"data":       draft,
+	})
+}
+
+// UpdateApplicationDraft saves the fields sent on top of an existing draft.
+func (h *MobileHandler) UpdateApplicationDraft(c *fiber.Ctx) error {
+	userData, ok := c.Locals("user_data").(dto.UserData)
+	if !ok {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{
+			"statusCode": 401,
+			"status":     false,
+			"message":    "Unauthorized",
+		})
+	}
+
+	id := c.Params("id")
+	intID, err := strconv.Atoi(id)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    "Invalid application ID",
+		})
+	}
+
+	var request dto.ApplicationDraftFields
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	draft, err := h.mobileService.UpdateApplicationDraft(c.Context(), int(userData.ID), intID, request)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"statusCode": 200,
+		"status":     true,
+		"message":    "Application draft saved successfully",
+		"data":       draft,
+	})
+}
+
+// ValidateApplicationDraft lists what still blocks a draft from being submitted.
+func (h *MobileHandler) ValidateApplicationDraft(c *fiber.Ctx) error {
+	userData, ok := c.Locals("user_data").(dto.UserData)
+	if !ok {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{
+			"statusCode": 401,
+			"status":     false,
+			"message":    "Unauthorized",
+		})
+	}
+
+	id := c.Params("id")
+	intID, err := strconv.Atoi(id)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    "Invalid application ID",
+		})
+	}
+
+	result, err := h.mobileService.ValidateApplicationDraft(c.Context(), int(userData.ID), intID)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"statusCode": 200,
+		"status":     true,
+		"message":    "Application draft validated",
+		"data":       result,
+	})
+}
+
+// SubmitApplicationDraft sends a complete draft to screening.
+func (h *MobileHandler) SubmitApplicationDraft(c *fiber.Ctx) error {
+	userData, ok := c.Locals("user_data").(dto.UserData)
+	if !ok {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{
+			"statusCode": 401,
+			"status":     false,
+			"message":    "Unauthorized",
+		})
+	}
+
+	id := c.Params("id")
+	intID, err := strconv.Atoi(id)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    "Invalid application ID",
+		})
+	}
+
+	if err := h.mobileService.SubmitApplicationDraft(c.Context(), int(userData.ID), intID); err != nil {
+		return applicationErrorResponse(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"statusCode": 200,
+		"status":     true,
+		"message":    "Application submitted successfully",
+	})
+}
+
+// applicationErrorResponse reports a rejected submission together with the
+// reasons behind it when the service gives them.
+func applicationErrorResponse(c *fiber.Ctx, err error) error {
+	code := statusCodeFromError(err)
+	response := fiber.Map{
+		"statusCode": code,
+		"status":     false,
+		"message":    err.Error(),
+	}
+	var incompleteErr *service.DraftIncompleteError
+	if errors.As(err, &incompleteErr) {
+		response["data"] = incompleteErr.Issues
+	}
+	var closedErr *service.ProgramClosedError
+	if errors.As(err, &closedErr) {
+		response["data"] = closedErr.Reasons
+	}
+	var ineligibleErr *service.IneligibleError
+	if errors.As(err, &ineligibleErr) {
+		response["data"] = ineligibleErr.UnmetRules
+	}
+	return c.Status(code).JSON(response)
+}
+
+func (h *MobileHandler) DeleteApplicationDraft(c *fiber.Ctx) error {
+	userData, ok := c.Locals("user_data").(dto.UserData)
+	if !ok {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{
+			"statusCode": 401,
+			"status":     false,
+			"message":    "Unauthorized",
+		})
+	}
+
+	id := c.Params("id")
+	intID, err := strconv.Atoi(id)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    "Invalid application ID",
+		})
+	}
+
+	if err := h.mobileService.DeleteApplicationDraft(c.Context(), int(userData.ID), intID); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"statusCode": 200,
+		"status":     true,
+		"message":    "Application draft deleted successfully",
 	})
 }
 
@@ -0,0 +1,188 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"UMKMGo-backend/internal/service"
+	"UMKMGo-backend/internal/types/dto"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type reviewQueueHandler struct {
+	reviewQueueService service.ReviewQueueService
+}
+
+func NewReviewQueueHandler(reviewQueueService service.ReviewQueueService) *reviewQueueHandler {
+	return &reviewQueueHandler{
+		reviewQueueService: reviewQueueService,
+	}
+}
+
+func (h *reviewQueueHandler) GetQueue(c *fiber.Ctx) error {
+	// Get user data from context
+	userData, ok := c.Locals("user_data").(dto.UserData)
+	if !ok {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{
+			"statusCode": 401,
+			"status":     false,
+			"message":    "Unauthorized",
+		})
+	}
+
+	queue, err := h.reviewQueueService.GetQueue(c.Context(), int(userData.ID), int(userData.Role), c.Query("stage", ""))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"statusCode": 200,
+		"status":     true,
+		"message":    "Get review queue",
+		"data":       queue,
+	})
+}
+
+func (h *reviewQueueHandler) ClaimNext(c *fiber.Ctx) error {
+	var request dto.ReviewQueueRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	// Get user data from context
+	userData, ok := c.Locals("user_data").(dto.UserData)
+	if !ok {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{
+			"statusCode": 401,
+			"status":     false,
+			"message":    "Unauthorized",
+		})
+	}
+
+	claim, err := h.reviewQueueService.ClaimNext(c.Context(), int(userData.ID), int(userData.Role), request.Stage)
+	if err != nil {
+		code := statusCodeFromError(err)
+		return c.Status(code).JSON(fiber.Map{
+			"statusCode": code,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"statusCode": 200,
+		"status":     true,
+		"message":    "Application claimed for review",
+		"data":       claim,
+	})
+}
+
+func (h *reviewQueueHandler) ClaimApplication(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    "Invalid application ID",
+		})
+	}
+
+	// Get user data from context
+	userData, ok := c.Locals("user_data").(dto.UserData)
+	if !ok {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{
+			"statusCode": 401,
+			"status":     false,
+			"message":    "Unauthorized",
+		})
+	}
+
+	claim, err := h.reviewQueueService.ClaimApplication(c.Context(), int(userData.ID), int(userData.Role), id)
+	if err != nil {
+		code := statusCodeFromError(err)
+		return c.Status(code).JSON(fiber.Map{
+			"statusCode": code,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"statusCode": 200,
+		"status":     true,
+		"message":    "Application claimed for review",
+		"data":       claim,
+	})
+}
+
+func (h *reviewQueueHandler) ReleaseClaim(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    "Invalid application ID",
+		})
+	}
+
+	// Get user data from context
+	userData, ok := c.Locals("user_data").(dto.UserData)
+	if !ok {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{
+			"statusCode": 401,
+			"status":     false,
+			"message":    "Unauthorized",
+		})
+	}
+
+	if err := h.reviewQueueService.ReleaseClaim(c.Context(), int(userData.ID), id); err != nil {
+		code := statusCodeFromError(err)
+		return c.Status(code).JSON(fiber.Map{
+			"statusCode": code,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"statusCode": 200,
+		"status":     true,
+		"message":    "Review claim released",
+	})
+}
+
+func (h *reviewQueueHandler) AssignApplications(c *fiber.Ctx) error {
+	var request dto.ReviewQueueRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	report, err := h.reviewQueueService.AssignApplications(c.Context(), request.Stage)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"statusCode": 200,
+		"status":     true,
+		"message":    "Applications assigned to reviewers",
+		"data":       report,
+	})
+}
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"net/http"
+	"strconv"
 
 	"UMKMGo-backend/internal/service"
 	"UMKMGo-backend/internal/types/dto"
@@ -10,12 +11,14 @@ import (
 )
 
 type slaHandler struct {
-	slaService service.SLAService
+	slaService        service.SLAService
+	slaMonitorService service.SLAMonitorService
 }
 
-func NewSLAHandler(slaService service.SLAService) *slaHandler {
+func NewSLAHandler(slaService service.SLAService, slaMonitorService service.SLAMonitorService) *slaHandler {
 	return &slaHandler{
-		slaService: slaService,
+		slaService:        slaService,
+		slaMonitorService: slaMonitorService,
 	}
 }
 
@@ -66,7 +69,16 @@ func (h *slaHandler) UpdateSLAScreening(c *fiber.Ctx) error {
 		})
 	}
 
-	result, err := h.slaService.UpdateSLAScreening(c.Context(), slaRequest)
+	userData, ok := c.Locals("user_data").(dto.UserData)
+	if !ok {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{
+			"statusCode": 401,
+			"status":     false,
+			"message":    "Unauthorized",
+		})
+	}
+
+	result, err := h.slaService.UpdateSLAScreening(c.Context(), int(userData.ID), slaRequest)
 	if err != nil {
 		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
 			"statusCode": 400,
@@ -94,7 +106,16 @@ func (h *slaHandler) UpdateSLAFinal(c *fiber.Ctx) error {
 		})
 	}
 
-	result, err := h.slaService.UpdateSLAFinal(c.Context(), slaRequest)
+	userData, ok := c.Locals("user_data").(dto.UserData)
+	if !ok {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{
+			"statusCode": 401,
+			"status":     false,
+			"message":    "Unauthorized",
+		})
+	}
+
+	result, err := h.slaService.UpdateSLAFinal(c.Context(), int(userData.ID), slaRequest)
 	if err != nil {
 		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
 			"statusCode": 400,
@@ -111,6 +132,112 @@ func (h *slaHandler) UpdateSLAFinal(c *fiber.Ctx) error {
 	})
 }
 
+func (h *slaHandler) GetSLARules(c *fiber.Ctx) error {
+	result, err := h.slaService.GetSLARules(c.Context(), c.Query("status"), c.Query("application_type"), c.QueryInt("program_id", 0))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"statusCode": 200,
+		"status":     true,
+		"message":    "SLA rules retrieved successfully",
+		"data":       result,
+	})
+}
+
+func (h *slaHandler) CreateSLARule(c *fiber.Ctx) error {
+	var slaRequest dto.SLA
+	if err := c.BodyParser(&slaRequest); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	userData, ok := c.Locals("user_data").(dto.UserData)
+	if !ok {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{
+			"statusCode": 401,
+			"status":     false,
+			"message":    "Unauthorized",
+		})
+	}
+
+	result, err := h.slaService.CreateSLARule(c.Context(), int(userData.ID), slaRequest)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusCreated).JSON(fiber.Map{
+		"statusCode": 201,
+		"status":     true,
+		"message":    "SLA rule created successfully",
+		"data":       result,
+	})
+}
+
+func (h *slaHandler) DeleteSLARule(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    "Invalid SLA ID",
+		})
+	}
+
+	userData, ok := c.Locals("user_data").(dto.UserData)
+	if !ok {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{
+			"statusCode": 401,
+			"status":     false,
+			"message":    "Unauthorized",
+		})
+	}
+
+	if err := h.slaService.DeleteSLARule(c.Context(), int(userData.ID), id); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"statusCode": 200,
+		"status":     true,
+		"message":    "SLA rule deleted successfully",
+	})
+}
+
+func (h *slaHandler) GetSLAChangeLogs(c *fiber.Ctx) error {
+	result, err := h.slaService.GetSLAChangeLogs(c.Context(), c.QueryInt("sla_id", 0))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"statusCode": 200,
+		"status":     true,
+		"message":    "SLA change logs retrieved successfully",
+		"data":       result,
+	})
+}
+
 func (h *slaHandler) ExportApplications(c *fiber.Ctx) error {
 	var exportRequest dto.ExportRequest
 	err := c.BodyParser(&exportRequest)
@@ -174,3 +301,21 @@ func (h *slaHandler) ExportPrograms(c *fiber.Ctx) error {
 
 	return c.Send(fileData)
 }
+
+func (h *slaHandler) CheckBreaches(c *fiber.Ctx) error {
+	report, err := h.slaMonitorService.CheckBreaches(c.Context())
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"statusCode": 200,
+		"status":     true,
+		"message":    "SLA breach check completed",
+		"data":       report,
+	})
+}
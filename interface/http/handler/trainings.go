@@ -0,0 +1,344 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"UMKMGo-backend/internal/service"
+	"UMKMGo-backend/internal/types/dto"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type trainingHandler struct {
+	trainingService service.TrainingService
+}
+
+func NewTrainingHandler(trainingService service.TrainingService) *trainingHandler {
+	return &trainingHandler{
+		trainingService: trainingService,
+	}
+}
+
+// Sessions
+func (h *trainingHandler) GetSessions(c *fiber.Ctx) error {
+	programID, err := strconv.Atoi(c.Params("programId"))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    "Invalid program ID",
+		})
+	}
+
+	sessions, err := h.trainingService.GetSessions(c.Context(), programID)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"statusCode": 200,
+		"status":     true,
+		"message":    "Get training sessions",
+		"data":       sessions,
+	})
+}
+
+func (h *trainingHandler) CreateSession(c *fiber.Ctx) error {
+	programID, err := strconv.Atoi(c.Params("programId"))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    "Invalid program ID",
+		})
+	}
+
+	var request dto.TrainingSessionRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	session, err := h.trainingService.CreateSession(c.Context(), programID, request)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusCreated).JSON(fiber.Map{
+		"statusCode": 201,
+		"status":     true,
+		"message":    "Training session created successfully",
+		"data":       session,
+	})
+}
+
+func (h *trainingHandler) UpdateSession(c *fiber.Ctx) error {
+	sessionID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    "Invalid session ID",
+		})
+	}
+
+	var request dto.TrainingSessionRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	session, err := h.trainingService.UpdateSession(c.Context(), sessionID, request)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"statusCode": 200,
+		"status":     true,
+		"message":    "Training session updated successfully",
+		"data":       session,
+	})
+}
+
+func (h *trainingHandler) DeleteSession(c *fiber.Ctx) error {
+	sessionID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    "Invalid session ID",
+		})
+	}
+
+	if err := h.trainingService.DeleteSession(c.Context(), sessionID); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"statusCode": 200,
+		"status":     true,
+		"message":    "Training session deleted successfully",
+	})
+}
+
+// Attendance
+func (h *trainingHandler) GetSessionAttendance(c *fiber.Ctx) error {
+	sessionID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    "Invalid session ID",
+		})
+	}
+
+	attendance, err := h.trainingService.GetSessionAttendance(c.Context(), sessionID)
+	if err != nil {
+		return c.Status(http.StatusNotFound).JSON(fiber.Map{
+			"statusCode": 404,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"statusCode": 200,
+		"status":     true,
+		"message":    "Get session attendance",
+		"data":       attendance,
+	})
+}
+
+func (h *trainingHandler) RecordAttendance(c *fiber.Ctx) error {
+	sessionID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    "Invalid session ID",
+		})
+	}
+
+	var request dto.AttendanceRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	// Get user data from context
+	userData, ok := c.Locals("user_data").(dto.UserData)
+	if !ok {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{
+			"statusCode": 401,
+			"status":     false,
+			"message":    "Unauthorized",
+		})
+	}
+
+	attendance, err := h.trainingService.RecordAttendance(c.Context(), int(userData.ID), sessionID, request)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"statusCode": 200,
+		"status":     true,
+		"message":    "Attendance recorded successfully",
+		"data":       attendance,
+	})
+}
+
+func (h *trainingHandler) GetParticipants(c *fiber.Ctx) error {
+	programID, err := strconv.Atoi(c.Params("programId"))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    "Invalid program ID",
+		})
+	}
+
+	participants, err := h.trainingService.GetParticipants(c.Context(), programID)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"statusCode": 200,
+		"status":     true,
+		"message":    "Get training participants",
+		"data":       participants,
+	})
+}
+
+// CheckIn records attendance by scanning the QR code on a participant's UMKM card.
+func (h *trainingHandler) CheckIn(c *fiber.Ctx) error {
+	sessionID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    "Invalid session ID",
+		})
+	}
+
+	var request dto.CheckInRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	// Get user data from context
+	userData, ok := c.Locals("user_data").(dto.UserData)
+	if !ok {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{
+			"statusCode": 401,
+			"status":     false,
+			"message":    "Unauthorized",
+		})
+	}
+
+	result, err := h.trainingService.CheckIn(c.Context(), int(userData.ID), sessionID, request)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	message := "Checked in successfully"
+	if result.AlreadyCheckedIn {
+		message = "Participant has already checked in"
+	}
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"statusCode": 200,
+		"status":     true,
+		"message":    message,
+		"data":       result,
+	})
+}
+
+// Certificates
+func (h *trainingHandler) IssueCertificates(c *fiber.Ctx) error {
+	programID, err := strconv.Atoi(c.Params("programId"))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    "Invalid program ID",
+		})
+	}
+
+	certificates, err := h.trainingService.IssueCertificates(c.Context(), programID)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"statusCode": 400,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"statusCode": 200,
+		"status":     true,
+		"message":    "Certificates issued successfully",
+		"data":       certificates,
+	})
+}
+
+func (h *trainingHandler) VerifyCertificate(c *fiber.Ctx) error {
+	verification, err := h.trainingService.VerifyCertificate(c.Context(), c.Params("code"))
+	if err != nil {
+		return c.Status(http.StatusNotFound).JSON(fiber.Map{
+			"statusCode": 404,
+			"status":     false,
+			"message":    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"statusCode": 200,
+		"status":     true,
+		"message":    "Certificate is valid",
+		"data":       verification,
+	})
+}
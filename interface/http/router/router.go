@@ -45,12 +45,20 @@ func SetupRouter() *fiber.App {
 
 	version := router.Group("/v1")
 
+	// Public routes, registered before the groups that add auth middleware
+	routes.VerificationRoutes(version, db.DB, storage.MinioClient)
+
 	routes.UserRoutes(version, db.DB, redis.GetRedisRepository(), storage.MinioClient)
 	routes.ProgramRoutes(version, db.DB, redis.GetRedisRepository(), storage.MinioClient)
 	routes.ApplicationRoutes(version, db.DB, redis.GetRedisRepository())
 	routes.DashboardRoutes(version, db.DB)
 	routes.SLARoutes(version, db.DB)
 	routes.NewsRoutes(version, db.DB, storage.MinioClient)
+	routes.AdminNotificationRoutes(version, db.DB)
+	routes.LoanRoutes(version, db.DB)
+	routes.TrainingRoutes(version, db.DB, storage.MinioClient)
+	routes.CertificationRoutes(version, db.DB, storage.MinioClient)
+	routes.KartuRoutes(version, db.DB, storage.MinioClient)
 	routes.MobileRoutes(version, db.DB, storage.MinioClient)
 
 	for _, routes := range router.Stack() {
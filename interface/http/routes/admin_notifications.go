@@ -0,0 +1,29 @@
+package routes
+
+import (
+	"UMKMGo-backend/interface/http/handler"
+	"UMKMGo-backend/interface/http/middleware"
+	"UMKMGo-backend/internal/repository"
+	"UMKMGo-backend/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+func AdminNotificationRoutes(version fiber.Router, db *gorm.DB) {
+	adminNotificationRepo := repository.NewAdminNotificationRepository(db)
+
+	adminNotificationService := service.NewAdminNotificationService(adminNotificationRepo)
+
+	adminNotificationHandler := handler.NewAdminNotificationHandler(adminNotificationService)
+
+	version.Use(middleware.AuthMiddleware())
+
+	notifications := version.Group("/notifications")
+	{
+		notifications.Get("/", adminNotificationHandler.GetNotifications)
+		notifications.Get("/unread-count", adminNotificationHandler.GetUnreadCount)
+		notifications.Put("/mark-as-read/:id", adminNotificationHandler.MarkAsRead)
+		notifications.Put("/mark-all-as-read", adminNotificationHandler.MarkAllAsRead)
+	}
+}
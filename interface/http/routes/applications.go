@@ -1,6 +1,7 @@
 package routes
 
 import (
+	"UMKMGo-backend/config/env"
 	"UMKMGo-backend/config/redis"
 	"UMKMGo-backend/interface/http/handler"
 	"UMKMGo-backend/interface/http/middleware"
@@ -17,13 +18,18 @@ func ApplicationRoutes(version fiber.Router, db *gorm.DB, redis redis.RedisRepos
 	userRepo := repository.NewUsersRepository(db)
 	notificationRepo := repository.NewNotificationRepository(db)
 	slaRepo := repository.NewSLARepository(db)
+	holidayRepo := repository.NewHolidayRepository(db)
 	vaultDecryptLogRepo := repository.NewVaultDecryptLogRepository(db)
+	adminNotificationRepo := repository.NewAdminNotificationRepository(db)
+	unitOfWork := repository.NewUnitOfWork(db)
 
 	// Service initialization
-	applicationService := service.NewApplicationsService(applicationRepo, userRepo, notificationRepo, slaRepo, vaultDecryptLogRepo)
+	applicationService := service.NewApplicationsService(applicationRepo, userRepo, notificationRepo, slaRepo, holidayRepo, vaultDecryptLogRepo, unitOfWork, redis)
+	reviewQueueService := service.NewReviewQueueService(applicationRepo, userRepo, adminNotificationRepo, redis, env.Cfg.ReviewQueue.ClaimLeaseMinutes, env.Cfg.ReviewQueue.AssignmentStrategy)
 
 	// Handler initialization
 	applicationHandler := handler.NewApplicationsHandler(applicationService)
+	reviewQueueHandler := handler.NewReviewQueueHandler(reviewQueueService)
 
 	// Apply auth middleware
 	version.Use(middleware.AuthMiddleware())
@@ -31,15 +37,36 @@ func ApplicationRoutes(version fiber.Router, db *gorm.DB, redis redis.RedisRepos
 	applications := version.Group("/applications")
 	{
 		applications.Get("/", applicationHandler.GetAllApplications)
+
+		// Reviewer work queue, registered before /:id
+		applications.Get("/queue", reviewQueueHandler.GetQueue)
+		applications.Post("/queue/claim-next", reviewQueueHandler.ClaimNext)
+		applications.Post("/queue/claim/:id", reviewQueueHandler.ClaimApplication)
+		applications.Delete("/queue/claim/:id", reviewQueueHandler.ReleaseClaim)
+		applications.Post("/queue/assign", reviewQueueHandler.AssignApplications)
+
+		// Scoring rubrics and rankings per program
+		applications.Get("/programs/:programId/rubric", applicationHandler.GetProgramRubric)
+		applications.Put("/programs/:programId/rubric", applicationHandler.SetProgramRubric)
+		applications.Get("/programs/:programId/ranking", applicationHandler.GetProgramRanking)
+
 		applications.Get("/:id", applicationHandler.GetApplicationByID)
+		applications.Get("/:id/documents/versions", applicationHandler.GetDocumentComparison)
 
 		// Screening decisions
 		applications.Put("/screening-approve/:id", applicationHandler.ScreeningApprove)
 		applications.Put("/screening-reject/:id", applicationHandler.ScreeningReject)
 		applications.Put("/screening-revise/:id", applicationHandler.ScreeningRevise)
+		applications.Put("/:id/documents/:documentId/review", applicationHandler.ReviewDocument)
+		applications.Put("/:id/scores", applicationHandler.ScoreApplication)
 
 		// Final decisions
 		applications.Put("/final-approve/:id", applicationHandler.FinalApprove)
 		applications.Put("/final-reject/:id", applicationHandler.FinalReject)
+
+		// Bulk decisions
+		applications.Post("/bulk/screening", applicationHandler.BulkScreeningDecision)
+		applications.Post("/bulk/final", applicationHandler.BulkFinalDecision)
+		applications.Post("/bulk/approve-top", applicationHandler.BulkApproveTop)
 	}
 }
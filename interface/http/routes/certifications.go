@@ -0,0 +1,33 @@
+package routes
+
+import (
+	"UMKMGo-backend/config/env"
+	"UMKMGo-backend/config/storage"
+	"UMKMGo-backend/interface/http/handler"
+	"UMKMGo-backend/interface/http/middleware"
+	"UMKMGo-backend/internal/repository"
+	"UMKMGo-backend/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+func CertificationRoutes(version fiber.Router, db *gorm.DB, minio *storage.MinIOManager) {
+	certificationRepo := repository.NewCertificationRepository(db)
+	applicationRepo := repository.NewApplicationsRepository(db)
+	notificationRepo := repository.NewNotificationRepository(db)
+
+	certificationService := service.NewCertificationService(certificationRepo, applicationRepo, notificationRepo, minio, env.Cfg.Certifications.VerifyURL, env.Cfg.Certifications.ReminderDays)
+
+	certificationHandler := handler.NewCertificationHandler(certificationService)
+
+	version.Use(middleware.AuthMiddleware())
+
+	certifications := version.Group("/certifications")
+	{
+		certifications.Get("/", certificationHandler.GetCertifications)
+		certifications.Get("/:id", certificationHandler.GetCertificationByID)
+		certifications.Post("/application/:applicationId", certificationHandler.RecordCertification)
+		certifications.Put("/:id", certificationHandler.UpdateCertification)
+	}
+}
@@ -0,0 +1,37 @@
+package routes
+
+import (
+	"UMKMGo-backend/config/env"
+	"UMKMGo-backend/config/storage"
+	"UMKMGo-backend/interface/http/handler"
+	"UMKMGo-backend/interface/http/middleware"
+	"UMKMGo-backend/internal/repository"
+	"UMKMGo-backend/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+func KartuRoutes(version fiber.Router, db *gorm.DB, minio *storage.MinIOManager) {
+	kartuRepo := repository.NewKartuRepository(db)
+	notificationRepo := repository.NewNotificationRepository(db)
+	vaultDecryptLogRepo := repository.NewVaultDecryptLogRepository(db)
+
+	kartuService := service.NewKartuService(kartuRepo, notificationRepo, vaultDecryptLogRepo, minio, env.Cfg.Kartu.VerifyURL, env.Cfg.Kartu.ValidityMonths)
+
+	kartuHandler := handler.NewKartuHandler(kartuService)
+
+	version.Use(middleware.AuthMiddleware())
+
+	kartu := version.Group("/kartu")
+	{
+		kartu.Get("/renewals", kartuHandler.GetRenewals)
+		kartu.Post("/renewals/:id/approve", kartuHandler.ApproveRenewal)
+		kartu.Post("/renewals/:id/reject", kartuHandler.RejectRenewal)
+		kartu.Get("/:umkmId", kartuHandler.GetKartu)
+		kartu.Post("/:umkmId/suspend", kartuHandler.SuspendKartu)
+		kartu.Post("/:umkmId/reactivate", kartuHandler.ReactivateKartu)
+		kartu.Post("/:umkmId/upgrade", kartuHandler.UpgradeKartu)
+		kartu.Post("/:umkmId/qr/rotate", kartuHandler.RotateQRCode)
+	}
+}
@@ -0,0 +1,29 @@
+package routes
+
+import (
+	"UMKMGo-backend/interface/http/handler"
+	"UMKMGo-backend/interface/http/middleware"
+	"UMKMGo-backend/internal/repository"
+	"UMKMGo-backend/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+func LoanRoutes(version fiber.Router, db *gorm.DB) {
+	loanRepo := repository.NewLoanRepository(db)
+	unitOfWork := repository.NewUnitOfWork(db)
+
+	loanService := service.NewLoanService(loanRepo, unitOfWork)
+
+	loanHandler := handler.NewLoanHandler(loanService)
+
+	version.Use(middleware.AuthMiddleware())
+
+	loans := version.Group("/loans")
+	{
+		loans.Get("/", loanHandler.GetLoans)
+		loans.Get("/application/:applicationId", loanHandler.GetLoanByApplicationID)
+		loans.Post("/:id/repayments", loanHandler.RecordRepayment)
+	}
+}
@@ -1,6 +1,7 @@
 package routes
 
 import (
+	"UMKMGo-backend/config/env"
 	"UMKMGo-backend/config/storage"
 	"UMKMGo-backend/interface/http/handler"
 	"UMKMGo-backend/interface/http/middleware"
@@ -18,13 +19,21 @@ func MobileRoutes(version fiber.Router, db *gorm.DB, minio *storage.MinIOManager
 	notificationRepo := repository.NewNotificationRepository(db)
 	applicationRepo := repository.NewApplicationsRepository(db)
 	slaRepo := repository.NewSLARepository(db)
+	holidayRepo := repository.NewHolidayRepository(db)
 	vaultDecryptLogRepo := repository.NewVaultDecryptLogRepository(db)
+	loanRepo := repository.NewLoanRepository(db)
+	trainingRepo := repository.NewTrainingRepository(db)
+	certificationRepo := repository.NewCertificationRepository(db)
+	kartuRepo := repository.NewKartuRepository(db)
+	unitOfWork := repository.NewUnitOfWork(db)
 
 	// Service initialization
-	mobileService := service.NewMobileService(mobileRepo, programRepo, notificationRepo, vaultDecryptLogRepo, applicationRepo, slaRepo, minio)
+	mobileService := service.NewMobileService(mobileRepo, programRepo, notificationRepo, vaultDecryptLogRepo, applicationRepo, slaRepo, holidayRepo, loanRepo, trainingRepo, certificationRepo, unitOfWork, minio)
+	kartuService := service.NewKartuService(kartuRepo, notificationRepo, vaultDecryptLogRepo, minio, env.Cfg.Kartu.VerifyURL, env.Cfg.Kartu.ValidityMonths)
 
 	// Handler initialization
 	mobileHandler := handler.NewMobileHandler(mobileService)
+	kartuHandler := handler.NewKartuHandler(kartuService)
 
 	// Apply auth middleware for all mobile routes
 	version.Use(middleware.MobileAuthMiddleware())
@@ -40,6 +49,7 @@ func MobileRoutes(version fiber.Router, db *gorm.DB, minio *storage.MinIOManager
 			programs.Get("/certification", mobileHandler.GetCertificationPrograms)
 			programs.Get("/funding", mobileHandler.GetFundingPrograms)
 			programs.Get("/:id", mobileHandler.GetProgramDetail)
+			programs.Post("/:id/simulate", mobileHandler.SimulateFunding)
 		}
 
 		// UMKM Profile
@@ -47,6 +57,7 @@ func MobileRoutes(version fiber.Router, db *gorm.DB, minio *storage.MinIOManager
 		{
 			profile.Get("/", mobileHandler.GetUMKMProfile)
 			profile.Put("/", mobileHandler.UpdateUMKMProfile)
+			profile.Post("/qr/rotate", kartuHandler.RotateMyQRCode)
 		}
 
 		// Documents
@@ -62,11 +73,26 @@ func MobileRoutes(version fiber.Router, db *gorm.DB, minio *storage.MinIOManager
 			applications.Post("/training", mobileHandler.CreateTrainingApplication)
 			applications.Post("/certification", mobileHandler.CreateCertificationApplication)
 			applications.Post("/funding", mobileHandler.CreateFundingApplication)
+			applications.Post("/drafts", mobileHandler.CreateApplicationDraft)
+			applications.Patch("/drafts/:id", mobileHandler.UpdateApplicationDraft)
+			applications.Post("/drafts/:id/validate", mobileHandler.ValidateApplicationDraft)
+			applications.Post("/drafts/:id/submit", mobileHandler.SubmitApplicationDraft)
+			applications.Delete("/drafts/:id", mobileHandler.DeleteApplicationDraft)
 			applications.Get("/", mobileHandler.GetApplicationList)
 			applications.Get("/:id", mobileHandler.GetApplicationDetail)
 			applications.Put("/:id", mobileHandler.ReviseApplication)
+			applications.Post("/:id/withdraw", mobileHandler.WithdrawApplication)
+			applications.Get("/:id/loan", mobileHandler.GetApplicationLoan)
 		}
 
+		// Kartu
+		mobile.Get("/kartu", kartuHandler.GetMyKartu)
+		mobile.Post("/kartu/renewal", kartuHandler.RequestRenewal)
+
+		// Certificates
+		mobile.Get("/certificates", mobileHandler.GetMyCertificates)
+		mobile.Get("/certifications", mobileHandler.GetMyCertifications)
+
 		// Notifications
 		notifications := mobile.Group("/notifications")
 		{
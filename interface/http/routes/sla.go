@@ -1,6 +1,7 @@
 package routes
 
 import (
+	"UMKMGo-backend/config/env"
 	"UMKMGo-backend/interface/http/handler"
 	"UMKMGo-backend/interface/http/middleware"
 	"UMKMGo-backend/internal/repository"
@@ -12,10 +13,17 @@ import (
 
 func SLARoutes(version fiber.Router, db *gorm.DB) {
 	slaRepo := repository.NewSLARepository(db)
+	holidayRepo := repository.NewHolidayRepository(db)
+	applicationRepo := repository.NewApplicationsRepository(db)
+	adminNotificationRepo := repository.NewAdminNotificationRepository(db)
+	unitOfWork := repository.NewUnitOfWork(db)
 
 	slaService := service.NewSLAService(slaRepo)
+	holidayService := service.NewHolidayService(holidayRepo)
+	slaMonitorService := service.NewSLAMonitorService(applicationRepo, adminNotificationRepo, slaRepo, holidayRepo, unitOfWork, env.Cfg.SLAMonitor.BreachPolicy)
 
-	slaHandler := handler.NewSLAHandler(slaService)
+	slaHandler := handler.NewSLAHandler(slaService, slaMonitorService)
+	holidayHandler := handler.NewHolidayHandler(holidayService)
 
 	version.Use(middleware.AuthMiddleware())
 
@@ -27,5 +35,19 @@ func SLARoutes(version fiber.Router, db *gorm.DB) {
 		sla.Put("/final", slaHandler.UpdateSLAFinal)
 		sla.Post("/export-applications", slaHandler.ExportApplications)
 		sla.Post("/export-programs", slaHandler.ExportPrograms)
+		sla.Post("/check-breaches", slaHandler.CheckBreaches)
+		sla.Get("/rules", slaHandler.GetSLARules)
+		sla.Post("/rules", slaHandler.CreateSLARule)
+		sla.Delete("/rules/:id", slaHandler.DeleteSLARule)
+		sla.Get("/change-logs", slaHandler.GetSLAChangeLogs)
+	}
+
+	holidays := sla.Group("/holidays")
+	{
+		holidays.Get("/", holidayHandler.GetHolidays)
+		holidays.Get("/:id", holidayHandler.GetHolidayByID)
+		holidays.Post("/", holidayHandler.CreateHoliday)
+		holidays.Put("/:id", holidayHandler.UpdateHoliday)
+		holidays.Delete("/:id", holidayHandler.DeleteHoliday)
 	}
 }
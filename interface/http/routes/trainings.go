@@ -0,0 +1,38 @@
+package routes
+
+import (
+	"UMKMGo-backend/config/storage"
+	"UMKMGo-backend/interface/http/handler"
+	"UMKMGo-backend/interface/http/middleware"
+	"UMKMGo-backend/internal/repository"
+	"UMKMGo-backend/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+func TrainingRoutes(version fiber.Router, db *gorm.DB, minio *storage.MinIOManager) {
+	trainingRepo := repository.NewTrainingRepository(db)
+	programRepo := repository.NewProgramsRepository(db)
+	notificationRepo := repository.NewNotificationRepository(db)
+	kartuRepo := repository.NewKartuRepository(db)
+
+	trainingService := service.NewTrainingService(trainingRepo, programRepo, notificationRepo, kartuRepo, minio)
+
+	trainingHandler := handler.NewTrainingHandler(trainingService)
+
+	version.Use(middleware.AuthMiddleware())
+
+	trainings := version.Group("/trainings")
+	{
+		trainings.Get("/programs/:programId/sessions", trainingHandler.GetSessions)
+		trainings.Post("/programs/:programId/sessions", trainingHandler.CreateSession)
+		trainings.Get("/programs/:programId/participants", trainingHandler.GetParticipants)
+		trainings.Post("/programs/:programId/certificates", trainingHandler.IssueCertificates)
+		trainings.Put("/sessions/:id", trainingHandler.UpdateSession)
+		trainings.Delete("/sessions/:id", trainingHandler.DeleteSession)
+		trainings.Get("/sessions/:id/attendance", trainingHandler.GetSessionAttendance)
+		trainings.Put("/sessions/:id/attendance", trainingHandler.RecordAttendance)
+		trainings.Post("/sessions/:id/check-in", trainingHandler.CheckIn)
+	}
+}
@@ -1,6 +1,7 @@
 package routes
 
 import (
+	"UMKMGo-backend/config/env"
 	"UMKMGo-backend/config/redis"
 	"UMKMGo-backend/config/storage"
 	"UMKMGo-backend/interface/http/handler"
@@ -15,7 +16,11 @@ import (
 func UserRoutes(version fiber.Router, db *gorm.DB, redis redis.RedisRepository, minio *storage.MinIOManager) {
 	User_repo := repository.NewUsersRepository(db)
 	OTP_repo := repository.NewOTPRepository(db)
-	User_serv := service.NewUsersService(User_repo, OTP_repo, redis, minio)
+	Kartu_repo := repository.NewKartuRepository(db)
+	Notification_repo := repository.NewNotificationRepository(db)
+	VaultDecryptLog_repo := repository.NewVaultDecryptLogRepository(db)
+	Kartu_serv := service.NewKartuService(Kartu_repo, Notification_repo, VaultDecryptLog_repo, minio, env.Cfg.Kartu.VerifyURL, env.Cfg.Kartu.ValidityMonths)
+	User_serv := service.NewUsersService(User_repo, OTP_repo, redis, minio, Kartu_serv)
 
 	User_handler := handler.NewUsersHandler(User_serv)
 
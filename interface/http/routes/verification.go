@@ -0,0 +1,40 @@
+package routes
+
+import (
+	"UMKMGo-backend/config/env"
+	"UMKMGo-backend/config/storage"
+	"UMKMGo-backend/interface/http/handler"
+	"UMKMGo-backend/internal/repository"
+	"UMKMGo-backend/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// VerificationRoutes are public: they are opened from the QR code printed on a
+// document or kartu, so they must be registered before any auth middleware.
+func VerificationRoutes(version fiber.Router, db *gorm.DB, minio *storage.MinIOManager) {
+	trainingRepo := repository.NewTrainingRepository(db)
+	programRepo := repository.NewProgramsRepository(db)
+	notificationRepo := repository.NewNotificationRepository(db)
+	certificationRepo := repository.NewCertificationRepository(db)
+	applicationRepo := repository.NewApplicationsRepository(db)
+	kartuRepo := repository.NewKartuRepository(db)
+	vaultDecryptLogRepo := repository.NewVaultDecryptLogRepository(db)
+
+	trainingService := service.NewTrainingService(trainingRepo, programRepo, notificationRepo, kartuRepo, minio)
+	certificationService := service.NewCertificationService(certificationRepo, applicationRepo, notificationRepo, minio, env.Cfg.Certifications.VerifyURL, env.Cfg.Certifications.ReminderDays)
+	kartuService := service.NewKartuService(kartuRepo, notificationRepo, vaultDecryptLogRepo, minio, env.Cfg.Kartu.VerifyURL, env.Cfg.Kartu.ValidityMonths)
+
+	trainingHandler := handler.NewTrainingHandler(trainingService)
+	certificationHandler := handler.NewCertificationHandler(certificationService)
+	kartuHandler := handler.NewKartuHandler(kartuService)
+
+	verify := version.Group("/verify")
+	{
+		verify.Get("/certificates/:code", trainingHandler.VerifyCertificate)
+		verify.Get("/certifications", certificationHandler.VerifyCertification)
+		verify.Get("/certifications/:code", certificationHandler.VerifyCertification)
+		verify.Get("/kartu/:token", kartuHandler.VerifyKartu)
+	}
+}
@@ -0,0 +1,65 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"UMKMGo-backend/config/env"
+	"UMKMGo-backend/config/log"
+	"UMKMGo-backend/config/redis"
+	"UMKMGo-backend/internal/repository"
+	"UMKMGo-backend/internal/service"
+
+	"gorm.io/gorm"
+)
+
+const (
+	certificationReminderLockKey  = "worker:certification_reminder:lock"
+	certificationReminderInterval = time.Hour
+)
+
+// StartCertificationReminder notifies UMKMs of certificates nearing expiry every
+// hour until ctx is cancelled. Reminders go out CERTIFICATION_REMINDER_DAYS before
+// expiry, each one only once.
+func StartCertificationReminder(ctx context.Context, db *gorm.DB, rdb redis.RedisRepository, cfg env.Certifications) {
+	certificationRepo := repository.NewCertificationRepository(db)
+	notificationRepo := repository.NewNotificationRepository(db)
+	reminderService := service.NewCertificationReminderService(certificationRepo, notificationRepo, cfg.ReminderDays)
+
+	go func() {
+		ticker := time.NewTicker(certificationReminderInterval)
+		defer ticker.Stop()
+
+		runCertificationReminder(ctx, reminderService, rdb)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				runCertificationReminder(ctx, reminderService, rdb)
+			}
+		}
+	}()
+}
+
+func runCertificationReminder(ctx context.Context, reminderService service.CertificationReminderService, rdb redis.RedisRepository) {
+	acquired, err := rdb.SetNX(ctx, certificationReminderLockKey, time.Now().Format(time.RFC3339), certificationReminderInterval*9/10)
+	if err != nil {
+		log.Error("Certification reminder failed to acquire lock: " + err.Error())
+		return
+	}
+	if !acquired {
+		return
+	}
+
+	sent, err := reminderService.SendExpiryReminders(ctx)
+	if err != nil {
+		log.Error("Certification reminder failed: " + err.Error())
+		return
+	}
+
+	if sent > 0 {
+		log.Info(fmt.Sprintf("Certification reminder sent %d expiry reminders", sent))
+	}
+}
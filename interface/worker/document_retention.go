@@ -0,0 +1,65 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"UMKMGo-backend/config/env"
+	"UMKMGo-backend/config/log"
+	"UMKMGo-backend/config/redis"
+	"UMKMGo-backend/config/storage"
+	"UMKMGo-backend/internal/repository"
+	"UMKMGo-backend/internal/service"
+
+	"gorm.io/gorm"
+)
+
+const (
+	documentRetentionLockKey  = "worker:document_retention:lock"
+	documentRetentionInterval = 24 * time.Hour
+)
+
+// StartDocumentRetention removes the stored files of superseded document versions
+// once a day until ctx is cancelled. Files are kept for DOCUMENT_RETENTION_DAYS
+// after the version was replaced.
+func StartDocumentRetention(ctx context.Context, db *gorm.DB, rdb redis.RedisRepository, minio *storage.MinIOManager, cfg env.Documents) {
+	applicationRepo := repository.NewApplicationsRepository(db)
+	retentionService := service.NewDocumentRetentionService(applicationRepo, minio, cfg.RetentionDays)
+
+	go func() {
+		ticker := time.NewTicker(documentRetentionInterval)
+		defer ticker.Stop()
+
+		runDocumentRetention(ctx, retentionService, rdb)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				runDocumentRetention(ctx, retentionService, rdb)
+			}
+		}
+	}()
+}
+
+func runDocumentRetention(ctx context.Context, retentionService service.DocumentRetentionService, rdb redis.RedisRepository) {
+	acquired, err := rdb.SetNX(ctx, documentRetentionLockKey, time.Now().Format(time.RFC3339), documentRetentionInterval*9/10)
+	if err != nil {
+		log.Error("Document retention failed to acquire lock: " + err.Error())
+		return
+	}
+	if !acquired {
+		return
+	}
+
+	purged, err := retentionService.PurgeSupersededDocuments(ctx)
+	if err != nil {
+		log.Error("Document retention failed: " + err.Error())
+		return
+	}
+
+	if purged > 0 {
+		log.Info(fmt.Sprintf("Document retention removed %d superseded document files", purged))
+	}
+}
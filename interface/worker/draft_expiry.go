@@ -0,0 +1,62 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"UMKMGo-backend/config/log"
+	"UMKMGo-backend/config/redis"
+	"UMKMGo-backend/internal/repository"
+	"UMKMGo-backend/internal/service"
+
+	"gorm.io/gorm"
+)
+
+const (
+	draftExpiryLockKey  = "worker:draft_expiry:lock"
+	draftExpiryInterval = time.Hour
+)
+
+// StartDraftExpiry discards idle application drafts every hour until ctx is
+// cancelled. The idle period itself comes from DRAFT_IDLE_DAYS when a draft is saved.
+func StartDraftExpiry(ctx context.Context, db *gorm.DB, rdb redis.RedisRepository) {
+	applicationRepo := repository.NewApplicationsRepository(db)
+	draftExpiryService := service.NewDraftExpiryService(applicationRepo)
+
+	go func() {
+		ticker := time.NewTicker(draftExpiryInterval)
+		defer ticker.Stop()
+
+		runDraftExpiry(ctx, draftExpiryService, rdb)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				runDraftExpiry(ctx, draftExpiryService, rdb)
+			}
+		}
+	}()
+}
+
+func runDraftExpiry(ctx context.Context, draftExpiryService service.DraftExpiryService, rdb redis.RedisRepository) {
+	acquired, err := rdb.SetNX(ctx, draftExpiryLockKey, time.Now().Format(time.RFC3339), draftExpiryInterval*9/10)
+	if err != nil {
+		log.Error("Draft expiry failed to acquire lock: " + err.Error())
+		return
+	}
+	if !acquired {
+		return
+	}
+
+	expired, err := draftExpiryService.ExpireDrafts(ctx)
+	if err != nil {
+		log.Error("Draft expiry failed: " + err.Error())
+		return
+	}
+
+	if expired > 0 {
+		log.Info(fmt.Sprintf("Draft expiry discarded %d idle application drafts", expired))
+	}
+}
@@ -0,0 +1,66 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"UMKMGo-backend/config/env"
+	"UMKMGo-backend/config/log"
+	"UMKMGo-backend/config/redis"
+	"UMKMGo-backend/config/storage"
+	"UMKMGo-backend/internal/repository"
+	"UMKMGo-backend/internal/service"
+
+	"gorm.io/gorm"
+)
+
+const (
+	kartuExpiryLockKey  = "worker:kartu_expiry:lock"
+	kartuExpiryInterval = time.Hour
+)
+
+// StartKartuExpiry marks active kartu past their expiry date as expired every
+// hour until ctx is cancelled, and asks their UMKMs to request a renewal.
+func StartKartuExpiry(ctx context.Context, db *gorm.DB, rdb redis.RedisRepository, minio *storage.MinIOManager, cfg env.Kartu) {
+	kartuRepo := repository.NewKartuRepository(db)
+	notificationRepo := repository.NewNotificationRepository(db)
+	vaultDecryptLogRepo := repository.NewVaultDecryptLogRepository(db)
+	kartuService := service.NewKartuService(kartuRepo, notificationRepo, vaultDecryptLogRepo, minio, cfg.VerifyURL, cfg.ValidityMonths)
+
+	go func() {
+		ticker := time.NewTicker(kartuExpiryInterval)
+		defer ticker.Stop()
+
+		runKartuExpiry(ctx, kartuService, rdb)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				runKartuExpiry(ctx, kartuService, rdb)
+			}
+		}
+	}()
+}
+
+func runKartuExpiry(ctx context.Context, kartuService service.KartuService, rdb redis.RedisRepository) {
+	acquired, err := rdb.SetNX(ctx, kartuExpiryLockKey, time.Now().Format(time.RFC3339), kartuExpiryInterval*9/10)
+	if err != nil {
+		log.Error("Kartu expiry failed to acquire lock: " + err.Error())
+		return
+	}
+	if !acquired {
+		return
+	}
+
+	expired, err := kartuService.ExpireKartus(ctx)
+	if err != nil {
+		log.Error("Kartu expiry failed: " + err.Error())
+		return
+	}
+
+	if expired > 0 {
+		log.Info(fmt.Sprintf("Kartu expiry marked %d kartu as expired", expired))
+	}
+}
@@ -0,0 +1,68 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"UMKMGo-backend/config/env"
+	"UMKMGo-backend/config/log"
+	"UMKMGo-backend/config/redis"
+	"UMKMGo-backend/config/storage"
+	"UMKMGo-backend/internal/repository"
+	"UMKMGo-backend/internal/service"
+
+	"gorm.io/gorm"
+)
+
+const (
+	kartuQRMigrationLockKey  = "worker:kartu_qr_migration:lock"
+	kartuQRMigrationInterval = 10 * time.Minute
+)
+
+// StartKartuQRMigration regenerates kartu QR codes that still hold the plaintext
+// kartu number, a batch every ten minutes until ctx is cancelled. Once every
+// UMKM has a signed QR code the runs find nothing to do, apart from picking up
+// QR codes that failed to upload at registration.
+func StartKartuQRMigration(ctx context.Context, db *gorm.DB, rdb redis.RedisRepository, minio *storage.MinIOManager, cfg env.Kartu) {
+	kartuRepo := repository.NewKartuRepository(db)
+	notificationRepo := repository.NewNotificationRepository(db)
+	vaultDecryptLogRepo := repository.NewVaultDecryptLogRepository(db)
+	kartuService := service.NewKartuService(kartuRepo, notificationRepo, vaultDecryptLogRepo, minio, cfg.VerifyURL, cfg.ValidityMonths)
+
+	go func() {
+		ticker := time.NewTicker(kartuQRMigrationInterval)
+		defer ticker.Stop()
+
+		runKartuQRMigration(ctx, kartuService, rdb)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				runKartuQRMigration(ctx, kartuService, rdb)
+			}
+		}
+	}()
+}
+
+func runKartuQRMigration(ctx context.Context, kartuService service.KartuService, rdb redis.RedisRepository) {
+	acquired, err := rdb.SetNX(ctx, kartuQRMigrationLockKey, time.Now().Format(time.RFC3339), kartuQRMigrationInterval*9/10)
+	if err != nil {
+		log.Error("Kartu QR migration failed to acquire lock: " + err.Error())
+		return
+	}
+	if !acquired {
+		return
+	}
+
+	regenerated, err := kartuService.RegenerateLegacyQRCodes(ctx)
+	if err != nil {
+		log.Error("Kartu QR migration failed: " + err.Error())
+		return
+	}
+
+	if regenerated > 0 {
+		log.Info(fmt.Sprintf("Kartu QR migration regenerated %d QR codes", regenerated))
+	}
+}
@@ -0,0 +1,77 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"UMKMGo-backend/config/env"
+	"UMKMGo-backend/config/log"
+	"UMKMGo-backend/config/redis"
+	"UMKMGo-backend/internal/repository"
+	"UMKMGo-backend/internal/service"
+
+	"gorm.io/gorm"
+)
+
+const slaMonitorLockKey = "worker:sla_monitor:lock"
+
+// StartSLAMonitor runs the SLA breach check every configured interval until ctx is
+// cancelled. A redis lock keeps prefork children and other replicas from handling
+// the same breaches twice within one interval.
+func StartSLAMonitor(ctx context.Context, db *gorm.DB, rdb redis.RedisRepository, cfg env.SLAMonitor) {
+	applicationRepo := repository.NewApplicationsRepository(db)
+	adminNotificationRepo := repository.NewAdminNotificationRepository(db)
+	slaRepo := repository.NewSLARepository(db)
+	holidayRepo := repository.NewHolidayRepository(db)
+	unitOfWork := repository.NewUnitOfWork(db)
+
+	slaMonitorService := service.NewSLAMonitorService(applicationRepo, adminNotificationRepo, slaRepo, holidayRepo, unitOfWork, cfg.BreachPolicy)
+
+	interval := time.Duration(cfg.IntervalMinutes) * time.Minute
+	if interval <= 0 {
+		interval = 15 * time.Minute
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		runSLACheck(ctx, slaMonitorService, rdb, interval)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				runSLACheck(ctx, slaMonitorService, rdb, interval)
+			}
+		}
+	}()
+}
+
+func runSLACheck(ctx context.Context, slaMonitorService service.SLAMonitorService, rdb redis.RedisRepository, interval time.Duration) {
+	acquired, err := rdb.SetNX(ctx, slaMonitorLockKey, time.Now().Format(time.RFC3339), interval*9/10)
+	if err != nil {
+		log.Error("SLA monitor failed to acquire lock: " + err.Error())
+		return
+	}
+	if !acquired {
+		return
+	}
+
+	report, err := slaMonitorService.CheckBreaches(ctx)
+	if err != nil {
+		log.Error("SLA monitor check failed: " + err.Error())
+		return
+	}
+
+	if report.Breached > 0 || report.Failed > 0 {
+		log.Info(fmt.Sprintf("SLA monitor flagged %d overdue applications", report.Breached), map[string]interface{}{
+			"policy":    report.Policy,
+			"escalated": report.Escalated,
+			"rejected":  report.Rejected,
+			"failed":    report.Failed,
+			"errors":    report.Errors,
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"UMKMGo-backend/internal/types/model"
+
+	"gorm.io/gorm"
+)
+
+type AdminNotificationRepository interface {
+	CreateAdminNotifications(ctx context.Context, notifications []model.AdminNotification) error
+	GetAdminNotificationsByUserID(ctx context.Context, userID int, limit, offset int) ([]model.AdminNotification, error)
+	GetUnreadCount(ctx context.Context, userID int) (int64, error)
+	MarkAsRead(ctx context.Context, notificationID int, userID int) error
+	MarkAllAsRead(ctx context.Context, userID int) error
+
+	// Recipients
+	GetUserIDsByPermissions(ctx context.Context, permissionCodes []string) ([]int, error)
+	GetUserIDsByRole(ctx context.Context, roleName string) ([]int, error)
+}
+
+type adminNotificationRepository struct {
+	db *gorm.DB
+}
+
+func NewAdminNotificationRepository(db *gorm.DB) AdminNotificationRepository {
+	return &adminNotificationRepository{db}
+}
+
+func (r *adminNotificationRepository) CreateAdminNotifications(ctx context.Context, notifications []model.AdminNotification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+	if err := r.db.WithContext(ctx).Create(&notifications).Error; err != nil {
+		return errors.New("failed to create admin notifications")
+	}
+	return nil
+}
+
+func (r *adminNotificationRepository) GetAdminNotificationsByUserID(ctx context.Context, userID int, limit, offset int) ([]model.AdminNotification, error) {
+	var notifications []model.AdminNotification
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND deleted_at IS NULL", userID).
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&notifications).Error
+	if err != nil {
+		return nil, err
+	}
+	return notifications, nil
+}
+
+func (r *adminNotificationRepository) GetUnreadCount(ctx context.Context, userID int) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&model.AdminNotification{}).
+		Where("user_id = ? AND is_read = ? AND deleted_at IS NULL", userID, false).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *adminNotificationRepository) MarkAsRead(ctx context.Context, notificationID int, userID int) error {
+	return r.db.WithContext(ctx).
+		Model(&model.AdminNotification{}).
+		Where("id = ? AND user_id = ?", notificationID, userID).
+		Updates(map[string]interface{}{
+			"is_read": true,
+			"read_at": "NOW()",
+		}).Error
+}
+
+func (r *adminNotificationRepository) MarkAllAsRead(ctx context.Context, userID int) error {
+	return r.db.WithContext(ctx).
+		Model(&model.AdminNotification{}).
+		Where("user_id = ? AND is_read = ?", userID, false).
+		Updates(map[string]interface{}{
+			"is_read": true,
+			"read_at": "NOW()",
+		}).Error
+}
+
+func (r *adminNotificationRepository) GetUserIDsByPermissions(ctx context.Context, permissionCodes []string) ([]int, error) {
+	var userIDs []int
+	err := r.db.WithContext(ctx).
+		Raw(`
+			SELECT DISTINCT users.id
+			FROM users
+			JOIN role_permissions ON role_permissions.role_id = users.role_id
+			JOIN permissions ON permissions.id = role_permissions.permission_id
+			WHERE permissions.code IN ? AND users.is_active = TRUE AND users.deleted_at IS NULL
+		`, permissionCodes).
+		Scan(&userIDs).Error
+	if err != nil {
+		return nil, errors.New("failed to get notification recipients")
+	}
+	return userIDs, nil
+}
+
+func (r *adminNotificationRepository) GetUserIDsByRole(ctx context.Context, roleName string) ([]int, error) {
+	var userIDs []int
+	err := r.db.WithContext(ctx).
+		Raw(`
+			SELECT users.id
+			FROM users
+			JOIN roles ON roles.id = users.role_id
+			WHERE roles.name = ? AND users.is_active = TRUE AND users.deleted_at IS NULL
+		`, roleName).
+		Scan(&userIDs).Error
+	if err != nil {
+		return nil, errors.New("failed to get notification recipients")
+	}
+	return userIDs, nil
+}
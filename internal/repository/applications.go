@@ -3,26 +3,36 @@ package repository
 import (
 	"context"
 	"errors"
-	"log"
 	"time"
 
+	"UMKMGo-backend/internal/types/dto"
 	"UMKMGo-backend/internal/types/model"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// ErrApplicationVersionConflict is returned by UpdateApplication when the row was
+// changed after the caller read it.
+var ErrApplicationVersionConflict = errors.New("application was modified by another request")
+
 type ApplicationsRepository interface {
-	GetAllApplications(ctx context.Context, filterType string) ([]model.Application, error)
+	GetAllApplications(ctx context.Context, params dto.ApplicationQueryParams) ([]model.Application, int64, error)
 	GetApplicationByID(ctx context.Context, id int) (model.Application, error)
 	GetApplicationsByUMKMID(ctx context.Context, umkmID int) ([]model.Application, error)
 	CreateApplication(ctx context.Context, application model.Application) (model.Application, error)
 	UpdateApplication(ctx context.Context, application model.Application) (model.Application, error)
+	UpdateApplicationForm(ctx context.Context, application model.Application) error
 	DeleteApplication(ctx context.Context, application model.Application) (model.Application, error)
 
 	// Documents
 	CreateApplicationDocuments(ctx context.Context, documents []model.ApplicationDocument) error
 	GetApplicationDocuments(ctx context.Context, applicationID int) ([]model.ApplicationDocument, error)
 	DeleteApplicationDocuments(ctx context.Context, applicationID int) error
+	UpdateDocumentReview(ctx context.Context, document model.ApplicationDocument) error
+	GetApplicationDocumentVersions(ctx context.Context, applicationID int) ([]model.ApplicationDocument, error)
+	GetPurgeableDocuments(ctx context.Context, supersededBefore time.Time, limit int) ([]model.ApplicationDocument, error)
+	MarkDocumentPurged(ctx context.Context, id int, purgedAt time.Time) error
 
 	// Histories
 	CreateApplicationHistory(ctx context.Context, history model.ApplicationHistory) error
@@ -32,6 +42,29 @@ type ApplicationsRepository interface {
 	GetProgramByID(ctx context.Context, id int) (model.Program, error)
 	GetUMKMByUserID(ctx context.Context, userID int) (model.UMKM, error)
 	IsApplicationExists(ctx context.Context, umkmID, programID int) bool
+
+	// SLA
+	GetOverdueApplications(ctx context.Context, statuses []string, now time.Time) ([]model.Application, error)
+	MarkApplicationOverdue(ctx context.Context, id int, overdueAt time.Time, escalatedAt *time.Time) error
+
+	// Review queue
+	GetReviewQueue(ctx context.Context, status string, types []string) ([]model.Application, error)
+
+	// Drafts
+	DeleteExpiredDrafts(ctx context.Context, now time.Time) (int64, error)
+
+	// Capacity
+	LockProgram(ctx context.Context, programID int) (model.Program, error)
+	GetProgramUsage(ctx context.Context, programIDs []int) (map[int]dto.ProgramUsage, error)
+	GetWaitlist(ctx context.Context, programID int) ([]model.Application, error)
+
+	// Scoring
+	GetRubricCriteria(ctx context.Context, programID int) ([]model.ProgramRubricCriterion, error)
+	ReplaceRubricCriteria(ctx context.Context, programID int, criteria []model.ProgramRubricCriterion) error
+	CountProgramScores(ctx context.Context, programID int) (int64, error)
+	SaveApplicationScores(ctx context.Context, scores []model.ApplicationScore) error
+	UpdateApplicationScoreTotal(ctx context.Context, applicationID int, total *float64) error
+	GetProgramRanking(ctx context.Context, programID int, statuses []string) ([]model.Application, error)
 }
 
 type applicationsRepository struct {
@@ -42,26 +75,101 @@ func NewApplicationsRepository(db *gorm.DB) ApplicationsRepository {
 	return &applicationsRepository{db}
 }
 
-func (repo *applicationsRepository) GetAllApplications(ctx context.Context, filterType string) ([]model.Application, error) {
+// applicationSortColumns maps the sort keys accepted by GetAllApplications to
+// SQL columns; unknown keys fall back to the newest applications first.
+var applicationSortColumns = map[string]string{
+	"submitted_at":  "applications.submitted_at",
+	"expired_at":    "applications.expired_at",
+	"created_at":    "applications.created_at",
+	"status":        "applications.status",
+	"business_name": "umkms.business_name",
+}
+
+// GetAllApplications filters, sorts and paginates in SQL, then preloads the
+// relations of the page in one batched query per relation.
+func (repo *applicationsRepository) GetAllApplications(ctx context.Context, params dto.ApplicationQueryParams) ([]model.Application, int64, error) {
 	var applications []model.Application
+	var total int64
+
 	query := repo.db.WithContext(ctx).
-		Debug().
+		Model(&model.Application{}).
+		Joins("JOIN umkms ON umkms.id = applications.umkm_id").
+		Where("applications.deleted_at IS NULL AND applications.status <> ?", "draft")
+
+	if params.Type != "" {
+		query = query.Where("applications.type = ?", params.Type)
+	}
+	if params.Status != "" {
+		query = query.Where("applications.status = ?", params.Status)
+	}
+	if params.ProgramID != 0 {
+		query = query.Where("applications.program_id = ?", params.ProgramID)
+	}
+	if params.ProvinceID != 0 {
+		query = query.Where("umkms.province_id = ?", params.ProvinceID)
+	}
+	if params.CityID != 0 {
+		query = query.Where("umkms.city_id = ?", params.CityID)
+	}
+	if params.KartuType != "" {
+		query = query.Where("umkms.kartu_type = ?", params.KartuType)
+	}
+	if params.SubmittedFrom != "" {
+		query = query.Where("applications.submitted_at >= ?::date", params.SubmittedFrom)
+	}
+	if params.SubmittedTo != "" {
+		query = query.Where("applications.submitted_at < ?::date + 1", params.SubmittedTo)
+	}
+	if params.IsOverdue != nil {
+		query = query.Where("applications.is_overdue = ?", *params.IsOverdue)
+	}
+
+	// Search by business name or owner name
+	if params.Search != "" {
+		searchPattern := "%" + params.Search + "%"
+		query = query.Joins("JOIN users ON users.id = umkms.user_id").
+			Where("umkms.business_name ILIKE ? OR users.name ILIKE ?", searchPattern, searchPattern)
+	}
+
+	// Count total
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errors.New("failed to count applications")
+	}
+
+	// Pagination
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	if params.Limit < 1 {
+		params.Limit = 10
+	}
+	offset := (params.Page - 1) * params.Limit
+
+	column, ok := applicationSortColumns[params.SortBy]
+	if !ok {
+		column = "applications.created_at"
+	}
+	direction := "DESC"
+	if params.SortOrder == "asc" {
+		direction = "ASC"
+	}
+
+	err := query.
+		Select("applications.*").
 		Preload("Program").
 		Preload("UMKM.User").
 		Preload("UMKM.City.Province").
-		Preload("Documents").
+		Preload("Documents", "superseded_at IS NULL").
 		Preload("Histories.User").
-		Where("applications.deleted_at IS NULL")
-
-	if filterType != "" {
-		query = query.Where("type = ?", filterType)
-	}
-	log.Printf("Query: %s", query.Statement.SQL.String())
-	err := query.WithContext(ctx).Find(&applications).Error
+		Order(column + " " + direction).
+		Order("applications.id " + direction).
+		Limit(params.Limit).
+		Offset(offset).
+		Find(&applications).Error
 	if err != nil {
-		return nil, err
+		return nil, 0, errors.New("failed to get applications")
 	}
-	return applications, nil
+	return applications, total, nil
 }
 
 func (repo *applicationsRepository) GetApplicationByID(ctx context.Context, id int) (model.Application, error) {
@@ -71,12 +179,13 @@ func (repo *applicationsRepository) GetApplicationByID(ctx context.Context, id i
 		Preload("Program").
 		Preload("UMKM.User").
 		Preload("UMKM.City.Province").
-		Preload("Documents").
+		Preload("Documents", "superseded_at IS NULL").
 		Preload("Histories.User").
+		Preload("Scores.User").
 		Preload("TrainingApplication").
 		Preload("CertificationApplication").
 		Preload("FundingApplication").
-		Where("applications.id = ? AND applications.deleted_at IS NULL", id).
+		Where("applications.id = ? AND applications.status <> ? AND applications.deleted_at IS NULL", id, "draft").
 		First(&application).Error
 	if err != nil {
 		return model.Application{}, errors.New("application not found")
@@ -91,9 +200,9 @@ func (repo *applicationsRepository) GetApplicationsByUMKMID(ctx context.Context,
 		Preload("Program").
 		Preload("UMKM.User").
 		Preload("UMKM.City.Province").
-		Preload("Documents").
+		Preload("Documents", "superseded_at IS NULL").
 		Preload("Histories.User").
-		Where("umkm_id = ? AND deleted_at IS NULL", umkmID).
+		Where("umkm_id = ? AND status <> ? AND deleted_at IS NULL", umkmID, "draft").
 		Find(&applications).Error
 	if err != nil {
 		return nil, err
@@ -102,8 +211,13 @@ func (repo *applicationsRepository) GetApplicationsByUMKMID(ctx context.Context,
 }
 
 func (repo *applicationsRepository) CreateApplication(ctx context.Context, application model.Application) (model.Application, error) {
-	application.SubmittedAt = time.Now()
-	application.ExpiredAt = time.Now().AddDate(0, 0, 30) // 30 days from now
+	// Callers stamp the SLA deadline; these are fallbacks only
+	if application.SubmittedAt.IsZero() {
+		application.SubmittedAt = time.Now()
+	}
+	if application.ExpiredAt.IsZero() {
+		application.ExpiredAt = time.Now().AddDate(0, 0, 30) // 30 days from now
+	}
 
 	err := repo.db.WithContext(ctx).Create(&application).Error
 	if err != nil {
@@ -112,14 +226,47 @@ func (repo *applicationsRepository) CreateApplication(ctx context.Context, appli
 	return application, nil
 }
 
+// UpdateApplication saves the application only if its version still matches the
+// one it was read at, and bumps the version on success.
 func (repo *applicationsRepository) UpdateApplication(ctx context.Context, application model.Application) (model.Application, error) {
-	err := repo.db.WithContext(ctx).Save(&application).Error
-	if err != nil {
+	readVersion := application.Version
+	application.Version = readVersion + 1
+	application.UpdatedAt = time.Now()
+
+	result := repo.db.WithContext(ctx).
+		Model(&model.Application{}).
+		Where("id = ? AND version = ? AND deleted_at IS NULL", application.ID, readVersion).
+		Select("*").
+		Omit("id", "created_at", clause.Associations).
+		Updates(&application)
+	if result.Error != nil {
 		return model.Application{}, errors.New("failed to update application")
 	}
+	if result.RowsAffected == 0 {
+		return model.Application{}, ErrApplicationVersionConflict
+	}
 	return application, nil
 }
 
+// UpdateApplicationForm saves the type-specific form record of the application.
+func (repo *applicationsRepository) UpdateApplicationForm(ctx context.Context, application model.Application) error {
+	db := repo.db.WithContext(ctx).Omit(clause.Associations)
+
+	var err error
+	switch {
+	case application.TrainingApplication != nil:
+		err = db.Save(application.TrainingApplication).Error
+	case application.CertificationApplication != nil:
+		err = db.Save(application.CertificationApplication).Error
+	case application.FundingApplication != nil:
+		err = db.Save(application.FundingApplication).Error
+	}
+	if err != nil {
+		return errors.New("failed to update application form")
+	}
+	return nil
+}
+
 func (repo *applicationsRepository) DeleteApplication(ctx context.Context, application model.Application) (model.Application, error) {
 	err := repo.db.WithContext(ctx).Delete(&application).Error
 	if err != nil {
@@ -141,7 +288,7 @@ func (repo *applicationsRepository) CreateApplicationDocuments(ctx context.Conte
 
 func (repo *applicationsRepository) GetApplicationDocuments(ctx context.Context, applicationID int) ([]model.ApplicationDocument, error) {
 	var documents []model.ApplicationDocument
-	err := repo.db.WithContext(ctx).Where("application_id = ? AND deleted_at IS NULL", applicationID).Find(&documents).Error
+	err := repo.db.WithContext(ctx).Where("application_id = ? AND superseded_at IS NULL AND deleted_at IS NULL", applicationID).Find(&documents).Error
 	if err != nil {
 		return nil, err
 	}
@@ -156,6 +303,69 @@ func (repo *applicationsRepository) DeleteApplicationDocuments(ctx context.Conte
 	return nil
 }
 
+// UpdateDocumentReview saves the reviewer's verdict on a single document.
+func (repo *applicationsRepository) UpdateDocumentReview(ctx context.Context, document model.ApplicationDocument) error {
+	result := repo.db.WithContext(ctx).
+		Model(&model.ApplicationDocument{}).
+		Where("id = ? AND application_id = ? AND deleted_at IS NULL", document.ID, document.ApplicationID).
+		Updates(map[string]interface{}{
+			"review_status": document.ReviewStatus,
+			"review_reason": document.ReviewReason,
+			"reviewed_by":   document.ReviewedBy,
+			"reviewed_at":   document.ReviewedAt,
+		})
+	if result.Error != nil {
+		return errors.New("failed to update document review")
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("document not found")
+	}
+	return nil
+}
+
+// GetApplicationDocumentVersions returns every version of the application's
+// documents, superseded ones included, oldest first within each type.
+func (repo *applicationsRepository) GetApplicationDocumentVersions(ctx context.Context, applicationID int) ([]model.ApplicationDocument, error) {
+	var documents []model.ApplicationDocument
+	err := repo.db.WithContext(ctx).
+		Where("application_id = ? AND deleted_at IS NULL", applicationID).
+		Order("type ASC").
+		Order("version ASC").
+		Find(&documents).Error
+	if err != nil {
+		return nil, errors.New("failed to get application document versions")
+	}
+	return documents, nil
+}
+
+// GetPurgeableDocuments returns superseded documents past the retention period
+// whose file is not still used by a current document.
+func (repo *applicationsRepository) GetPurgeableDocuments(ctx context.Context, supersededBefore time.Time, limit int) ([]model.ApplicationDocument, error) {
+	var documents []model.ApplicationDocument
+	err := repo.db.WithContext(ctx).
+		Where("superseded_at < ? AND purged_at IS NULL", supersededBefore).
+		Where("NOT EXISTS (SELECT 1 FROM application_documents current WHERE current.file = application_documents.file AND current.superseded_at IS NULL AND current.deleted_at IS NULL)").
+		Order("superseded_at ASC").
+		Limit(limit).
+		Find(&documents).Error
+	if err != nil {
+		return nil, errors.New("failed to get purgeable documents")
+	}
+	return documents, nil
+}
+
+// MarkDocumentPurged records that the stored file of a superseded document was removed.
+func (repo *applicationsRepository) MarkDocumentPurged(ctx context.Context, id int, purgedAt time.Time) error {
+	err := repo.db.WithContext(ctx).
+		Model(&model.ApplicationDocument{}).
+		Where("id = ?", id).
+		Update("purged_at", purgedAt).Error
+	if err != nil {
+		return errors.New("failed to mark document purged")
+	}
+	return nil
+}
+
 func (repo *applicationsRepository) CreateApplicationHistory(ctx context.Context, history model.ApplicationHistory) error {
 	history.ActionedAt = time.Now()
 	err := repo.db.WithContext(ctx).Create(&history).Error
@@ -194,6 +404,215 @@ func (repo *applicationsRepository) GetUMKMByUserID(ctx context.Context, userID
 
 func (repo *applicationsRepository) IsApplicationExists(ctx context.Context, umkmID, programID int) bool {
 	var count int64
-	repo.db.WithContext(ctx).Model(&model.Application{}).Where("umkm_id = ? AND program_id = ? AND deleted_at IS NULL AND status NOT IN ('rejected')", umkmID, programID).Count(&count)
+	repo.db.WithContext(ctx).Model(&model.Application{}).Where("umkm_id = ? AND program_id = ? AND deleted_at IS NULL AND status NOT IN ('rejected', 'withdrawn')", umkmID, programID).Count(&count)
 	return count > 0
 }
+
+func (repo *applicationsRepository) GetOverdueApplications(ctx context.Context, statuses []string, now time.Time) ([]model.Application, error) {
+	var applications []model.Application
+	err := repo.db.WithContext(ctx).
+		Preload("Program").
+		Where("status IN ? AND expired_at < ? AND is_overdue = ? AND deleted_at IS NULL", statuses, now, false).
+		Order("expired_at ASC").
+		Find(&applications).Error
+	if err != nil {
+		return nil, errors.New("failed to get overdue applications")
+	}
+	return applications, nil
+}
+
+func (repo *applicationsRepository) MarkApplicationOverdue(ctx context.Context, id int, overdueAt time.Time, escalatedAt *time.Time) error {
+	err := repo.db.WithContext(ctx).
+		Model(&model.Application{}).
+		Where("id = ? AND deleted_at IS NULL", id).
+		Updates(map[string]interface{}{
+			"is_overdue":   true,
+			"overdue_at":   overdueAt,
+			"escalated_at": escalatedAt,
+			"version":      gorm.Expr("version + 1"),
+		}).Error
+	if err != nil {
+		return errors.New("failed to mark application overdue")
+	}
+	return nil
+}
+
+// GetReviewQueue returns applications awaiting review in a status, most urgent first.
+func (repo *applicationsRepository) GetReviewQueue(ctx context.Context, status string, types []string) ([]model.Application, error) {
+	var applications []model.Application
+	err := repo.db.WithContext(ctx).
+		Preload("Program").
+		Preload("UMKM").
+		Where("status = ? AND type IN ? AND deleted_at IS NULL", status, types).
+		Order("expired_at ASC, id ASC").
+		Find(&applications).Error
+	if err != nil {
+		return nil, errors.New("failed to get review queue")
+	}
+	return applications, nil
+}
+
+// DeleteExpiredDrafts discards drafts left untouched past their idle expiry.
+func (repo *applicationsRepository) DeleteExpiredDrafts(ctx context.Context, now time.Time) (int64, error) {
+	result := repo.db.WithContext(ctx).
+		Where("status = ? AND expired_at < ?", "draft", now).
+		Delete(&model.Application{})
+	if result.Error != nil {
+		return 0, errors.New("failed to delete expired drafts")
+	}
+	return result.RowsAffected, nil
+}
+
+// LockProgram loads the program and locks its row until the transaction ends, so
+// decisions against its capacity are taken one at a time.
+func (repo *applicationsRepository) LockProgram(ctx context.Context, programID int) (model.Program, error) {
+	var program model.Program
+	err := repo.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ? AND deleted_at IS NULL", programID).
+		First(&program).Error
+	if err != nil {
+		return model.Program{}, errors.New("program not found")
+	}
+	return program, nil
+}
+
+// GetProgramUsage counts the approved applications of each program and the
+// funding they requested. Programs without approvals are left out.
+func (repo *applicationsRepository) GetProgramUsage(ctx context.Context, programIDs []int) (map[int]dto.ProgramUsage, error) {
+	usage := make(map[int]dto.ProgramUsage, len(programIDs))
+	if len(programIDs) == 0 {
+		return usage, nil
+	}
+
+	var rows []dto.ProgramUsage
+	err := repo.db.WithContext(ctx).
+		Table("applications").
+		Select("applications.program_id, COUNT(*) AS approved_count, COALESCE(SUM(funding_applications.requested_amount), 0) AS approved_amount").
+		Joins("LEFT JOIN funding_applications ON funding_applications.application_id = applications.id AND funding_applications.deleted_at IS NULL").
+		Where("applications.program_id IN ? AND applications.status = ? AND applications.deleted_at IS NULL", programIDs, "approved").
+		Group("applications.program_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, errors.New("failed to get program usage")
+	}
+
+	for _, row := range rows {
+		usage[row.ProgramID] = row
+	}
+	return usage, nil
+}
+
+// GetWaitlist returns the program's waitlisted applications, first in line first.
+func (repo *applicationsRepository) GetWaitlist(ctx context.Context, programID int) ([]model.Application, error) {
+	var applications []model.Application
+	err := repo.db.WithContext(ctx).
+		Preload("Program").
+		Preload("FundingApplication").
+		Where("program_id = ? AND status = ? AND deleted_at IS NULL", programID, "waitlisted").
+		Order("waitlisted_at ASC").
+		Order("id ASC").
+		Find(&applications).Error
+	if err != nil {
+		return nil, errors.New("failed to get waitlist")
+	}
+	return applications, nil
+}
+
+// GetRubricCriteria returns the criteria of a program's scoring rubric in display order.
+func (repo *applicationsRepository) GetRubricCriteria(ctx context.Context, programID int) ([]model.ProgramRubricCriterion, error) {
+	var criteria []model.ProgramRubricCriterion
+	err := repo.db.WithContext(ctx).
+		Where("program_id = ? AND deleted_at IS NULL", programID).
+		Order("sort_order ASC").
+		Order("id ASC").
+		Find(&criteria).Error
+	if err != nil {
+		return nil, errors.New("failed to get rubric criteria")
+	}
+	return criteria, nil
+}
+
+// ReplaceRubricCriteria swaps the program's rubric for the given criteria. Run
+// it in a transaction so the program is never left without a rubric.
+func (repo *applicationsRepository) ReplaceRubricCriteria(ctx context.Context, programID int, criteria []model.ProgramRubricCriterion) error {
+	err := repo.db.WithContext(ctx).Where("program_id = ?", programID).Unscoped().Delete(&model.ProgramRubricCriterion{}).Error
+	if err != nil {
+		return errors.New("failed to delete rubric criteria")
+	}
+	if len(criteria) == 0 {
+		return nil
+	}
+	if err := repo.db.WithContext(ctx).Create(&criteria).Error; err != nil {
+		return errors.New("failed to create rubric criteria")
+	}
+	return nil
+}
+
+// CountProgramScores counts the scores recorded against the program's rubric.
+func (repo *applicationsRepository) CountProgramScores(ctx context.Context, programID int) (int64, error) {
+	var count int64
+	err := repo.db.WithContext(ctx).
+		Model(&model.ApplicationScore{}).
+		Joins("JOIN program_rubric_criteria ON program_rubric_criteria.id = application_scores.criterion_id").
+		Where("program_rubric_criteria.program_id = ? AND application_scores.deleted_at IS NULL", programID).
+		Count(&count).Error
+	if err != nil {
+		return 0, errors.New("failed to count program scores")
+	}
+	return count, nil
+}
+
+// SaveApplicationScores records the scores, replacing an earlier score of the
+// same criterion on the same application.
+func (repo *applicationsRepository) SaveApplicationScores(ctx context.Context, scores []model.ApplicationScore) error {
+	if len(scores) == 0 {
+		return nil
+	}
+	err := repo.db.WithContext(ctx).
+		Omit(clause.Associations).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "application_id"}, {Name: "criterion_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"score", "notes", "scored_by", "scored_at", "updated_at"}),
+		}).
+		Create(&scores).Error
+	if err != nil {
+		return errors.New("failed to save application scores")
+	}
+	return nil
+}
+
+// UpdateApplicationScoreTotal stores the weighted total; nil clears it while the
+// scorecard is incomplete. The version is bumped so a decision read before the
+// scores were saved cannot write the old total back.
+func (repo *applicationsRepository) UpdateApplicationScoreTotal(ctx context.Context, applicationID int, total *float64) error {
+	err := repo.db.WithContext(ctx).
+		Model(&model.Application{}).
+		Where("id = ? AND deleted_at IS NULL", applicationID).
+		Updates(map[string]interface{}{
+			"score_total": total,
+			"version":     gorm.Expr("version + 1"),
+			"updated_at":  time.Now(),
+		}).Error
+	if err != nil {
+		return errors.New("failed to update application score total")
+	}
+	return nil
+}
+
+// GetProgramRanking returns the program's fully scored applications in the given
+// statuses, best total first and earliest submission first among equal totals.
+func (repo *applicationsRepository) GetProgramRanking(ctx context.Context, programID int, statuses []string) ([]model.Application, error) {
+	var applications []model.Application
+	err := repo.db.WithContext(ctx).
+		Preload("UMKM").
+		Where("program_id = ? AND status IN ? AND score_total IS NOT NULL AND deleted_at IS NULL", programID, statuses).
+		Order("score_total DESC").
+		Order("submitted_at ASC").
+		Order("id ASC").
+		Find(&applications).Error
+	if err != nil {
+		return nil, errors.New("failed to get program ranking")
+	}
+	return applications, nil
+}
@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"UMKMGo-backend/internal/types/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CertificationRepository interface {
+	GetCertifications(ctx context.Context, status string, today, expiringUntil time.Time) ([]model.Certification, error)
+	GetCertificationByID(ctx context.Context, id int) (model.Certification, error)
+	GetCertificationByApplicationID(ctx context.Context, applicationID int) (model.Certification, error)
+	GetCertificationByNumber(ctx context.Context, number string) (model.Certification, error)
+	GetCertificationByCode(ctx context.Context, code string) (model.Certification, error)
+	GetCertificationsByUMKMID(ctx context.Context, umkmID int) ([]model.Certification, error)
+	CreateCertification(ctx context.Context, certification model.Certification) (model.Certification, error)
+	UpdateCertification(ctx context.Context, certification model.Certification) (model.Certification, error)
+
+	// Reminders
+	GetExpiringCertifications(ctx context.Context, from, until time.Time) ([]model.Certification, error)
+	UpdateRemindedDays(ctx context.Context, id, days int) error
+}
+
+type certificationRepository struct {
+	db *gorm.DB
+}
+
+func NewCertificationRepository(db *gorm.DB) CertificationRepository {
+	return &certificationRepository{db}
+}
+
+// withApplication loads the application, program and UMKM a certification
+// belongs to.
+func (repo *certificationRepository) withApplication(ctx context.Context) *gorm.DB {
+	return repo.db.WithContext(ctx).
+		Preload("CertificationApplication.Application.Program").
+		Preload("CertificationApplication.Application.UMKM.User").
+		Joins("JOIN certification_applications ON certification_applications.id = certifications.certification_application_id").
+		Joins("JOIN applications ON applications.id = certification_applications.application_id").
+		Where("certifications.deleted_at IS NULL")
+}
+
+// GetCertifications lists certifications by status as of today: expired ones
+// are past their expiry date, expiring ones expire by expiringUntil and valid
+// ones have not expired.
+func (repo *certificationRepository) GetCertifications(ctx context.Context, status string, today, expiringUntil time.Time) ([]model.Certification, error) {
+	query := repo.withApplication(ctx)
+	switch status {
+	case "expired":
+		query = query.Where("certifications.expiry_date < ?", today)
+	case "expiring":
+		query = query.Where("certifications.expiry_date >= ? AND certifications.expiry_date <= ?", today, expiringUntil)
+	case "valid":
+		query = query.Where("(certifications.expiry_date IS NULL OR certifications.expiry_date >= ?)", today)
+	}
+
+	var certifications []model.Certification
+	if err := query.Order("certifications.issued_date DESC, certifications.id DESC").Find(&certifications).Error; err != nil {
+		return nil, errors.New("failed to get certifications")
+	}
+	return certifications, nil
+}
+
+func (repo *certificationRepository) GetCertificationByID(ctx context.Context, id int) (model.Certification, error) {
+	var certification model.Certification
+	if err := repo.withApplication(ctx).Where("certifications.id = ?", id).First(&certification).Error; err != nil {
+		return model.Certification{}, errors.New("certification not found")
+	}
+	return certification, nil
+}
+
+func (repo *certificationRepository) GetCertificationByApplicationID(ctx context.Context, applicationID int) (model.Certification, error) {
+	var certification model.Certification
+	if err := repo.withApplication(ctx).Where("applications.id = ?", applicationID).First(&certification).Error; err != nil {
+		return model.Certification{}, errors.New("certification not found")
+	}
+	return certification, nil
+}
+
+func (repo *certificationRepository) GetCertificationByNumber(ctx context.Context, number string) (model.Certification, error) {
+	var certification model.Certification
+	if err := repo.withApplication(ctx).Where("UPPER(certifications.certificate_number) = UPPER(?)", number).First(&certification).Error; err != nil {
+		return model.Certification{}, errors.New("certification not found")
+	}
+	return certification, nil
+}
+
+func (repo *certificationRepository) GetCertificationByCode(ctx context.Context, code string) (model.Certification, error) {
+	var certification model.Certification
+	if err := repo.withApplication(ctx).Where("certifications.verification_code = ?", code).First(&certification).Error; err != nil {
+		return model.Certification{}, errors.New("certification not found")
+	}
+	return certification, nil
+}
+
+func (repo *certificationRepository) GetCertificationsByUMKMID(ctx context.Context, umkmID int) ([]model.Certification, error) {
+	var certifications []model.Certification
+	err := repo.withApplication(ctx).
+		Where("applications.umkm_id = ?", umkmID).
+		Order("certifications.issued_date DESC").
+		Find(&certifications).Error
+	if err != nil {
+		return nil, errors.New("failed to get certifications")
+	}
+	return certifications, nil
+}
+
+func (repo *certificationRepository) CreateCertification(ctx context.Context, certification model.Certification) (model.Certification, error) {
+	err := repo.db.WithContext(ctx).Omit(clause.Associations).Create(&certification).Error
+	if err != nil {
+		return model.Certification{}, errors.New("failed to create certification")
+	}
+	return certification, nil
+}
+
+func (repo *certificationRepository) UpdateCertification(ctx context.Context, certification model.Certification) (model.Certification, error) {
+	err := repo.db.WithContext(ctx).Omit(clause.Associations).Save(&certification).Error
+	if err != nil {
+		return model.Certification{}, errors.New("failed to update certification")
+	}
+	return certification, nil
+}
+
+// Reminders
+
+// GetExpiringCertifications returns the certifications expiring between from
+// and until, inclusive.
+func (repo *certificationRepository) GetExpiringCertifications(ctx context.Context, from, until time.Time) ([]model.Certification, error) {
+	var certifications []model.Certification
+	err := repo.withApplication(ctx).
+		Where("certifications.expiry_date >= ? AND certifications.expiry_date <= ?", from, until).
+		Order("certifications.expiry_date ASC").
+		Find(&certifications).Error
+	if err != nil {
+		return nil, errors.New("failed to get expiring certifications")
+	}
+	return certifications, nil
+}
+
+func (repo *certificationRepository) UpdateRemindedDays(ctx context.Context, id, days int) error {
+	err := repo.db.WithContext(ctx).
+		Model(&model.Certification{}).
+		Where("id = ?", id).
+		Update("reminded_days", days).Error
+	if err != nil {
+		return errors.New("failed to update certification reminder")
+	}
+	return nil
+}
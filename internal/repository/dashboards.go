@@ -35,6 +35,8 @@ func (repo *dashboardRepository) GetUMKMByCardType(ctx context.Context) ([]map[s
 				COUNT(*) as count
 			FROM umkms
 			WHERE deleted_at IS NULL AND kartu_type IS NOT NULL
+				AND kartu_status = 'active'
+				AND (kartu_expiry_date IS NULL OR kartu_expiry_date >= CURRENT_DATE)
 			GROUP BY kartu_type
 		`).
 		Scan(&results).Error
@@ -48,31 +50,38 @@ func (repo *dashboardRepository) GetApplicationStatusSummary(ctx context.Context
 	// Total applications
 	var total int64
 	repo.db.WithContext(ctx).
-		Raw("SELECT COUNT(*) FROM applications WHERE deleted_at IS NULL").
+		Raw("SELECT COUNT(*) FROM applications WHERE deleted_at IS NULL AND status <> 'draft'").
 		Scan(&total)
 	result["total_applications"] = total
 
 	// In process (screening + revised + final)
 	var inProcess int64
 	repo.db.WithContext(ctx).
-		Raw("SELECT COUNT(*) FROM applications WHERE deleted_at IS NULL AND status IN ('screening', 'revised', 'final')").
+		Raw("SELECT COUNT(*) FROM applications WHERE deleted_at IS NULL AND status <> 'draft' AND status IN ('screening', 'revised', 'final')").
 		Scan(&inProcess)
 	result["in_process"] = inProcess
 
 	// Approved
 	var approved int64
 	repo.db.WithContext(ctx).
-		Raw("SELECT COUNT(*) FROM applications WHERE deleted_at IS NULL AND status = 'approved'").
+		Raw("SELECT COUNT(*) FROM applications WHERE deleted_at IS NULL AND status <> 'draft' AND status = 'approved'").
 		Scan(&approved)
 	result["approved"] = approved
 
 	// Rejected
 	var rejected int64
 	repo.db.WithContext(ctx).
-		Raw("SELECT COUNT(*) FROM applications WHERE deleted_at IS NULL AND status = 'rejected'").
+		Raw("SELECT COUNT(*) FROM applications WHERE deleted_at IS NULL AND status <> 'draft' AND status = 'rejected'").
 		Scan(&rejected)
 	result["rejected"] = rejected
 
+	// Overdue (in process and past SLA deadline)
+	var overdue int64
+	repo.db.WithContext(ctx).
+		Raw("SELECT COUNT(*) FROM applications WHERE deleted_at IS NULL AND status <> 'draft' AND status IN ('screening', 'revised', 'final') AND (is_overdue = TRUE OR expired_at < NOW())").
+		Scan(&overdue)
+	result["overdue"] = overdue
+
 	return result, nil
 }
 
@@ -88,7 +97,7 @@ func (repo *dashboardRepository) GetApplicationStatusDetail(ctx context.Context)
 		Raw(`
 			SELECT status, COUNT(*) as count
 			FROM applications
-			WHERE deleted_at IS NULL
+			WHERE deleted_at IS NULL AND status <> 'draft'
 			GROUP BY status
 		`).
 		Scan(&statuses).Error
@@ -115,7 +124,7 @@ func (repo *dashboardRepository) GetApplicationByType(ctx context.Context) (map[
 		Raw(`
 			SELECT type, COUNT(*) as count
 			FROM applications
-			WHERE deleted_at IS NULL
+			WHERE deleted_at IS NULL AND status <> 'draft'
 			GROUP BY type
 		`).
 		Scan(&types).Error
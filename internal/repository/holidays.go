@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"UMKMGo-backend/internal/types/model"
+
+	"gorm.io/gorm"
+)
+
+type HolidayRepository interface {
+	GetHolidays(ctx context.Context, year int) ([]model.Holiday, error)
+	GetHolidayByID(ctx context.Context, id int) (model.Holiday, error)
+	GetHolidaysBetween(ctx context.Context, from, to time.Time) ([]model.Holiday, error)
+	CreateHoliday(ctx context.Context, holiday model.Holiday) (model.Holiday, error)
+	UpdateHoliday(ctx context.Context, holiday model.Holiday) (model.Holiday, error)
+	DeleteHoliday(ctx context.Context, holiday model.Holiday) error
+	IsHolidayDateExists(ctx context.Context, date time.Time, excludeID int) bool
+}
+
+type holidayRepository struct {
+	db *gorm.DB
+}
+
+func NewHolidayRepository(db *gorm.DB) HolidayRepository {
+	return &holidayRepository{db}
+}
+
+func (repo *holidayRepository) GetHolidays(ctx context.Context, year int) ([]model.Holiday, error) {
+	var holidays []model.Holiday
+	query := repo.db.WithContext(ctx).Where("deleted_at IS NULL")
+
+	if year > 0 {
+		query = query.Where("EXTRACT(YEAR FROM date) = ?", year)
+	}
+
+	err := query.Order("date ASC").Find(&holidays).Error
+	if err != nil {
+		return nil, errors.New("failed to get holidays")
+	}
+	return holidays, nil
+}
+
+func (repo *holidayRepository) GetHolidayByID(ctx context.Context, id int) (model.Holiday, error) {
+	var holiday model.Holiday
+	err := repo.db.WithContext(ctx).Where("id = ? AND deleted_at IS NULL", id).First(&holiday).Error
+	if err != nil {
+		return model.Holiday{}, errors.New("holiday not found")
+	}
+	return holiday, nil
+}
+
+func (repo *holidayRepository) GetHolidaysBetween(ctx context.Context, from, to time.Time) ([]model.Holiday, error) {
+	var holidays []model.Holiday
+	err := repo.db.WithContext(ctx).
+		Where("date BETWEEN ? AND ? AND deleted_at IS NULL", from.Format("2006-01-02"), to.Format("2006-01-02")).
+		Order("date ASC").
+		Find(&holidays).Error
+	if err != nil {
+		return nil, errors.New("failed to get holidays")
+	}
+	return holidays, nil
+}
+
+func (repo *holidayRepository) CreateHoliday(ctx context.Context, holiday model.Holiday) (model.Holiday, error) {
+	err := repo.db.WithContext(ctx).Create(&holiday).Error
+	if err != nil {
+		return model.Holiday{}, errors.New("failed to create holiday")
+	}
+	return holiday, nil
+}
+
+func (repo *holidayRepository) UpdateHoliday(ctx context.Context, holiday model.Holiday) (model.Holiday, error) {
+	err := repo.db.WithContext(ctx).Save(&holiday).Error
+	if err != nil {
+		return model.Holiday{}, errors.New("failed to update holiday")
+	}
+	return holiday, nil
+}
+
+func (repo *holidayRepository) DeleteHoliday(ctx context.Context, holiday model.Holiday) error {
+	err := repo.db.WithContext(ctx).Delete(&holiday).Error
+	if err != nil {
+		return errors.New("failed to delete holiday")
+	}
+	return nil
+}
+
+func (repo *holidayRepository) IsHolidayDateExists(ctx context.Context, date time.Time, excludeID int) bool {
+	var count int64
+	query := repo.db.WithContext(ctx).Model(&model.Holiday{}).
+		Where("date = ? AND deleted_at IS NULL", date.Format("2006-01-02"))
+
+	if excludeID > 0 {
+		query = query.Where("id != ?", excludeID)
+	}
+
+	query.Count(&count)
+	return count > 0
+}
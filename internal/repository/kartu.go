@@ -0,0 +1,243 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"UMKMGo-backend/internal/types/model"
+
+	"gorm.io/gorm"
+)
+
+// ErrKartuConflict is returned by SaveKartuChange and UpdateRenewal when the
+// kartu or renewal changed after the caller read it.
+var ErrKartuConflict = errors.New("kartu was changed by another request")
+
+type KartuRepository interface {
+	GetUMKMByID(ctx context.Context, id int) (model.UMKM, error)
+
+	// QR codes
+	GetLegacyQRUMKMs(ctx context.Context, limit int) ([]model.UMKM, error)
+	UpdateQRCode(ctx context.Context, id, fromVersion, toVersion int, qrCode string) error
+
+	// Lifecycle
+	SaveKartuChange(ctx context.Context, before, after model.UMKM, history model.KartuHistory, renewal *model.KartuRenewal) error
+	GetKartuHistories(ctx context.Context, umkmID int) ([]model.KartuHistory, error)
+	GetExpiredKartus(ctx context.Context, today time.Time, limit int) ([]model.UMKM, error)
+
+	// Renewals
+	GetRenewals(ctx context.Context, status string) ([]model.KartuRenewal, error)
+	GetRenewalByID(ctx context.Context, id int) (model.KartuRenewal, error)
+	GetPendingRenewal(ctx context.Context, umkmID int) (model.KartuRenewal, error)
+	CreateRenewal(ctx context.Context, renewal model.KartuRenewal) (model.KartuRenewal, error)
+	UpdateRenewal(ctx context.Context, renewal model.KartuRenewal) error
+}
+
+type kartuRepository struct {
+	db *gorm.DB
+}
+
+func NewKartuRepository(db *gorm.DB) KartuRepository {
+	return &kartuRepository{db}
+}
+
+func (repo *kartuRepository) GetUMKMByID(ctx context.Context, id int) (model.UMKM, error) {
+	var umkm model.UMKM
+	err := repo.db.WithContext(ctx).
+		Preload("User").
+		Preload("Province").
+		Preload("City").
+		Where("id = ? AND deleted_at IS NULL", id).
+		First(&umkm).Error
+	if err != nil {
+		return model.UMKM{}, errors.New("UMKM not found")
+	}
+	return umkm, nil
+}
+
+// GetLegacyQRUMKMs returns UMKMs whose QR code has not been issued as a signed
+// token yet, oldest first.
+func (repo *kartuRepository) GetLegacyQRUMKMs(ctx context.Context, limit int) ([]model.UMKM, error) {
+	var umkms []model.UMKM
+	err := repo.db.WithContext(ctx).
+		Where("qr_token_version = 0 AND deleted_at IS NULL").
+		Order("id ASC").
+		Limit(limit).
+		Find(&umkms).Error
+	if err != nil {
+		return nil, errors.New("failed to get UMKMs with legacy QR codes")
+	}
+	return umkms, nil
+}
+
+// UpdateQRCode stores a rotated QR code. The update only applies while the
+// UMKM is still on fromVersion, so two rotations cannot both succeed.
+func (repo *kartuRepository) UpdateQRCode(ctx context.Context, id, fromVersion, toVersion int, qrCode string) error {
+	result := repo.db.WithContext(ctx).
+		Model(&model.UMKM{}).
+		Where("id = ? AND qr_token_version = ?", id, fromVersion).
+		Updates(map[string]interface{}{"qr_code": qrCode, "qr_token_version": toVersion})
+	if result.Error != nil {
+		return errors.New("failed to update QR code")
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("QR code was rotated by another request")
+	}
+	return nil
+}
+
+// SaveKartuChange stores the kartu fields of a UMKM together with the history
+// entry describing the change and, when the change settles one, the renewal.
+// The change only applies while the kartu and the renewal are still as the
+// caller read them, so a worker or a second admin cannot undo it.
+func (repo *kartuRepository) SaveKartuChange(ctx context.Context, before, after model.UMKM, history model.KartuHistory, renewal *model.KartuRenewal) error {
+	err := repo.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.UMKM{}).
+			Where("id = ? AND kartu_status = ? AND kartu_type = ?", before.ID, before.KartuStatus, before.KartuType).
+			Where("kartu_expiry_date IS NOT DISTINCT FROM CAST(? AS date)", kartuDate(before.KartuExpiryDate)).
+			Updates(map[string]interface{}{
+				"kartu_type":        after.KartuType,
+				"kartu_status":      after.KartuStatus,
+				"kartu_expiry_date": after.KartuExpiryDate,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrKartuConflict
+		}
+
+		if err := tx.Create(&history).Error; err != nil {
+			return err
+		}
+
+		if renewal != nil {
+			if err := reviewRenewal(tx, *renewal); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if errors.Is(err, ErrKartuConflict) {
+		return ErrKartuConflict
+	}
+	if err != nil {
+		return errors.New("failed to update kartu")
+	}
+	return nil
+}
+
+// kartuDate formats an expiry date for comparison with the date column, so the
+// session time zone cannot shift it.
+func kartuDate(date *time.Time) *string {
+	if date == nil {
+		return nil
+	}
+	formatted := date.Format("2006-01-02")
+	return &formatted
+}
+
+// reviewRenewal stores the review of a renewal that is still pending.
+func reviewRenewal(tx *gorm.DB, renewal model.KartuRenewal) error {
+	result := tx.Model(&model.KartuRenewal{}).
+		Where("id = ? AND status = 'pending' AND deleted_at IS NULL", renewal.ID).
+		Updates(map[string]interface{}{
+			"status":       renewal.Status,
+			"review_notes": renewal.ReviewNotes,
+			"reviewed_by":  renewal.ReviewedBy,
+			"reviewed_at":  renewal.ReviewedAt,
+			"updated_at":   time.Now(),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrKartuConflict
+	}
+	return nil
+}
+
+func (repo *kartuRepository) GetKartuHistories(ctx context.Context, umkmID int) ([]model.KartuHistory, error) {
+	var histories []model.KartuHistory
+	err := repo.db.WithContext(ctx).
+		Preload("User").
+		Where("umkm_id = ? AND deleted_at IS NULL", umkmID).
+		Order("actioned_at DESC, id DESC").
+		Find(&histories).Error
+	if err != nil {
+		return nil, errors.New("failed to get kartu history")
+	}
+	return histories, nil
+}
+
+// GetExpiredKartus returns kartu still marked active whose expiry date is
+// before today.
+func (repo *kartuRepository) GetExpiredKartus(ctx context.Context, today time.Time, limit int) ([]model.UMKM, error) {
+	var umkms []model.UMKM
+	err := repo.db.WithContext(ctx).
+		Where("kartu_status = 'active' AND kartu_expiry_date < ? AND deleted_at IS NULL", today).
+		Order("kartu_expiry_date ASC").
+		Limit(limit).
+		Find(&umkms).Error
+	if err != nil {
+		return nil, errors.New("failed to get expired kartu")
+	}
+	return umkms, nil
+}
+
+func (repo *kartuRepository) GetRenewals(ctx context.Context, status string) ([]model.KartuRenewal, error) {
+	var renewals []model.KartuRenewal
+	query := repo.db.WithContext(ctx).
+		Preload("UMKM").
+		Where("deleted_at IS NULL")
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if err := query.Order("created_at ASC").Find(&renewals).Error; err != nil {
+		return nil, errors.New("failed to get kartu renewals")
+	}
+	return renewals, nil
+}
+
+func (repo *kartuRepository) GetRenewalByID(ctx context.Context, id int) (model.KartuRenewal, error) {
+	var renewal model.KartuRenewal
+	err := repo.db.WithContext(ctx).
+		Preload("UMKM").
+		Where("id = ? AND deleted_at IS NULL", id).
+		First(&renewal).Error
+	if err != nil {
+		return model.KartuRenewal{}, errors.New("kartu renewal not found")
+	}
+	return renewal, nil
+}
+
+func (repo *kartuRepository) GetPendingRenewal(ctx context.Context, umkmID int) (model.KartuRenewal, error) {
+	var renewal model.KartuRenewal
+	err := repo.db.WithContext(ctx).
+		Where("umkm_id = ? AND status = 'pending' AND deleted_at IS NULL", umkmID).
+		First(&renewal).Error
+	if err != nil {
+		return model.KartuRenewal{}, errors.New("kartu renewal not found")
+	}
+	return renewal, nil
+}
+
+func (repo *kartuRepository) CreateRenewal(ctx context.Context, renewal model.KartuRenewal) (model.KartuRenewal, error) {
+	if err := repo.db.WithContext(ctx).Omit("UMKM").Create(&renewal).Error; err != nil {
+		return model.KartuRenewal{}, errors.New("failed to create kartu renewal")
+	}
+	return renewal, nil
+}
+
+// UpdateRenewal stores the review of a renewal, only while it is still pending.
+func (repo *kartuRepository) UpdateRenewal(ctx context.Context, renewal model.KartuRenewal) error {
+	err := reviewRenewal(repo.db.WithContext(ctx), renewal)
+	if errors.Is(err, ErrKartuConflict) {
+		return ErrKartuConflict
+	}
+	if err != nil {
+		return errors.New("failed to update kartu renewal")
+	}
+	return nil
+}
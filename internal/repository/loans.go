@@ -0,0 +1,189 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"UMKMGo-backend/internal/types/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrLoanInRepayment is returned by CancelLoan when the loan already received
+// money back and can no longer simply be called off.
+var ErrLoanInRepayment = errors.New("loan already has repayments and cannot be cancelled")
+
+type LoanRepository interface {
+	CreateLoan(ctx context.Context, loan model.Loan) (model.Loan, error)
+	GetLoanByID(ctx context.Context, id int) (model.Loan, error)
+	GetLoanByApplicationID(ctx context.Context, applicationID int) (model.Loan, error)
+	GetLoans(ctx context.Context, status string, overdueAt *time.Time) ([]model.Loan, error)
+	LockLoan(ctx context.Context, id int) (model.Loan, error)
+	CancelLoan(ctx context.Context, applicationID int) error
+
+	// Repayments
+	CreateRepayment(ctx context.Context, repayment model.LoanRepayment) (model.LoanRepayment, error)
+	UpdateInstallmentPayment(ctx context.Context, installment model.LoanInstallment) error
+	UpdateLoanPayment(ctx context.Context, loan model.Loan) error
+}
+
+type loanRepository struct {
+	db *gorm.DB
+}
+
+func NewLoanRepository(db *gorm.DB) LoanRepository {
+	return &loanRepository{db}
+}
+
+// CreateLoan saves the loan together with its installments.
+func (repo *loanRepository) CreateLoan(ctx context.Context, loan model.Loan) (model.Loan, error) {
+	err := repo.db.WithContext(ctx).Omit("Application", "Repayments").Create(&loan).Error
+	if err != nil {
+		return model.Loan{}, errors.New("failed to create loan")
+	}
+	return loan, nil
+}
+
+func (repo *loanRepository) GetLoanByID(ctx context.Context, id int) (model.Loan, error) {
+	return repo.getLoan(ctx, "id = ?", id)
+}
+
+func (repo *loanRepository) GetLoanByApplicationID(ctx context.Context, applicationID int) (model.Loan, error) {
+	return repo.getLoan(ctx, "application_id = ?", applicationID)
+}
+
+func (repo *loanRepository) getLoan(ctx context.Context, condition string, value int) (model.Loan, error) {
+	var loan model.Loan
+	err := repo.db.WithContext(ctx).
+		Preload("Application.UMKM").
+		Preload("Application.Program").
+		Preload("Installments", func(db *gorm.DB) *gorm.DB {
+			return db.Order("number ASC")
+		}).
+		Preload("Repayments", func(db *gorm.DB) *gorm.DB {
+			return db.Order("paid_at ASC, id ASC")
+		}).
+		Preload("Repayments.User").
+		Where(condition+" AND deleted_at IS NULL", value).
+		First(&loan).Error
+	if err != nil {
+		return model.Loan{}, errors.New("loan not found")
+	}
+	return loan, nil
+}
+
+// GetLoans lists loans, optionally by status and, when overdueAt is set, only
+// those with an installment still unpaid before that date.
+func (repo *loanRepository) GetLoans(ctx context.Context, status string, overdueAt *time.Time) ([]model.Loan, error) {
+	var loans []model.Loan
+	query := repo.db.WithContext(ctx).
+		Preload("Application.UMKM").
+		Preload("Application.Program").
+		Preload("Installments", func(db *gorm.DB) *gorm.DB {
+			return db.Order("number ASC")
+		}).
+		Where("loans.deleted_at IS NULL")
+
+	if status != "" {
+		query = query.Where("loans.status = ?", status)
+	}
+	if overdueAt != nil {
+		query = query.Where("EXISTS (SELECT 1 FROM loan_installments WHERE loan_installments.loan_id = loans.id AND loan_installments.status <> ? AND loan_installments.due_date < ? AND loan_installments.deleted_at IS NULL)", "paid", *overdueAt)
+	}
+
+	if err := query.Order("loans.id DESC").Find(&loans).Error; err != nil {
+		return nil, errors.New("failed to get loans")
+	}
+	return loans, nil
+}
+
+// LockLoan loads the loan with its installments and locks its row until the
+// transaction ends, so repayments are applied one at a time.
+func (repo *loanRepository) LockLoan(ctx context.Context, id int) (model.Loan, error) {
+	var loan model.Loan
+	err := repo.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ? AND deleted_at IS NULL", id).
+		First(&loan).Error
+	if err != nil {
+		return model.Loan{}, errors.New("loan not found")
+	}
+
+	err = repo.db.WithContext(ctx).
+		Where("loan_id = ? AND deleted_at IS NULL", id).
+		Order("number ASC").
+		Find(&loan.Installments).Error
+	if err != nil {
+		return model.Loan{}, errors.New("failed to get loan installments")
+	}
+	return loan, nil
+}
+
+// CancelLoan stops the active loan of an application that left the program.
+// A loan that has been partly repaid is left alone and ErrLoanInRepayment is
+// returned, so the caller's transaction rolls back.
+func (repo *loanRepository) CancelLoan(ctx context.Context, applicationID int) error {
+	result := repo.db.WithContext(ctx).
+		Model(&model.Loan{}).
+		Where("application_id = ? AND status = ? AND deleted_at IS NULL", applicationID, "active").
+		Where("paid_amount = 0 AND NOT EXISTS (SELECT 1 FROM loan_repayments WHERE loan_repayments.loan_id = loans.id AND loan_repayments.deleted_at IS NULL)").
+		Update("status", "cancelled")
+	if result.Error != nil {
+		return errors.New("failed to cancel loan")
+	}
+	if result.RowsAffected > 0 {
+		return nil
+	}
+
+	var remaining int64
+	err := repo.db.WithContext(ctx).
+		Model(&model.Loan{}).
+		Where("application_id = ? AND status = ? AND deleted_at IS NULL", applicationID, "active").
+		Count(&remaining).Error
+	if err != nil {
+		return errors.New("failed to cancel loan")
+	}
+	if remaining > 0 {
+		return ErrLoanInRepayment
+	}
+	return nil
+}
+
+func (repo *loanRepository) CreateRepayment(ctx context.Context, repayment model.LoanRepayment) (model.LoanRepayment, error) {
+	err := repo.db.WithContext(ctx).Omit(clause.Associations).Create(&repayment).Error
+	if err != nil {
+		return model.LoanRepayment{}, errors.New("failed to create loan repayment")
+	}
+	return repayment, nil
+}
+
+func (repo *loanRepository) UpdateInstallmentPayment(ctx context.Context, installment model.LoanInstallment) error {
+	err := repo.db.WithContext(ctx).
+		Model(&model.LoanInstallment{}).
+		Where("id = ?", installment.ID).
+		Updates(map[string]interface{}{
+			"paid_amount": installment.PaidAmount,
+			"status":      installment.Status,
+			"paid_at":     installment.PaidAt,
+		}).Error
+	if err != nil {
+		return errors.New("failed to update loan installment")
+	}
+	return nil
+}
+
+func (repo *loanRepository) UpdateLoanPayment(ctx context.Context, loan model.Loan) error {
+	err := repo.db.WithContext(ctx).
+		Model(&model.Loan{}).
+		Where("id = ?", loan.ID).
+		Updates(map[string]interface{}{
+			"paid_amount": loan.PaidAmount,
+			"status":      loan.Status,
+		}).Error
+	if err != nil {
+		return errors.New("failed to update loan")
+	}
+	return nil
+}
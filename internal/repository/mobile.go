@@ -3,11 +3,13 @@ package repository
 import (
 	"context"
 	"errors"
+	"time"
 
 	"UMKMGo-backend/internal/types/dto"
 	"UMKMGo-backend/internal/types/model"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type MobileRepository interface {
@@ -33,8 +35,15 @@ type MobileRepository interface {
 	GetApplicationDetailByID(ctx context.Context, id int) (model.Application, error)
 	DeleteApplicationDocumentsByApplicationID(ctx context.Context, applicationID int) error
 
+	// Drafts
+	CreateApplicationDraft(ctx context.Context, application model.Application) (model.Application, error)
+	UpdateApplicationDraft(ctx context.Context, application model.Application) error
+	DeleteApplicationDraft(ctx context.Context, id int) error
+	CreateApplicationDocumentVersions(ctx context.Context, documents []model.ApplicationDocument) error
+
 	// Validations
 	GetProgramByID(ctx context.Context, id int) (model.Program, error)
+	GetProgramForApplication(ctx context.Context, id int) (model.Program, error)
 	GetProgramRequirements(ctx context.Context, programID int) ([]model.ProgramRequirement, error)
 	IsApplicationExists(ctx context.Context, umkmID, programID int) bool
 
@@ -197,7 +206,7 @@ func (r *mobileRepository) GetApplicationDetailByID(ctx context.Context, id int)
 	var application model.Application
 	err := r.db.WithContext(ctx).
 		Preload("Program").
-		Preload("Documents").
+		Preload("Documents", "superseded_at IS NULL").
 		Preload("Histories.User").
 		Preload("TrainingApplication").
 		Preload("CertificationApplication").
@@ -220,6 +229,124 @@ func (r *mobileRepository) DeleteApplicationDocumentsByApplicationID(ctx context
 	return nil
 }
 
+// Drafts
+// CreateApplicationDraft stores the application together with its type-specific record.
+func (r *mobileRepository) CreateApplicationDraft(ctx context.Context, application model.Application) (model.Application, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(&application).Error; err != nil {
+			return err
+		}
+
+		switch {
+		case application.TrainingApplication != nil:
+			application.TrainingApplication.ApplicationID = application.ID
+			return tx.Omit(clause.Associations).Create(application.TrainingApplication).Error
+		case application.CertificationApplication != nil:
+			application.CertificationApplication.ApplicationID = application.ID
+			return tx.Omit(clause.Associations).Create(application.CertificationApplication).Error
+		case application.FundingApplication != nil:
+			application.FundingApplication.ApplicationID = application.ID
+			return tx.Omit(clause.Associations).Create(application.FundingApplication).Error
+		}
+		return nil
+	})
+	if err != nil {
+		return model.Application{}, errors.New("failed to create application draft")
+	}
+	return application, nil
+}
+
+// UpdateApplicationDraft saves the draft's type-specific record and pushes its
+// idle expiry forward. Applications that left draft status are not touched.
+func (r *mobileRepository) UpdateApplicationDraft(ctx context.Context, application model.Application) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.Application{}).
+			Where("id = ? AND status = ? AND deleted_at IS NULL", application.ID, "draft").
+			Updates(map[string]interface{}{
+				"expired_at": application.ExpiredAt,
+				"version":    gorm.Expr("version + 1"),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		switch {
+		case application.TrainingApplication != nil:
+			return tx.Omit(clause.Associations).Save(application.TrainingApplication).Error
+		case application.CertificationApplication != nil:
+			return tx.Omit(clause.Associations).Save(application.CertificationApplication).Error
+		case application.FundingApplication != nil:
+			return tx.Omit(clause.Associations).Save(application.FundingApplication).Error
+		}
+		return nil
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return errors.New("draft not found")
+	}
+	if err != nil {
+		return errors.New("failed to update application draft")
+	}
+	return nil
+}
+
+func (r *mobileRepository) DeleteApplicationDraft(ctx context.Context, id int) error {
+	result := r.db.WithContext(ctx).
+		Where("id = ? AND status = ?", id, "draft").
+		Delete(&model.Application{})
+	if result.Error != nil {
+		return errors.New("failed to delete application draft")
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("draft not found")
+	}
+	return nil
+}
+
+// CreateApplicationDocumentVersions stores each document as the newest version of
+// its type. The current version it replaces is kept, marked superseded and linked
+// as its predecessor.
+func (r *mobileRepository) CreateApplicationDocumentVersions(ctx context.Context, documents []model.ApplicationDocument) error {
+	if len(documents) == 0 {
+		return nil
+	}
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		for _, document := range documents {
+			var previous model.ApplicationDocument
+			err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+				Where("application_id = ? AND type = ? AND superseded_at IS NULL AND deleted_at IS NULL", document.ApplicationID, document.Type).
+				Order("version DESC").
+				First(&previous).Error
+			switch {
+			case err == nil:
+				if err := tx.Model(&model.ApplicationDocument{}).
+					Where("id = ?", previous.ID).
+					Update("superseded_at", now).Error; err != nil {
+					return err
+				}
+				document.Version = previous.Version + 1
+				document.PreviousID = &previous.ID
+			case errors.Is(err, gorm.ErrRecordNotFound):
+				document.Version = 1
+			default:
+				return err
+			}
+
+			if err := tx.Create(&document).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.New("failed to create application documents")
+	}
+	return nil
+}
+
 // Validations
 func (r *mobileRepository) GetProgramByID(ctx context.Context, id int) (model.Program, error) {
 	var program model.Program
@@ -232,6 +359,20 @@ func (r *mobileRepository) GetProgramByID(ctx context.Context, id int) (model.Pr
 	return program, nil
 }
 
+// GetProgramForApplication loads a program whatever its state, including soft
+// deleted ones, so the submission gate can tell why it is closed.
+func (r *mobileRepository) GetProgramForApplication(ctx context.Context, id int) (model.Program, error) {
+	var program model.Program
+	err := r.db.WithContext(ctx).
+		Unscoped().
+		Where("id = ?", id).
+		First(&program).Error
+	if err != nil {
+		return model.Program{}, errors.New("program not found")
+	}
+	return program, nil
+}
+
 func (r *mobileRepository) GetProgramRequirements(ctx context.Context, programID int) ([]model.ProgramRequirement, error) {
 	var requirements []model.ProgramRequirement
 	err := r.db.WithContext(ctx).
@@ -247,7 +388,7 @@ func (r *mobileRepository) IsApplicationExists(ctx context.Context, umkmID, prog
 	var count int64
 	r.db.WithContext(ctx).
 		Model(&model.Application{}).
-		Where("umkm_id = ? AND program_id = ? AND deleted_at IS NULL AND status NOT IN ('rejected')", umkmID, programID).
+		Where("umkm_id = ? AND program_id = ? AND deleted_at IS NULL AND status NOT IN ('rejected', 'withdrawn')", umkmID, programID).
 		Count(&count)
 	return count > 0
 }
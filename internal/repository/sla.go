@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"errors"
+	"time"
 
 	"UMKMGo-backend/internal/types/model"
 
@@ -11,7 +12,12 @@ import (
 
 type SLARepository interface {
 	GetSLAByStatus(ctx context.Context, status string) (model.SLA, error)
-	UpdateSLA(ctx context.Context, sla model.SLA) (model.SLA, error)
+	GetEffectiveSLA(ctx context.Context, status, applicationType string, programID int, at time.Time) (model.SLA, error)
+	GetSLAByID(ctx context.Context, id int) (model.SLA, error)
+	GetSLARules(ctx context.Context, status, applicationType string, programID int) ([]model.SLA, error)
+	CreateSLA(ctx context.Context, sla model.SLA, changeLog model.SLAChangeLog) (model.SLA, error)
+	DeleteSLA(ctx context.Context, sla model.SLA, changeLog model.SLAChangeLog) error
+	GetSLAChangeLogs(ctx context.Context, slaID int) ([]model.SLAChangeLog, error)
 	GetApplicationsForExport(ctx context.Context, applicationType string) ([]model.Application, error)
 	GetProgramsForExport(ctx context.Context, applicationType string) ([]model.Program, error)
 }
@@ -24,30 +30,121 @@ func NewSLARepository(db *gorm.DB) SLARepository {
 	return &slaRepository{db}
 }
 
+// GetSLAByStatus returns the stage default currently in force.
 func (repo *slaRepository) GetSLAByStatus(ctx context.Context, status string) (model.SLA, error) {
 	var sla model.SLA
-	err := repo.db.WithContext(ctx).Where("status = ? AND deleted_at IS NULL", status).First(&sla).Error
+	err := repo.db.WithContext(ctx).
+		Where("status = ? AND application_type IS NULL AND program_id IS NULL", status).
+		Where("effective_from <= ? AND deleted_at IS NULL", time.Now()).
+		Order("effective_from DESC, id DESC").
+		First(&sla).Error
 	if err != nil {
 		return model.SLA{}, errors.New("SLA not found")
 	}
 	return sla, nil
 }
 
-func (repo *slaRepository) UpdateSLA(ctx context.Context, sla model.SLA) (model.SLA, error) {
-	err := repo.db.WithContext(ctx).Save(&sla).Error
+// GetEffectiveSLA resolves the rule that governed a stage at the given time. A
+// program override beats a program type rule, which beats the stage default;
+// within the same scope the latest effective_from wins.
+func (repo *slaRepository) GetEffectiveSLA(ctx context.Context, status, applicationType string, programID int, at time.Time) (model.SLA, error) {
+	var sla model.SLA
+	err := repo.db.WithContext(ctx).
+		Where("status = ? AND effective_from <= ? AND deleted_at IS NULL", status, at).
+		Where("program_id = ? OR (program_id IS NULL AND application_type = ?) OR (program_id IS NULL AND application_type IS NULL)", programID, applicationType).
+		Order("program_id IS NOT NULL DESC, application_type IS NOT NULL DESC, effective_from DESC, id DESC").
+		First(&sla).Error
+	if err != nil {
+		return model.SLA{}, errors.New("SLA not found")
+	}
+	return sla, nil
+}
+
+func (repo *slaRepository) GetSLAByID(ctx context.Context, id int) (model.SLA, error) {
+	var sla model.SLA
+	err := repo.db.WithContext(ctx).Where("id = ? AND deleted_at IS NULL", id).First(&sla).Error
+	if err != nil {
+		return model.SLA{}, errors.New("SLA not found")
+	}
+	return sla, nil
+}
+
+func (repo *slaRepository) GetSLARules(ctx context.Context, status, applicationType string, programID int) ([]model.SLA, error) {
+	var slas []model.SLA
+	query := repo.db.WithContext(ctx).Where("deleted_at IS NULL")
+
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if applicationType != "" {
+		query = query.Where("application_type = ?", applicationType)
+	}
+	if programID > 0 {
+		query = query.Where("program_id = ?", programID)
+	}
+
+	err := query.Order("status ASC, application_type ASC NULLS FIRST, program_id ASC NULLS FIRST, effective_from DESC").Find(&slas).Error
 	if err != nil {
-		return model.SLA{}, errors.New("failed to update SLA")
+		return nil, errors.New("failed to get SLA rules")
+	}
+	return slas, nil
+}
+
+// CreateSLA stores a new rule version together with its change log entry.
+func (repo *slaRepository) CreateSLA(ctx context.Context, sla model.SLA, changeLog model.SLAChangeLog) (model.SLA, error) {
+	err := repo.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&sla).Error; err != nil {
+			return err
+		}
+
+		changeLog.SLAID = sla.ID
+		return tx.Create(&changeLog).Error
+	})
+	if err != nil {
+		return model.SLA{}, errors.New("failed to create SLA")
 	}
 	return sla, nil
 }
 
+func (repo *slaRepository) DeleteSLA(ctx context.Context, sla model.SLA, changeLog model.SLAChangeLog) error {
+	err := repo.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&sla).Error; err != nil {
+			return err
+		}
+
+		changeLog.SLAID = sla.ID
+		return tx.Create(&changeLog).Error
+	})
+	if err != nil {
+		return errors.New("failed to delete SLA")
+	}
+	return nil
+}
+
+func (repo *slaRepository) GetSLAChangeLogs(ctx context.Context, slaID int) ([]model.SLAChangeLog, error) {
+	var changeLogs []model.SLAChangeLog
+	query := repo.db.WithContext(ctx).
+		Preload("User").
+		Where("deleted_at IS NULL")
+
+	if slaID > 0 {
+		query = query.Where("sla_id = ?", slaID)
+	}
+
+	err := query.Order("created_at DESC").Find(&changeLogs).Error
+	if err != nil {
+		return nil, errors.New("failed to get SLA change logs")
+	}
+	return changeLogs, nil
+}
+
 func (repo *slaRepository) GetApplicationsForExport(ctx context.Context, applicationType string) ([]model.Application, error) {
 	var applications []model.Application
 	query := repo.db.WithContext(ctx).
 		Preload("Program").
 		Preload("UMKM.User").
 		Preload("UMKM.City.Province").
-		Where("applications.deleted_at IS NULL")
+		Where("applications.deleted_at IS NULL AND applications.status <> ?", "draft")
 
 	if applicationType != "all" {
 		query = query.Where("type = ?", applicationType)
@@ -0,0 +1,198 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"UMKMGo-backend/internal/types/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TrainingRepository interface {
+	// Sessions
+	GetSessions(ctx context.Context, programID int) ([]model.TrainingSession, error)
+	GetSessionByID(ctx context.Context, id int) (model.TrainingSession, error)
+	CreateSession(ctx context.Context, session model.TrainingSession) (model.TrainingSession, error)
+	UpdateSession(ctx context.Context, session model.TrainingSession) (model.TrainingSession, error)
+	DeleteSession(ctx context.Context, id int) error
+
+	// Attendance
+	GetParticipants(ctx context.Context, programID int) ([]model.Application, error)
+	GetProgramAttendances(ctx context.Context, programID int) ([]model.TrainingAttendance, error)
+	GetSessionAttendances(ctx context.Context, sessionID int) ([]model.TrainingAttendance, error)
+	SaveAttendances(ctx context.Context, attendances []model.TrainingAttendance) error
+
+	// Certificates
+	GetProgramCertificates(ctx context.Context, programID int) ([]model.TrainingCertificate, error)
+	GetCertificatesByUMKMID(ctx context.Context, umkmID int) ([]model.TrainingCertificate, error)
+	GetCertificateByCode(ctx context.Context, code string) (model.TrainingCertificate, error)
+	CreateCertificate(ctx context.Context, certificate model.TrainingCertificate) (model.TrainingCertificate, error)
+}
+
+type trainingRepository struct {
+	db *gorm.DB
+}
+
+func NewTrainingRepository(db *gorm.DB) TrainingRepository {
+	return &trainingRepository{db}
+}
+
+// Sessions
+func (repo *trainingRepository) GetSessions(ctx context.Context, programID int) ([]model.TrainingSession, error) {
+	var sessions []model.TrainingSession
+	err := repo.db.WithContext(ctx).
+		Where("program_id = ? AND deleted_at IS NULL", programID).
+		Order("starts_at ASC, id ASC").
+		Find(&sessions).Error
+	if err != nil {
+		return nil, errors.New("failed to get training sessions")
+	}
+	return sessions, nil
+}
+
+func (repo *trainingRepository) GetSessionByID(ctx context.Context, id int) (model.TrainingSession, error) {
+	var session model.TrainingSession
+	err := repo.db.WithContext(ctx).
+		Preload("Program").
+		Where("id = ? AND deleted_at IS NULL", id).
+		First(&session).Error
+	if err != nil {
+		return model.TrainingSession{}, errors.New("training session not found")
+	}
+	return session, nil
+}
+
+func (repo *trainingRepository) CreateSession(ctx context.Context, session model.TrainingSession) (model.TrainingSession, error) {
+	err := repo.db.WithContext(ctx).Omit(clause.Associations).Create(&session).Error
+	if err != nil {
+		return model.TrainingSession{}, errors.New("failed to create training session")
+	}
+	return session, nil
+}
+
+func (repo *trainingRepository) UpdateSession(ctx context.Context, session model.TrainingSession) (model.TrainingSession, error) {
+	err := repo.db.WithContext(ctx).Omit(clause.Associations).Save(&session).Error
+	if err != nil {
+		return model.TrainingSession{}, errors.New("failed to update training session")
+	}
+	return session, nil
+}
+
+func (repo *trainingRepository) DeleteSession(ctx context.Context, id int) error {
+	err := repo.db.WithContext(ctx).Delete(&model.TrainingSession{}, id).Error
+	if err != nil {
+		return errors.New("failed to delete training session")
+	}
+	return nil
+}
+
+// Attendance
+
+// GetParticipants returns the approved applications of a training program,
+// the people expected at its sessions.
+func (repo *trainingRepository) GetParticipants(ctx context.Context, programID int) ([]model.Application, error) {
+	var applications []model.Application
+	err := repo.db.WithContext(ctx).
+		Preload("UMKM.User").
+		Preload("Program").
+		Where("program_id = ? AND type = ? AND status = ? AND deleted_at IS NULL", programID, "training", "approved").
+		Order("id ASC").
+		Find(&applications).Error
+	if err != nil {
+		return nil, errors.New("failed to get training participants")
+	}
+	return applications, nil
+}
+
+func (repo *trainingRepository) GetProgramAttendances(ctx context.Context, programID int) ([]model.TrainingAttendance, error) {
+	var attendances []model.TrainingAttendance
+	err := repo.db.WithContext(ctx).
+		Joins("JOIN training_sessions ON training_sessions.id = training_attendances.session_id AND training_sessions.deleted_at IS NULL").
+		Where("training_sessions.program_id = ? AND training_attendances.deleted_at IS NULL", programID).
+		Find(&attendances).Error
+	if err != nil {
+		return nil, errors.New("failed to get training attendances")
+	}
+	return attendances, nil
+}
+
+func (repo *trainingRepository) GetSessionAttendances(ctx context.Context, sessionID int) ([]model.TrainingAttendance, error) {
+	var attendances []model.TrainingAttendance
+	err := repo.db.WithContext(ctx).
+		Where("session_id = ? AND deleted_at IS NULL", sessionID).
+		Find(&attendances).Error
+	if err != nil {
+		return nil, errors.New("failed to get session attendances")
+	}
+	return attendances, nil
+}
+
+// SaveAttendances records attendance, replacing an earlier record of the same
+// participant for the same session.
+func (repo *trainingRepository) SaveAttendances(ctx context.Context, attendances []model.TrainingAttendance) error {
+	if len(attendances) == 0 {
+		return nil
+	}
+	err := repo.db.WithContext(ctx).
+		Omit(clause.Associations).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "session_id"}, {Name: "application_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"status", "checked_in_at", "notes", "recorded_by", "updated_at"}),
+		}).
+		Create(&attendances).Error
+	if err != nil {
+		return errors.New("failed to save attendances")
+	}
+	return nil
+}
+
+// Certificates
+func (repo *trainingRepository) GetProgramCertificates(ctx context.Context, programID int) ([]model.TrainingCertificate, error) {
+	var certificates []model.TrainingCertificate
+	err := repo.db.WithContext(ctx).
+		Joins("JOIN applications ON applications.id = training_certificates.application_id").
+		Where("applications.program_id = ? AND training_certificates.deleted_at IS NULL", programID).
+		Find(&certificates).Error
+	if err != nil {
+		return nil, errors.New("failed to get training certificates")
+	}
+	return certificates, nil
+}
+
+func (repo *trainingRepository) GetCertificatesByUMKMID(ctx context.Context, umkmID int) ([]model.TrainingCertificate, error) {
+	var certificates []model.TrainingCertificate
+	err := repo.db.WithContext(ctx).
+		Preload("Application.Program").
+		Preload("Application.UMKM.User").
+		Joins("JOIN applications ON applications.id = training_certificates.application_id").
+		Where("applications.umkm_id = ? AND training_certificates.deleted_at IS NULL", umkmID).
+		Order("training_certificates.issued_at DESC").
+		Find(&certificates).Error
+	if err != nil {
+		return nil, errors.New("failed to get certificates")
+	}
+	return certificates, nil
+}
+
+func (repo *trainingRepository) GetCertificateByCode(ctx context.Context, code string) (model.TrainingCertificate, error) {
+	var certificate model.TrainingCertificate
+	err := repo.db.WithContext(ctx).
+		Preload("Application.Program").
+		Preload("Application.UMKM.User").
+		Where("verification_code = ? AND deleted_at IS NULL", code).
+		First(&certificate).Error
+	if err != nil {
+		return model.TrainingCertificate{}, errors.New("certificate not found")
+	}
+	return certificate, nil
+}
+
+func (repo *trainingRepository) CreateCertificate(ctx context.Context, certificate model.TrainingCertificate) (model.TrainingCertificate, error) {
+	err := repo.db.WithContext(ctx).Omit(clause.Associations).Create(&certificate).Error
+	if err != nil {
+		return model.TrainingCertificate{}, errors.New("failed to create certificate")
+	}
+	return certificate, nil
+}
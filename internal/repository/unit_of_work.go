@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// TxRepositories exposes the repositories bound to a single transaction.
+type TxRepositories struct {
+	Applications       ApplicationsRepository
+	Notifications      NotificationRepository
+	AdminNotifications AdminNotificationRepository
+	Mobile             MobileRepository
+	Loans              LoanRepository
+}
+
+// UnitOfWork runs fn inside one database transaction. Any error returned by fn
+// rolls back every write made through the given repositories.
+type UnitOfWork interface {
+	Do(ctx context.Context, fn func(repos TxRepositories) error) error
+}
+
+type unitOfWork struct {
+	db *gorm.DB
+}
+
+func NewUnitOfWork(db *gorm.DB) UnitOfWork {
+	return &unitOfWork{db}
+}
+
+func (u *unitOfWork) Do(ctx context.Context, fn func(repos TxRepositories) error) error {
+	return u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(TxRepositories{
+			Applications:       NewApplicationsRepository(tx),
+			Notifications:      NewNotificationRepository(tx),
+			AdminNotifications: NewAdminNotificationRepository(tx),
+			Mobile:             NewMobileRepository(tx),
+			Loans:              NewLoanRepository(tx),
+		})
+	})
+}
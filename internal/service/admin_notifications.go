@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+
+	"UMKMGo-backend/internal/repository"
+	"UMKMGo-backend/internal/types/dto"
+)
+
+type AdminNotificationService interface {
+	GetNotifications(ctx context.Context, userID int) ([]dto.AdminNotificationResponse, error)
+	GetUnreadCount(ctx context.Context, userID int) (int64, error)
+	MarkAsRead(ctx context.Context, userID, notificationID int) error
+	MarkAllAsRead(ctx context.Context, userID int) error
+}
+
+type adminNotificationService struct {
+	adminNotificationRepository repository.AdminNotificationRepository
+}
+
+func NewAdminNotificationService(adminNotificationRepo repository.AdminNotificationRepository) AdminNotificationService {
+	return &adminNotificationService{
+		adminNotificationRepository: adminNotificationRepo,
+	}
+}
+
+func (s *adminNotificationService) GetNotifications(ctx context.Context, userID int) ([]dto.AdminNotificationResponse, error) {
+	notifications, err := s.adminNotificationRepository.GetAdminNotificationsByUserID(ctx, userID, 100, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.AdminNotificationResponse, 0, len(notifications))
+	for _, n := range notifications {
+		var readAt string
+		if n.ReadAt != nil {
+			readAt = n.ReadAt.Format("2006-01-02 15:04:05")
+		}
+		response = append(response, dto.AdminNotificationResponse{
+			ID:            n.ID,
+			Type:          n.Type,
+			Title:         n.Title,
+			Message:       n.Message,
+			IsRead:        n.IsRead,
+			ApplicationID: n.ApplicationID,
+			CreatedAt:     n.CreatedAt.Format("2006-01-02 15:04:05"),
+			ReadAt:        readAt,
+		})
+	}
+
+	return response, nil
+}
+
+func (s *adminNotificationService) GetUnreadCount(ctx context.Context, userID int) (int64, error) {
+	return s.adminNotificationRepository.GetUnreadCount(ctx, userID)
+}
+
+func (s *adminNotificationService) MarkAsRead(ctx context.Context, userID, notificationID int) error {
+	return s.adminNotificationRepository.MarkAsRead(ctx, notificationID, userID)
+}
+
+func (s *adminNotificationService) MarkAllAsRead(ctx context.Context, userID int) error {
+	return s.adminNotificationRepository.MarkAllAsRead(ctx, userID)
+}
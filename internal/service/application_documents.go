@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+
+	"UMKMGo-backend/internal/types/dto"
+	"UMKMGo-backend/internal/types/model"
+)
+
+// GetDocumentComparison lists, per document type, the version sent with the
+// original submission next to the current one so reviewers can see what a
+// resubmission changed.
+func (s *applicationsService) GetDocumentComparison(ctx context.Context, applicationID int) ([]dto.DocumentComparison, error) {
+	application, err := s.applicationRepository.GetApplicationByID(ctx, applicationID)
+	if err != nil {
+		return nil, err
+	}
+
+	documents, err := s.applicationRepository.GetApplicationDocumentVersions(ctx, application.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Versions come ordered by type, oldest first
+	comparisons := []dto.DocumentComparison{}
+	for _, document := range documents {
+		version := documentVersionDTO(document)
+		if len(comparisons) == 0 || comparisons[len(comparisons)-1].Type != document.Type {
+			comparisons = append(comparisons, dto.DocumentComparison{Type: document.Type})
+		}
+		comparison := &comparisons[len(comparisons)-1]
+		comparison.Versions = append(comparison.Versions, version)
+
+		if comparison.Submitted == nil {
+			submitted := version
+			comparison.Submitted = &submitted
+		}
+		if document.SupersededAt == nil {
+			current := version
+			comparison.Current = &current
+		}
+	}
+
+	for i := range comparisons {
+		comparison := &comparisons[i]
+		comparison.Changed = comparison.Current == nil || comparison.Current.ID != comparison.Submitted.ID
+	}
+
+	return comparisons, nil
+}
+
+func documentVersionDTO(document model.ApplicationDocument) dto.ApplicationDocuments {
+	return dto.ApplicationDocuments{
+		ID:            document.ID,
+		ApplicationID: document.ApplicationID,
+		Type:          document.Type,
+		File:          document.File,
+		ReviewStatus:  document.ReviewStatus,
+		ReviewReason:  document.ReviewReason,
+		ReviewedBy:    document.ReviewedBy,
+		ReviewedAt:    formatOptionalTime(document.ReviewedAt),
+		Version:       document.Version,
+		RevisionRound: document.RevisionRound,
+		SupersededAt:  formatOptionalTime(document.SupersededAt),
+		CreatedAt:     document.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt:     document.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
@@ -0,0 +1,310 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"UMKMGo-backend/config/env"
+	"UMKMGo-backend/internal/types/dto"
+	"UMKMGo-backend/internal/types/model"
+	"UMKMGo-backend/internal/utils/constant"
+)
+
+// defaultDraftIdleDays applies when DRAFT_IDLE_DAYS is not loaded (e.g. in tests).
+const defaultDraftIdleDays = 30
+
+// DraftIncompleteError is returned when a draft is submitted before it passes validation.
+type DraftIncompleteError struct {
+	Issues []dto.DraftValidationIssue
+}
+
+func (e *DraftIncompleteError) Error() string {
+	return fmt.Sprintf("draft is incomplete: %s %s", e.Issues[0].Field, e.Issues[0].Message)
+}
+
+// CreateApplicationDraft starts an application that is saved but not yet submitted.
+// The program decides the application type; drafts are only visible to the applicant.
+func (s *mobileService) CreateApplicationDraft(ctx context.Context, userID int, request dto.CreateApplicationDraft) (dto.ApplicationDetailMobile, error) {
+	program, err := s.openProgram(ctx, request.ProgramID)
+	if err != nil {
+		return dto.ApplicationDetailMobile{}, err
+	}
+
+	umkm, err := s.mobileRepo.GetUMKMProfileByID(ctx, userID)
+	if err != nil {
+		return dto.ApplicationDetailMobile{}, errors.New("UMKM profile not found, please complete your profile first")
+	}
+
+	// A draft counts as an application, so one program holds at most one
+	if s.mobileRepo.IsApplicationExists(ctx, umkm.ID, request.ProgramID) {
+		return dto.ApplicationDetailMobile{}, errors.New("you have already applied for this program")
+	}
+
+	// SubmittedAt and ExpiredAt are restamped from the SLA on submission; until
+	// then ExpiredAt is when the idle draft is discarded
+	now := time.Now()
+	application := model.Application{
+		UMKMID:      umkm.ID,
+		ProgramID:   program.ID,
+		Type:        program.Type,
+		Status:      constant.ApplicationStatusDraft,
+		SubmittedAt: now,
+		ExpiredAt:   draftExpiry(now),
+	}
+	applyFormFields(&application, request.ApplicationFormFields)
+
+	createdApp, err := s.mobileRepo.CreateApplicationDraft(ctx, application)
+	if err != nil {
+		return dto.ApplicationDetailMobile{}, err
+	}
+
+	if len(request.Documents) > 0 {
+		go s.processAndSaveDocuments(ctx, createdApp.ID, 0, request.Documents)
+	}
+
+	return s.GetApplicationDetail(ctx, createdApp.ID)
+}
+
+// UpdateApplicationDraft applies the fields sent on top of the saved draft and
+// pushes its idle expiry forward.
+func (s *mobileService) UpdateApplicationDraft(ctx context.Context, userID, applicationID int, request dto.ApplicationDraftFields) (dto.ApplicationDetailMobile, error) {
+	_, application, err := s.getOwnApplication(ctx, userID, applicationID)
+	if err != nil {
+		return dto.ApplicationDetailMobile{}, err
+	}
+	if application.Status != constant.ApplicationStatusDraft {
+		return dto.ApplicationDetailMobile{}, errors.New("only draft applications can be edited")
+	}
+
+	applyFormFields(&application, request.ApplicationFormFields)
+	application.ExpiredAt = draftExpiry(time.Now())
+
+	if err := s.mobileRepo.UpdateApplicationDraft(ctx, application); err != nil {
+		return dto.ApplicationDetailMobile{}, err
+	}
+
+	if len(request.Documents) > 0 {
+		go s.processAndSaveDocuments(ctx, application.ID, 0, request.Documents)
+	}
+
+	return s.GetApplicationDetail(ctx, application.ID)
+}
+
+// ValidateApplicationDraft reports what still blocks the draft from being submitted.
+func (s *mobileService) ValidateApplicationDraft(ctx context.Context, userID, applicationID int) (dto.DraftValidationResult, error) {
+	umkm, application, err := s.getOwnApplication(ctx, userID, applicationID)
+	if err != nil {
+		return dto.DraftValidationResult{}, err
+	}
+	if application.Status != constant.ApplicationStatusDraft {
+		return dto.DraftValidationResult{}, errors.New("only draft applications can be validated")
+	}
+
+	return s.validateDraft(ctx, umkm, application), nil
+}
+
+// SubmitApplicationDraft moves a complete draft into screening. The screening SLA
+// starts at submission, not when the draft was created.
+func (s *mobileService) SubmitApplicationDraft(ctx context.Context, userID, applicationID int) error {
+	umkm, application, err := s.getOwnApplication(ctx, userID, applicationID)
+	if err != nil {
+		return err
+	}
+
+	workflow := newApplicationWorkflow(s.uow, newSLACalendar(s.slaRepo, s.holidayRepo))
+	if err := workflow.Check(eventSubmitDraft, application); err != nil {
+		return err
+	}
+
+	if result := s.validateDraft(ctx, umkm, application); !result.Valid {
+		return &DraftIncompleteError{Issues: result.Issues}
+	}
+
+	// Record the eligibility the draft was submitted with for the reviewers
+	program, err := s.openProgram(ctx, application.ProgramID)
+	if err != nil {
+		return err
+	}
+	eligibility, err := checkEligibility(program, umkm, application)
+	if err != nil {
+		return err
+	}
+	application.Eligibility = eligibility
+
+	if _, err := workflow.Fire(ctx, eventSubmitDraft, application, umkm.UserID, ""); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteApplicationDraft discards a draft the applicant no longer wants.
+func (s *mobileService) DeleteApplicationDraft(ctx context.Context, userID, applicationID int) error {
+	_, application, err := s.getOwnApplication(ctx, userID, applicationID)
+	if err != nil {
+		return err
+	}
+	if application.Status != constant.ApplicationStatusDraft {
+		return errors.New("only draft applications can be deleted")
+	}
+
+	return s.mobileRepo.DeleteApplicationDraft(ctx, application.ID)
+}
+
+// validateDraft applies the checks of the one-shot create endpoints to a draft.
+func (s *mobileService) validateDraft(ctx context.Context, umkm model.UMKM, application model.Application) dto.DraftValidationResult {
+	issues := []dto.DraftValidationIssue{}
+
+	var limits *model.Program
+	program, err := s.openProgram(ctx, application.ProgramID)
+	var closedErr *ProgramClosedError
+	switch {
+	case errors.As(err, &closedErr):
+		for _, reason := range closedErr.Reasons {
+			issues = append(issues, dto.DraftValidationIssue{Field: "program_id", Message: reason.Message})
+		}
+	case err != nil:
+		issues = append(issues, dto.DraftValidationIssue{Field: "program_id", Message: "program is no longer open for applications"})
+	default:
+		limits = &program
+		for _, rule := range evaluateEligibility(program, &umkm, &application, time.Now()).UnmetRules {
+			issues = append(issues, dto.DraftValidationIssue{Field: rule.Rule, Message: rule.Message})
+		}
+	}
+	issues = append(issues, formFieldIssues(application, limits)...)
+
+	if len(application.Documents) == 0 {
+		issues = append(issues, dto.DraftValidationIssue{Field: "documents", Message: "at least one document is required"})
+	}
+
+	return dto.DraftValidationResult{
+		Valid:  len(issues) == 0,
+		Issues: issues,
+	}
+}
+
+// formFieldIssues checks the type-specific form of an application the way the
+// create endpoints do. The program's amount and tenure limits are skipped when
+// program is nil.
+func formFieldIssues(application model.Application, program *model.Program) []dto.DraftValidationIssue {
+	var issues []dto.DraftValidationIssue
+	require := func(field, value string) {
+		if value == "" {
+			issues = append(issues, dto.DraftValidationIssue{Field: field, Message: "is required"})
+		}
+	}
+
+	switch application.Type {
+	case "training":
+		training := application.TrainingApplication
+		if training == nil {
+			training = &model.TrainingApplication{}
+		}
+		require("motivation", training.Motivation)
+	case "certification":
+		certification := application.CertificationApplication
+		if certification == nil {
+			certification = &model.CertificationApplication{}
+		}
+		require("business_sector", certification.BusinessSector)
+		require("product_or_service", certification.ProductOrService)
+		require("business_description", certification.BusinessDescription)
+		require("certification_goals", certification.CertificationGoals)
+	case "funding":
+		funding := application.FundingApplication
+		if funding == nil {
+			funding = &model.FundingApplication{}
+		}
+		require("business_sector", funding.BusinessSector)
+		require("business_description", funding.BusinessDescription)
+		require("fund_purpose", funding.FundPurpose)
+
+		switch {
+		case funding.RequestedAmount <= 0:
+			issues = append(issues, dto.DraftValidationIssue{Field: "requested_amount", Message: "is required"})
+		case program != nil && program.MinAmount != nil && funding.RequestedAmount < *program.MinAmount:
+			issues = append(issues, dto.DraftValidationIssue{Field: "requested_amount", Message: fmt.Sprintf("must be at least %.2f", *program.MinAmount)})
+		case program != nil && program.MaxAmount != nil && funding.RequestedAmount > *program.MaxAmount:
+			issues = append(issues, dto.DraftValidationIssue{Field: "requested_amount", Message: fmt.Sprintf("cannot exceed %.2f", *program.MaxAmount)})
+		}
+
+		switch {
+		case funding.RequestedTenureMonths <= 0:
+			issues = append(issues, dto.DraftValidationIssue{Field: "requested_tenure_months", Message: "is required"})
+		case program != nil && program.MaxTenureMonths != nil && funding.RequestedTenureMonths > *program.MaxTenureMonths:
+			issues = append(issues, dto.DraftValidationIssue{Field: "requested_tenure_months", Message: fmt.Sprintf("cannot exceed %d months", *program.MaxTenureMonths)})
+		}
+	}
+
+	return issues
+}
+
+// applyFormFields copies the fields sent into the type-specific record of the
+// application, creating the record on first use.
+func applyFormFields(application *model.Application, fields dto.ApplicationFormFields) {
+	switch application.Type {
+	case "training":
+		training := model.TrainingApplication{ApplicationID: application.ID}
+		if application.TrainingApplication != nil {
+			training = *application.TrainingApplication
+		}
+		assignFormField(&training.Motivation, fields.Motivation)
+		assignFormField(&training.BusinessExperience, fields.BusinessExperience)
+		assignFormField(&training.LearningObjectives, fields.LearningObjectives)
+		assignFormField(&training.AvailabilityNotes, fields.AvailabilityNotes)
+		application.TrainingApplication = &training
+	case "certification":
+		certification := model.CertificationApplication{ApplicationID: application.ID}
+		if application.CertificationApplication != nil {
+			certification = *application.CertificationApplication
+		}
+		assignFormField(&certification.BusinessSector, fields.BusinessSector)
+		assignFormField(&certification.ProductOrService, fields.ProductOrService)
+		assignFormField(&certification.BusinessDescription, fields.BusinessDescription)
+		assignFormField(&certification.CurrentStandards, fields.CurrentStandards)
+		assignFormField(&certification.CertificationGoals, fields.CertificationGoals)
+		if fields.YearsOperating != nil {
+			certification.YearsOperating = fields.YearsOperating
+		}
+		application.CertificationApplication = &certification
+	case "funding":
+		funding := model.FundingApplication{ApplicationID: application.ID}
+		if application.FundingApplication != nil {
+			funding = *application.FundingApplication
+		}
+		assignFormField(&funding.BusinessSector, fields.BusinessSector)
+		assignFormField(&funding.BusinessDescription, fields.BusinessDescription)
+		assignFormField(&funding.RequestedAmount, fields.RequestedAmount)
+		assignFormField(&funding.FundPurpose, fields.FundPurpose)
+		assignFormField(&funding.BusinessPlan, fields.BusinessPlan)
+		assignFormField(&funding.RequestedTenureMonths, fields.RequestedTenureMonths)
+		assignFormField(&funding.CollateralDescription, fields.CollateralDescription)
+		if fields.YearsOperating != nil {
+			funding.YearsOperating = fields.YearsOperating
+		}
+		if fields.RevenueProjection != nil {
+			funding.RevenueProjection = fields.RevenueProjection
+		}
+		if fields.MonthlyRevenue != nil {
+			funding.MonthlyRevenue = fields.MonthlyRevenue
+		}
+		application.FundingApplication = &funding
+	}
+}
+
+func assignFormField[T any](dst *T, value *T) {
+	if value != nil {
+		*dst = *value
+	}
+}
+
+// draftExpiry is when a draft last saved at from is discarded.
+func draftExpiry(from time.Time) time.Time {
+	idleDays := env.Cfg.Drafts.IdleDays
+	if idleDays <= 0 {
+		idleDays = defaultDraftIdleDays
+	}
+	return from.AddDate(0, 0, idleDays)
+}
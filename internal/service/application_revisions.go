@@ -0,0 +1,242 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"UMKMGo-backend/internal/repository"
+	"UMKMGo-backend/internal/types/dto"
+	"UMKMGo-backend/internal/types/model"
+	"UMKMGo-backend/internal/utils/constant"
+)
+
+// revisableFields lists, per application type, the form fields a reviewer may
+// flag for revision. Names match the JSON fields of the create requests.
+var revisableFields = map[string][]string{
+	"training": {
+		"motivation", "business_experience", "learning_objectives", "availability_notes",
+	},
+	"certification": {
+		"business_sector", "product_or_service", "business_description", "years_operating",
+		"current_standards", "certification_goals",
+	},
+	"funding": {
+		"business_sector", "business_description", "years_operating", "requested_amount", "fund_purpose",
+		"business_plan", "revenue_projection", "monthly_revenue", "requested_tenure_months", "collateral_description",
+	},
+}
+
+// ReviewDocument records a screening verdict on one uploaded document. Rejected
+// documents are the ones the UMKM has to replace once a revision is requested.
+func (s *applicationsService) ReviewDocument(ctx context.Context, userID, applicationID, documentID int, request dto.DocumentReviewRequest) (dto.ApplicationDocuments, error) {
+	if request.Status != constant.DocumentReviewAccepted && request.Status != constant.DocumentReviewRejected {
+		return dto.ApplicationDocuments{}, errors.New("status must be accepted or rejected")
+	}
+	reason := strings.TrimSpace(request.Reason)
+	if request.Status == constant.DocumentReviewRejected && reason == "" {
+		return dto.ApplicationDocuments{}, errors.New("reason is required when rejecting a document")
+	}
+
+	application, err := s.applicationRepository.GetApplicationByID(ctx, applicationID)
+	if err != nil {
+		return dto.ApplicationDocuments{}, err
+	}
+	if application.Status != constant.ApplicationStatusScreening {
+		return dto.ApplicationDocuments{}, errors.New("documents can only be reviewed while the application is in screening")
+	}
+
+	// Verdicts belong to the screening reviewer holding the claim
+	if err := s.claims.Require(ctx, constant.ApplicationStatusScreening, application.ID, userID); err != nil {
+		return dto.ApplicationDocuments{}, err
+	}
+
+	index := slices.IndexFunc(application.Documents, func(doc model.ApplicationDocument) bool {
+		return doc.ID == documentID
+	})
+	if index < 0 {
+		return dto.ApplicationDocuments{}, errors.New("document not found")
+	}
+
+	now := time.Now()
+	document := application.Documents[index]
+	document.ReviewStatus = request.Status
+	document.ReviewReason = reason
+	document.ReviewedBy = &userID
+	document.ReviewedAt = &now
+
+	if err := s.applicationRepository.UpdateDocumentReview(ctx, document); err != nil {
+		return dto.ApplicationDocuments{}, err
+	}
+
+	return dto.ApplicationDocuments{
+		ID:            document.ID,
+		ApplicationID: document.ApplicationID,
+		Type:          document.Type,
+		File:          document.File,
+		ReviewStatus:  document.ReviewStatus,
+		ReviewReason:  document.ReviewReason,
+		ReviewedBy:    document.ReviewedBy,
+		ReviewedAt:    formatOptionalTime(document.ReviewedAt),
+		Version:       document.Version,
+		RevisionRound: document.RevisionRound,
+		CreatedAt:     document.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt:     document.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}, nil
+}
+
+// flagRevisionItems rejects the named documents and records the named form fields
+// on the application, inside the transaction that moves it to revised. Documents
+// already rejected keep their own reason; the others get the revision notes.
+func flagRevisionItems(ctx context.Context, repos repository.TxRepositories, application *model.Application, reviewerID int, documentIDs []int, fields []string, notes string) error {
+	var flaggedFields []string
+	for _, field := range fields {
+		field = strings.TrimSpace(field)
+		if !slices.Contains(revisableFields[application.Type], field) {
+			return fmt.Errorf("field %q cannot be revised on %s applications", field, application.Type)
+		}
+		if !slices.Contains(flaggedFields, field) {
+			flaggedFields = append(flaggedFields, field)
+		}
+	}
+
+	var indexes []int
+	for _, documentID := range documentIDs {
+		index := slices.IndexFunc(application.Documents, func(doc model.ApplicationDocument) bool {
+			return doc.ID == documentID
+		})
+		if index < 0 {
+			return fmt.Errorf("document %d does not belong to application %d", documentID, application.ID)
+		}
+		if !slices.Contains(indexes, index) {
+			indexes = append(indexes, index)
+		}
+	}
+
+	now := time.Now()
+	documents := slices.Clone(application.Documents)
+	for _, index := range indexes {
+		document := documents[index]
+		if document.ReviewStatus != constant.DocumentReviewRejected || document.ReviewReason == "" {
+			document.ReviewReason = notes
+		}
+		document.ReviewStatus = constant.DocumentReviewRejected
+		document.ReviewedBy = &reviewerID
+		document.ReviewedAt = &now
+
+		if err := repos.Applications.UpdateDocumentReview(ctx, document); err != nil {
+			return err
+		}
+		documents[index] = document
+	}
+
+	application.Documents = documents
+	application.RevisionFields = strings.Join(flaggedFields, ",")
+	return nil
+}
+
+// flaggedDocumentTypes returns the document types rejected during screening.
+func flaggedDocumentTypes(application model.Application) []string {
+	var types []string
+	for _, document := range application.Documents {
+		if document.ReviewStatus == constant.DocumentReviewRejected && !slices.Contains(types, document.Type) {
+			types = append(types, document.Type)
+		}
+	}
+	return types
+}
+
+// splitRevisionFields turns the stored revision fields into a list.
+func splitRevisionFields(fields string) []string {
+	if fields == "" {
+		return nil
+	}
+	return strings.Split(fields, ",")
+}
+
+// formFieldValues returns the revisable form fields of the application keyed by
+// their JSON names. Unset optional fields are nil.
+func formFieldValues(application model.Application) map[string]any {
+	switch application.Type {
+	case "training":
+		training := application.TrainingApplication
+		if training == nil {
+			training = &model.TrainingApplication{}
+		}
+		return map[string]any{
+			"motivation":          training.Motivation,
+			"business_experience": training.BusinessExperience,
+			"learning_objectives": training.LearningObjectives,
+			"availability_notes":  training.AvailabilityNotes,
+		}
+	case "certification":
+		certification := application.CertificationApplication
+		if certification == nil {
+			certification = &model.CertificationApplication{}
+		}
+		return map[string]any{
+			"business_sector":      certification.BusinessSector,
+			"product_or_service":   certification.ProductOrService,
+			"business_description": certification.BusinessDescription,
+			"years_operating":      optionalValue(certification.YearsOperating),
+			"current_standards":    certification.CurrentStandards,
+			"certification_goals":  certification.CertificationGoals,
+		}
+	case "funding":
+		funding := application.FundingApplication
+		if funding == nil {
+			funding = &model.FundingApplication{}
+		}
+		return map[string]any{
+			"business_sector":         funding.BusinessSector,
+			"business_description":    funding.BusinessDescription,
+			"years_operating":         optionalValue(funding.YearsOperating),
+			"requested_amount":        funding.RequestedAmount,
+			"fund_purpose":            funding.FundPurpose,
+			"business_plan":           funding.BusinessPlan,
+			"revenue_projection":      optionalValue(funding.RevenueProjection),
+			"monthly_revenue":         optionalValue(funding.MonthlyRevenue),
+			"requested_tenure_months": funding.RequestedTenureMonths,
+			"collateral_description":  funding.CollateralDescription,
+		}
+	}
+	return nil
+}
+
+// formChanges lists the revisable fields whose value differs between the two
+// versions of an application, in form order.
+func formChanges(before, after model.Application) []dto.FieldChange {
+	previous := formFieldValues(before)
+	current := formFieldValues(after)
+
+	var changes []dto.FieldChange
+	for _, field := range revisableFields[after.Type] {
+		if previous[field] != current[field] {
+			changes = append(changes, dto.FieldChange{Field: field, Previous: previous[field], Current: current[field]})
+		}
+	}
+	return changes
+}
+
+// historyChanges decodes the form changes recorded on a history entry.
+func historyChanges(history model.ApplicationHistory) []dto.FieldChange {
+	if history.Changes == nil {
+		return nil
+	}
+	var changes []dto.FieldChange
+	if err := json.Unmarshal([]byte(*history.Changes), &changes); err != nil {
+		return nil
+	}
+	return changes
+}
+
+func optionalValue[T any](value *T) any {
+	if value == nil {
+		return nil
+	}
+	return *value
+}
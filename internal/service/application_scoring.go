@@ -0,0 +1,412 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"UMKMGo-backend/internal/repository"
+	"UMKMGo-backend/internal/types/dto"
+	"UMKMGo-backend/internal/types/model"
+	"UMKMGo-backend/internal/utils/constant"
+)
+
+// rankedStatuses are the statuses an application keeps its place in the
+// program ranking in; rejected and withdrawn applications drop out.
+var rankedStatuses = []string{
+	constant.ApplicationStatusScreening,
+	constant.ApplicationStatusFinal,
+	constant.ApplicationStatusApproved,
+	constant.ApplicationStatusWaitlisted,
+}
+
+func (s *applicationsService) GetProgramRubric(ctx context.Context, programID int) (dto.ProgramRubric, error) {
+	if _, err := s.applicationRepository.GetProgramByID(ctx, programID); err != nil {
+		return dto.ProgramRubric{}, err
+	}
+
+	criteria, err := s.applicationRepository.GetRubricCriteria(ctx, programID)
+	if err != nil {
+		return dto.ProgramRubric{}, err
+	}
+	scored, err := s.applicationRepository.CountProgramScores(ctx, programID)
+	if err != nil {
+		return dto.ProgramRubric{}, err
+	}
+
+	return mapProgramRubric(programID, criteria, scored > 0), nil
+}
+
+// SetProgramRubric replaces the program's rubric. Once an application is scored
+// against it the rubric is locked, so every total in the ranking stays
+// comparable.
+func (s *applicationsService) SetProgramRubric(ctx context.Context, programID int, rubric dto.ProgramRubric) (dto.ProgramRubric, error) {
+	if _, err := s.applicationRepository.GetProgramByID(ctx, programID); err != nil {
+		return dto.ProgramRubric{}, err
+	}
+	if err := validateRubric(rubric.Criteria); err != nil {
+		return dto.ProgramRubric{}, err
+	}
+
+	scored, err := s.applicationRepository.CountProgramScores(ctx, programID)
+	if err != nil {
+		return dto.ProgramRubric{}, err
+	}
+	if scored > 0 {
+		return dto.ProgramRubric{}, errors.New("rubric cannot be changed after applications of the program have been scored")
+	}
+
+	criteria := make([]model.ProgramRubricCriterion, 0, len(rubric.Criteria))
+	for i, c := range rubric.Criteria {
+		criteria = append(criteria, model.ProgramRubricCriterion{
+			ProgramID:   programID,
+			Name:        strings.TrimSpace(c.Name),
+			Description: strings.TrimSpace(c.Description),
+			Weight:      c.Weight,
+			MinScore:    c.MinScore,
+			MaxScore:    c.MaxScore,
+			SortOrder:   i,
+		})
+	}
+
+	err = s.uow.Do(ctx, func(repos repository.TxRepositories) error {
+		return repos.Applications.ReplaceRubricCriteria(ctx, programID, criteria)
+	})
+	if err != nil {
+		return dto.ProgramRubric{}, err
+	}
+
+	saved, err := s.applicationRepository.GetRubricCriteria(ctx, programID)
+	if err != nil {
+		return dto.ProgramRubric{}, err
+	}
+	return mapProgramRubric(programID, saved, false), nil
+}
+
+// ScoreApplication records the reviewer's scores on some or all criteria of the
+// program's rubric. A criterion scored again keeps only the latest score and
+// reviewer. The total is recomputed and set once every criterion is scored.
+func (s *applicationsService) ScoreApplication(ctx context.Context, userID, applicationID int, request dto.ApplicationScoresRequest) (dto.ApplicationScorecard, error) {
+	if len(request.Scores) == 0 {
+		return dto.ApplicationScorecard{}, errors.New("scores are required")
+	}
+
+	application, err := s.applicationRepository.GetApplicationByID(ctx, applicationID)
+	if err != nil {
+		return dto.ApplicationScorecard{}, err
+	}
+	if application.Status != constant.ApplicationStatusScreening && application.Status != constant.ApplicationStatusFinal {
+		return dto.ApplicationScorecard{}, errors.New("applications can only be scored while in screening or final review")
+	}
+
+	// Scores belong to the reviewer holding the claim for the current stage
+	if err := s.claims.Require(ctx, application.Status, application.ID, userID); err != nil {
+		return dto.ApplicationScorecard{}, err
+	}
+
+	criteria, err := s.applicationRepository.GetRubricCriteria(ctx, application.ProgramID)
+	if err != nil {
+		return dto.ApplicationScorecard{}, err
+	}
+	if len(criteria) == 0 {
+		return dto.ApplicationScorecard{}, errors.New("program has no scoring rubric")
+	}
+
+	now := time.Now()
+	scores := make([]model.ApplicationScore, 0, len(request.Scores))
+	seen := make(map[int]bool, len(request.Scores))
+	for _, input := range request.Scores {
+		if seen[input.CriterionID] {
+			return dto.ApplicationScorecard{}, fmt.Errorf("criterion ID %d is scored more than once", input.CriterionID)
+		}
+		seen[input.CriterionID] = true
+
+		index := slices.IndexFunc(criteria, func(c model.ProgramRubricCriterion) bool {
+			return c.ID == input.CriterionID
+		})
+		if index < 0 {
+			return dto.ApplicationScorecard{}, fmt.Errorf("criterion ID %d is not part of the program rubric", input.CriterionID)
+		}
+		criterion := criteria[index]
+		if input.Score < criterion.MinScore || input.Score > criterion.MaxScore {
+			return dto.ApplicationScorecard{}, fmt.Errorf("score for %s must be between %g and %g", criterion.Name, criterion.MinScore, criterion.MaxScore)
+		}
+
+		scores = append(scores, model.ApplicationScore{
+			ApplicationID: application.ID,
+			CriterionID:   criterion.ID,
+			Score:         input.Score,
+			Notes:         strings.TrimSpace(input.Notes),
+			ScoredBy:      userID,
+			ScoredAt:      now,
+		})
+	}
+
+	// The new scores replace the recorded ones of the same criteria
+	merged := slices.DeleteFunc(slices.Clone(application.Scores), func(score model.ApplicationScore) bool {
+		return seen[score.CriterionID]
+	})
+	merged = append(merged, scores...)
+	total := scoreTotal(criteria, merged)
+
+	err = s.uow.Do(ctx, func(repos repository.TxRepositories) error {
+		if err := repos.Applications.SaveApplicationScores(ctx, scores); err != nil {
+			return err
+		}
+		return repos.Applications.UpdateApplicationScoreTotal(ctx, application.ID, total)
+	})
+	if err != nil {
+		return dto.ApplicationScorecard{}, err
+	}
+
+	// Reload for the reviewer names and the new place in the ranking
+	application, err = s.applicationRepository.GetApplicationByID(ctx, applicationID)
+	if err != nil {
+		return dto.ApplicationScorecard{}, err
+	}
+	scorecard, err := s.scorecard(ctx, application, criteria)
+	if err != nil {
+		return dto.ApplicationScorecard{}, err
+	}
+	return *scorecard, nil
+}
+
+// GetProgramRanking lists the program's fully scored applications that are
+// still in the running, best first.
+func (s *applicationsService) GetProgramRanking(ctx context.Context, programID int) ([]dto.ApplicationRanking, error) {
+	if _, err := s.applicationRepository.GetProgramByID(ctx, programID); err != nil {
+		return nil, err
+	}
+
+	applications, err := s.applicationRepository.GetProgramRanking(ctx, programID, rankedStatuses)
+	if err != nil {
+		return nil, err
+	}
+
+	ranked, ranks := rankApplications(applications)
+	ranking := make([]dto.ApplicationRanking, 0, len(ranked))
+	for _, app := range ranked {
+		ranking = append(ranking, dto.ApplicationRanking{
+			Rank:          ranks[app.ID],
+			ApplicationID: app.ID,
+			BusinessName:  app.UMKM.BusinessName,
+			Status:        app.Status,
+			ScoreTotal:    *app.ScoreTotal,
+			SubmittedAt:   app.SubmittedAt.Format("2006-01-02 15:04:05"),
+		})
+	}
+	return ranking, nil
+}
+
+// BulkApproveTop approves the best ranked applications waiting at a stage, with
+// the same rules as a bulk decision over their IDs. Between equal totals the
+// earlier submission goes first; unscored applications are never picked.
+func (s *applicationsService) BulkApproveTop(ctx context.Context, userID int, request dto.BulkTopDecision) (dto.BulkDecisionReport, error) {
+	report := dto.BulkDecisionReport{
+		Stage:        request.Stage,
+		Action:       "approve",
+		AllOrNothing: request.AllOrNothing,
+		Results:      []dto.BulkDecisionResult{},
+	}
+
+	if _, ok := bulkDecisionEvents[request.Stage]; !ok {
+		return report, errors.New("stage must be screening or final")
+	}
+	if request.Count < 1 {
+		return report, errors.New("count must be at least 1")
+	}
+	if request.Count > maxBulkDecisionSize {
+		return report, fmt.Errorf("at most %d applications can be decided at once", maxBulkDecisionSize)
+	}
+	if _, err := s.applicationRepository.GetProgramByID(ctx, request.ProgramID); err != nil {
+		return report, err
+	}
+
+	applications, err := s.applicationRepository.GetProgramRanking(ctx, request.ProgramID, []string{request.Stage})
+	if err != nil {
+		return report, err
+	}
+	ranked, _ := rankApplications(applications)
+	if len(ranked) == 0 {
+		return report, fmt.Errorf("no scored applications are waiting at the %s stage", request.Stage)
+	}
+
+	ids := make([]int, 0, request.Count)
+	for _, app := range ranked[:min(request.Count, len(ranked))] {
+		ids = append(ids, app.ID)
+	}
+
+	return s.decideBulk(ctx, request.Stage, userID, dto.BulkApplicationDecision{
+		ApplicationIDs: ids,
+		Action:         "approve",
+		Notes:          request.Notes,
+		AllOrNothing:   request.AllOrNothing,
+	})
+}
+
+// scorecard maps the application's scores against the rubric, or returns nil
+// when the program has no rubric.
+func (s *applicationsService) scorecard(ctx context.Context, application model.Application, criteria []model.ProgramRubricCriterion) (*dto.ApplicationScorecard, error) {
+	if len(criteria) == 0 {
+		return nil, nil
+	}
+
+	recorded := make(map[int]model.ApplicationScore, len(application.Scores))
+	for _, score := range application.Scores {
+		recorded[score.CriterionID] = score
+	}
+
+	scorecard := &dto.ApplicationScorecard{
+		ApplicationID: application.ID,
+		ProgramID:     application.ProgramID,
+		Scores:        make([]dto.ApplicationScore, 0, len(criteria)),
+		ScoreTotal:    application.ScoreTotal,
+	}
+	for _, criterion := range criteria {
+		item := dto.ApplicationScore{
+			CriterionID: criterion.ID,
+			Criterion:   criterion.Name,
+			Weight:      criterion.Weight,
+			MinScore:    criterion.MinScore,
+			MaxScore:    criterion.MaxScore,
+		}
+		if score, ok := recorded[criterion.ID]; ok {
+			value, scoredBy := score.Score, score.ScoredBy
+			item.Score = &value
+			item.Notes = score.Notes
+			item.ScoredBy = &scoredBy
+			item.ScoredByName = score.User.Name
+			item.ScoredAt = score.ScoredAt.Format("2006-01-02 15:04:05")
+		}
+		scorecard.Scores = append(scorecard.Scores, item)
+	}
+	scorecard.Complete = scoreTotal(criteria, application.Scores) != nil
+
+	applications, err := s.applicationRepository.GetProgramRanking(ctx, application.ProgramID, rankedStatuses)
+	if err != nil {
+		return nil, err
+	}
+	ranked, ranks := rankApplications(applications)
+	scorecard.RankedCount = len(ranked)
+	if rank, ok := ranks[application.ID]; ok {
+		scorecard.Rank = &rank
+	}
+	return scorecard, nil
+}
+
+// scoreTotal is the weighted mean of the scores, each normalized over its
+// criterion's range, on a 0-100 scale. It is nil until every criterion is scored.
+func scoreTotal(criteria []model.ProgramRubricCriterion, scores []model.ApplicationScore) *float64 {
+	if len(criteria) == 0 {
+		return nil
+	}
+
+	byCriterion := make(map[int]float64, len(scores))
+	for _, score := range scores {
+		byCriterion[score.CriterionID] = score.Score
+	}
+
+	var weighted, weights float64
+	for _, criterion := range criteria {
+		score, ok := byCriterion[criterion.ID]
+		if !ok {
+			return nil
+		}
+		weighted += criterion.Weight * (score - criterion.MinScore) / (criterion.MaxScore - criterion.MinScore)
+		weights += criterion.Weight
+	}
+
+	total := math.Round(weighted/weights*100*100) / 100
+	return &total
+}
+
+// rankApplications orders the fully scored applications in a ranked status per
+// program, best total first, and ranks them. Equal totals share a rank and the
+// next rank is skipped, so a tie for first is followed by third.
+func rankApplications(applications []model.Application) ([]model.Application, map[int]int) {
+	var ranked []model.Application
+	for _, app := range applications {
+		if app.ScoreTotal != nil && slices.Contains(rankedStatuses, app.Status) {
+			ranked = append(ranked, app)
+		}
+	}
+	slices.SortStableFunc(ranked, func(a, b model.Application) int {
+		switch {
+		case a.ProgramID != b.ProgramID:
+			return a.ProgramID - b.ProgramID
+		case *a.ScoreTotal != *b.ScoreTotal:
+			if *a.ScoreTotal > *b.ScoreTotal {
+				return -1
+			}
+			return 1
+		case !a.SubmittedAt.Equal(b.SubmittedAt):
+			return a.SubmittedAt.Compare(b.SubmittedAt)
+		}
+		return a.ID - b.ID
+	})
+
+	ranks := make(map[int]int, len(ranked))
+	position := 0
+	for i, app := range ranked {
+		if i == 0 || app.ProgramID != ranked[i-1].ProgramID {
+			position = 0
+		}
+		position++
+		if i > 0 && app.ProgramID == ranked[i-1].ProgramID && *app.ScoreTotal == *ranked[i-1].ScoreTotal {
+			ranks[app.ID] = ranks[ranked[i-1].ID]
+			continue
+		}
+		ranks[app.ID] = position
+	}
+	return ranked, ranks
+}
+
+func validateRubric(criteria []dto.RubricCriterion) error {
+	if len(criteria) == 0 {
+		return errors.New("rubric needs at least one criterion")
+	}
+
+	names := make(map[string]bool, len(criteria))
+	for _, c := range criteria {
+		name := strings.TrimSpace(c.Name)
+		if name == "" {
+			return errors.New("criterion name is required")
+		}
+		if names[strings.ToLower(name)] {
+			return fmt.Errorf("criterion %s is listed more than once", name)
+		}
+		names[strings.ToLower(name)] = true
+
+		if c.Weight <= 0 {
+			return fmt.Errorf("weight of %s must be greater than 0", name)
+		}
+		if c.MaxScore <= c.MinScore {
+			return fmt.Errorf("max_score of %s must be greater than min_score", name)
+		}
+	}
+	return nil
+}
+
+func mapProgramRubric(programID int, criteria []model.ProgramRubricCriterion, locked bool) dto.ProgramRubric {
+	rubric := dto.ProgramRubric{
+		ProgramID: programID,
+		Criteria:  make([]dto.RubricCriterion, 0, len(criteria)),
+		Locked:    locked,
+	}
+	for _, c := range criteria {
+		rubric.Criteria = append(rubric.Criteria, dto.RubricCriterion{
+			ID:          c.ID,
+			Name:        c.Name,
+			Description: c.Description,
+			Weight:      c.Weight,
+			MinScore:    c.MinScore,
+			MaxScore:    c.MaxScore,
+		})
+		rubric.TotalWeight += c.Weight
+	}
+	return rubric
+}
@@ -0,0 +1,587 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"UMKMGo-backend/internal/repository"
+	"UMKMGo-backend/internal/types/dto"
+	"UMKMGo-backend/internal/types/model"
+	"UMKMGo-backend/internal/utils/constant"
+)
+
+// applicationEvent names an edge of the application workflow. Every admin decision
+// and UMKM resubmission is fired as an event against the transition table below.
+type applicationEvent string
+
+const (
+	eventScreeningApprove applicationEvent = "screening_approve"
+	eventScreeningReject  applicationEvent = "screening_reject"
+	eventScreeningRevise  applicationEvent = "screening_revise"
+	eventFinalApprove     applicationEvent = "final_approve"
+	eventFinalReject      applicationEvent = "final_reject"
+	eventResubmit         applicationEvent = "resubmit"
+	eventSLAAutoReject    applicationEvent = "sla_auto_reject"
+	eventWithdraw         applicationEvent = "withdraw"
+	eventSubmitDraft      applicationEvent = "submit_draft"
+	eventFinalWaitlist    applicationEvent = "final_waitlist"
+	eventWaitlistPromote  applicationEvent = "waitlist_promote"
+)
+
+type transitionNotification struct {
+	Type    string
+	Title   string
+	Message string
+	// WithNotes formats the decision notes into Message (single %s verb)
+	WithNotes bool
+}
+
+type applicationTransition struct {
+	From         []string
+	To           string
+	Action       string // application_history_action recorded for the move
+	RequireNotes bool
+	NotesError   string
+	DefaultNotes string
+	SourceError  string
+	Notification transitionNotification
+	// ReviewerNotification, when set, alerts the reviewers of the stage the
+	// application leaves. Message verbs: ID, program title, stage, notes.
+	ReviewerNotification *transitionNotification
+	// Apply runs after the status is set and before the application is saved
+	Apply func(ctx context.Context, w *applicationWorkflow, application *model.Application) error
+}
+
+// applicationTransitions is the single source of truth for which status an
+// application may move to, what gets written to its history and who is notified.
+var applicationTransitions = map[applicationEvent]applicationTransition{
+	eventScreeningApprove: {
+		From:         []string{constant.ApplicationStatusScreening},
+		To:           constant.ApplicationStatusFinal,
+		Action:       constant.ApplicationActionApproveByAdminScreening,
+		DefaultNotes: "Approved by admin screening",
+		SourceError:  "application must be in screening status",
+		Notification: transitionNotification{
+			Type:    constant.NotificationApproved,
+			Title:   constant.NotificationTitleApproved,
+			Message: constant.NotificationMessageApproved,
+		},
+		Apply: stampFinalExpiry,
+	},
+	eventScreeningReject: {
+		From:         []string{constant.ApplicationStatusScreening},
+		To:           constant.ApplicationStatusRejected,
+		Action:       constant.ApplicationActionRejectByAdminScreening,
+		RequireNotes: true,
+		NotesError:   "notes are required for rejection",
+		SourceError:  "application must be in screening status",
+		Notification: transitionNotification{
+			Type:      constant.NotificationRejected,
+			Title:     constant.NotificationTitleRejected,
+			Message:   constant.NotificationMessageRejected,
+			WithNotes: true,
+		},
+	},
+	eventScreeningRevise: {
+		From:         []string{constant.ApplicationStatusScreening},
+		To:           constant.ApplicationStatusRevised,
+		Action:       constant.ApplicationActionRevise,
+		RequireNotes: true,
+		NotesError:   "notes are required for revision",
+		SourceError:  "application must be in screening status",
+		Notification: transitionNotification{
+			Type:      constant.NotificationRevised,
+			Title:     constant.NotificationTitleRevised,
+			Message:   constant.NotificationMessageRevised,
+			WithNotes: true,
+		},
+	},
+	eventFinalApprove: {
+		From:         []string{constant.ApplicationStatusFinal},
+		To:           constant.ApplicationStatusApproved,
+		Action:       constant.ApplicationActionApproveByAdminVendor,
+		DefaultNotes: "Approved by admin vendor",
+		SourceError:  "application must be in final status",
+		Notification: transitionNotification{
+			Type:    constant.NotificationFinalApproved,
+			Title:   constant.NotificationTitleFinalApproved,
+			Message: constant.NotificationMessageFinalApproved,
+		},
+	},
+	eventFinalWaitlist: {
+		From:         []string{constant.ApplicationStatusFinal},
+		To:           constant.ApplicationStatusWaitlisted,
+		Action:       constant.ApplicationActionWaitlist,
+		DefaultNotes: "Approved by admin vendor, program is full",
+		SourceError:  "application must be in final status",
+		Notification: transitionNotification{
+			Type:    constant.NotificationWaitlisted,
+			Title:   constant.NotificationTitleWaitlisted,
+			Message: constant.NotificationMessageWaitlisted,
+		},
+		Apply: stampWaitlisted,
+	},
+	eventWaitlistPromote: {
+		From:         []string{constant.ApplicationStatusWaitlisted},
+		To:           constant.ApplicationStatusApproved,
+		Action:       constant.ApplicationActionPromoteFromWaitlist,
+		DefaultNotes: "Promoted from the waitlist",
+		SourceError:  "application is not on the waitlist",
+		Notification: transitionNotification{
+			Type:    constant.NotificationFinalApproved,
+			Title:   constant.NotificationTitlePromoted,
+			Message: constant.NotificationMessagePromoted,
+		},
+	},
+	eventFinalReject: {
+		From:         []string{constant.ApplicationStatusFinal},
+		To:           constant.ApplicationStatusRejected,
+		Action:       constant.ApplicationActionRejectByAdminVendor,
+		RequireNotes: true,
+		NotesError:   "notes are required for rejection",
+		SourceError:  "application must be in final status",
+		Notification: transitionNotification{
+			Type:      constant.NotificationFinalRejected,
+			Title:     constant.NotificationTitleFinalRejected,
+			Message:   constant.NotificationMessageFinalRejected,
+			WithNotes: true,
+		},
+	},
+	eventResubmit: {
+		From:         []string{constant.ApplicationStatusRevised},
+		To:           constant.ApplicationStatusScreening,
+		Action:       constant.ApplicationActionSubmit,
+		DefaultNotes: "Application resubmitted after revision",
+		SourceError:  "application is not in a revisable state",
+		Notification: transitionNotification{
+			Type:    constant.NotificationSubmitted,
+			Title:   constant.NotificationTitleResubmitted,
+			Message: constant.NotificationMessageResubmitted,
+		},
+		Apply: resubmitRevision,
+	},
+	eventSLAAutoReject: {
+		From: []string{
+			constant.ApplicationStatusScreening,
+			constant.ApplicationStatusRevised,
+			constant.ApplicationStatusFinal,
+		},
+		To:           constant.ApplicationStatusRejected,
+		Action:       constant.ApplicationActionAutoReject,
+		DefaultNotes: "Automatically rejected after SLA breach",
+		SourceError:  "application is no longer awaiting review",
+		Notification: transitionNotification{
+			Type:    constant.NotificationAutoRejected,
+			Title:   constant.NotificationTitleAutoRejected,
+			Message: constant.NotificationMessageAutoRejected,
+		},
+	},
+	eventWithdraw: {
+		From: []string{
+			constant.ApplicationStatusScreening,
+			constant.ApplicationStatusRevised,
+			constant.ApplicationStatusApproved,
+			constant.ApplicationStatusWaitlisted,
+		},
+		To:           constant.ApplicationStatusWithdrawn,
+		Action:       constant.ApplicationActionWithdraw,
+		DefaultNotes: "Withdrawn by applicant",
+		SourceError:  "application can only be withdrawn while in screening, revised, approved or waitlisted status",
+		Notification: transitionNotification{
+			Type:    constant.NotificationWithdrawn,
+			Title:   constant.NotificationTitleWithdrawn,
+			Message: constant.NotificationMessageWithdrawn,
+		},
+		ReviewerNotification: &transitionNotification{
+			Type:    constant.AdminNotificationWithdrawn,
+			Title:   constant.AdminNotificationTitleWithdrawn,
+			Message: constant.AdminNotificationMessageWithdrawn,
+		},
+	},
+	eventSubmitDraft: {
+		From:         []string{constant.ApplicationStatusDraft},
+		To:           constant.ApplicationStatusScreening,
+		Action:       constant.ApplicationActionSubmit,
+		DefaultNotes: "Application submitted from draft",
+		SourceError:  "only draft applications can be submitted",
+		Notification: transitionNotification{
+			Type:    constant.NotificationSubmitted,
+			Title:   constant.NotificationTitleSubmitted,
+			Message: constant.NotificationMessageSubmitted,
+		},
+		Apply: restampSubmission,
+	},
+}
+
+// InvalidTransitionError is returned when an event is fired against an application
+// whose current status is not one of the transition's source states.
+type InvalidTransitionError struct {
+	ApplicationID int
+	Status        string
+	Event         string
+	message       string
+}
+
+func (e *InvalidTransitionError) Error() string {
+	if e.message != "" {
+		return e.message
+	}
+	return fmt.Sprintf("application %d cannot %s from status %s", e.ApplicationID, e.Event, e.Status)
+}
+
+// ApplicationConflictError is returned when an application changed after the
+// caller read it, either against an If-Match version or during the save itself.
+type ApplicationConflictError struct {
+	ApplicationID  int
+	CurrentVersion int
+}
+
+func (e *ApplicationConflictError) Error() string {
+	return fmt.Sprintf("application %d was modified by another request, reload it and try again", e.ApplicationID)
+}
+
+type applicationWorkflow struct {
+	uow      repository.UnitOfWork
+	calendar *slaCalendar
+}
+
+func newApplicationWorkflow(uow repository.UnitOfWork, calendar *slaCalendar) *applicationWorkflow {
+	return &applicationWorkflow{
+		uow:      uow,
+		calendar: calendar,
+	}
+}
+
+// ValidateInput checks the request-level inputs of an event before anything is loaded.
+func (w *applicationWorkflow) ValidateInput(event applicationEvent, notes string) error {
+	transition, ok := applicationTransitions[event]
+	if !ok {
+		return errors.New("unknown application transition")
+	}
+	if transition.RequireNotes && notes == "" {
+		return errors.New(transition.NotesError)
+	}
+	return nil
+}
+
+// Check reports whether the event may be fired from the application's current status.
+func (w *applicationWorkflow) Check(event applicationEvent, application model.Application) error {
+	transition, ok := applicationTransitions[event]
+	if !ok {
+		return errors.New("unknown application transition")
+	}
+	if !slices.Contains(transition.From, application.Status) {
+		return &InvalidTransitionError{
+			ApplicationID: application.ID,
+			Status:        application.Status,
+			Event:         string(event),
+			message:       transition.SourceError,
+		}
+	}
+	return nil
+}
+
+// Fire moves the application along the event's edge, then records history and
+// notifies the UMKM as declared by the transition. An actorID of 0 records the
+// move as a system action. Status, history and notification are committed together.
+func (w *applicationWorkflow) Fire(ctx context.Context, event applicationEvent, application model.Application, actorID int, notes string) (model.Application, error) {
+	var updatedApplication model.Application
+	err := w.uow.Do(ctx, func(repos repository.TxRepositories) error {
+		updated, err := w.FireIn(ctx, repos, event, application, actorID, notes)
+		if err != nil {
+			return err
+		}
+		updatedApplication = updated
+		return nil
+	})
+	if err != nil {
+		return model.Application{}, err
+	}
+
+	return updatedApplication, nil
+}
+
+// FireIn is Fire against repositories of a transaction the caller already holds,
+// so several transitions can be committed or rolled back as one.
+func (w *applicationWorkflow) FireIn(ctx context.Context, repos repository.TxRepositories, event applicationEvent, application model.Application, actorID int, notes string) (model.Application, error) {
+	return w.FireInWithChanges(ctx, repos, event, application, actorID, notes, nil)
+}
+
+// FireInWithChanges is FireIn that also records on the history entry the form
+// fields changed together with the transition.
+func (w *applicationWorkflow) FireInWithChanges(ctx context.Context, repos repository.TxRepositories, event applicationEvent, application model.Application, actorID int, notes string, changes []dto.FieldChange) (model.Application, error) {
+	if err := w.ValidateInput(event, notes); err != nil {
+		return model.Application{}, err
+	}
+	if err := w.Check(event, application); err != nil {
+		return model.Application{}, err
+	}
+
+	// Approvals past the program's capacity or fund pool join its waitlist
+	if event == eventFinalApprove {
+		admitted, err := w.admit(ctx, repos, application)
+		if err != nil {
+			return model.Application{}, err
+		}
+		if !admitted {
+			event = eventFinalWaitlist
+		}
+	}
+
+	transition := applicationTransitions[event]
+	if notes == "" {
+		notes = transition.DefaultNotes
+	}
+
+	fromStatus := application.Status
+	fromStage := slaStage(application.Status)
+	application.Status = transition.To
+	if transition.Apply != nil {
+		if err := transition.Apply(ctx, w, &application); err != nil {
+			return model.Application{}, err
+		}
+	}
+
+	message := transition.Notification.Message
+	if transition.Notification.WithNotes {
+		message = fmt.Sprintf(message, notes)
+	}
+
+	metadata, err := json.Marshal(map[string]any{})
+	if err != nil {
+		return model.Application{}, err
+	}
+
+	var actionedBy *int
+	if actorID > 0 {
+		actionedBy = &actorID
+	}
+
+	updatedApplication, err := repos.Applications.UpdateApplication(ctx, application)
+	if errors.Is(err, repository.ErrApplicationVersionConflict) {
+		return model.Application{}, &ApplicationConflictError{ApplicationID: application.ID}
+	}
+	if err != nil {
+		return model.Application{}, err
+	}
+
+	// Create history
+	history := model.ApplicationHistory{
+		ApplicationID: updatedApplication.ID,
+		Status:        transition.Action,
+		Notes:         notes,
+		ActionedBy:    actionedBy,
+	}
+	if len(changes) > 0 {
+		encoded, err := json.Marshal(changes)
+		if err != nil {
+			return model.Application{}, err
+		}
+		historyChanges := string(encoded)
+		history.Changes = &historyChanges
+	}
+	if err := repos.Applications.CreateApplicationHistory(ctx, history); err != nil {
+		return model.Application{}, err
+	}
+
+	// Create notification
+	notification := model.Notification{
+		UMKMID:        updatedApplication.UMKMID,
+		Title:         transition.Notification.Title,
+		Message:       message,
+		IsRead:        false,
+		ApplicationID: &updatedApplication.ID,
+		Type:          transition.Notification.Type,
+		Metadata:      string(metadata),
+	}
+	if err := repos.Notifications.CreateNotification(ctx, notification); err != nil {
+		return model.Application{}, err
+	}
+
+	if reviewer := transition.ReviewerNotification; reviewer != nil {
+		reviewerIDs, err := repos.AdminNotifications.GetUserIDsByPermissions(ctx, []string{reviewPermissionCode(fromStage, application.Type)})
+		if err != nil {
+			return model.Application{}, err
+		}
+		notifications := buildAdminNotifications(reviewerIDs, updatedApplication, reviewer.Type, reviewer.Title,
+			fmt.Sprintf(reviewer.Message, updatedApplication.ID, updatedApplication.Program.Title, fromStage, notes),
+			string(metadata))
+		if err := repos.AdminNotifications.CreateAdminNotifications(ctx, notifications); err != nil {
+			return model.Application{}, err
+		}
+	}
+
+	// Approved funding opens its loan; leaving the program stops it
+	if transition.To == constant.ApplicationStatusApproved {
+		if err := w.openLoan(ctx, repos, updatedApplication); err != nil {
+			return model.Application{}, err
+		}
+	}
+	if fromStatus == constant.ApplicationStatusApproved && updatedApplication.Type == "funding" {
+		if err := repos.Loans.CancelLoan(ctx, updatedApplication.ID); err != nil {
+			return model.Application{}, err
+		}
+	}
+
+	// A seat or funds freed by leaving the program go to the waitlist
+	leftProgram := fromStatus == constant.ApplicationStatusApproved || fromStatus == constant.ApplicationStatusWaitlisted
+	if leftProgram && event != eventWaitlistPromote {
+		if err := w.promoteWaitlist(ctx, repos, updatedApplication.ProgramID); err != nil {
+			return model.Application{}, err
+		}
+	}
+
+	return updatedApplication, nil
+}
+
+// admit locks the application's program and reports whether approving it stays
+// within the program's capacity and fund pool. Applications queue behind an
+// existing waitlist so it stays first come, first served.
+func (w *applicationWorkflow) admit(ctx context.Context, repos repository.TxRepositories, application model.Application) (bool, error) {
+	program, err := repos.Applications.LockProgram(ctx, application.ProgramID)
+	if err != nil {
+		return false, err
+	}
+	if program.Capacity == nil && program.FundPool == nil {
+		return true, nil
+	}
+
+	waitlist, err := repos.Applications.GetWaitlist(ctx, program.ID)
+	if err != nil {
+		return false, err
+	}
+	if len(waitlist) > 0 {
+		return false, nil
+	}
+
+	usage, err := repos.Applications.GetProgramUsage(ctx, []int{program.ID})
+	if err != nil {
+		return false, err
+	}
+	return fitsCapacity(program, usage[program.ID], requestedAmount(application)), nil
+}
+
+// promoteWaitlist approves waitlisted applications in order for as long as the
+// head of the waitlist fits the program's remaining capacity and fund pool.
+func (w *applicationWorkflow) promoteWaitlist(ctx context.Context, repos repository.TxRepositories, programID int) error {
+	program, err := repos.Applications.LockProgram(ctx, programID)
+	if err != nil {
+		return err
+	}
+
+	waitlist, err := repos.Applications.GetWaitlist(ctx, programID)
+	if err != nil {
+		return err
+	}
+
+	for _, application := range waitlist {
+		usage, err := repos.Applications.GetProgramUsage(ctx, []int{programID})
+		if err != nil {
+			return err
+		}
+		if !fitsCapacity(program, usage[programID], requestedAmount(application)) {
+			return nil
+		}
+		if _, err := w.FireIn(ctx, repos, eventWaitlistPromote, application, 0, ""); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// openLoan schedules the repayments of an approved funding application from
+// the requested amount and tenure and the program's interest terms. The first
+// installment is due a month after approval.
+func (w *applicationWorkflow) openLoan(ctx context.Context, repos repository.TxRepositories, application model.Application) error {
+	funding := application.FundingApplication
+	if application.Type != "funding" || funding == nil {
+		return nil
+	}
+
+	program, err := repos.Applications.LockProgram(ctx, application.ProgramID)
+	if err != nil {
+		return err
+	}
+
+	terms := loanTermsFor(program, funding.RequestedAmount, funding.RequestedTenureMonths, startOfDay(time.Now()))
+	loan := newLoan(application.ID, terms)
+	if len(loan.Installments) == 0 {
+		return errors.New("funding application has no tenure to schedule repayments over")
+	}
+
+	_, err = repos.Loans.CreateLoan(ctx, loan)
+	return err
+}
+
+// fitsCapacity reports whether one more approval of the given amount stays within
+// the program's limits. A program without limits always fits.
+func fitsCapacity(program model.Program, usage dto.ProgramUsage, amount float64) bool {
+	if program.Capacity != nil && usage.ApprovedCount >= int64(*program.Capacity) {
+		return false
+	}
+	if program.FundPool != nil && usage.ApprovedAmount+amount > *program.FundPool {
+		return false
+	}
+	return true
+}
+
+// requestedAmount is the funding an application draws from its program's fund pool.
+func requestedAmount(application model.Application) float64 {
+	if application.FundingApplication == nil {
+		return 0
+	}
+	return application.FundingApplication.RequestedAmount
+}
+
+// stampFinalExpiry moves the deadline to the final-stage SLA once screening passes.
+func stampFinalExpiry(ctx context.Context, w *applicationWorkflow, application *model.Application) error {
+	expiredAt, err := w.calendar.Deadline(ctx, constant.ApplicationStatusFinal, application.Type, application.ProgramID, application.SubmittedAt)
+	if err != nil {
+		return err
+	}
+	application.ExpiredAt = expiredAt
+	clearOverdue(application)
+	return nil
+}
+
+// restampSubmission starts the screening clock when a draft is submitted and
+// restarts it when a revised application comes back.
+func restampSubmission(ctx context.Context, w *applicationWorkflow, application *model.Application) error {
+	submittedAt := time.Now()
+	expiredAt, err := w.calendar.Deadline(ctx, constant.ApplicationStatusScreening, application.Type, application.ProgramID, submittedAt)
+	if err != nil {
+		return err
+	}
+	application.SubmittedAt = submittedAt
+	application.ExpiredAt = expiredAt
+	clearOverdue(application)
+	return nil
+}
+
+// resubmitRevision clears the fields flagged by the revision request, opens the
+// next revision round and restarts the screening clock.
+func resubmitRevision(ctx context.Context, w *applicationWorkflow, application *model.Application) error {
+	application.RevisionFields = ""
+	application.RevisionRound++
+	return restampSubmission(ctx, w, application)
+}
+
+// stampWaitlisted records when the application joined the waitlist, which sets
+// its place in line.
+func stampWaitlisted(ctx context.Context, w *applicationWorkflow, application *model.Application) error {
+	now := time.Now()
+	application.WaitlistedAt = &now
+	return nil
+}
+
+// clearOverdue resets the breach flags once a new stage deadline is stamped.
+func clearOverdue(application *model.Application) {
+	application.IsOverdue = false
+	application.OverdueAt = nil
+	application.EscalatedAt = nil
+}
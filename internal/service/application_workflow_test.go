@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"UMKMGo-backend/internal/types/model"
+	"UMKMGo-backend/internal/utils/constant"
+)
+
+func TestApplicationTransitionsTable(t *testing.T) {
+	for event, transition := range applicationTransitions {
+		if len(transition.From) == 0 {
+			t.Errorf("Transition %s has no source states", event)
+		}
+		if transition.To == "" || transition.Action == "" {
+			t.Errorf("Transition %s must declare target status and history action", event)
+		}
+		if transition.RequireNotes && transition.NotesError == "" {
+			t.Errorf("Transition %s requires notes but has no notes error", event)
+		}
+		if transition.SourceError == "" {
+			t.Errorf("Transition %s has no source error message", event)
+		}
+	}
+}
+
+func TestApplicationWorkflowFire(t *testing.T) {
+	service, mockRepo, _ := setupApplicationsService()
+	workflow := newApplicationWorkflow(service.uow, newSLACalendar(service.slaRepo, service.holidayRepo))
+	ctx := context.Background()
+
+	t.Run("Illegal transition returns typed error", func(t *testing.T) {
+		application := model.Application{ID: 1, UMKMID: 1, Status: constant.ApplicationStatusApproved}
+		mockRepo.applications[1] = application
+
+		_, err := workflow.Fire(ctx, eventFinalReject, application, 1, "late")
+
+		var transitionErr *InvalidTransitionError
+		if !errors.As(err, &transitionErr) {
+			t.Fatalf("Expected InvalidTransitionError, got %v", err)
+		}
+		if transitionErr.Status != constant.ApplicationStatusApproved {
+			t.Errorf("Expected status 'approved' in error, got '%s'", transitionErr.Status)
+		}
+		if mockRepo.applications[1].Status != constant.ApplicationStatusApproved {
+			t.Error("Application must not change on illegal transition")
+		}
+	})
+
+	t.Run("Resubmit records history with default notes", func(t *testing.T) {
+		application := model.Application{ID: 2, UMKMID: 1, Status: constant.ApplicationStatusRevised}
+		mockRepo.applications[2] = application
+
+		updated, err := workflow.Fire(ctx, eventResubmit, application, 7, "")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if updated.Status != constant.ApplicationStatusScreening {
+			t.Errorf("Expected status 'screening', got '%s'", updated.Status)
+		}
+		if time.Since(updated.SubmittedAt) > time.Minute {
+			t.Error("Expected submitted_at to be restamped")
+		}
+		if updated.RevisionRound != 1 {
+			t.Errorf("Expected revision round 1, got %d", updated.RevisionRound)
+		}
+
+		histories := mockRepo.histories[2]
+		if len(histories) != 1 {
+			t.Fatalf("Expected 1 history, got %d", len(histories))
+		}
+		if histories[0].Status != constant.ApplicationActionSubmit {
+			t.Errorf("Expected history action 'submit', got '%s'", histories[0].Status)
+		}
+		if histories[0].Notes != "Application resubmitted after revision" {
+			t.Errorf("Expected default notes, got '%s'", histories[0].Notes)
+		}
+	})
+
+	t.Run("Final deadline uses the program type SLA", func(t *testing.T) {
+		service, mockRepo, mockSLARepo := setupApplicationsService()
+		mockSLARepo.slas["final:funding"] = model.SLA{ID: 3, Status: "final", MaxDays: 30}
+		workflow := newApplicationWorkflow(service.uow, newSLACalendar(service.slaRepo, service.holidayRepo))
+
+		submittedAt := time.Now().AddDate(0, 0, -2)
+		application := model.Application{ID: 3, UMKMID: 1, Type: "funding", Status: constant.ApplicationStatusScreening, SubmittedAt: submittedAt}
+		mockRepo.applications[3] = application
+
+		updated, err := workflow.Fire(ctx, eventScreeningApprove, application, 1, "")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !updated.ExpiredAt.Equal(submittedAt.AddDate(0, 0, 30)) {
+			t.Errorf("Expected funding final deadline of 30 days, got %v", updated.ExpiredAt)
+		}
+	})
+
+	t.Run("Missing notes fails before status check", func(t *testing.T) {
+		err := workflow.ValidateInput(eventScreeningRevise, "")
+		if err == nil || err.Error() != "notes are required for revision" {
+			t.Errorf("Expected notes error, got %v", err)
+		}
+	})
+}
@@ -2,10 +2,14 @@ package service
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
+	"time"
 
+	"UMKMGo-backend/config/log"
+	"UMKMGo-backend/config/redis"
 	"UMKMGo-backend/config/vault"
 	"UMKMGo-backend/internal/repository"
 	"UMKMGo-backend/internal/types/dto"
@@ -15,17 +19,30 @@ import (
 )
 
 type ApplicationsService interface {
-	GetAllApplications(ctx context.Context, userID int, filterType string) ([]dto.Applications, error)
+	GetAllApplications(ctx context.Context, userID int, params dto.ApplicationQueryParams) ([]dto.Applications, dto.PaginationMeta, error)
 	GetApplicationByID(ctx context.Context, userID, id int) (dto.Applications, error)
 
 	// Screening Decisions
-	ScreeningApprove(ctx context.Context, userID int, applicationID int) (dto.Applications, error)
+	ScreeningApprove(ctx context.Context, userID int, applicationID, expectedVersion int) (dto.Applications, error)
 	ScreeningReject(ctx context.Context, userID int, decision dto.ApplicationDecision) (dto.Applications, error)
 	ScreeningRevise(ctx context.Context, userID int, decision dto.ApplicationDecision) (dto.Applications, error)
+	ReviewDocument(ctx context.Context, userID, applicationID, documentID int, request dto.DocumentReviewRequest) (dto.ApplicationDocuments, error)
+	GetDocumentComparison(ctx context.Context, applicationID int) ([]dto.DocumentComparison, error)
 
 	// Final Decisions
-	FinalApprove(ctx context.Context, userID int, applicationID int) (dto.Applications, error)
+	FinalApprove(ctx context.Context, userID int, applicationID, expectedVersion int) (dto.Applications, error)
 	FinalReject(ctx context.Context, userID int, decision dto.ApplicationDecision) (dto.Applications, error)
+
+	// Bulk Decisions
+	BulkScreeningDecision(ctx context.Context, userID int, request dto.BulkApplicationDecision) (dto.BulkDecisionReport, error)
+	BulkFinalDecision(ctx context.Context, userID int, request dto.BulkApplicationDecision) (dto.BulkDecisionReport, error)
+	BulkApproveTop(ctx context.Context, userID int, request dto.BulkTopDecision) (dto.BulkDecisionReport, error)
+
+	// Scoring
+	GetProgramRubric(ctx context.Context, programID int) (dto.ProgramRubric, error)
+	SetProgramRubric(ctx context.Context, programID int, rubric dto.ProgramRubric) (dto.ProgramRubric, error)
+	ScoreApplication(ctx context.Context, userID, applicationID int, request dto.ApplicationScoresRequest) (dto.ApplicationScorecard, error)
+	GetProgramRanking(ctx context.Context, programID int) ([]dto.ApplicationRanking, error)
 }
 
 type applicationsService struct {
@@ -33,45 +50,66 @@ type applicationsService struct {
 	userRepository         repository.UsersRepository
 	notificationRepository repository.NotificationRepository
 	slaRepo                repository.SLARepository
+	holidayRepo            repository.HolidayRepository
 	vaultDecryptLogRepo    repository.VaultDecryptLogRepository
+	uow                    repository.UnitOfWork
+	claims                 *reviewClaims
 }
 
-func NewApplicationsService(applicationRepo repository.ApplicationsRepository, userRepo repository.UsersRepository, notificationRepo repository.NotificationRepository, slaRepo repository.SLARepository, vaultDecryptLogRepo repository.VaultDecryptLogRepository) ApplicationsService {
+func NewApplicationsService(applicationRepo repository.ApplicationsRepository, userRepo repository.UsersRepository, notificationRepo repository.NotificationRepository, slaRepo repository.SLARepository, holidayRepo repository.HolidayRepository, vaultDecryptLogRepo repository.VaultDecryptLogRepository, uow repository.UnitOfWork, redisRepo redis.RedisRepository) ApplicationsService {
 	return &applicationsService{
 		applicationRepository:  applicationRepo,
 		userRepository:         userRepo,
 		notificationRepository: notificationRepo,
 		slaRepo:                slaRepo,
+		holidayRepo:            holidayRepo,
 		vaultDecryptLogRepo:    vaultDecryptLogRepo,
+		uow:                    uow,
+		claims:                 newReviewClaims(redisRepo),
 	}
 }
 
-func (s *applicationsService) GetAllApplications(ctx context.Context, userID int, filterType string) ([]dto.Applications, error) {
-	applications, err := s.applicationRepository.GetAllApplications(ctx, filterType)
+func (s *applicationsService) GetAllApplications(ctx context.Context, userID int, params dto.ApplicationQueryParams) ([]dto.Applications, dto.PaginationMeta, error) {
+	if err := validateApplicationQuery(&params); err != nil {
+		return nil, dto.PaginationMeta{}, err
+	}
+
+	applications, total, err := s.applicationRepository.GetAllApplications(ctx, params)
 	if err != nil {
-		return nil, err
+		return nil, dto.PaginationMeta{}, err
+	}
+
+	meta := dto.PaginationMeta{
+		Page:       params.Page,
+		Limit:      params.Limit,
+		Total:      total,
+		TotalPages: int((total + int64(params.Limit) - 1) / int64(params.Limit)),
 	}
 
+	now := time.Now()
 	var applicationsDTO []dto.Applications
 	for _, app := range applications {
-		// Get documents
-		documents, _ := s.applicationRepository.GetApplicationDocuments(ctx, app.ID)
+		// Documents and histories come preloaded with the page
 		var documentsDTO []dto.ApplicationDocuments
-		for _, doc := range documents {
+		for _, doc := range app.Documents {
 			documentsDTO = append(documentsDTO, dto.ApplicationDocuments{
 				ID:            doc.ID,
 				ApplicationID: doc.ApplicationID,
 				Type:          doc.Type,
 				File:          doc.File,
+				ReviewStatus:  doc.ReviewStatus,
+				ReviewReason:  doc.ReviewReason,
+				ReviewedBy:    doc.ReviewedBy,
+				ReviewedAt:    formatOptionalTime(doc.ReviewedAt),
+				Version:       doc.Version,
+				RevisionRound: doc.RevisionRound,
 				CreatedAt:     doc.CreatedAt.Format("2006-01-02 15:04:05"),
 				UpdatedAt:     doc.UpdatedAt.Format("2006-01-02 15:04:05"),
 			})
 		}
 
-		// Get histories
-		histories, _ := s.applicationRepository.GetApplicationHistories(ctx, app.ID)
 		var historiesDTO []dto.ApplicationHistories
-		for _, hist := range histories {
+		for _, hist := range app.Histories {
 			historiesDTO = append(historiesDTO, dto.ApplicationHistories{
 				ID:             hist.ID,
 				ApplicationID:  hist.ApplicationID,
@@ -80,23 +118,30 @@ func (s *applicationsService) GetAllApplications(ctx context.Context, userID int
 				ActionedAt:     hist.ActionedAt.Format("2006-01-02 15:04:05"),
 				ActionedBy:     hist.ActionedBy,
 				ActionedByName: hist.User.Name,
+				Changes:        historyChanges(hist),
 				CreatedAt:      hist.CreatedAt.Format("2006-01-02 15:04:05"),
 				UpdatedAt:      hist.UpdatedAt.Format("2006-01-02 15:04:05"),
 			})
 		}
 
 		applicationDTO := dto.Applications{
-			ID:          app.ID,
-			UMKMID:      app.UMKMID,
-			ProgramID:   app.ProgramID,
-			Type:        app.Type,
-			Status:      app.Status,
-			SubmittedAt: app.SubmittedAt.Format("2006-01-02 15:04:05"),
-			ExpiredAt:   app.ExpiredAt.Format("2006-01-02 15:04:05"),
-			CreatedAt:   app.CreatedAt.Format("2006-01-02 15:04:05"),
-			UpdatedAt:   app.UpdatedAt.Format("2006-01-02 15:04:05"),
-			Documents:   documentsDTO,
-			Histories:   historiesDTO,
+			ID:             app.ID,
+			UMKMID:         app.UMKMID,
+			ProgramID:      app.ProgramID,
+			Type:           app.Type,
+			Status:         app.Status,
+			SubmittedAt:    app.SubmittedAt.Format("2006-01-02 15:04:05"),
+			ExpiredAt:      app.ExpiredAt.Format("2006-01-02 15:04:05"),
+			IsOverdue:      isApplicationOverdue(app, now),
+			OverdueAt:      formatOptionalTime(app.OverdueAt),
+			EscalatedAt:    formatOptionalTime(app.EscalatedAt),
+			Version:        app.Version,
+			RevisionFields: splitRevisionFields(app.RevisionFields),
+			ScoreTotal:     app.ScoreTotal,
+			CreatedAt:      app.CreatedAt.Format("2006-01-02 15:04:05"),
+			UpdatedAt:      app.UpdatedAt.Format("2006-01-02 15:04:05"),
+			Documents:      documentsDTO,
+			Histories:      historiesDTO,
 			Program: &dto.Programs{
 				ID:                  app.Program.ID,
 				Title:               app.Program.Title,
@@ -129,7 +174,70 @@ func (s *applicationsService) GetAllApplications(ctx context.Context, userID int
 		applicationsDTO = append(applicationsDTO, applicationDTO)
 	}
 
-	return applicationsDTO, nil
+	return applicationsDTO, meta, nil
+}
+
+// maxApplicationPageSize caps the page size of admin application lists.
+const maxApplicationPageSize = 100
+
+var applicationSortFields = []string{"submitted_at", "expired_at", "created_at", "status", "business_name"}
+
+// validateApplicationQuery rejects unknown filter values and normalises paging.
+func validateApplicationQuery(params *dto.ApplicationQueryParams) error {
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	if params.Limit < 1 {
+		params.Limit = 10
+	}
+	if params.Limit > maxApplicationPageSize {
+		params.Limit = maxApplicationPageSize
+	}
+
+	if params.Type != "" && !slices.Contains(applicationTypes, params.Type) {
+		return errors.New("type must be one of training, certification, funding")
+	}
+	if params.Status != "" && !slices.Contains([]string{
+		constant.ApplicationStatusScreening,
+		constant.ApplicationStatusRevised,
+		constant.ApplicationStatusFinal,
+		constant.ApplicationStatusApproved,
+		constant.ApplicationStatusRejected,
+		constant.ApplicationStatusWithdrawn,
+		constant.ApplicationStatusWaitlisted,
+	}, params.Status) {
+		return errors.New("invalid application status")
+	}
+	if params.KartuType != "" && params.KartuType != "produktif" && params.KartuType != "afirmatif" {
+		return errors.New("kartu_type must be produktif or afirmatif")
+	}
+
+	var from, to time.Time
+	var err error
+	if params.SubmittedFrom != "" {
+		if from, err = time.Parse("2006-01-02", params.SubmittedFrom); err != nil {
+			return errors.New("submitted_from must be in YYYY-MM-DD format")
+		}
+	}
+	if params.SubmittedTo != "" {
+		if to, err = time.Parse("2006-01-02", params.SubmittedTo); err != nil {
+			return errors.New("submitted_to must be in YYYY-MM-DD format")
+		}
+	}
+	if !from.IsZero() && !to.IsZero() && to.Before(from) {
+		return errors.New("submitted_to must not be before submitted_from")
+	}
+
+	if params.SortBy != "" && !slices.Contains(applicationSortFields, params.SortBy) {
+		return fmt.Errorf("sort_by must be one of %s", strings.Join(applicationSortFields, ", "))
+	}
+	params.SortOrder = strings.ToLower(params.SortOrder)
+	if params.SortOrder != "" && params.SortOrder != "asc" && params.SortOrder != "desc" {
+		return errors.New("sort_order must be asc or desc")
+	}
+	params.Search = strings.TrimSpace(params.Search)
+
+	return nil
 }
 
 func (s *applicationsService) GetApplicationByID(ctx context.Context, userID, id int) (dto.Applications, error) {
@@ -158,6 +266,12 @@ func (s *applicationsService) GetApplicationByID(ctx context.Context, userID, id
 			ApplicationID: doc.ApplicationID,
 			Type:          doc.Type,
 			File:          doc.File,
+			ReviewStatus:  doc.ReviewStatus,
+			ReviewReason:  doc.ReviewReason,
+			ReviewedBy:    doc.ReviewedBy,
+			ReviewedAt:    formatOptionalTime(doc.ReviewedAt),
+			Version:       doc.Version,
+			RevisionRound: doc.RevisionRound,
 			CreatedAt:     doc.CreatedAt.Format("2006-01-02 15:04:05"),
 			UpdatedAt:     doc.UpdatedAt.Format("2006-01-02 15:04:05"),
 		})
@@ -174,23 +288,31 @@ func (s *applicationsService) GetApplicationByID(ctx context.Context, userID, id
 			ActionedAt:     hist.ActionedAt.Format("2006-01-02 15:04:05"),
 			ActionedBy:     hist.ActionedBy,
 			ActionedByName: hist.User.Name,
+			Changes:        historyChanges(hist),
 			CreatedAt:      hist.CreatedAt.Format("2006-01-02 15:04:05"),
 			UpdatedAt:      hist.UpdatedAt.Format("2006-01-02 15:04:05"),
 		})
 	}
 
 	detail := dto.Applications{
-		ID:          application.ID,
-		UMKMID:      application.UMKMID,
-		ProgramID:   application.ProgramID,
-		Type:        application.Type,
-		Status:      application.Status,
-		SubmittedAt: application.SubmittedAt.Format("2006-01-02 15:04:05"),
-		ExpiredAt:   application.ExpiredAt.Format("2006-01-02 15:04:05"),
-		CreatedAt:   application.CreatedAt.Format("2006-01-02 15:04:05"),
-		UpdatedAt:   application.UpdatedAt.Format("2006-01-02 15:04:05"),
-		Documents:   documents,
-		Histories:   histories,
+		ID:             application.ID,
+		UMKMID:         application.UMKMID,
+		ProgramID:      application.ProgramID,
+		Type:           application.Type,
+		Status:         application.Status,
+		SubmittedAt:    application.SubmittedAt.Format("2006-01-02 15:04:05"),
+		ExpiredAt:      application.ExpiredAt.Format("2006-01-02 15:04:05"),
+		IsOverdue:      isApplicationOverdue(application, time.Now()),
+		OverdueAt:      formatOptionalTime(application.OverdueAt),
+		EscalatedAt:    formatOptionalTime(application.EscalatedAt),
+		Version:        application.Version,
+		RevisionFields: splitRevisionFields(application.RevisionFields),
+		Eligibility:    applicationEligibility(application),
+		ScoreTotal:     application.ScoreTotal,
+		CreatedAt:      application.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt:      application.UpdatedAt.Format("2006-01-02 15:04:05"),
+		Documents:      documents,
+		Histories:      histories,
 		Program: &dto.Programs{
 			ID:                  application.Program.ID,
 			Title:               application.Program.Title,
@@ -225,6 +347,15 @@ func (s *applicationsService) GetApplicationByID(ctx context.Context, userID, id
 		},
 	}
 
+	// Rubric scores and the place in the program ranking
+	criteria, err := s.applicationRepository.GetRubricCriteria(ctx, application.ProgramID)
+	if err != nil {
+		return dto.Applications{}, err
+	}
+	if detail.Scorecard, err = s.scorecard(ctx, application, criteria); err != nil {
+		return dto.Applications{}, err
+	}
+
 	// Add specific application data based on type
 	switch application.Type {
 	case "training":
@@ -267,301 +398,262 @@ func (s *applicationsService) GetApplicationByID(ctx context.Context, userID, id
 	return detail, nil
 }
 
-func (s *applicationsService) ScreeningApprove(ctx context.Context, userID int, applicationID int) (dto.Applications, error) {
-	// Get application
-	application, err := s.applicationRepository.GetApplicationByID(ctx, applicationID)
-	if err != nil {
-		return dto.Applications{}, err
-	}
-
-	// Validate status
-	if application.Status != "screening" {
-		return dto.Applications{}, errors.New("application must be in screening status")
-	}
-
-	// Update expired date based on SLA
-	finalExpiredAt, err := s.slaRepo.GetSLAByStatus(ctx, "final")
-	if err != nil {
-		return dto.Applications{}, err
-	}
+func (s *applicationsService) ScreeningApprove(ctx context.Context, userID int, applicationID, expectedVersion int) (dto.Applications, error) {
+	return s.decide(ctx, eventScreeningApprove, userID, applicationID, expectedVersion, "")
+}
 
-	// Update status to final
-	application.Status = "final"
-	application.ExpiredAt = application.SubmittedAt.AddDate(0, 0, finalExpiredAt.MaxDays)
-	updatedApplication, err := s.applicationRepository.UpdateApplication(ctx, application)
-	if err != nil {
-		return dto.Applications{}, err
-	}
+func (s *applicationsService) ScreeningReject(ctx context.Context, userID int, decision dto.ApplicationDecision) (dto.Applications, error) {
+	return s.decide(ctx, eventScreeningReject, userID, decision.ApplicationID, decision.Version, decision.Notes)
+}
 
-	// Create history
-	history := model.ApplicationHistory{
-		ApplicationID: applicationID,
-		Status:        "approve_by_admin_screening",
-		Notes:         "Approved by admin screening",
-		ActionedBy:    &userID,
-	}
-	if err := s.applicationRepository.CreateApplicationHistory(ctx, history); err != nil {
-		return dto.Applications{}, err
-	}
+// ScreeningRevise sends the application back to the UMKM. Documents and form
+// fields named in the decision are flagged, and only those can be replaced.
+func (s *applicationsService) ScreeningRevise(ctx context.Context, userID int, decision dto.ApplicationDecision) (dto.Applications, error) {
+	return s.decideWith(ctx, eventScreeningRevise, userID, decision.ApplicationID, decision.Version, decision.Notes,
+		func(repos repository.TxRepositories, application *model.Application) error {
+			return flagRevisionItems(ctx, repos, application, userID, decision.DocumentIDs, decision.Fields, decision.Notes)
+		})
+}
 
-	// Create notification
-	metadata, err := json.Marshal(map[string]any{})
-	if err != nil {
-		return dto.Applications{}, err
-	}
+func (s *applicationsService) FinalApprove(ctx context.Context, userID int, applicationID, expectedVersion int) (dto.Applications, error) {
+	return s.decide(ctx, eventFinalApprove, userID, applicationID, expectedVersion, "")
+}
 
-	notification := model.Notification{
-		UMKMID:        application.UMKMID,
-		Title:         constant.NotificationTitleApproved,
-		Message:       constant.NotificationMessageApproved,
-		IsRead:        false,
-		ApplicationID: &updatedApplication.ID,
-		Type:          constant.NotificationApproved,
-		Metadata:      string(metadata),
-	}
-	if err := s.notificationRepository.CreateNotification(ctx, notification); err != nil {
-		return dto.Applications{}, err
-	}
+func (s *applicationsService) FinalReject(ctx context.Context, userID int, decision dto.ApplicationDecision) (dto.Applications, error) {
+	return s.decide(ctx, eventFinalReject, userID, decision.ApplicationID, decision.Version, decision.Notes)
+}
 
-	return dto.Applications{
-		ID:     updatedApplication.ID,
-		Status: updatedApplication.Status,
-	}, nil
+// decide fires a workflow event for an admin decision on a single application.
+// A non-zero expectedVersion must match the application's current version.
+func (s *applicationsService) decide(ctx context.Context, event applicationEvent, userID, applicationID, expectedVersion int, notes string) (dto.Applications, error) {
+	return s.decideWith(ctx, event, userID, applicationID, expectedVersion, notes, nil)
 }
 
-func (s *applicationsService) ScreeningReject(ctx context.Context, userID int, decision dto.ApplicationDecision) (dto.Applications, error) {
-	// Validate notes
-	if decision.Notes == "" {
-		return dto.Applications{}, errors.New("notes are required for rejection")
-	}
+// decideWith is decide with a hook that runs in the decision's transaction right
+// before the event is fired, for writes that must commit together with it.
+func (s *applicationsService) decideWith(ctx context.Context, event applicationEvent, userID, applicationID, expectedVersion int, notes string, prepare func(repos repository.TxRepositories, application *model.Application) error) (dto.Applications, error) {
+	workflow := newApplicationWorkflow(s.uow, newSLACalendar(s.slaRepo, s.holidayRepo))
 
-	// Get application
-	application, err := s.applicationRepository.GetApplicationByID(ctx, decision.ApplicationID)
-	if err != nil {
+	// Validate notes
+	if err := workflow.ValidateInput(event, notes); err != nil {
 		return dto.Applications{}, err
 	}
 
-	// Validate status
-	if application.Status != "screening" {
-		return dto.Applications{}, errors.New("application must be in screening status")
-	}
-
-	// Update status to rejected
-	application.Status = "rejected"
-	updatedApplication, err := s.applicationRepository.UpdateApplication(ctx, application)
+	application, stage, err := s.prepareDecision(ctx, workflow, event, userID, applicationID, expectedVersion)
 	if err != nil {
 		return dto.Applications{}, err
 	}
 
-	// Create history
-	history := model.ApplicationHistory{
-		ApplicationID: decision.ApplicationID,
-		Status:        "reject_by_admin_screening",
-		Notes:         decision.Notes,
-		ActionedBy:    &userID,
-	}
-	if err := s.applicationRepository.CreateApplicationHistory(ctx, history); err != nil {
-		return dto.Applications{}, err
-	}
-
-	// Create notification
-	metadata, err := json.Marshal(map[string]any{})
+	var updatedApplication model.Application
+	err = s.uow.Do(ctx, func(repos repository.TxRepositories) error {
+		if prepare != nil {
+			if err := prepare(repos, &application); err != nil {
+				return err
+			}
+		}
+		updated, err := workflow.FireIn(ctx, repos, event, application, userID, notes)
+		if err != nil {
+			return err
+		}
+		updatedApplication = updated
+		return nil
+	})
 	if err != nil {
 		return dto.Applications{}, err
 	}
 
-	notification := model.Notification{
-		UMKMID:        application.UMKMID,
-		Title:         constant.NotificationTitleRejected,
-		Message:       fmt.Sprintf(constant.NotificationMessageRejected, decision.Notes),
-		IsRead:        false,
-		ApplicationID: &updatedApplication.ID,
-		Type:          constant.NotificationRejected,
-		Metadata:      string(metadata),
-	}
-	if err := s.notificationRepository.CreateNotification(ctx, notification); err != nil {
-		return dto.Applications{}, err
-	}
+	s.releaseClaim(ctx, stage, application.ID, userID)
 
 	return dto.Applications{
-		ID:     updatedApplication.ID,
-		Status: updatedApplication.Status,
+		ID:      updatedApplication.ID,
+		Status:  updatedApplication.Status,
+		Version: updatedApplication.Version,
 	}, nil
 }
 
-func (s *applicationsService) ScreeningRevise(ctx context.Context, userID int, decision dto.ApplicationDecision) (dto.Applications, error) {
-	// Validate notes
-	if decision.Notes == "" {
-		return dto.Applications{}, errors.New("notes are required for revision")
-	}
-
+// prepareDecision loads the application and runs every check a decision needs
+// before anything is written, returning the review stage it is decided at.
+func (s *applicationsService) prepareDecision(ctx context.Context, workflow *applicationWorkflow, event applicationEvent, userID, applicationID, expectedVersion int) (model.Application, string, error) {
 	// Get application
-	application, err := s.applicationRepository.GetApplicationByID(ctx, decision.ApplicationID)
+	application, err := s.applicationRepository.GetApplicationByID(ctx, applicationID)
 	if err != nil {
-		return dto.Applications{}, err
+		return model.Application{}, "", err
 	}
 
-	// Validate status
-	if application.Status != "screening" {
-		return dto.Applications{}, errors.New("application must be in screening status")
+	if expectedVersion != 0 && expectedVersion != application.Version {
+		return model.Application{}, "", &ApplicationConflictError{
+			ApplicationID:  application.ID,
+			CurrentVersion: application.Version,
+		}
 	}
 
-	// Update status to revised
-	application.Status = "revised"
-	updatedApplication, err := s.applicationRepository.UpdateApplication(ctx, application)
-	if err != nil {
-		return dto.Applications{}, err
+	if err := workflow.Check(event, application); err != nil {
+		return model.Application{}, "", err
 	}
 
-	// Create history
-	history := model.ApplicationHistory{
-		ApplicationID: decision.ApplicationID,
-		Status:        "revise",
-		Notes:         decision.Notes,
-		ActionedBy:    &userID,
-	}
-	if err := s.applicationRepository.CreateApplicationHistory(ctx, history); err != nil {
-		return dto.Applications{}, err
+	// Only the reviewer holding the claim for this stage may decide
+	stage := slaStage(application.Status)
+	if err := s.claims.Require(ctx, stage, application.ID, userID); err != nil {
+		return model.Application{}, "", err
 	}
 
-	// Create notification
-	metadata, err := json.Marshal(map[string]any{})
-	if err != nil {
-		return dto.Applications{}, err
-	}
+	return application, stage, nil
+}
 
-	notification := model.Notification{
-		UMKMID:        application.UMKMID,
-		Title:         constant.NotificationTitleRevised,
-		Message:       fmt.Sprintf(constant.NotificationMessageRevised, decision.Notes),
-		IsRead:        false,
-		ApplicationID: &updatedApplication.ID,
-		Type:          constant.NotificationRevised,
-		Metadata:      string(metadata),
-	}
-	if err := s.notificationRepository.CreateNotification(ctx, notification); err != nil {
-		return dto.Applications{}, err
+func (s *applicationsService) releaseClaim(ctx context.Context, stage string, applicationID, userID int) {
+	if err := s.claims.Release(ctx, stage, applicationID, userID); err != nil {
+		log.Log.Warnf("failed to release review claim for application ID %d: %v", applicationID, err)
 	}
+}
 
-	return dto.Applications{
-		ID:     updatedApplication.ID,
-		Status: updatedApplication.Status,
-	}, nil
+// maxBulkDecisionSize caps how many applications one bulk request may decide.
+const maxBulkDecisionSize = 500
+
+// bulkDecisionEvents maps a bulk action to its workflow event per review stage.
+var bulkDecisionEvents = map[string]map[string]applicationEvent{
+	constant.ApplicationStatusScreening: {
+		"approve": eventScreeningApprove,
+		"reject":  eventScreeningReject,
+		"revise":  eventScreeningRevise,
+	},
+	constant.ApplicationStatusFinal: {
+		"approve": eventFinalApprove,
+		"reject":  eventFinalReject,
+	},
 }
 
-func (s *applicationsService) FinalApprove(ctx context.Context, userID int, applicationID int) (dto.Applications, error) {
-	// Get application
-	application, err := s.applicationRepository.GetApplicationByID(ctx, applicationID)
-	if err != nil {
-		return dto.Applications{}, err
-	}
+func (s *applicationsService) BulkScreeningDecision(ctx context.Context, userID int, request dto.BulkApplicationDecision) (dto.BulkDecisionReport, error) {
+	return s.decideBulk(ctx, constant.ApplicationStatusScreening, userID, request)
+}
 
-	// Validate status
-	if application.Status != "final" {
-		return dto.Applications{}, errors.New("application must be in final status")
-	}
+func (s *applicationsService) BulkFinalDecision(ctx context.Context, userID int, request dto.BulkApplicationDecision) (dto.BulkDecisionReport, error) {
+	return s.decideBulk(ctx, constant.ApplicationStatusFinal, userID, request)
+}
 
-	// Update status to approved
-	application.Status = "approved"
-	updatedApplication, err := s.applicationRepository.UpdateApplication(ctx, application)
-	if err != nil {
-		return dto.Applications{}, err
+// decideBulk applies one decision to many applications with the same rules as
+// the single-item endpoints. Each item commits on its own unless AllOrNothing is
+// set, in which case every item is checked first and all of them are committed
+// in one transaction, or none are.
+func (s *applicationsService) decideBulk(ctx context.Context, stage string, userID int, request dto.BulkApplicationDecision) (dto.BulkDecisionReport, error) {
+	report := dto.BulkDecisionReport{
+		Stage:        stage,
+		Action:       request.Action,
+		AllOrNothing: request.AllOrNothing,
+		Results:      []dto.BulkDecisionResult{},
 	}
 
-	// Create history
-	history := model.ApplicationHistory{
-		ApplicationID: applicationID,
-		Status:        "approve_by_admin_vendor",
-		Notes:         "Approved by admin vendor",
-		ActionedBy:    &userID,
-	}
-	if err := s.applicationRepository.CreateApplicationHistory(ctx, history); err != nil {
-		return dto.Applications{}, err
+	event, ok := bulkDecisionEvents[stage][request.Action]
+	if !ok {
+		return report, fmt.Errorf("action %q is not allowed at the %s stage", request.Action, stage)
 	}
-
-	// Create notification
-	metadata, err := json.Marshal(map[string]any{})
-	if err != nil {
-		return dto.Applications{}, err
+	if len(request.ApplicationIDs) == 0 {
+		return report, errors.New("application_ids are required")
 	}
-
-	notification := model.Notification{
-		UMKMID:        application.UMKMID,
-		Title:         constant.NotificationTitleApproved,
-		Message:       constant.NotificationMessageApproved,
-		IsRead:        false,
-		ApplicationID: &updatedApplication.ID,
-		Type:          constant.NotificationFinalApproved,
-		Metadata:      string(metadata),
+	if len(request.ApplicationIDs) > maxBulkDecisionSize {
+		return report, fmt.Errorf("at most %d applications can be decided at once", maxBulkDecisionSize)
 	}
-	if err := s.notificationRepository.CreateNotification(ctx, notification); err != nil {
-		return dto.Applications{}, err
+	seen := make(map[int]bool, len(request.ApplicationIDs))
+	for _, id := range request.ApplicationIDs {
+		if seen[id] {
+			return report, fmt.Errorf("application ID %d is listed more than once", id)
+		}
+		seen[id] = true
 	}
 
-	return dto.Applications{
-		ID:     updatedApplication.ID,
-		Status: updatedApplication.Status,
-	}, nil
-}
-
-func (s *applicationsService) FinalReject(ctx context.Context, userID int, decision dto.ApplicationDecision) (dto.Applications, error) {
-	// Validate notes
-	if decision.Notes == "" {
-		return dto.Applications{}, errors.New("notes are required for rejection")
+	workflow := newApplicationWorkflow(s.uow, newSLACalendar(s.slaRepo, s.holidayRepo))
+	if err := workflow.ValidateInput(event, request.Notes); err != nil {
+		return report, err
 	}
 
-	// Get application
-	application, err := s.applicationRepository.GetApplicationByID(ctx, decision.ApplicationID)
-	if err != nil {
-		return dto.Applications{}, err
+	if request.AllOrNothing {
+		s.decideAllOrNothing(ctx, workflow, event, stage, userID, request, &report)
+		return report, nil
 	}
 
-	// Validate status
-	if application.Status != "final" {
-		return dto.Applications{}, errors.New("application must be in final status")
+	for _, id := range request.ApplicationIDs {
+		result, err := s.decide(ctx, event, userID, id, 0, request.Notes)
+		if err != nil {
+			report.Results = append(report.Results, bulkFailure(id, err))
+			report.Failed++
+			continue
+		}
+		report.Results = append(report.Results, dto.BulkDecisionResult{
+			ApplicationID: id,
+			Success:       true,
+			Status:        result.Status,
+			Version:       result.Version,
+		})
+		report.Succeeded++
 	}
 
-	// Update status to rejected
-	application.Status = "rejected"
-	updatedApplication, err := s.applicationRepository.UpdateApplication(ctx, application)
-	if err != nil {
-		return dto.Applications{}, err
-	}
+	return report, nil
+}
 
-	// Create history
-	history := model.ApplicationHistory{
-		ApplicationID: decision.ApplicationID,
-		Status:        "reject_by_admin_vendor",
-		Notes:         decision.Notes,
-		ActionedBy:    &userID,
-	}
-	if err := s.applicationRepository.CreateApplicationHistory(ctx, history); err != nil {
-		return dto.Applications{}, err
+func (s *applicationsService) decideAllOrNothing(ctx context.Context, workflow *applicationWorkflow, event applicationEvent, stage string, userID int, request dto.BulkApplicationDecision, report *dto.BulkDecisionReport) {
+	applications := make([]model.Application, 0, len(request.ApplicationIDs))
+	failures := make(map[int]error)
+	for _, id := range request.ApplicationIDs {
+		application, _, err := s.prepareDecision(ctx, workflow, event, userID, id, 0)
+		if err != nil {
+			failures[id] = err
+			continue
+		}
+		applications = append(applications, application)
+	}
+
+	updated := make(map[int]model.Application, len(applications))
+	if len(failures) == 0 {
+		err := s.uow.Do(ctx, func(repos repository.TxRepositories) error {
+			for _, application := range applications {
+				updatedApplication, err := workflow.FireIn(ctx, repos, event, application, userID, request.Notes)
+				if err != nil {
+					failures[application.ID] = err
+					return err
+				}
+				updated[application.ID] = updatedApplication
+			}
+			return nil
+		})
+		if err != nil && len(failures) == 0 {
+			// The commit itself failed, so no item was applied
+			for _, application := range applications {
+				failures[application.ID] = err
+			}
+		}
 	}
 
-	// Create notification
-	metadata, err := json.Marshal(map[string]any{})
-	if err != nil {
-		return dto.Applications{}, err
+	if len(failures) > 0 {
+		for _, id := range request.ApplicationIDs {
+			if err, ok := failures[id]; ok {
+				report.Results = append(report.Results, bulkFailure(id, err))
+			} else {
+				report.Results = append(report.Results, dto.BulkDecisionResult{
+					ApplicationID: id,
+					Message:       "not applied, another application in the batch failed",
+				})
+			}
+		}
+		report.Failed = len(request.ApplicationIDs)
+		return
 	}
 
-	notification := model.Notification{
-		UMKMID:        application.UMKMID,
-		Title:         constant.NotificationTitleRejected,
-		Message:       fmt.Sprintf(constant.NotificationMessageRejected, decision.Notes),
-		IsRead:        false,
-		ApplicationID: &updatedApplication.ID,
-		Type:          constant.NotificationFinalRejected,
-		Metadata:      string(metadata),
-	}
-	if err := s.notificationRepository.CreateNotification(ctx, notification); err != nil {
-		return dto.Applications{}, err
+	for _, id := range request.ApplicationIDs {
+		s.releaseClaim(ctx, stage, id, userID)
+		report.Results = append(report.Results, dto.BulkDecisionResult{
+			ApplicationID: id,
+			Success:       true,
+			Status:        updated[id].Status,
+			Version:       updated[id].Version,
+		})
 	}
+	report.Succeeded = len(request.ApplicationIDs)
+}
 
-	return dto.Applications{
-		ID:     updatedApplication.ID,
-		Status: updatedApplication.Status,
-	}, nil
+func bulkFailure(applicationID int, err error) dto.BulkDecisionResult {
+	return dto.BulkDecisionResult{
+		ApplicationID: applicationID,
+		Message:       err.Error(),
+	}
 }
 
 // Helper function
@@ -603,3 +695,19 @@ func (s *applicationsService) decryptUMKMData(ctx context.Context, umkmID int, c
 
 	return plaintext, nil
 }
+
+// isApplicationOverdue reports whether an application still under review has passed
+// its SLA deadline, including breaches the monitor has not flagged yet.
+func isApplicationOverdue(application model.Application, now time.Time) bool {
+	if !slices.Contains(monitoredStatuses, application.Status) {
+		return false
+	}
+	return application.IsOverdue || now.After(application.ExpiredAt)
+}
+
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format("2006-01-02 15:04:05")
+}
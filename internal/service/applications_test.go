@@ -3,11 +3,17 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
 	"testing"
 	"time"
 
+	"UMKMGo-backend/internal/repository"
 	"UMKMGo-backend/internal/types/dto"
 	"UMKMGo-backend/internal/types/model"
+	"UMKMGo-backend/internal/utils/constant"
 )
 
 // ==================== MOCK REPOSITORIES ====================
@@ -19,6 +25,7 @@ type mockApplicationsRepo struct {
 	histories    map[int][]model.ApplicationHistory
 	programs     map[int]model.Program
 	umkms        map[int]model.UMKM
+	criteria     map[int][]model.ProgramRubricCriterion
 }
 
 func newMockApplicationsRepo() *mockApplicationsRepo {
@@ -26,6 +33,7 @@ func newMockApplicationsRepo() *mockApplicationsRepo {
 		applications: make(map[int]model.Application),
 		documents:    make(map[int][]model.ApplicationDocument),
 		histories:    make(map[int][]model.ApplicationHistory),
+		criteria:     make(map[int][]model.ProgramRubricCriterion),
 		programs: map[int]model.Program{
 			1: {ID: 1, Title: "Training Program", Type: "training", IsActive: true},
 			2: {ID: 2, Title: "Funding Program", Type: "funding", IsActive: true},
@@ -51,14 +59,36 @@ func newMockApplicationsRepo() *mockApplicationsRepo {
 	}
 }
 
-func (m *mockApplicationsRepo) GetAllApplications(ctx context.Context, filterType string) ([]model.Application, error) {
+func (m *mockApplicationsRepo) GetAllApplications(ctx context.Context, params dto.ApplicationQueryParams) ([]model.Application, int64, error) {
 	var apps []model.Application
 	for _, app := range m.applications {
-		if filterType == "" || app.Type == filterType {
-			apps = append(apps, app)
+		if params.Type != "" && app.Type != params.Type {
+			continue
+		}
+		if params.Status != "" && app.Status != params.Status {
+			continue
+		}
+		if params.ProgramID != 0 && app.ProgramID != params.ProgramID {
+			continue
 		}
+		if params.IsOverdue != nil && app.IsOverdue != *params.IsOverdue {
+			continue
+		}
+		if params.Search != "" && !strings.Contains(strings.ToLower(app.UMKM.BusinessName), strings.ToLower(params.Search)) {
+			continue
+		}
+		apps = append(apps, app)
 	}
-	return apps, nil
+	slices.SortFunc(apps, func(a, b model.Application) int {
+		return a.ID - b.ID
+	})
+
+	total := int64(len(apps))
+	if params.Limit > 0 {
+		start := min((params.Page-1)*params.Limit, len(apps))
+		apps = apps[start:min(start+params.Limit, len(apps))]
+	}
+	return apps, total, nil
 }
 
 func (m *mockApplicationsRepo) GetApplicationByID(ctx context.Context, id int) (model.Application, error) {
@@ -87,9 +117,14 @@ func (m *mockApplicationsRepo) CreateApplication(ctx context.Context, app model.
 }
 
 func (m *mockApplicationsRepo) UpdateApplication(ctx context.Context, app model.Application) (model.Application, error) {
-	if _, exists := m.applications[app.ID]; !exists {
+	current, exists := m.applications[app.ID]
+	if !exists {
 		return model.Application{}, errors.New("application not found")
 	}
+	if current.Version != app.Version {
+		return model.Application{}, repository.ErrApplicationVersionConflict
+	}
+	app.Version++
 	m.applications[app.ID] = app
 	return app, nil
 }
@@ -157,6 +192,214 @@ func (m *mockApplicationsRepo) IsApplicationExists(ctx context.Context, umkmID,
 	return false
 }
 
+func (m *mockApplicationsRepo) GetOverdueApplications(ctx context.Context, statuses []string, now time.Time) ([]model.Application, error) {
+	var apps []model.Application
+	for _, app := range m.applications {
+		if slices.Contains(statuses, app.Status) && app.ExpiredAt.Before(now) && !app.IsOverdue {
+			apps = append(apps, app)
+		}
+	}
+	return apps, nil
+}
+
+func (m *mockApplicationsRepo) MarkApplicationOverdue(ctx context.Context, id int, overdueAt time.Time, escalatedAt *time.Time) error {
+	app, exists := m.applications[id]
+	if !exists {
+		return errors.New("application not found")
+	}
+	app.IsOverdue = true
+	app.OverdueAt = &overdueAt
+	app.EscalatedAt = escalatedAt
+	app.Version++
+	m.applications[id] = app
+	return nil
+}
+
+func (m *mockApplicationsRepo) GetReviewQueue(ctx context.Context, status string, types []string) ([]model.Application, error) {
+	var result []model.Application
+	for _, app := range m.applications {
+		if app.Status == status && slices.Contains(types, app.Type) {
+			result = append(result, app)
+		}
+	}
+	slices.SortFunc(result, func(a, b model.Application) int {
+		if c := a.ExpiredAt.Compare(b.ExpiredAt); c != 0 {
+			return c
+		}
+		return a.ID - b.ID
+	})
+	return result, nil
+}
+
+func (m *mockApplicationsRepo) UpdateDocumentReview(ctx context.Context, document model.ApplicationDocument) error {
+	app, exists := m.applications[document.ApplicationID]
+	if !exists {
+		return errors.New("document not found")
+	}
+	documents := slices.Clone(app.Documents)
+	for i := range documents {
+		if documents[i].ID == document.ID {
+			documents[i] = document
+			app.Documents = documents
+			m.applications[app.ID] = app
+			return nil
+		}
+	}
+	return errors.New("document not found")
+}
+
+func (m *mockApplicationsRepo) UpdateApplicationForm(ctx context.Context, application model.Application) error {
+	app, exists := m.applications[application.ID]
+	if !exists {
+		return errors.New("failed to update application form")
+	}
+	app.TrainingApplication = application.TrainingApplication
+	app.CertificationApplication = application.CertificationApplication
+	app.FundingApplication = application.FundingApplication
+	m.applications[app.ID] = app
+	return nil
+}
+
+func (m *mockApplicationsRepo) GetApplicationDocumentVersions(ctx context.Context, appID int) ([]model.ApplicationDocument, error) {
+	return m.documents[appID], nil
+}
+
+func (m *mockApplicationsRepo) GetPurgeableDocuments(ctx context.Context, supersededBefore time.Time, limit int) ([]model.ApplicationDocument, error) {
+	var documents []model.ApplicationDocument
+	for _, docs := range m.documents {
+		for _, doc := range docs {
+			if doc.SupersededAt != nil && doc.SupersededAt.Before(supersededBefore) && doc.PurgedAt == nil && len(documents) < limit {
+				documents = append(documents, doc)
+			}
+		}
+	}
+	return documents, nil
+}
+
+func (m *mockApplicationsRepo) MarkDocumentPurged(ctx context.Context, id int, purgedAt time.Time) error {
+	for appID, docs := range m.documents {
+		for i := range docs {
+			if docs[i].ID == id {
+				docs[i].PurgedAt = &purgedAt
+				m.documents[appID] = docs
+				return nil
+			}
+		}
+	}
+	return errors.New("document not found")
+}
+
+func (m *mockApplicationsRepo) DeleteExpiredDrafts(ctx context.Context, now time.Time) (int64, error) {
+	var deleted int64
+	for id, app := range m.applications {
+		if app.Status == constant.ApplicationStatusDraft && app.ExpiredAt.Before(now) {
+			delete(m.applications, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func (m *mockApplicationsRepo) LockProgram(ctx context.Context, programID int) (model.Program, error) {
+	// Fixtures without a program behave like a program without limits
+	program, ok := m.programs[programID]
+	if !ok {
+		return model.Program{ID: programID}, nil
+	}
+	return program, nil
+}
+
+func (m *mockApplicationsRepo) GetProgramUsage(ctx context.Context, programIDs []int) (map[int]dto.ProgramUsage, error) {
+	usage := make(map[int]dto.ProgramUsage)
+	for _, app := range m.applications {
+		if app.Status != constant.ApplicationStatusApproved || !slices.Contains(programIDs, app.ProgramID) {
+			continue
+		}
+		programUsage := usage[app.ProgramID]
+		programUsage.ProgramID = app.ProgramID
+		programUsage.ApprovedCount++
+		if app.FundingApplication != nil {
+			programUsage.ApprovedAmount += app.FundingApplication.RequestedAmount
+		}
+		usage[app.ProgramID] = programUsage
+	}
+	return usage, nil
+}
+
+func (m *mockApplicationsRepo) GetWaitlist(ctx context.Context, programID int) ([]model.Application, error) {
+	var waitlist []model.Application
+	for _, app := range m.applications {
+		if app.ProgramID == programID && app.Status == constant.ApplicationStatusWaitlisted {
+			waitlist = append(waitlist, app)
+		}
+	}
+	sort.Slice(waitlist, func(i, j int) bool {
+		if !waitlist[i].WaitlistedAt.Equal(*waitlist[j].WaitlistedAt) {
+			return waitlist[i].WaitlistedAt.Before(*waitlist[j].WaitlistedAt)
+		}
+		return waitlist[i].ID < waitlist[j].ID
+	})
+	return waitlist, nil
+}
+
+func (m *mockApplicationsRepo) GetRubricCriteria(ctx context.Context, programID int) ([]model.ProgramRubricCriterion, error) {
+	return m.criteria[programID], nil
+}
+
+func (m *mockApplicationsRepo) ReplaceRubricCriteria(ctx context.Context, programID int, criteria []model.ProgramRubricCriterion) error {
+	nextID := 1
+	for _, existing := range m.criteria {
+		for _, c := range existing {
+			nextID = max(nextID, c.ID+1)
+		}
+	}
+	for i := range criteria {
+		criteria[i].ID = nextID + i
+	}
+	m.criteria[programID] = criteria
+	return nil
+}
+
+func (m *mockApplicationsRepo) CountProgramScores(ctx context.Context, programID int) (int64, error) {
+	var count int64
+	for _, app := range m.applications {
+		if app.ProgramID == programID {
+			count += int64(len(app.Scores))
+		}
+	}
+	return count, nil
+}
+
+func (m *mockApplicationsRepo) SaveApplicationScores(ctx context.Context, scores []model.ApplicationScore) error {
+	for _, score := range scores {
+		app := m.applications[score.ApplicationID]
+		app.Scores = slices.DeleteFunc(app.Scores, func(existing model.ApplicationScore) bool {
+			return existing.CriterionID == score.CriterionID
+		})
+		app.Scores = append(app.Scores, score)
+		m.applications[score.ApplicationID] = app
+	}
+	return nil
+}
+
+func (m *mockApplicationsRepo) UpdateApplicationScoreTotal(ctx context.Context, applicationID int, total *float64) error {
+	app := m.applications[applicationID]
+	app.ScoreTotal = total
+	app.Version++
+	m.applications[applicationID] = app
+	return nil
+}
+
+func (m *mockApplicationsRepo) GetProgramRanking(ctx context.Context, programID int, statuses []string) ([]model.Application, error) {
+	var ranking []model.Application
+	for _, app := range m.applications {
+		if app.ProgramID == programID && app.ScoreTotal != nil && slices.Contains(statuses, app.Status) {
+			ranking = append(ranking, app)
+		}
+	}
+	return ranking, nil
+}
+
 // Mock Users Repository
 type mockUsersRepo struct {
 	users map[int]model.User
@@ -311,10 +554,39 @@ func (m *mockSLARepo) GetSLAByStatus(ctx context.Context, status string) (model.
 	return model.SLA{}, errors.New("SLA not found")
 }
 
-func (m *mockSLARepo) UpdateSLA(ctx context.Context, sla model.SLA) (model.SLA, error) {
+// GetEffectiveSLA prefers a "<stage>:<type>" rule over the stage default
+func (m *mockSLARepo) GetEffectiveSLA(ctx context.Context, status, applicationType string, programID int, at time.Time) (model.SLA, error) {
+	if sla, exists := m.slas[status+":"+applicationType]; exists {
+		return sla, nil
+	}
+	return m.GetSLAByStatus(ctx, status)
+}
+
+func (m *mockSLARepo) GetSLAByID(ctx context.Context, id int) (model.SLA, error) {
+	for _, sla := range m.slas {
+		if sla.ID == id {
+			return sla, nil
+		}
+	}
+	return model.SLA{}, errors.New("SLA not found")
+}
+
+func (m *mockSLARepo) GetSLARules(ctx context.Context, status, applicationType string, programID int) ([]model.SLA, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockSLARepo) CreateSLA(ctx context.Context, sla model.SLA, changeLog model.SLAChangeLog) (model.SLA, error) {
 	return sla, nil
 }
 
+func (m *mockSLARepo) DeleteSLA(ctx context.Context, sla model.SLA, changeLog model.SLAChangeLog) error {
+	return nil
+}
+
+func (m *mockSLARepo) GetSLAChangeLogs(ctx context.Context, slaID int) ([]model.SLAChangeLog, error) {
+	return nil, errors.New("not implemented")
+}
+
 func (m *mockSLARepo) GetApplicationsForExport(ctx context.Context, appType string) ([]model.Application, error) {
 	return nil, errors.New("not implemented")
 }
@@ -346,6 +618,23 @@ func (m *mockVaultDecryptLogRepo) GetLogsByUMKMID(ctx context.Context, umkmID in
 	return nil, errors.New("not implemented")
 }
 
+// Mock Unit of Work
+type mockUnitOfWork struct {
+	repos repository.TxRepositories
+}
+
+func newMockUnitOfWork(repos repository.TxRepositories) *mockUnitOfWork {
+	// Approving funding opens a loan, so every unit of work gets a loan repository
+	if repos.Loans == nil {
+		repos.Loans = newMockLoanRepo()
+	}
+	return &mockUnitOfWork{repos: repos}
+}
+
+func (m *mockUnitOfWork) Do(ctx context.Context, fn func(repos repository.TxRepositories) error) error {
+	return fn(m.repos)
+}
+
 // ==================== TEST FUNCTIONS ====================
 
 func setupApplicationsService() (*applicationsService, *mockApplicationsRepo, *mockSLARepo) {
@@ -355,12 +644,26 @@ func setupApplicationsService() (*applicationsService, *mockApplicationsRepo, *m
 	mockSLARepo := newMockSLARepo()
 	mockVaultRepo := newMockVaultDecryptLogRepo()
 
+	// Admin 1 holds the review claims on the fixture applications
+	mockRedis := newMockRedisRepository()
+	for id := 1; id <= 10; id++ {
+		for _, stage := range []string{constant.ApplicationStatusScreening, constant.ApplicationStatusFinal} {
+			mockRedis.SetNX(context.Background(), reviewClaimKey(stage, id), "1", time.Hour)
+		}
+	}
+
 	service := &applicationsService{
 		applicationRepository:  mockAppRepo,
 		userRepository:         mockUserRepo,
 		notificationRepository: mockNotifRepo,
 		slaRepo:                mockSLARepo,
+		holidayRepo:            newMockHolidayRepo(),
 		vaultDecryptLogRepo:    mockVaultRepo,
+		uow: newMockUnitOfWork(repository.TxRepositories{
+			Applications:  mockAppRepo,
+			Notifications: mockNotifRepo,
+		}),
+		claims: newReviewClaims(mockRedis),
 	}
 
 	return service, mockAppRepo, mockSLARepo
@@ -383,7 +686,7 @@ func TestGetAllApplications(t *testing.T) {
 	}
 
 	t.Run("Get all applications without filter", func(t *testing.T) {
-		result, err := service.GetAllApplications(ctx, 0, "")
+		result, _, err := service.GetAllApplications(ctx, 0, dto.ApplicationQueryParams{})
 		if err != nil {
 			t.Errorf("Expected no error, got %v", err)
 		}
@@ -408,7 +711,7 @@ func TestGetAllApplications(t *testing.T) {
 			Program:   mockRepo.programs[2],
 		}
 
-		result, err := service.GetAllApplications(ctx, 0, "training")
+		result, _, err := service.GetAllApplications(ctx, 0, dto.ApplicationQueryParams{Type: "training"})
 		if err != nil {
 			t.Errorf("Expected no error, got %v", err)
 		}
@@ -419,6 +722,86 @@ func TestGetAllApplications(t *testing.T) {
 	})
 }
 
+// Test GetAllApplications paging, filters and validation
+func TestGetAllApplicationsQuery(t *testing.T) {
+	service, mockRepo, _ := setupApplicationsService()
+	ctx := context.Background()
+
+	for id := 1; id <= 25; id++ {
+		status := "screening"
+		if id%5 == 0 {
+			status = "final"
+		}
+		mockRepo.applications[id] = model.Application{
+			ID:        id,
+			UMKMID:    1,
+			ProgramID: 1,
+			Type:      "training",
+			Status:    status,
+			UMKM:      mockRepo.umkms[1],
+			Program:   mockRepo.programs[1],
+			Documents: []model.ApplicationDocument{{ID: id, ApplicationID: id, Type: "ktp", File: "ktp.pdf"}},
+		}
+	}
+
+	t.Run("Pages carry their meta and preloaded documents", func(t *testing.T) {
+		result, meta, err := service.GetAllApplications(ctx, 0, dto.ApplicationQueryParams{Page: 3, Limit: 10})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(result) != 5 || result[0].ID != 21 {
+			t.Fatalf("Expected the last 5 applications, got %d", len(result))
+		}
+		if meta.Total != 25 || meta.TotalPages != 3 || meta.Page != 3 || meta.Limit != 10 {
+			t.Errorf("Unexpected meta %+v", meta)
+		}
+		if len(result[0].Documents) != 1 {
+			t.Error("Expected documents from the preloaded page")
+		}
+	})
+
+	t.Run("Filters narrow the total", func(t *testing.T) {
+		_, meta, err := service.GetAllApplications(ctx, 0, dto.ApplicationQueryParams{Status: "final", Search: "test bus"})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if meta.Total != 5 {
+			t.Errorf("Expected 5 final applications, got %d", meta.Total)
+		}
+	})
+
+	t.Run("Page size is capped", func(t *testing.T) {
+		_, meta, _ := service.GetAllApplications(ctx, 0, dto.ApplicationQueryParams{Limit: 1000})
+		if meta.Limit != maxApplicationPageSize {
+			t.Errorf("Expected limit %d, got %d", maxApplicationPageSize, meta.Limit)
+		}
+	})
+
+	t.Run("Invalid parameters are rejected", func(t *testing.T) {
+		cases := []struct {
+			params   dto.ApplicationQueryParams
+			expected string
+		}{
+			{dto.ApplicationQueryParams{Status: "pending"}, "invalid application status"},
+			{dto.ApplicationQueryParams{SubmittedFrom: "01-12-2025"}, "submitted_from must be in YYYY-MM-DD format"},
+			{dto.ApplicationQueryParams{SubmittedFrom: "2025-12-10", SubmittedTo: "2025-12-01"}, "submitted_to must not be before submitted_from"},
+			{dto.ApplicationQueryParams{SortOrder: "up"}, "sort_order must be asc or desc"},
+			{dto.ApplicationQueryParams{KartuType: "gold"}, "kartu_type must be produktif or afirmatif"},
+		}
+		for _, tc := range cases {
+			_, _, err := service.GetAllApplications(ctx, 0, tc.params)
+			if err == nil || err.Error() != tc.expected {
+				t.Errorf("Expected %q, got %v", tc.expected, err)
+			}
+		}
+
+		_, _, err := service.GetAllApplications(ctx, 0, dto.ApplicationQueryParams{SortBy: "nik"})
+		if err == nil {
+			t.Error("Expected unknown sort field to be rejected")
+		}
+	})
+}
+
 // Test GetApplicationByID
 func TestGetApplicationByID(t *testing.T) {
 	service, mockRepo, _ := setupApplicationsService()
@@ -726,7 +1109,7 @@ func TestScreeningApprove(t *testing.T) {
 			SubmittedAt: time.Now(),
 		}
 
-		result, err := service.ScreeningApprove(ctx, 1, 1)
+		result, err := service.ScreeningApprove(ctx, 1, 1, 0)
 		if err != nil {
 			t.Errorf("Expected no error, got %v", err)
 		}
@@ -743,7 +1126,7 @@ func TestScreeningApprove(t *testing.T) {
 			Status: "final",
 		}
 
-		_, err := service.ScreeningApprove(ctx, 1, 2)
+		_, err := service.ScreeningApprove(ctx, 1, 2, 0)
 
 		if err == nil {
 			t.Error("Expected error for non-screening status, got none")
@@ -755,7 +1138,7 @@ func TestScreeningApprove(t *testing.T) {
 	})
 
 	t.Run("Approve non-existing application", func(t *testing.T) {
-		_, err := service.ScreeningApprove(ctx, 1, 999)
+		_, err := service.ScreeningApprove(ctx, 1, 999, 0)
 
 		if err == nil {
 			t.Error("Expected error for non-existing application, got none")
@@ -903,7 +1286,7 @@ func TestFinalApprove(t *testing.T) {
 			Status: "final",
 		}
 
-		result, err := service.FinalApprove(ctx, 1, 1)
+		result, err := service.FinalApprove(ctx, 1, 1, 0)
 		if err != nil {
 			t.Errorf("Expected no error, got %v", err)
 		}
@@ -920,7 +1303,7 @@ func TestFinalApprove(t *testing.T) {
 			Status: "screening",
 		}
 
-		_, err := service.FinalApprove(ctx, 1, 2)
+		_, err := service.FinalApprove(ctx, 1, 2, 0)
 
 		if err == nil {
 			t.Error("Expected error for non-final status, got none")
@@ -932,7 +1315,7 @@ func TestFinalApprove(t *testing.T) {
 	})
 
 	t.Run("Approve non-existing application", func(t *testing.T) {
-		_, err := service.FinalApprove(ctx, 1, 999)
+		_, err := service.FinalApprove(ctx, 1, 999, 0)
 
 		if err == nil {
 			t.Error("Expected error for non-existing application, got none")
@@ -940,6 +1323,87 @@ func TestFinalApprove(t *testing.T) {
 	})
 }
 
+func TestProgramCapacity(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func(capacity *int, fundPool *float64) (*applicationsService, *mockApplicationsRepo) {
+		service, mockRepo, _ := setupApplicationsService()
+		program := mockRepo.programs[2]
+		program.Capacity = capacity
+		program.FundPool = fundPool
+		mockRepo.programs[2] = program
+		return service, mockRepo
+	}
+	addApplication := func(mockRepo *mockApplicationsRepo, id int, status string, amount float64) {
+		mockRepo.applications[id] = model.Application{
+			ID:                 id,
+			UMKMID:             id,
+			ProgramID:          2,
+			Type:               "funding",
+			Status:             status,
+			FundingApplication: &model.FundingApplication{ApplicationID: id, RequestedAmount: amount, RequestedTenureMonths: 12},
+		}
+	}
+
+	t.Run("Approval past the capacity joins the waitlist", func(t *testing.T) {
+		capacity := 1
+		service, mockRepo := setup(&capacity, nil)
+		addApplication(mockRepo, 1, constant.ApplicationStatusApproved, 10000000)
+		addApplication(mockRepo, 2, constant.ApplicationStatusFinal, 10000000)
+
+		result, err := service.FinalApprove(ctx, 1, 2, 0)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if result.Status != constant.ApplicationStatusWaitlisted || mockRepo.applications[2].WaitlistedAt == nil {
+			t.Errorf("Expected waitlisted application, got %s", result.Status)
+		}
+		if history := mockRepo.histories[2]; len(history) != 1 || history[0].Status != constant.ApplicationActionWaitlist {
+			t.Errorf("Expected waitlist history, got %+v", history)
+		}
+	})
+
+	t.Run("Approval past the fund pool joins the waitlist", func(t *testing.T) {
+		fundPool := 25000000.0
+		service, mockRepo := setup(nil, &fundPool)
+		addApplication(mockRepo, 1, constant.ApplicationStatusApproved, 10000000)
+		addApplication(mockRepo, 2, constant.ApplicationStatusFinal, 15000000)
+		addApplication(mockRepo, 3, constant.ApplicationStatusFinal, 1000000)
+
+		if result, _ := service.FinalApprove(ctx, 1, 2, 0); result.Status != constant.ApplicationStatusApproved {
+			t.Errorf("Expected approval within the fund pool, got %s", result.Status)
+		}
+		if result, _ := service.FinalApprove(ctx, 1, 3, 0); result.Status != constant.ApplicationStatusWaitlisted {
+			t.Errorf("Expected approval past the fund pool to be waitlisted, got %s", result.Status)
+		}
+	})
+
+	t.Run("Approvals queue behind an existing waitlist", func(t *testing.T) {
+		fundPool := 20000000.0
+		service, mockRepo := setup(nil, &fundPool)
+		addApplication(mockRepo, 1, constant.ApplicationStatusApproved, 10000000)
+		addApplication(mockRepo, 2, constant.ApplicationStatusWaitlisted, 15000000)
+		waitlistedAt := time.Now()
+		app := mockRepo.applications[2]
+		app.WaitlistedAt = &waitlistedAt
+		mockRepo.applications[2] = app
+		addApplication(mockRepo, 3, constant.ApplicationStatusFinal, 5000000)
+
+		if result, _ := service.FinalApprove(ctx, 1, 3, 0); result.Status != constant.ApplicationStatusWaitlisted {
+			t.Errorf("Expected approval to wait behind the waitlist, got %s", result.Status)
+		}
+	})
+
+	t.Run("Program without limits approves", func(t *testing.T) {
+		service, mockRepo := setup(nil, nil)
+		addApplication(mockRepo, 1, constant.ApplicationStatusFinal, 10000000)
+
+		if result, _ := service.FinalApprove(ctx, 1, 1, 0); result.Status != constant.ApplicationStatusApproved {
+			t.Errorf("Expected approved status, got %s", result.Status)
+		}
+	})
+}
+
 // Test FinalReject
 func TestFinalReject(t *testing.T) {
 	service, mockRepo, _ := setupApplicationsService()
@@ -1010,7 +1474,7 @@ func TestApplicationsServiceEdgeCases(t *testing.T) {
 	ctx := context.Background()
 
 	t.Run("GetAllApplications with empty repository", func(t *testing.T) {
-		result, err := service.GetAllApplications(ctx, 0, "")
+		result, _, err := service.GetAllApplications(ctx, 0, dto.ApplicationQueryParams{})
 		if err != nil {
 			t.Errorf("Expected no error for empty repo, got %v", err)
 		}
@@ -1030,13 +1494,13 @@ func TestApplicationsServiceEdgeCases(t *testing.T) {
 		}
 
 		// Approve at screening
-		result1, err := service.ScreeningApprove(ctx, 1, 1)
+		result1, err := service.ScreeningApprove(ctx, 1, 1, 0)
 		if err != nil || result1.Status != "final" {
 			t.Error("Failed to approve at screening stage")
 		}
 
 		// Approve at final
-		result2, err := service.FinalApprove(ctx, 1, 1)
+		result2, err := service.FinalApprove(ctx, 1, 1, 0)
 		if err != nil || result2.Status != "approved" {
 			t.Error("Failed to approve at final stage")
 		}
@@ -1070,7 +1534,7 @@ func TestApplicationsServiceEdgeCases(t *testing.T) {
 			SubmittedAt: time.Now(),
 		}
 
-		service.ScreeningApprove(ctx, 1, 3)
+		service.ScreeningApprove(ctx, 1, 3, 0)
 
 		// In real implementation, check if notification was created
 		// This is a simplified check
@@ -1110,3 +1574,502 @@ func TestConcurrentOperations(t *testing.T) {
 		}
 	})
 }
+
+// Test optimistic concurrency on decisions
+func TestApplicationDecisionConcurrency(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Stale If-Match version is rejected", func(t *testing.T) {
+		service, mockRepo, _ := setupApplicationsService()
+		mockRepo.applications[1] = model.Application{ID: 1, UMKMID: 1, ProgramID: 1, Type: "training", Status: "screening", Version: 2}
+
+		_, err := service.ScreeningApprove(ctx, 1, 1, 1)
+		var conflictErr *ApplicationConflictError
+		if !errors.As(err, &conflictErr) || conflictErr.CurrentVersion != 2 {
+			t.Fatalf("Expected conflict at version 2, got %v", err)
+		}
+		if mockRepo.applications[1].Status != "screening" {
+			t.Error("Expected application to be left untouched")
+		}
+	})
+
+	t.Run("Matching version succeeds and bumps the version", func(t *testing.T) {
+		service, mockRepo, _ := setupApplicationsService()
+		mockRepo.applications[1] = model.Application{ID: 1, UMKMID: 1, ProgramID: 1, Type: "training", Status: "screening", Version: 2}
+
+		result, err := service.ScreeningReject(ctx, 1, dto.ApplicationDecision{ApplicationID: 1, Notes: "Incomplete", Version: 2})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if result.Version != 3 || mockRepo.applications[1].Version != 3 {
+			t.Errorf("Expected version 3, got %d", result.Version)
+		}
+	})
+
+	t.Run("Concurrent decision on the same read loses", func(t *testing.T) {
+		service, mockRepo, _ := setupApplicationsService()
+		application := model.Application{ID: 1, UMKMID: 1, ProgramID: 1, Type: "training", Status: "screening", Version: 1}
+		mockRepo.applications[1] = application
+		workflow := newApplicationWorkflow(service.uow, newSLACalendar(service.slaRepo, service.holidayRepo))
+
+		if _, err := workflow.Fire(ctx, eventScreeningReject, application, 1, "Incomplete"); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		_, err := workflow.Fire(ctx, eventScreeningApprove, application, 2, "")
+		var conflictErr *ApplicationConflictError
+		if !errors.As(err, &conflictErr) {
+			t.Fatalf("Expected conflict error, got %v", err)
+		}
+		if mockRepo.applications[1].Status != "rejected" || len(mockRepo.histories[1]) != 1 {
+			t.Errorf("Expected only the first decision to be recorded, got status %s with %d histories", mockRepo.applications[1].Status, len(mockRepo.histories[1]))
+		}
+	})
+}
+
+// Test bulk decisions
+func TestBulkDecisions(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func() (*applicationsService, *mockApplicationsRepo) {
+		service, mockRepo, _ := setupApplicationsService()
+		for id := 1; id <= 3; id++ {
+			mockRepo.applications[id] = model.Application{ID: id, UMKMID: 1, ProgramID: 1, Type: "training", Status: "screening"}
+		}
+		// Application 4 is already past screening
+		mockRepo.applications[4] = model.Application{ID: 4, UMKMID: 1, ProgramID: 1, Type: "training", Status: "final"}
+		return service, mockRepo
+	}
+
+	t.Run("Failures do not roll back other items", func(t *testing.T) {
+		service, mockRepo := setup()
+
+		report, err := service.BulkScreeningDecision(ctx, 1, dto.BulkApplicationDecision{
+			ApplicationIDs: []int{1, 4, 2, 999},
+			Action:         "approve",
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if report.Succeeded != 2 || report.Failed != 2 || len(report.Results) != 4 {
+			t.Fatalf("Expected 2 succeeded and 2 failed, got %+v", report)
+		}
+		if !report.Results[0].Success || report.Results[1].Success || report.Results[1].Message != "application must be in screening status" {
+			t.Errorf("Unexpected results %+v", report.Results)
+		}
+		if mockRepo.applications[1].Status != "final" || mockRepo.applications[2].Status != "final" {
+			t.Error("Expected successful items to be committed")
+		}
+	})
+
+	t.Run("All or nothing applies nothing when one item fails", func(t *testing.T) {
+		service, mockRepo := setup()
+
+		report, err := service.BulkScreeningDecision(ctx, 1, dto.BulkApplicationDecision{
+			ApplicationIDs: []int{1, 2, 4},
+			Action:         "reject",
+			Notes:          "Kuota pelatihan penuh",
+			AllOrNothing:   true,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if report.Succeeded != 0 || report.Failed != 3 {
+			t.Fatalf("Expected every item to fail, got %+v", report)
+		}
+		if report.Results[2].Message != "application must be in screening status" {
+			t.Errorf("Expected the failing item to carry its own error, got %+v", report.Results[2])
+		}
+		for id := 1; id <= 2; id++ {
+			if mockRepo.applications[id].Status != "screening" || len(mockRepo.histories[id]) != 0 {
+				t.Errorf("Expected application %d to be left untouched", id)
+			}
+		}
+	})
+
+	t.Run("All or nothing commits every item", func(t *testing.T) {
+		service, mockRepo := setup()
+
+		report, err := service.BulkScreeningDecision(ctx, 1, dto.BulkApplicationDecision{
+			ApplicationIDs: []int{1, 2, 3},
+			Action:         "revise",
+			Notes:          "Lengkapi dokumen NIB",
+			AllOrNothing:   true,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if report.Succeeded != 3 || report.Failed != 0 {
+			t.Fatalf("Expected 3 succeeded, got %+v", report)
+		}
+		for id := 1; id <= 3; id++ {
+			if mockRepo.applications[id].Status != "revised" {
+				t.Errorf("Expected application %d to be revised", id)
+			}
+		}
+	})
+
+	t.Run("Request level validation", func(t *testing.T) {
+		service, _ := setup()
+
+		cases := []struct {
+			name     string
+			request  dto.BulkApplicationDecision
+			expected string
+		}{
+			{"missing notes", dto.BulkApplicationDecision{ApplicationIDs: []int{1}, Action: "reject"}, "notes are required for rejection"},
+			{"empty list", dto.BulkApplicationDecision{Action: "approve"}, "application_ids are required"},
+			{"duplicate ID", dto.BulkApplicationDecision{ApplicationIDs: []int{1, 1}, Action: "approve"}, "application ID 1 is listed more than once"},
+		}
+		for _, tc := range cases {
+			_, err := service.BulkScreeningDecision(ctx, 1, tc.request)
+			if err == nil || err.Error() != tc.expected {
+				t.Errorf("%s: expected %q, got %v", tc.name, tc.expected, err)
+			}
+		}
+
+		_, err := service.BulkFinalDecision(ctx, 1, dto.BulkApplicationDecision{ApplicationIDs: []int{4}, Action: "revise", Notes: "x"})
+		if err == nil {
+			t.Error("Expected revise to be rejected at the final stage")
+		}
+	})
+}
+
+func TestApplicationScoring(t *testing.T) {
+	ctx := context.Background()
+
+	rubric := dto.ProgramRubric{Criteria: []dto.RubricCriterion{
+		{Name: "Kelayakan usaha", Weight: 3, MinScore: 1, MaxScore: 5},
+		{Name: "Rencana bisnis", Weight: 1, MinScore: 0, MaxScore: 10},
+	}}
+	setup := func() (*applicationsService, *mockApplicationsRepo) {
+		service, mockRepo, _ := setupApplicationsService()
+		for id := 1; id <= 4; id++ {
+			mockRepo.applications[id] = model.Application{
+				ID:          id,
+				UMKMID:      1,
+				ProgramID:   2,
+				Type:        "funding",
+				Status:      constant.ApplicationStatusFinal,
+				SubmittedAt: time.Date(2025, 12, id, 9, 0, 0, 0, time.Local),
+			}
+		}
+		if _, err := service.SetProgramRubric(ctx, 2, rubric); err != nil {
+			t.Fatalf("Expected rubric to be saved, got %v", err)
+		}
+		return service, mockRepo
+	}
+	score := func(service *applicationsService, applicationID int, first, second float64) dto.ApplicationScorecard {
+		t.Helper()
+		criteria := service.applicationRepository.(*mockApplicationsRepo).criteria[2]
+		scorecard, err := service.ScoreApplication(ctx, 1, applicationID, dto.ApplicationScoresRequest{Scores: []dto.ApplicationScoreInput{
+			{CriterionID: criteria[0].ID, Score: first},
+			{CriterionID: criteria[1].ID, Score: second},
+		}})
+		if err != nil {
+			t.Fatalf("Expected application %d to be scored, got %v", applicationID, err)
+		}
+		return scorecard
+	}
+
+	t.Run("Total is the weighted mean of normalized scores", func(t *testing.T) {
+		service, mockRepo := setup()
+		criteria := mockRepo.criteria[2]
+
+		partial, err := service.ScoreApplication(ctx, 1, 1, dto.ApplicationScoresRequest{Scores: []dto.ApplicationScoreInput{
+			{CriterionID: criteria[0].ID, Score: 4, Notes: "Omzet stabil"},
+		}})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if partial.Complete || partial.ScoreTotal != nil || partial.Rank != nil {
+			t.Errorf("Expected no total before every criterion is scored, got %+v", partial)
+		}
+
+		scorecard := score(service, 1, 4, 5)
+		// (3 * 3/4 + 1 * 5/10) / 4 * 100
+		if !scorecard.Complete || scorecard.ScoreTotal == nil || *scorecard.ScoreTotal != 68.75 {
+			t.Fatalf("Expected a total of 68.75, got %+v", scorecard)
+		}
+		if scorecard.Rank == nil || *scorecard.Rank != 1 || scorecard.RankedCount != 1 {
+			t.Errorf("Expected rank 1 of 1, got %+v", scorecard)
+		}
+		if len(mockRepo.applications[1].Scores) != 2 || mockRepo.applications[1].Scores[0].ScoredBy != 1 {
+			t.Errorf("Expected one score per criterion with the reviewer, got %+v", mockRepo.applications[1].Scores)
+		}
+	})
+
+	t.Run("Scoring bumps the version so a stale decision conflicts", func(t *testing.T) {
+		service, mockRepo := setup()
+		stale := mockRepo.applications[1]
+
+		score(service, 1, 4, 5)
+		if mockRepo.applications[1].Version == stale.Version {
+			t.Fatal("Expected saving scores to bump the version")
+		}
+
+		workflow := newApplicationWorkflow(service.uow, newSLACalendar(service.slaRepo, service.holidayRepo))
+		_, err := workflow.Fire(ctx, eventFinalReject, stale, 1, "Not a fit")
+		var conflictErr *ApplicationConflictError
+		if !errors.As(err, &conflictErr) {
+			t.Fatalf("Expected a conflict, got %v", err)
+		}
+		if mockRepo.applications[1].ScoreTotal == nil {
+			t.Error("Expected the saved total to be kept")
+		}
+	})
+
+	t.Run("Invalid scores are rejected", func(t *testing.T) {
+		service, mockRepo := setup()
+		criteria := mockRepo.criteria[2]
+
+		cases := []struct {
+			name     string
+			scores   []dto.ApplicationScoreInput
+			expected string
+		}{
+			{"out of range", []dto.ApplicationScoreInput{{CriterionID: criteria[0].ID, Score: 6}}, "score for Kelayakan usaha must be between 1 and 5"},
+			{"unknown criterion", []dto.ApplicationScoreInput{{CriterionID: 99, Score: 1}}, "criterion ID 99 is not part of the program rubric"},
+			{"duplicate criterion", []dto.ApplicationScoreInput{{CriterionID: criteria[1].ID, Score: 1}, {CriterionID: criteria[1].ID, Score: 2}}, fmt.Sprintf("criterion ID %d is scored more than once", criteria[1].ID)},
+		}
+		for _, tc := range cases {
+			_, err := service.ScoreApplication(ctx, 1, 1, dto.ApplicationScoresRequest{Scores: tc.scores})
+			if err == nil || err.Error() != tc.expected {
+				t.Errorf("%s: expected %q, got %v", tc.name, tc.expected, err)
+			}
+		}
+
+		// Admin 2 does not hold the claim
+		_, err := service.ScoreApplication(ctx, 2, 1, dto.ApplicationScoresRequest{Scores: []dto.ApplicationScoreInput{{CriterionID: criteria[0].ID, Score: 3}}})
+		var claimErr *ReviewClaimError
+		if !errors.As(err, &claimErr) {
+			t.Errorf("Expected a review claim error, got %v", err)
+		}
+	})
+
+	t.Run("Rubric is locked once applications are scored", func(t *testing.T) {
+		service, _ := setup()
+		score(service, 1, 3, 5)
+
+		_, err := service.SetProgramRubric(ctx, 2, rubric)
+		if err == nil || err.Error() != "rubric cannot be changed after applications of the program have been scored" {
+			t.Errorf("Expected locked rubric error, got %v", err)
+		}
+		if result, _ := service.GetProgramRubric(ctx, 2); !result.Locked || result.TotalWeight != 4 {
+			t.Errorf("Expected a locked rubric with total weight 4, got %+v", result)
+		}
+	})
+
+	t.Run("Ranking shares ranks between equal totals", func(t *testing.T) {
+		service, _ := setup()
+		score(service, 1, 3, 5)
+		score(service, 2, 5, 10)
+		score(service, 3, 3, 5)
+
+		ranking, err := service.GetProgramRanking(ctx, 2)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		got := make([][2]int, 0, len(ranking))
+		for _, r := range ranking {
+			got = append(got, [2]int{r.ApplicationID, r.Rank})
+		}
+		expected := [][2]int{{2, 1}, {1, 2}, {3, 2}}
+		if !slices.Equal(got, expected) {
+			t.Errorf("Expected ranking %v, got %v", expected, got)
+		}
+	})
+
+	t.Run("Approve top N picks the best ranked applications", func(t *testing.T) {
+		service, mockRepo := setup()
+		score(service, 1, 2, 0)
+		score(service, 2, 5, 10)
+		score(service, 3, 4, 8)
+		// Application 4 is not scored and never picked
+
+		report, err := service.BulkApproveTop(ctx, 1, dto.BulkTopDecision{ProgramID: 2, Stage: constant.ApplicationStatusFinal, Count: 2})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if report.Succeeded != 2 || report.Results[0].ApplicationID != 2 || report.Results[1].ApplicationID != 3 {
+			t.Fatalf("Expected applications 2 and 3 to be approved, got %+v", report)
+		}
+		if mockRepo.applications[1].Status != constant.ApplicationStatusFinal || mockRepo.applications[4].Status != constant.ApplicationStatusFinal {
+			t.Error("Expected lower ranked and unscored applications to stay in final")
+		}
+
+		detail, err := service.GetApplicationByID(ctx, 1, 2)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if detail.Scorecard == nil || *detail.Scorecard.Rank != 1 || *detail.ScoreTotal != 100 {
+			t.Errorf("Expected the approved application to keep rank 1, got %+v", detail.Scorecard)
+		}
+	})
+}
+
+func TestDocumentReviews(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func() (*applicationsService, *mockApplicationsRepo) {
+		service, mockRepo, _ := setupApplicationsService()
+		mockRepo.applications[1] = model.Application{
+			ID:        1,
+			UMKMID:    1,
+			ProgramID: 1,
+			Type:      "training",
+			Status:    constant.ApplicationStatusScreening,
+			Version:   1,
+			Documents: []model.ApplicationDocument{
+				{ID: 11, ApplicationID: 1, Type: "ktp", File: "ktp.jpg", ReviewStatus: constant.DocumentReviewPending},
+				{ID: 12, ApplicationID: 1, Type: "nib", File: "nib.pdf", ReviewStatus: constant.DocumentReviewPending},
+				{ID: 13, ApplicationID: 1, Type: "proposal", File: "proposal.pdf", ReviewStatus: constant.DocumentReviewPending},
+			},
+		}
+		return service, mockRepo
+	}
+
+	t.Run("Reviewer records verdicts per document", func(t *testing.T) {
+		service, mockRepo := setup()
+
+		document, err := service.ReviewDocument(ctx, 1, 1, 11, dto.DocumentReviewRequest{Status: constant.DocumentReviewRejected, Reason: "Foto KTP buram"})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if document.ReviewStatus != constant.DocumentReviewRejected || document.ReviewReason != "Foto KTP buram" || *document.ReviewedBy != 1 {
+			t.Errorf("Unexpected review %+v", document)
+		}
+		if _, err := service.ReviewDocument(ctx, 1, 1, 12, dto.DocumentReviewRequest{Status: constant.DocumentReviewAccepted}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		documents := mockRepo.applications[1].Documents
+		if documents[0].ReviewStatus != constant.DocumentReviewRejected || documents[1].ReviewStatus != constant.DocumentReviewAccepted {
+			t.Errorf("Expected verdicts to be saved, got %+v", documents)
+		}
+	})
+
+	t.Run("Invalid verdicts are refused", func(t *testing.T) {
+		service, _ := setup()
+
+		tests := []struct {
+			name       string
+			reviewer   int
+			documentID int
+			request    dto.DocumentReviewRequest
+			expected   string
+		}{
+			{"Unknown status", 1, 11, dto.DocumentReviewRequest{Status: "pending"}, "status must be accepted or rejected"},
+			{"Rejection without reason", 1, 11, dto.DocumentReviewRequest{Status: constant.DocumentReviewRejected}, "reason is required when rejecting a document"},
+			{"Document of another application", 1, 99, dto.DocumentReviewRequest{Status: constant.DocumentReviewAccepted}, "document not found"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := service.ReviewDocument(ctx, tt.reviewer, 1, tt.documentID, tt.request)
+				if err == nil || err.Error() != tt.expected {
+					t.Errorf("Expected %q, got %v", tt.expected, err)
+				}
+			})
+		}
+
+		// Admin 2 does not hold the screening claim
+		_, err := service.ReviewDocument(ctx, 2, 1, 11, dto.DocumentReviewRequest{Status: constant.DocumentReviewAccepted})
+		var claimErr *ReviewClaimError
+		if !errors.As(err, &claimErr) {
+			t.Errorf("Expected claim error, got %v", err)
+		}
+	})
+
+	t.Run("Revise flags the named documents and fields", func(t *testing.T) {
+		service, mockRepo := setup()
+		service.ReviewDocument(ctx, 1, 1, 11, dto.DocumentReviewRequest{Status: constant.DocumentReviewRejected, Reason: "Foto KTP buram"})
+
+		_, err := service.ScreeningRevise(ctx, 1, dto.ApplicationDecision{
+			ApplicationID: 1,
+			Notes:         "Lengkapi proposal",
+			DocumentIDs:   []int{11, 13, 13},
+			Fields:        []string{"motivation", "motivation"},
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		application := mockRepo.applications[1]
+		if application.Status != constant.ApplicationStatusRevised || application.RevisionFields != "motivation" {
+			t.Errorf("Expected revised application flagging motivation, got %s %q", application.Status, application.RevisionFields)
+		}
+		reasons := map[int]string{}
+		for _, document := range application.Documents {
+			if document.ReviewStatus == constant.DocumentReviewRejected {
+				reasons[document.ID] = document.ReviewReason
+			}
+		}
+		// The earlier verdict keeps its own reason
+		if len(reasons) != 2 || reasons[11] != "Foto KTP buram" || reasons[13] != "Lengkapi proposal" {
+			t.Errorf("Unexpected flagged documents %v", reasons)
+		}
+	})
+
+	t.Run("Revise refuses unknown documents and fields", func(t *testing.T) {
+		service, mockRepo := setup()
+
+		_, err := service.ScreeningRevise(ctx, 1, dto.ApplicationDecision{ApplicationID: 1, Notes: "Revisi", DocumentIDs: []int{99}})
+		if err == nil || err.Error() != "document 99 does not belong to application 1" {
+			t.Errorf("Expected unknown document error, got %v", err)
+		}
+		_, err = service.ScreeningRevise(ctx, 1, dto.ApplicationDecision{ApplicationID: 1, Notes: "Revisi", Fields: []string{"requested_amount"}})
+		if err == nil || err.Error() != `field "requested_amount" cannot be revised on training applications` {
+			t.Errorf("Expected unknown field error, got %v", err)
+		}
+		if mockRepo.applications[1].Status != constant.ApplicationStatusScreening {
+			t.Error("Expected application to stay in screening")
+		}
+	})
+}
+
+func TestDocumentComparison(t *testing.T) {
+	ctx := context.Background()
+	service, mockRepo, _ := setupApplicationsService()
+
+	supersededAt := time.Now().AddDate(0, 0, -3)
+	previousID := 11
+	mockRepo.applications[1] = model.Application{ID: 1, UMKMID: 1, ProgramID: 1, Type: "training", Status: constant.ApplicationStatusScreening, RevisionRound: 1}
+	mockRepo.documents[1] = []model.ApplicationDocument{
+		{ID: 11, ApplicationID: 1, Type: "ktp", File: "ktp-v1.jpg", Version: 1, ReviewStatus: constant.DocumentReviewRejected, SupersededAt: &supersededAt},
+		{ID: 14, ApplicationID: 1, Type: "ktp", File: "ktp-v2.jpg", Version: 2, PreviousID: &previousID, RevisionRound: 1},
+		{ID: 12, ApplicationID: 1, Type: "nib", File: "nib.pdf", Version: 1, ReviewStatus: constant.DocumentReviewAccepted},
+	}
+
+	t.Run("Submitted version is set against the current one", func(t *testing.T) {
+		comparisons, err := service.GetDocumentComparison(ctx, 1)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(comparisons) != 2 {
+			t.Fatalf("Expected 2 document types, got %d", len(comparisons))
+		}
+
+		ktp := comparisons[0]
+		if ktp.Type != "ktp" || !ktp.Changed || len(ktp.Versions) != 2 {
+			t.Fatalf("Unexpected ktp comparison %+v", ktp)
+		}
+		if ktp.Submitted.File != "ktp-v1.jpg" || ktp.Current.File != "ktp-v2.jpg" || ktp.Current.RevisionRound != 1 {
+			t.Errorf("Expected v1 submitted and v2 current, got %s and %s", ktp.Submitted.File, ktp.Current.File)
+		}
+		if ktp.Versions[0].SupersededAt == "" {
+			t.Error("Expected the replaced version to carry its superseded time")
+		}
+
+		nib := comparisons[1]
+		if nib.Type != "nib" || nib.Changed || nib.Submitted.ID != nib.Current.ID {
+			t.Errorf("Expected nib unchanged, got %+v", nib)
+		}
+	})
+
+	t.Run("Unknown application", func(t *testing.T) {
+		_, err := service.GetDocumentComparison(ctx, 99)
+		if err == nil {
+			t.Error("Expected error for unknown application")
+		}
+	})
+}
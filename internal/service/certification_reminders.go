@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"UMKMGo-backend/config/log"
+	"UMKMGo-backend/internal/repository"
+	"UMKMGo-backend/internal/types/model"
+	"UMKMGo-backend/internal/utils/constant"
+)
+
+type CertificationReminderService interface {
+	SendExpiryReminders(ctx context.Context) (int, error)
+}
+
+type certificationReminderService struct {
+	certificationRepo repository.CertificationRepository
+	notificationRepo  repository.NotificationRepository
+	reminderDays      []int
+}
+
+func NewCertificationReminderService(certificationRepo repository.CertificationRepository, notificationRepo repository.NotificationRepository, reminderDays []int) CertificationReminderService {
+	if len(reminderDays) == 0 {
+		reminderDays = []int{90, 30, 7}
+	}
+	return &certificationReminderService{
+		certificationRepo: certificationRepo,
+		notificationRepo:  notificationRepo,
+		reminderDays:      reminderDays,
+	}
+}
+
+// SendExpiryReminders notifies UMKMs of certificates reaching a reminder
+// threshold, e.g. 90, 30 and 7 days before expiry. Each threshold is sent once;
+// a certificate first seen inside several thresholds only gets the nearest.
+func (s *certificationReminderService) SendExpiryReminders(ctx context.Context) (int, error) {
+	today := startOfDay(time.Now())
+	certifications, err := s.certificationRepo.GetExpiringCertifications(ctx, today, today.AddDate(0, 0, reminderWindow(s.reminderDays)))
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, certification := range certifications {
+		daysLeft := daysUntil(today, *certification.ExpiryDate)
+		due, ok := dueReminder(s.reminderDays, daysLeft)
+		if !ok || (certification.RemindedDays != nil && *certification.RemindedDays <= due) {
+			continue
+		}
+
+		if err := s.notify(ctx, certification, daysLeft); err != nil {
+			log.Log.Errorf("failed to send expiry reminder for certification ID %d: %v", certification.ID, err)
+			continue
+		}
+		if err := s.certificationRepo.UpdateRemindedDays(ctx, certification.ID, due); err != nil {
+			return sent, err
+		}
+		sent++
+	}
+	return sent, nil
+}
+
+func (s *certificationReminderService) notify(ctx context.Context, certification model.Certification, daysLeft int) error {
+	application := certification.CertificationApplication.Application
+	metadata, err := json.Marshal(map[string]any{"certification_id": certification.ID, "days_until_expiry": daysLeft})
+	if err != nil {
+		return err
+	}
+
+	return s.notificationRepo.CreateNotification(ctx, model.Notification{
+		UMKMID:        application.UMKMID,
+		ApplicationID: &application.ID,
+		Type:          constant.NotificationCertificationExpiring,
+		Title:         constant.NotificationTitleCertificationExpiring,
+		Message: fmt.Sprintf(constant.NotificationMessageCertificationExpiring,
+			application.Program.Title, certification.CertificateNumber, certification.ExpiryDate.Format("02-01-2006"), daysLeft, certification.IssuingBody),
+		Metadata: string(metadata),
+	})
+}
+
+// dueReminder returns the nearest reminder threshold that daysLeft has reached.
+func dueReminder(reminderDays []int, daysLeft int) (int, bool) {
+	thresholds := append([]int(nil), reminderDays...)
+	sort.Ints(thresholds)
+	for _, days := range thresholds {
+		if daysLeft <= days {
+			return days, true
+		}
+	}
+	return 0, false
+}
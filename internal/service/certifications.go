@@ -0,0 +1,341 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"UMKMGo-backend/config/log"
+	"UMKMGo-backend/config/storage"
+	"UMKMGo-backend/internal/repository"
+	"UMKMGo-backend/internal/types/dto"
+	"UMKMGo-backend/internal/types/model"
+	"UMKMGo-backend/internal/utils"
+	"UMKMGo-backend/internal/utils/constant"
+)
+
+type CertificationService interface {
+	GetCertifications(ctx context.Context, params dto.CertificationQueryParams) ([]dto.Certification, error)
+	GetCertificationByID(ctx context.Context, id int) (dto.Certification, error)
+	RecordCertification(ctx context.Context, userID, applicationID int, request dto.CertificationRequest) (dto.Certification, error)
+	UpdateCertification(ctx context.Context, id int, request dto.CertificationRequest) (dto.Certification, error)
+	VerifyCertification(ctx context.Context, number, code string) (dto.CertificationVerification, error)
+}
+
+type certificationService struct {
+	certificationRepo repository.CertificationRepository
+	applicationRepo   repository.ApplicationsRepository
+	notificationRepo  repository.NotificationRepository
+	verifyURL         string
+	reminderDays      []int
+	// storeFile uploads base64 data and returns its URL
+	storeFile func(ctx context.Context, prefix, base64Data string) (string, error)
+}
+
+func NewCertificationService(certificationRepo repository.CertificationRepository, applicationRepo repository.ApplicationsRepository, notificationRepo repository.NotificationRepository, minio *storage.MinIOManager, verifyURL string, reminderDays []int) CertificationService {
+	return &certificationService{
+		certificationRepo: certificationRepo,
+		applicationRepo:   applicationRepo,
+		notificationRepo:  notificationRepo,
+		verifyURL:         strings.TrimRight(verifyURL, "/"),
+		reminderDays:      reminderDays,
+		storeFile: func(ctx context.Context, prefix, base64Data string) (string, error) {
+			res, err := minio.UploadFile(ctx, storage.UploadRequest{
+				Base64Data: base64Data,
+				BucketName: storage.ApplicationBucket,
+				Prefix:     prefix,
+				Validation: storage.CreateImageValidationConfig(),
+			})
+			if err != nil {
+				return "", err
+			}
+			return res.URL, nil
+		},
+	}
+}
+
+func (s *certificationService) GetCertifications(ctx context.Context, params dto.CertificationQueryParams) ([]dto.Certification, error) {
+	switch params.Status {
+	case "", constant.CertificationStatusValid, constant.CertificationStatusExpiring, constant.CertificationStatusExpired:
+	default:
+		return nil, errors.New("invalid status, must be valid, expiring or expired")
+	}
+
+	today := startOfDay(time.Now())
+	window := reminderWindow(s.reminderDays)
+	certifications, err := s.certificationRepo.GetCertifications(ctx, params.Status, today, today.AddDate(0, 0, window))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]dto.Certification, 0, len(certifications))
+	for _, certification := range certifications {
+		result = append(result, mapCertificationToDTO(certification, today, window))
+	}
+	return result, nil
+}
+
+func (s *certificationService) GetCertificationByID(ctx context.Context, id int) (dto.Certification, error) {
+	certification, err := s.certificationRepo.GetCertificationByID(ctx, id)
+	if err != nil {
+		return dto.Certification{}, err
+	}
+	return mapCertificationToDTO(certification, startOfDay(time.Now()), reminderWindow(s.reminderDays)), nil
+}
+
+// RecordCertification registers the certificate issued for an approved
+// certification application. The issuing body is the program's provider, and
+// the certificate gets a verification code with a QR code leading to the
+// public verification endpoint.
+func (s *certificationService) RecordCertification(ctx context.Context, userID, applicationID int, request dto.CertificationRequest) (dto.Certification, error) {
+	application, err := s.applicationRepo.GetApplicationByID(ctx, applicationID)
+	if err != nil {
+		return dto.Certification{}, err
+	}
+	if application.Type != "certification" || application.CertificationApplication == nil {
+		return dto.Certification{}, errors.New("certificates can only be recorded for certification applications")
+	}
+	if application.Status != "approved" {
+		return dto.Certification{}, errors.New("certificates can only be recorded for approved applications")
+	}
+	if strings.TrimSpace(application.Program.Provider) == "" {
+		return dto.Certification{}, errors.New("program has no provider to record as the issuing body")
+	}
+	if _, err := s.certificationRepo.GetCertificationByApplicationID(ctx, application.ID); err == nil {
+		return dto.Certification{}, errors.New("a certificate has already been recorded for this application")
+	}
+	if request.Document == "" {
+		return dto.Certification{}, errors.New("document is required")
+	}
+
+	certification := model.Certification{
+		CertificationApplicationID: application.CertificationApplication.ID,
+		IssuingBody:                application.Program.Provider,
+		RecordedBy:                 userID,
+	}
+	if err := applyCertificationRequest(&certification, request); err != nil {
+		return dto.Certification{}, err
+	}
+	if err := s.ensureNumberAvailable(ctx, certification); err != nil {
+		return dto.Certification{}, err
+	}
+
+	certification.VerificationCode, err = utils.GenerateVerificationCode(12)
+	if err != nil {
+		return dto.Certification{}, err
+	}
+	certification.DocumentURL, err = s.storeFile(ctx, utils.GenerateFileName(application.UMKM.BusinessName, "certification_"), request.Document)
+	if err != nil {
+		return dto.Certification{}, fmt.Errorf("failed to upload document: %w", err)
+	}
+	qrCode, err := utils.GenerateQRCode(s.verificationLink(certification.VerificationCode), 256)
+	if err != nil {
+		return dto.Certification{}, err
+	}
+	certification.QRCode, err = s.storeFile(ctx, utils.GenerateFileName(application.UMKM.BusinessName, "certification_qr_"), qrCode)
+	if err != nil {
+		return dto.Certification{}, fmt.Errorf("failed to upload QR code: %w", err)
+	}
+
+	created, err := s.certificationRepo.CreateCertification(ctx, certification)
+	if err != nil {
+		return dto.Certification{}, err
+	}
+
+	metadata, err := json.Marshal(map[string]any{"certification_id": created.ID, "certificate_number": created.CertificateNumber})
+	if err != nil {
+		return dto.Certification{}, err
+	}
+	if err := s.notificationRepo.CreateNotification(ctx, model.Notification{
+		UMKMID:        application.UMKMID,
+		ApplicationID: &application.ID,
+		Type:          constant.NotificationCertificationRecorded,
+		Title:         constant.NotificationTitleCertificationRecorded,
+		Message:       fmt.Sprintf(constant.NotificationMessageCertificationRecorded, application.Program.Title, created.CertificateNumber, created.IssuingBody),
+		Metadata:      string(metadata),
+	}); err != nil {
+		log.Log.Errorf("failed to notify certification for application ID %d: %v", application.ID, err)
+	}
+
+	return s.GetCertificationByID(ctx, created.ID)
+}
+
+// UpdateCertification corrects a recorded certificate or records its renewal.
+// The document is replaced only when a new one is sent, and a new expiry date
+// restarts the expiry reminders.
+func (s *certificationService) UpdateCertification(ctx context.Context, id int, request dto.CertificationRequest) (dto.Certification, error) {
+	certification, err := s.certificationRepo.GetCertificationByID(ctx, id)
+	if err != nil {
+		return dto.Certification{}, err
+	}
+	application := certification.CertificationApplication.Application
+
+	previousExpiry := certification.ExpiryDate
+	if err := applyCertificationRequest(&certification, request); err != nil {
+		return dto.Certification{}, err
+	}
+	if err := s.ensureNumberAvailable(ctx, certification); err != nil {
+		return dto.Certification{}, err
+	}
+	if !sameDate(previousExpiry, certification.ExpiryDate) {
+		certification.RemindedDays = nil
+	}
+	if request.Document != "" {
+		certification.DocumentURL, err = s.storeFile(ctx, utils.GenerateFileName(application.UMKM.BusinessName, "certification_"), request.Document)
+		if err != nil {
+			return dto.Certification{}, fmt.Errorf("failed to upload document: %w", err)
+		}
+	}
+
+	if _, err := s.certificationRepo.UpdateCertification(ctx, certification); err != nil {
+		return dto.Certification{}, err
+	}
+	return s.GetCertificationByID(ctx, certification.ID)
+}
+
+func (s *certificationService) ensureNumberAvailable(ctx context.Context, certification model.Certification) error {
+	existing, err := s.certificationRepo.GetCertificationByNumber(ctx, certification.CertificateNumber)
+	if err == nil && existing.ID != certification.ID {
+		return fmt.Errorf("certificate number %s is already recorded", certification.CertificateNumber)
+	}
+	return nil
+}
+
+func (s *certificationService) verificationLink(code string) string {
+	if s.verifyURL == "" {
+		return code
+	}
+	return s.verifyURL + "/" + code
+}
+
+// applyCertificationRequest validates the certificate number and dates. A
+// certificate cannot be issued in the future and, when it expires, expires
+// after it was issued.
+func applyCertificationRequest(certification *model.Certification, request dto.CertificationRequest) error {
+	number := strings.TrimSpace(request.CertificateNumber)
+	if number == "" {
+		return errors.New("certificate_number is required")
+	}
+
+	issuedDate, err := time.ParseInLocation("2006-01-02", request.IssuedDate, time.Local)
+	if err != nil {
+		return errors.New("invalid issued_date format, use YYYY-MM-DD")
+	}
+	if issuedDate.After(time.Now()) {
+		return errors.New("issued_date cannot be in the future")
+	}
+
+	var expiryDate *time.Time
+	if request.ExpiryDate != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", request.ExpiryDate, time.Local)
+		if err != nil {
+			return errors.New("invalid expiry_date format, use YYYY-MM-DD")
+		}
+		if !parsed.After(issuedDate) {
+			return errors.New("expiry_date must be after issued_date")
+		}
+		expiryDate = &parsed
+	}
+
+	certification.CertificateNumber = number
+	certification.IssuedDate = issuedDate
+	certification.ExpiryDate = expiryDate
+	return nil
+}
+
+// VerifyCertification looks a certificate up by its number or by the code in
+// its QR code, for anyone checking a UMKM's claim. Only what is printed on the
+// certificate is returned.
+func (s *certificationService) VerifyCertification(ctx context.Context, number, code string) (dto.CertificationVerification, error) {
+	number, code = strings.TrimSpace(number), strings.ToUpper(strings.TrimSpace(code))
+
+	var certification model.Certification
+	var err error
+	switch {
+	case code != "":
+		certification, err = s.certificationRepo.GetCertificationByCode(ctx, code)
+	case number != "":
+		certification, err = s.certificationRepo.GetCertificationByNumber(ctx, number)
+	default:
+		return dto.CertificationVerification{}, errors.New("certificate number or verification code is required")
+	}
+	if err != nil {
+		return dto.CertificationVerification{}, err
+	}
+
+	mapped := mapCertificationToDTO(certification, startOfDay(time.Now()), reminderWindow(s.reminderDays))
+	return dto.CertificationVerification{
+		Valid:             mapped.Status != constant.CertificationStatusExpired,
+		Status:            mapped.Status,
+		CertificateNumber: mapped.CertificateNumber,
+		CertificationName: mapped.ProgramTitle,
+		IssuingBody:       mapped.IssuingBody,
+		BusinessName:      mapped.BusinessName,
+		IssuedDate:        mapped.IssuedDate,
+		ExpiryDate:        mapped.ExpiryDate,
+	}, nil
+}
+
+// reminderWindow is how many days ahead of expiry a certificate counts as
+// expiring: the earliest reminder.
+func reminderWindow(reminderDays []int) int {
+	window := 0
+	for _, days := range reminderDays {
+		if days > window {
+			window = days
+		}
+	}
+	return window
+}
+
+func sameDate(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return a.Format("2006-01-02") == b.Format("2006-01-02")
+}
+
+// mapCertificationToDTO maps a certification with its status as of today.
+// Status is not stored: a certificate is expired after its expiry date and
+// expiring within window days of it.
+func mapCertificationToDTO(certification model.Certification, today time.Time, window int) dto.Certification {
+	application := certification.CertificationApplication.Application
+	result := dto.Certification{
+		ID:                         certification.ID,
+		ApplicationID:              certification.CertificationApplication.ApplicationID,
+		CertificationApplicationID: certification.CertificationApplicationID,
+		ProgramID:                  application.ProgramID,
+		ProgramTitle:               application.Program.Title,
+		BusinessName:               application.UMKM.BusinessName,
+		OwnerName:                  application.UMKM.User.Name,
+		CertificateNumber:          certification.CertificateNumber,
+		IssuingBody:                certification.IssuingBody,
+		IssuedDate:                 certification.IssuedDate.Format("2006-01-02"),
+		Status:                     constant.CertificationStatusValid,
+		DocumentURL:                certification.DocumentURL,
+		VerificationCode:           certification.VerificationCode,
+		QRCode:                     certification.QRCode,
+	}
+
+	if certification.ExpiryDate != nil {
+		result.ExpiryDate = certification.ExpiryDate.Format("2006-01-02")
+		daysLeft := daysUntil(today, *certification.ExpiryDate)
+		result.DaysUntilExpiry = &daysLeft
+		switch {
+		case daysLeft < 0:
+			result.Status = constant.CertificationStatusExpired
+		case daysLeft <= window:
+			result.Status = constant.CertificationStatusExpiring
+		}
+	}
+	return result
+}
+
+// daysUntil counts calendar days from today to date, negative once it has passed.
+func daysUntil(today, date time.Time) int {
+	target := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, today.Location())
+	return int(target.Sub(startOfDay(today)).Hours() / 24)
+}
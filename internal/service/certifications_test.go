@@ -0,0 +1,454 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"UMKMGo-backend/internal/types/dto"
+	"UMKMGo-backend/internal/types/model"
+	"UMKMGo-backend/internal/utils/constant"
+)
+
+// Mock Certification Repository
+type mockCertificationRepo struct {
+	certifications map[int]model.Certification
+	// applications hydrates CertificationApplication by its ID
+	applications map[int]model.CertificationApplication
+}
+
+func newMockCertificationRepo() *mockCertificationRepo {
+	return &mockCertificationRepo{
+		certifications: make(map[int]model.Certification),
+		applications:   make(map[int]model.CertificationApplication),
+	}
+}
+
+func (m *mockCertificationRepo) hydrate(certification model.Certification) model.Certification {
+	certification.CertificationApplication = m.applications[certification.CertificationApplicationID]
+	return certification
+}
+
+func (m *mockCertificationRepo) find(match func(model.Certification) bool) (model.Certification, error) {
+	for _, certification := range m.certifications {
+		certification = m.hydrate(certification)
+		if match(certification) {
+			return certification, nil
+		}
+	}
+	return model.Certification{}, errors.New("certification not found")
+}
+
+func (m *mockCertificationRepo) GetCertifications(ctx context.Context, status string, today, expiringUntil time.Time) ([]model.Certification, error) {
+	var certifications []model.Certification
+	for _, certification := range m.certifications {
+		expiry := certification.ExpiryDate
+		switch status {
+		case "expired":
+			if expiry == nil || !expiry.Before(today) {
+				continue
+			}
+		case "expiring":
+			if expiry == nil || expiry.Before(today) || expiry.After(expiringUntil) {
+				continue
+			}
+		case "valid":
+			if expiry != nil && expiry.Before(today) {
+				continue
+			}
+		}
+		certifications = append(certifications, m.hydrate(certification))
+	}
+	return certifications, nil
+}
+
+func (m *mockCertificationRepo) GetCertificationByID(ctx context.Context, id int) (model.Certification, error) {
+	return m.find(func(c model.Certification) bool { return c.ID == id })
+}
+
+func (m *mockCertificationRepo) GetCertificationByApplicationID(ctx context.Context, applicationID int) (model.Certification, error) {
+	return m.find(func(c model.Certification) bool { return c.CertificationApplication.ApplicationID == applicationID })
+}
+
+func (m *mockCertificationRepo) GetCertificationByNumber(ctx context.Context, number string) (model.Certification, error) {
+	return m.find(func(c model.Certification) bool { return strings.EqualFold(c.CertificateNumber, number) })
+}
+
+func (m *mockCertificationRepo) GetCertificationByCode(ctx context.Context, code string) (model.Certification, error) {
+	return m.find(func(c model.Certification) bool { return c.VerificationCode == code })
+}
+
+func (m *mockCertificationRepo) GetCertificationsByUMKMID(ctx context.Context, umkmID int) ([]model.Certification, error) {
+	var certifications []model.Certification
+	for _, certification := range m.certifications {
+		certification = m.hydrate(certification)
+		if certification.CertificationApplication.Application.UMKMID == umkmID {
+			certifications = append(certifications, certification)
+		}
+	}
+	return certifications, nil
+}
+
+func (m *mockCertificationRepo) CreateCertification(ctx context.Context, certification model.Certification) (model.Certification, error) {
+	certification.ID = len(m.certifications) + 1
+	m.certifications[certification.ID] = certification
+	return certification, nil
+}
+
+func (m *mockCertificationRepo) UpdateCertification(ctx context.Context, certification model.Certification) (model.Certification, error) {
+	certification.CertificationApplication = model.CertificationApplication{}
+	m.certifications[certification.ID] = certification
+	return certification, nil
+}
+
+func (m *mockCertificationRepo) GetExpiringCertifications(ctx context.Context, from, until time.Time) ([]model.Certification, error) {
+	var certifications []model.Certification
+	for _, certification := range m.certifications {
+		if expiry := certification.ExpiryDate; expiry != nil && !expiry.Before(from) && !expiry.After(until) {
+			certifications = append(certifications, m.hydrate(certification))
+		}
+	}
+	return certifications, nil
+}
+
+func (m *mockCertificationRepo) UpdateRemindedDays(ctx context.Context, id, days int) error {
+	certification := m.certifications[id]
+	certification.RemindedDays = &days
+	m.certifications[id] = certification
+	return nil
+}
+
+// setupCertificationService builds a service with an approved certification
+// application (ID 20) under a halal certification program.
+func setupCertificationService() (*certificationService, *mockCertificationRepo, *mockApplicationsRepo, *mockNotificationRepo, *[]string) {
+	certificationRepo := newMockCertificationRepo()
+	applicationRepo := newMockApplicationsRepo()
+	notificationRepo := newMockNotificationRepo()
+
+	program := model.Program{ID: 5, Title: "Sertifikasi Halal", Type: "certification", Provider: "BPJPH"}
+	certificationApplication := model.CertificationApplication{ID: 7, ApplicationID: 20}
+	application := model.Application{
+		ID:                       20,
+		UMKMID:                   1,
+		ProgramID:                5,
+		Type:                     "certification",
+		Status:                   "approved",
+		Program:                  program,
+		UMKM:                     applicationRepo.umkms[1],
+		CertificationApplication: &certificationApplication,
+	}
+	applicationRepo.applications[20] = application
+
+	certificationApplication.Application = application
+	certificationRepo.applications[7] = certificationApplication
+
+	uploads := &[]string{}
+	service := &certificationService{
+		certificationRepo: certificationRepo,
+		applicationRepo:   applicationRepo,
+		notificationRepo:  notificationRepo,
+		verifyURL:         "https://umkmgo.id/verify/certifications",
+		reminderDays:      []int{90, 30, 7},
+		storeFile: func(ctx context.Context, prefix, base64Data string) (string, error) {
+			*uploads = append(*uploads, prefix)
+			return "http://minio/applications/" + prefix, nil
+		},
+	}
+	return service, certificationRepo, applicationRepo, notificationRepo, uploads
+}
+
+func dateOffset(days int) string {
+	return time.Now().AddDate(0, 0, days).Format("2006-01-02")
+}
+
+func TestRecordCertification(t *testing.T) {
+	ctx := context.Background()
+	request := dto.CertificationRequest{
+		CertificateNumber: "ID31110000123450725",
+		IssuedDate:        dateOffset(-10),
+		ExpiryDate:        dateOffset(4 * 365),
+		Document:          "JVBERi0xLjQK",
+	}
+
+	t.Run("Records an approved certification application", func(t *testing.T) {
+		service, repo, _, notificationRepo, uploads := setupCertificationService()
+
+		result, err := service.RecordCertification(ctx, 3, 20, request)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if result.IssuingBody != "BPJPH" || result.ApplicationID != 20 || result.Status != constant.CertificationStatusValid {
+			t.Errorf("Unexpected certification %+v", result)
+		}
+		if len(result.VerificationCode) != 12 || result.QRCode == "" || result.DocumentURL == "" {
+			t.Errorf("Expected verification code, QR code and document, got %+v", result)
+		}
+		if len(*uploads) != 2 {
+			t.Errorf("Expected document and QR code uploads, got %v", *uploads)
+		}
+		if repo.certifications[1].RecordedBy != 3 || repo.certifications[1].CertificationApplicationID != 7 {
+			t.Errorf("Unexpected stored certification %+v", repo.certifications[1])
+		}
+		if len(notificationRepo.notifications) != 1 || notificationRepo.notifications[0].Type != constant.NotificationCertificationRecorded {
+			t.Errorf("Expected a recorded notification, got %+v", notificationRepo.notifications)
+		}
+
+		if _, err := service.RecordCertification(ctx, 3, 20, request); err == nil {
+			t.Error("Expected error recording the application twice")
+		}
+	})
+
+	t.Run("Rejects invalid requests", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			modify  func(*dto.CertificationRequest)
+			prepare func(*mockApplicationsRepo)
+			want    string
+		}{
+			{"not approved", nil, func(r *mockApplicationsRepo) {
+				app := r.applications[20]
+				app.Status = "final"
+				r.applications[20] = app
+			}, "approved applications"},
+			{"no provider", nil, func(r *mockApplicationsRepo) {
+				app := r.applications[20]
+				app.Program.Provider = ""
+				r.applications[20] = app
+			}, "no provider"},
+			{"missing document", func(r *dto.CertificationRequest) { r.Document = "" }, nil, "document is required"},
+			{"missing number", func(r *dto.CertificationRequest) { r.CertificateNumber = " " }, nil, "certificate_number is required"},
+			{"issued in the future", func(r *dto.CertificationRequest) { r.IssuedDate = dateOffset(2) }, nil, "cannot be in the future"},
+			{"expiry before issue", func(r *dto.CertificationRequest) { r.ExpiryDate = dateOffset(-20) }, nil, "expiry_date must be after issued_date"},
+			{"bad date", func(r *dto.CertificationRequest) { r.IssuedDate = "10/12/2025" }, nil, "invalid issued_date format"},
+		}
+		for _, tt := range tests {
+			service, _, applicationRepo, _, _ := setupCertificationService()
+			req := request
+			if tt.modify != nil {
+				tt.modify(&req)
+			}
+			if tt.prepare != nil {
+				tt.prepare(applicationRepo)
+			}
+			_, err := service.RecordCertification(ctx, 3, 20, req)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("%s: expected error containing %q, got %v", tt.name, tt.want, err)
+			}
+		}
+	})
+
+	t.Run("Rejects other application types", func(t *testing.T) {
+		service, _, applicationRepo, _, _ := setupCertificationService()
+		applicationRepo.applications[21] = model.Application{ID: 21, Type: "training", Status: "approved"}
+
+		if _, err := service.RecordCertification(ctx, 3, 21, request); err == nil {
+			t.Error("Expected error for training application")
+		}
+	})
+
+	t.Run("Certificate numbers are unique", func(t *testing.T) {
+		service, repo, _, _, _ := setupCertificationService()
+		repo.certifications[1] = model.Certification{ID: 1, CertificationApplicationID: 99, CertificateNumber: "id31110000123450725"}
+
+		_, err := service.RecordCertification(ctx, 3, 20, request)
+		if err == nil || !strings.Contains(err.Error(), "already recorded") {
+			t.Errorf("Expected duplicate number error, got %v", err)
+		}
+	})
+}
+
+func TestUpdateCertification(t *testing.T) {
+	ctx := context.Background()
+	service, repo, _, _, uploads := setupCertificationService()
+	expiry := startOfDay(time.Now()).AddDate(0, 0, 20)
+	reminded := 30
+	repo.certifications[1] = model.Certification{
+		ID:                         1,
+		CertificationApplicationID: 7,
+		CertificateNumber:          "ID31110000123450725",
+		IssuingBody:                "BPJPH",
+		IssuedDate:                 startOfDay(time.Now()).AddDate(-4, 0, 0),
+		ExpiryDate:                 &expiry,
+		DocumentURL:                "http://minio/old.pdf",
+		VerificationCode:           "ABCDEFGH2345",
+		RemindedDays:               &reminded,
+	}
+
+	// Renewal keeps the document when none is sent and restarts reminders
+	result, err := service.UpdateCertification(ctx, 1, dto.CertificationRequest{
+		CertificateNumber: "ID31110000123450725",
+		IssuedDate:        dateOffset(-1),
+		ExpiryDate:        dateOffset(4 * 365),
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.DocumentURL != "http://minio/old.pdf" || len(*uploads) != 0 {
+		t.Errorf("Expected document to be kept, got %s", result.DocumentURL)
+	}
+	if repo.certifications[1].RemindedDays != nil {
+		t.Error("Expected reminders to restart after a new expiry date")
+	}
+	if result.VerificationCode != "ABCDEFGH2345" {
+		t.Errorf("Expected verification code to be kept, got %s", result.VerificationCode)
+	}
+}
+
+func TestVerifyCertification(t *testing.T) {
+	ctx := context.Background()
+	service, repo, _, _, _ := setupCertificationService()
+	expired := startOfDay(time.Now()).AddDate(0, 0, -1)
+	repo.certifications[1] = model.Certification{
+		ID:                         1,
+		CertificationApplicationID: 7,
+		CertificateNumber:          "ID31110000123450725",
+		IssuingBody:                "BPJPH",
+		IssuedDate:                 startOfDay(time.Now()).AddDate(-4, 0, 0),
+		VerificationCode:           "ABCDEFGH2345",
+	}
+
+	t.Run("By QR code", func(t *testing.T) {
+		result, err := service.VerifyCertification(ctx, "", "abcdefgh2345")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !result.Valid || result.BusinessName != "Test Business" || result.CertificationName != "Sertifikasi Halal" || result.ExpiryDate != "" {
+			t.Errorf("Unexpected verification %+v", result)
+		}
+	})
+
+	t.Run("By number, expired", func(t *testing.T) {
+		certification := repo.certifications[1]
+		certification.ExpiryDate = &expired
+		repo.certifications[1] = certification
+
+		result, err := service.VerifyCertification(ctx, " ID31110000123450725 ", "")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if result.Valid || result.Status != constant.CertificationStatusExpired {
+			t.Errorf("Expected expired certificate to be invalid, got %+v", result)
+		}
+	})
+
+	t.Run("Unknown or missing", func(t *testing.T) {
+		if _, err := service.VerifyCertification(ctx, "UNKNOWN", ""); err == nil {
+			t.Error("Expected error for unknown number")
+		}
+		if _, err := service.VerifyCertification(ctx, "", ""); err == nil {
+			t.Error("Expected error without number or code")
+		}
+	})
+}
+
+func TestGetCertificationsByStatus(t *testing.T) {
+	ctx := context.Background()
+	service, repo, _, _, _ := setupCertificationService()
+	today := startOfDay(time.Now())
+	for id, days := range map[int]int{1: -3, 2: 10, 3: 400} {
+		expiry := today.AddDate(0, 0, days)
+		repo.certifications[id] = model.Certification{ID: id, CertificationApplicationID: 7, IssuedDate: today.AddDate(-1, 0, 0), ExpiryDate: &expiry}
+	}
+
+	tests := map[string]int{"": 3, "valid": 2, "expiring": 1, "expired": 1}
+	for status, want := range tests {
+		result, err := service.GetCertifications(ctx, dto.CertificationQueryParams{Status: status})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(result) != want {
+			t.Errorf("Status %q: expected %d, got %d", status, want, len(result))
+		}
+		for _, certification := range result {
+			if status != "" && status != "valid" && certification.Status != status {
+				t.Errorf("Status %q: got certification with status %s", status, certification.Status)
+			}
+		}
+	}
+
+	if _, err := service.GetCertifications(ctx, dto.CertificationQueryParams{Status: "revoked"}); err == nil {
+		t.Error("Expected error for unknown status")
+	}
+}
+
+func TestSendExpiryReminders(t *testing.T) {
+	ctx := context.Background()
+	_, repo, _, notificationRepo, _ := setupCertificationService()
+	reminders := NewCertificationReminderService(repo, notificationRepo, []int{90, 30, 7})
+	today := startOfDay(time.Now())
+
+	setExpiry := func(days int) {
+		expiry := today.AddDate(0, 0, days)
+		certification := repo.certifications[1]
+		certification.ExpiryDate = &expiry
+		repo.certifications[1] = certification
+	}
+	repo.certifications[1] = model.Certification{ID: 1, CertificationApplicationID: 7, CertificateNumber: "ID31110000123450725", IssuingBody: "BPJPH"}
+
+	steps := []struct {
+		daysLeft     int
+		wantSent     int
+		wantReminded int
+	}{
+		{120, 0, 0},
+		{85, 1, 90},
+		{60, 0, 90},
+		{25, 1, 30},
+		{25, 0, 30},
+		{3, 1, 7},
+	}
+	for _, step := range steps {
+		setExpiry(step.daysLeft)
+		sent, err := reminders.SendExpiryReminders(ctx)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if sent != step.wantSent {
+			t.Errorf("%d days left: expected %d reminders, got %d", step.daysLeft, step.wantSent, sent)
+		}
+		if reminded := repo.certifications[1].RemindedDays; step.wantReminded > 0 && (reminded == nil || *reminded != step.wantReminded) {
+			t.Errorf("%d days left: expected reminded at %d, got %v", step.daysLeft, step.wantReminded, reminded)
+		}
+	}
+
+	if len(notificationRepo.notifications) != 3 {
+		t.Fatalf("Expected 3 notifications, got %d", len(notificationRepo.notifications))
+	}
+	last := notificationRepo.notifications[2]
+	if last.Type != constant.NotificationCertificationExpiring || last.UMKMID != 1 || !strings.Contains(last.Message, "3 hari lagi") {
+		t.Errorf("Unexpected reminder %+v", last)
+	}
+
+	// A certificate first seen close to expiry only gets the nearest reminder
+	repo.certifications[2] = model.Certification{ID: 2, CertificationApplicationID: 7}
+	expiry := today.AddDate(0, 0, 5)
+	certification := repo.certifications[2]
+	certification.ExpiryDate = &expiry
+	repo.certifications[2] = certification
+	if sent, _ := reminders.SendExpiryReminders(ctx); sent != 1 {
+		t.Errorf("Expected 1 reminder for the new certificate, got %d", sent)
+	}
+	if sent, _ := reminders.SendExpiryReminders(ctx); sent != 0 {
+		t.Errorf("Expected no repeated reminders, got %d", sent)
+	}
+}
+
+func TestGetMyCertifications(t *testing.T) {
+	ctx := context.Background()
+	mobile, _ := setupMobileServiceForTests()
+	_, repo, _, _, _ := setupCertificationService()
+	repo.certifications[1] = model.Certification{ID: 1, CertificationApplicationID: 7, CertificateNumber: "ID31110000123450725", IssuedDate: time.Now()}
+	repo.applications[8] = model.CertificationApplication{ID: 8, ApplicationID: 30, Application: model.Application{ID: 30, UMKMID: 2}}
+	repo.certifications[2] = model.Certification{ID: 2, CertificationApplicationID: 8, CertificateNumber: "OTHER", IssuedDate: time.Now()}
+	mobile.certificationRepo = repo
+
+	certifications, err := mobile.GetMyCertifications(ctx, 1)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(certifications) != 1 || certifications[0].CertificateNumber != "ID31110000123450725" || certifications[0].Status != constant.CertificationStatusValid {
+		t.Errorf("Expected only the UMKM's certification, got %+v", certifications)
+	}
+}
@@ -27,6 +27,7 @@ func newMockDashboardRepository() *mockDashboardRepository {
 			"in_process":         200,
 			"approved":           250,
 			"rejected":           50,
+			"overdue":            20,
 		},
 		applicationStatusDetail: map[string]int64{
 			"screening": 100,
@@ -140,8 +141,8 @@ func TestGetApplicationStatusSummary(t *testing.T) {
 			t.Errorf("Expected no error, got %v", err)
 		}
 
-		if len(result) != 4 {
-			t.Errorf("Expected 4 summary items, got %d", len(result))
+		if len(result) != 5 {
+			t.Errorf("Expected 5 summary items, got %d", len(result))
 		}
 
 		if result[0].TotalApplications != 500 {
@@ -159,6 +160,10 @@ func TestGetApplicationStatusSummary(t *testing.T) {
 		if result[3].Rejected != 50 {
 			t.Errorf("Expected rejected 50, got %d", result[3].Rejected)
 		}
+
+		if result[4].Overdue != 20 {
+			t.Errorf("Expected overdue 20, got %d", result[4].Overdue)
+		}
 	})
 
 	t.Run("Handle database error", func(t *testing.T) {
@@ -52,6 +52,7 @@ func (s *dashboardService) GetApplicationStatusSummary(ctx context.Context) ([]d
 		{InProcess: data["in_process"]},
 		{Approved: data["approved"]},
 		{Rejected: data["rejected"]},
+		{Overdue: data["overdue"]},
 	}
 
 	return response, nil
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"UMKMGo-backend/config/log"
+	"UMKMGo-backend/config/storage"
+	"UMKMGo-backend/internal/repository"
+)
+
+// documentPurgeBatchSize bounds how many files a single retention run removes.
+const documentPurgeBatchSize = 200
+
+type DocumentRetentionService interface {
+	PurgeSupersededDocuments(ctx context.Context) (int, error)
+}
+
+type documentRetentionService struct {
+	applicationRepo repository.ApplicationsRepository
+	minio           *storage.MinIOManager
+	retentionDays   int
+}
+
+func NewDocumentRetentionService(applicationRepo repository.ApplicationsRepository, minio *storage.MinIOManager, retentionDays int) DocumentRetentionService {
+	return &documentRetentionService{
+		applicationRepo: applicationRepo,
+		minio:           minio,
+		retentionDays:   retentionDays,
+	}
+}
+
+// PurgeSupersededDocuments removes the stored files of document versions replaced
+// longer ago than the retention period. The version rows stay as the record of
+// what was submitted; only files in the application bucket are deleted, since
+// documents may also link to files owned by the UMKM profile.
+func (s *documentRetentionService) PurgeSupersededDocuments(ctx context.Context) (int, error) {
+	cutoff := time.Now().AddDate(0, 0, -s.retentionDays)
+	documents, err := s.applicationRepo.GetPurgeableDocuments(ctx, cutoff, documentPurgeBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	purged := 0
+	for _, document := range documents {
+		bucket, objectName, err := storage.ParseMinioURL(document.File)
+		if err == nil && bucket == storage.ApplicationBucket {
+			if err := s.minio.DeleteFile(ctx, bucket, objectName); err != nil {
+				log.Log.Warnf("failed to delete superseded document %d from storage: %v", document.ID, err)
+				continue
+			}
+		}
+
+		if err := s.applicationRepo.MarkDocumentPurged(ctx, document.ID, time.Now()); err != nil {
+			return purged, err
+		}
+		purged++
+	}
+
+	return purged, nil
+}
@@ -0,0 +1,28 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"UMKMGo-backend/internal/repository"
+)
+
+type DraftExpiryService interface {
+	ExpireDrafts(ctx context.Context) (int64, error)
+}
+
+type draftExpiryService struct {
+	applicationRepo repository.ApplicationsRepository
+}
+
+func NewDraftExpiryService(applicationRepo repository.ApplicationsRepository) DraftExpiryService {
+	return &draftExpiryService{
+		applicationRepo: applicationRepo,
+	}
+}
+
+// ExpireDrafts discards drafts that were not saved within the idle period. Every
+// save pushes a draft's ExpiredAt forward, so only abandoned drafts are removed.
+func (s *draftExpiryService) ExpireDrafts(ctx context.Context) (int64, error) {
+	return s.applicationRepo.DeleteExpiredDrafts(ctx, time.Now())
+}
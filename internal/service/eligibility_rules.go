@@ -0,0 +1,288 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"UMKMGo-backend/internal/types/dto"
+	"UMKMGo-backend/internal/types/model"
+	"UMKMGo-backend/internal/utils/constant"
+)
+
+// IneligibleError is returned when an application is submitted by a UMKM that
+// does not meet the program's eligibility rules.
+type IneligibleError struct {
+	UnmetRules []dto.EligibilityCheck
+}
+
+func (e *IneligibleError) Error() string {
+	return fmt.Sprintf("you are not eligible for this program: %s", e.UnmetRules[0].Message)
+}
+
+// eligibilityDocuments maps the documents a rule may require to the UMKM profile
+// field holding them.
+var eligibilityDocuments = map[string]func(umkm model.UMKM) string{
+	constant.DocumentTypeNib:            func(umkm model.UMKM) string { return umkm.NIB },
+	constant.DocumentTypeNPWP:           func(umkm model.UMKM) string { return umkm.NPWP },
+	constant.DocumentTypeRevenueRecord:  func(umkm model.UMKM) string { return umkm.RevenueRecord },
+	constant.DocumentTypeBusinessPermit: func(umkm model.UMKM) string { return umkm.BusinessPermit },
+}
+
+// validateEligibilityRules checks the rules an admin sets on a program. Rules on
+// the application form only apply to program types whose form asks for them.
+func validateEligibilityRules(programType string, rules *dto.EligibilityRules) error {
+	if rules == nil {
+		return nil
+	}
+
+	for _, kartuType := range rules.KartuTypes {
+		if kartuType != "produktif" && kartuType != "afirmatif" {
+			return errors.New("eligibility kartu_types must be produktif or afirmatif")
+		}
+	}
+	for _, gender := range rules.Genders {
+		if gender != "male" && gender != "female" && gender != "other" {
+			return errors.New("eligibility genders must be male, female or other")
+		}
+	}
+	for _, document := range rules.RequiredDocuments {
+		if _, ok := eligibilityDocuments[document]; !ok {
+			return errors.New("eligibility required_documents must be nib, npwp, revenue_record or business_permit")
+		}
+	}
+	if rules.MinYearsOperating != nil {
+		if programType == "training" {
+			return errors.New("eligibility min_years_operating is only available for certification and funding programs")
+		}
+		if *rules.MinYearsOperating < 0 {
+			return errors.New("eligibility min_years_operating must not be negative")
+		}
+	}
+	if rules.MinMonthlyRevenue != nil || rules.MaxMonthlyRevenue != nil {
+		if programType != "funding" {
+			return errors.New("eligibility revenue band is only available for funding programs")
+		}
+		if rules.MinMonthlyRevenue != nil && rules.MaxMonthlyRevenue != nil && *rules.MinMonthlyRevenue > *rules.MaxMonthlyRevenue {
+			return errors.New("eligibility min_monthly_revenue cannot exceed max_monthly_revenue")
+		}
+	}
+	if rules.MinAge != nil && rules.MaxAge != nil && *rules.MinAge > *rules.MaxAge {
+		return errors.New("eligibility min_age cannot exceed max_age")
+	}
+	return nil
+}
+
+// encodeEligibilityRules stores the rules on the program, or clears them when none are set.
+func encodeEligibilityRules(rules *dto.EligibilityRules) (*string, error) {
+	if rules == nil {
+		return nil, nil
+	}
+	encoded, err := json.Marshal(rules)
+	if err != nil {
+		return nil, err
+	}
+	value := string(encoded)
+	return &value, nil
+}
+
+// programEligibilityRules decodes the rules stored on a program.
+func programEligibilityRules(program model.Program) *dto.EligibilityRules {
+	if program.EligibilityRules == nil {
+		return nil
+	}
+	var rules dto.EligibilityRules
+	if err := json.Unmarshal([]byte(*program.EligibilityRules), &rules); err != nil {
+		return nil
+	}
+	return &rules
+}
+
+// applicationEligibility decodes the eligibility recorded at submission.
+func applicationEligibility(application model.Application) *dto.EligibilityResult {
+	if application.Eligibility == nil {
+		return nil
+	}
+	var result dto.EligibilityResult
+	if err := json.Unmarshal([]byte(*application.Eligibility), &result); err != nil {
+		return nil
+	}
+	return &result
+}
+
+// evaluateEligibility runs the program's rules against the UMKM profile and, when
+// given, the application form. Without an application the form rules are
+// reported as pending; without a profile every profile rule is unmet. Every
+// program, with or without rules, requires an active kartu.
+func evaluateEligibility(program model.Program, umkm *model.UMKM, application *model.Application, now time.Time) dto.EligibilityResult {
+	result := dto.EligibilityResult{
+		Checks:      []dto.EligibilityCheck{},
+		UnmetRules:  []dto.EligibilityCheck{},
+		EvaluatedAt: now.Format("2006-01-02 15:04:05"),
+	}
+	rules := programEligibilityRules(program)
+	if rules == nil {
+		rules = &dto.EligibilityRules{}
+	}
+
+	check := func(rule string, met bool, message string) {
+		result.Checks = append(result.Checks, dto.EligibilityCheck{Rule: rule, Met: met, Message: message})
+	}
+	profileCheck := func(rule string, met func(umkm model.UMKM) bool, message string) {
+		check(rule, umkm != nil && met(*umkm), message)
+	}
+	formCheck := func(rule string, value *float64, met func(value float64) bool, message string) {
+		if application == nil {
+			result.Checks = append(result.Checks, dto.EligibilityCheck{Rule: rule, Pending: true, Message: message})
+			return
+		}
+		check(rule, value != nil && met(*value), message)
+	}
+
+	// Only listed when unmet, so programs without rules keep an empty check list
+	if umkm != nil {
+		if status := kartuStatus(*umkm, now); status != constant.KartuStatusActive {
+			check("kartu_status", false, fmt.Sprintf("kartu must be active, it is %s", status))
+		}
+	}
+	if len(rules.KartuTypes) > 0 {
+		profileCheck("kartu_types", func(umkm model.UMKM) bool {
+			return slices.Contains(rules.KartuTypes, umkm.KartuType)
+		}, fmt.Sprintf("kartu type must be %s", strings.Join(rules.KartuTypes, " or ")))
+	}
+	if len(rules.ProvinceIDs) > 0 {
+		profileCheck("province_ids", func(umkm model.UMKM) bool {
+			return slices.Contains(rules.ProvinceIDs, umkm.ProvinceID)
+		}, "business must be located in an eligible province")
+	}
+	if len(rules.CityIDs) > 0 {
+		profileCheck("city_ids", func(umkm model.UMKM) bool {
+			return slices.Contains(rules.CityIDs, umkm.CityID)
+		}, "business must be located in an eligible city")
+	}
+	if len(rules.Genders) > 0 {
+		profileCheck("genders", func(umkm model.UMKM) bool {
+			return slices.Contains(rules.Genders, umkm.Gender)
+		}, fmt.Sprintf("gender must be %s", strings.Join(rules.Genders, " or ")))
+	}
+	if rules.MinAge != nil || rules.MaxAge != nil {
+		profileCheck("age", func(umkm model.UMKM) bool {
+			if umkm.BirthDate.IsZero() {
+				return false
+			}
+			age := ageAt(umkm.BirthDate, now)
+			return (rules.MinAge == nil || age >= *rules.MinAge) && (rules.MaxAge == nil || age <= *rules.MaxAge)
+		}, ageRuleMessage(rules.MinAge, rules.MaxAge))
+	}
+	for _, document := range rules.RequiredDocuments {
+		field := eligibilityDocuments[document]
+		profileCheck("required_documents", func(umkm model.UMKM) bool {
+			return field != nil && field(umkm) != ""
+		}, fmt.Sprintf("%s document must be uploaded to the profile", document))
+	}
+	if rules.MinYearsOperating != nil {
+		var years *float64
+		if value := applicationYearsOperating(application); value != nil {
+			converted := float64(*value)
+			years = &converted
+		}
+		formCheck("min_years_operating", years, func(value float64) bool {
+			return value >= float64(*rules.MinYearsOperating)
+		}, fmt.Sprintf("business must have been operating for at least %d years", *rules.MinYearsOperating))
+	}
+	if rules.MinMonthlyRevenue != nil || rules.MaxMonthlyRevenue != nil {
+		var revenue *float64
+		if application != nil && application.FundingApplication != nil {
+			revenue = application.FundingApplication.MonthlyRevenue
+		}
+		formCheck("monthly_revenue", revenue, func(value float64) bool {
+			return (rules.MinMonthlyRevenue == nil || value >= *rules.MinMonthlyRevenue) &&
+				(rules.MaxMonthlyRevenue == nil || value <= *rules.MaxMonthlyRevenue)
+		}, revenueRuleMessage(rules.MinMonthlyRevenue, rules.MaxMonthlyRevenue))
+	}
+
+	for _, c := range result.Checks {
+		if !c.Met && !c.Pending {
+			result.UnmetRules = append(result.UnmetRules, c)
+		}
+	}
+	result.Eligible = len(result.UnmetRules) == 0
+	return result
+}
+
+// checkEligibility evaluates the rules for a submission and returns the result to
+// record on the application, or IneligibleError when a rule is not met.
+func checkEligibility(program model.Program, umkm model.UMKM, application model.Application) (*string, error) {
+	result := evaluateEligibility(program, &umkm, &application, time.Now())
+	if !result.Eligible {
+		return nil, &IneligibleError{UnmetRules: result.UnmetRules}
+	}
+	return encodeEligibilityResult(result)
+}
+
+// encodeEligibilityResult encodes an evaluation for storage on the application.
+func encodeEligibilityResult(result dto.EligibilityResult) (*string, error) {
+	encoded, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+	value := string(encoded)
+	return &value, nil
+}
+
+// eligibilityFor evaluates a program's rules for the calling UMKM before it applies.
+func (s *mobileService) eligibilityFor(ctx context.Context, userID int, program model.Program) *dto.EligibilityResult {
+	var umkm *model.UMKM
+	if profile, err := s.mobileRepo.GetUMKMProfileByID(ctx, userID); err == nil {
+		umkm = &profile
+	}
+	result := evaluateEligibility(program, umkm, nil, time.Now())
+	return &result
+}
+
+func applicationYearsOperating(application *model.Application) *int {
+	switch {
+	case application == nil:
+		return nil
+	case application.CertificationApplication != nil:
+		return application.CertificationApplication.YearsOperating
+	case application.FundingApplication != nil:
+		return application.FundingApplication.YearsOperating
+	}
+	return nil
+}
+
+// ageAt returns the age in whole years on the given day.
+func ageAt(birthDate, now time.Time) int {
+	age := now.Year() - birthDate.Year()
+	if now.Month() < birthDate.Month() || (now.Month() == birthDate.Month() && now.Day() < birthDate.Day()) {
+		age--
+	}
+	return age
+}
+
+func ageRuleMessage(minAge, maxAge *int) string {
+	switch {
+	case minAge != nil && maxAge != nil:
+		return fmt.Sprintf("applicant must be between %d and %d years old", *minAge, *maxAge)
+	case minAge != nil:
+		return fmt.Sprintf("applicant must be at least %d years old", *minAge)
+	default:
+		return fmt.Sprintf("applicant must be at most %d years old", *maxAge)
+	}
+}
+
+func revenueRuleMessage(minRevenue, maxRevenue *float64) string {
+	switch {
+	case minRevenue != nil && maxRevenue != nil:
+		return fmt.Sprintf("monthly revenue must be between %.2f and %.2f", *minRevenue, *maxRevenue)
+	case minRevenue != nil:
+		return fmt.Sprintf("monthly revenue must be at least %.2f", *minRevenue)
+	default:
+		return fmt.Sprintf("monthly revenue must be at most %.2f", *maxRevenue)
+	}
+}
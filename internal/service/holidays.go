@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"UMKMGo-backend/internal/repository"
+	"UMKMGo-backend/internal/types/dto"
+	"UMKMGo-backend/internal/types/model"
+)
+
+type HolidayService interface {
+	GetHolidays(ctx context.Context, year int) ([]dto.Holiday, error)
+	GetHolidayByID(ctx context.Context, id int) (dto.Holiday, error)
+	CreateHoliday(ctx context.Context, request dto.Holiday) (dto.Holiday, error)
+	UpdateHoliday(ctx context.Context, id int, request dto.Holiday) (dto.Holiday, error)
+	DeleteHoliday(ctx context.Context, id int) error
+}
+
+type holidayService struct {
+	holidayRepository repository.HolidayRepository
+}
+
+func NewHolidayService(holidayRepo repository.HolidayRepository) HolidayService {
+	return &holidayService{
+		holidayRepository: holidayRepo,
+	}
+}
+
+func (s *holidayService) GetHolidays(ctx context.Context, year int) ([]dto.Holiday, error) {
+	holidays, err := s.holidayRepository.GetHolidays(ctx, year)
+	if err != nil {
+		return nil, err
+	}
+
+	holidaysDTO := make([]dto.Holiday, 0, len(holidays))
+	for _, holiday := range holidays {
+		holidaysDTO = append(holidaysDTO, toHolidayDTO(holiday))
+	}
+
+	return holidaysDTO, nil
+}
+
+func (s *holidayService) GetHolidayByID(ctx context.Context, id int) (dto.Holiday, error) {
+	holiday, err := s.holidayRepository.GetHolidayByID(ctx, id)
+	if err != nil {
+		return dto.Holiday{}, err
+	}
+
+	return toHolidayDTO(holiday), nil
+}
+
+func (s *holidayService) CreateHoliday(ctx context.Context, request dto.Holiday) (dto.Holiday, error) {
+	date, err := validateHolidayRequest(request)
+	if err != nil {
+		return dto.Holiday{}, err
+	}
+
+	if s.holidayRepository.IsHolidayDateExists(ctx, date, 0) {
+		return dto.Holiday{}, errors.New("holiday already exists for this date")
+	}
+
+	holiday, err := s.holidayRepository.CreateHoliday(ctx, model.Holiday{
+		Date:              date,
+		Name:              strings.TrimSpace(request.Name),
+		IsCollectiveLeave: request.IsCollectiveLeave,
+		Description:       request.Description,
+	})
+	if err != nil {
+		return dto.Holiday{}, err
+	}
+
+	return toHolidayDTO(holiday), nil
+}
+
+func (s *holidayService) UpdateHoliday(ctx context.Context, id int, request dto.Holiday) (dto.Holiday, error) {
+	date, err := validateHolidayRequest(request)
+	if err != nil {
+		return dto.Holiday{}, err
+	}
+
+	existingHoliday, err := s.holidayRepository.GetHolidayByID(ctx, id)
+	if err != nil {
+		return dto.Holiday{}, err
+	}
+
+	if s.holidayRepository.IsHolidayDateExists(ctx, date, id) {
+		return dto.Holiday{}, errors.New("holiday already exists for this date")
+	}
+
+	existingHoliday.Date = date
+	existingHoliday.Name = strings.TrimSpace(request.Name)
+	existingHoliday.IsCollectiveLeave = request.IsCollectiveLeave
+	existingHoliday.Description = request.Description
+
+	updatedHoliday, err := s.holidayRepository.UpdateHoliday(ctx, existingHoliday)
+	if err != nil {
+		return dto.Holiday{}, err
+	}
+
+	return toHolidayDTO(updatedHoliday), nil
+}
+
+func (s *holidayService) DeleteHoliday(ctx context.Context, id int) error {
+	holiday, err := s.holidayRepository.GetHolidayByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return s.holidayRepository.DeleteHoliday(ctx, holiday)
+}
+
+func validateHolidayRequest(request dto.Holiday) (time.Time, error) {
+	if strings.TrimSpace(request.Name) == "" {
+		return time.Time{}, errors.New("name is required")
+	}
+
+	date, err := time.ParseInLocation("2006-01-02", request.Date, time.Local)
+	if err != nil {
+		return time.Time{}, errors.New("date must be in YYYY-MM-DD format")
+	}
+
+	return date, nil
+}
+
+func toHolidayDTO(holiday model.Holiday) dto.Holiday {
+	return dto.Holiday{
+		ID:                holiday.ID,
+		Date:              holiday.Date.Format("2006-01-02"),
+		Name:              holiday.Name,
+		IsCollectiveLeave: holiday.IsCollectiveLeave,
+		Description:       holiday.Description,
+		CreatedAt:         holiday.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt:         holiday.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
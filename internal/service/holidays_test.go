@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"UMKMGo-backend/internal/types/dto"
+	"UMKMGo-backend/internal/types/model"
+)
+
+// ==================== MOCK HOLIDAY REPOSITORY ====================
+
+type mockHolidayRepo struct {
+	holidays map[int]model.Holiday
+	nextID   int
+}
+
+func newMockHolidayRepo() *mockHolidayRepo {
+	return &mockHolidayRepo{
+		holidays: make(map[int]model.Holiday),
+		nextID:   1,
+	}
+}
+
+func (m *mockHolidayRepo) addHoliday(date, name string) {
+	parsed, _ := time.ParseInLocation("2006-01-02", date, time.Local)
+	m.holidays[m.nextID] = model.Holiday{ID: m.nextID, Date: parsed, Name: name}
+	m.nextID++
+}
+
+func (m *mockHolidayRepo) GetHolidays(ctx context.Context, year int) ([]model.Holiday, error) {
+	var result []model.Holiday
+	for _, holiday := range m.holidays {
+		if year == 0 || holiday.Date.Year() == year {
+			result = append(result, holiday)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockHolidayRepo) GetHolidayByID(ctx context.Context, id int) (model.Holiday, error) {
+	if holiday, exists := m.holidays[id]; exists {
+		return holiday, nil
+	}
+	return model.Holiday{}, errors.New("holiday not found")
+}
+
+func (m *mockHolidayRepo) GetHolidaysBetween(ctx context.Context, from, to time.Time) ([]model.Holiday, error) {
+	var result []model.Holiday
+	for _, holiday := range m.holidays {
+		date := holiday.Date.Format("2006-01-02")
+		if date >= from.Format("2006-01-02") && date <= to.Format("2006-01-02") {
+			result = append(result, holiday)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockHolidayRepo) CreateHoliday(ctx context.Context, holiday model.Holiday) (model.Holiday, error) {
+	holiday.ID = m.nextID
+	m.nextID++
+	m.holidays[holiday.ID] = holiday
+	return holiday, nil
+}
+
+func (m *mockHolidayRepo) UpdateHoliday(ctx context.Context, holiday model.Holiday) (model.Holiday, error) {
+	m.holidays[holiday.ID] = holiday
+	return holiday, nil
+}
+
+func (m *mockHolidayRepo) DeleteHoliday(ctx context.Context, holiday model.Holiday) error {
+	delete(m.holidays, holiday.ID)
+	return nil
+}
+
+func (m *mockHolidayRepo) IsHolidayDateExists(ctx context.Context, date time.Time, excludeID int) bool {
+	for _, holiday := range m.holidays {
+		if holiday.ID != excludeID && holiday.Date.Format("2006-01-02") == date.Format("2006-01-02") {
+			return true
+		}
+	}
+	return false
+}
+
+// ==================== TEST FUNCTIONS ====================
+
+func setupHolidayService() (*holidayService, *mockHolidayRepo) {
+	mockRepo := newMockHolidayRepo()
+	service := &holidayService{
+		holidayRepository: mockRepo,
+	}
+	return service, mockRepo
+}
+
+func TestCreateHoliday(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Create holiday successfully", func(t *testing.T) {
+		service, _ := setupHolidayService()
+
+		result, err := service.CreateHoliday(ctx, dto.Holiday{
+			Date:              "2026-03-20",
+			Name:              "Hari Raya Idul Fitri",
+			IsCollectiveLeave: false,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if result.ID == 0 || result.Date != "2026-03-20" {
+			t.Errorf("Unexpected holiday %+v", result)
+		}
+	})
+
+	t.Run("Reject duplicate date", func(t *testing.T) {
+		service, mockRepo := setupHolidayService()
+		mockRepo.addHoliday("2026-08-17", "Hari Kemerdekaan")
+
+		_, err := service.CreateHoliday(ctx, dto.Holiday{Date: "2026-08-17", Name: "Duplicate"})
+		if err == nil || err.Error() != "holiday already exists for this date" {
+			t.Errorf("Expected duplicate date error, got %v", err)
+		}
+	})
+
+	t.Run("Reject invalid date", func(t *testing.T) {
+		service, _ := setupHolidayService()
+
+		_, err := service.CreateHoliday(ctx, dto.Holiday{Date: "17-08-2026", Name: "Hari Kemerdekaan"})
+		if err == nil || err.Error() != "date must be in YYYY-MM-DD format" {
+			t.Errorf("Expected date format error, got %v", err)
+		}
+	})
+
+	t.Run("Reject empty name", func(t *testing.T) {
+		service, _ := setupHolidayService()
+
+		_, err := service.CreateHoliday(ctx, dto.Holiday{Date: "2026-08-17", Name: " "})
+		if err == nil || err.Error() != "name is required" {
+			t.Errorf("Expected name required error, got %v", err)
+		}
+	})
+}
+
+func TestUpdateHoliday(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Update holiday keeping its own date", func(t *testing.T) {
+		service, mockRepo := setupHolidayService()
+		mockRepo.addHoliday("2026-03-21", "Cuti Bersama")
+
+		result, err := service.UpdateHoliday(ctx, 1, dto.Holiday{Date: "2026-03-21", Name: "Cuti Bersama Idul Fitri", IsCollectiveLeave: true})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !result.IsCollectiveLeave || result.Name != "Cuti Bersama Idul Fitri" {
+			t.Errorf("Unexpected holiday %+v", result)
+		}
+	})
+
+	t.Run("Holiday not found", func(t *testing.T) {
+		service, _ := setupHolidayService()
+
+		_, err := service.UpdateHoliday(ctx, 99, dto.Holiday{Date: "2026-03-21", Name: "Cuti Bersama"})
+		if err == nil {
+			t.Error("Expected error for missing holiday")
+		}
+	})
+}
+
+func TestDeleteHoliday(t *testing.T) {
+	ctx := context.Background()
+	service, mockRepo := setupHolidayService()
+	mockRepo.addHoliday("2026-12-25", "Hari Raya Natal")
+
+	if err := service.DeleteHoliday(ctx, 1); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(mockRepo.holidays) != 0 {
+		t.Error("Expected holiday to be deleted")
+	}
+}
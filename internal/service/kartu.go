@@ -0,0 +1,608 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"UMKMGo-backend/config/log"
+	"UMKMGo-backend/config/storage"
+	"UMKMGo-backend/config/vault"
+	"UMKMGo-backend/internal/repository"
+	"UMKMGo-backend/internal/types/dto"
+	"UMKMGo-backend/internal/types/model"
+	"UMKMGo-backend/internal/utils"
+	"UMKMGo-backend/internal/utils/constant"
+)
+
+const (
+	// legacyQRBatchSize bounds how many QR codes a single migration run regenerates.
+	legacyQRBatchSize = 100
+	// kartuExpiryBatchSize bounds how many kartu a single expiry run marks expired.
+	kartuExpiryBatchSize = 200
+	// kartuRenewalWindowDays is how long before expiry a UMKM may request renewal.
+	kartuRenewalWindowDays = 90
+)
+
+type KartuService interface {
+	// QR codes
+	VerifyKartu(ctx context.Context, qrData string) (dto.KartuVerification, error)
+	RotateQRCode(ctx context.Context, umkmID int) (dto.KartuQRCode, error)
+	RegenerateLegacyQRCodes(ctx context.Context) (int, error)
+
+	// Lifecycle
+	GetKartu(ctx context.Context, umkmID int) (dto.Kartu, error)
+	SuspendKartu(ctx context.Context, userID, umkmID int, request dto.KartuActionRequest) (dto.Kartu, error)
+	ReactivateKartu(ctx context.Context, userID, umkmID int, request dto.KartuActionRequest) (dto.Kartu, error)
+	UpgradeKartu(ctx context.Context, userID, umkmID int, request dto.KartuActionRequest) (dto.Kartu, error)
+	ExpireKartus(ctx context.Context) (int, error)
+
+	// Renewals
+	RequestRenewal(ctx context.Context, umkmID int, request dto.KartuRenewalRequest) (dto.KartuRenewal, error)
+	GetRenewals(ctx context.Context, params dto.KartuRenewalQueryParams) ([]dto.KartuRenewal, error)
+	ApproveRenewal(ctx context.Context, userID, renewalID int, request dto.KartuActionRequest) (dto.KartuRenewal, error)
+	RejectRenewal(ctx context.Context, userID, renewalID int, request dto.KartuActionRequest) (dto.KartuRenewal, error)
+}
+
+type kartuService struct {
+	kartuRepo        repository.KartuRepository
+	notificationRepo repository.NotificationRepository
+	verifyURL        string
+	validityMonths   int
+	// storeQRCode uploads a base64 QR image and returns its URL
+	storeQRCode func(ctx context.Context, prefix, base64Data string) (string, error)
+	// deleteQRCode removes a replaced QR image
+	deleteQRCode func(ctx context.Context, url string) error
+	// maskKartu decrypts the kartu number, audited, and returns it masked
+	maskKartu func(ctx context.Context, umkm model.UMKM) (string, error)
+}
+
+func NewKartuService(kartuRepo repository.KartuRepository, notificationRepo repository.NotificationRepository, vaultLogRepo repository.VaultDecryptLogRepository, minio *storage.MinIOManager, verifyURL string, validityMonths int) KartuService {
+	if validityMonths <= 0 {
+		validityMonths = 60
+	}
+	return &kartuService{
+		kartuRepo:        kartuRepo,
+		notificationRepo: notificationRepo,
+		verifyURL:        strings.TrimRight(verifyURL, "/"),
+		validityMonths:   validityMonths,
+		storeQRCode: func(ctx context.Context, prefix, base64Data string) (string, error) {
+			res, err := minio.UploadFile(ctx, storage.UploadRequest{
+				Base64Data: base64Data,
+				BucketName: storage.UMKMBucket,
+				Prefix:     prefix,
+				Validation: storage.CreateImageValidationConfig(),
+			})
+			if err != nil {
+				return "", err
+			}
+			return res.URL, nil
+		},
+		deleteQRCode: func(ctx context.Context, url string) error {
+			bucket, objectName, err := storage.ParseMinioURL(url)
+			if err != nil || bucket != storage.UMKMBucket {
+				return nil
+			}
+			return minio.DeleteFile(ctx, bucket, objectName)
+		},
+		maskKartu: func(ctx context.Context, umkm model.UMKM) (string, error) {
+			ipAddress, userAgent, requestID := vault.GetContextInfo(ctx)
+			return vault.DecryptKartuNumberWithLog(ctx, umkm.KartuNumber, vault.DecryptParams{
+				UserID:    umkm.UserID,
+				UMKMID:    &umkm.ID,
+				RecordID:  umkm.ID,
+				Purpose:   "kartu_verification",
+				IPAddress: ipAddress,
+				UserAgent: userAgent,
+				RequestID: requestID,
+			}, vaultLogRepo)
+		},
+	}
+}
+
+// VerifyKartu resolves a scanned kartu QR code to a masked view of the card.
+// Suspended and expired kartu still resolve, but are not valid.
+func (s *kartuService) VerifyKartu(ctx context.Context, qrData string) (dto.KartuVerification, error) {
+	umkm, err := resolveKartuQR(ctx, s.kartuRepo, qrData)
+	if err != nil {
+		return dto.KartuVerification{}, err
+	}
+
+	maskedKartu, err := s.maskKartu(ctx, umkm)
+	if err != nil {
+		return dto.KartuVerification{}, errors.New("failed to decrypt Kartu Number")
+	}
+
+	status := kartuStatus(umkm, time.Now())
+	return dto.KartuVerification{
+		Valid:        status == constant.KartuStatusActive,
+		Status:       status,
+		BusinessName: umkm.BusinessName,
+		KartuType:    umkm.KartuType,
+		KartuNumber:  maskedKartu,
+		City:         umkm.City.Name,
+		Province:     umkm.Province.Name,
+	}, nil
+}
+
+// RotateQRCode issues a QR code with a new token version, which revokes every
+// token printed before it, and replaces the stored QR image.
+func (s *kartuService) RotateQRCode(ctx context.Context, umkmID int) (dto.KartuQRCode, error) {
+	umkm, err := s.kartuRepo.GetUMKMByID(ctx, umkmID)
+	if err != nil {
+		return dto.KartuQRCode{}, err
+	}
+
+	version := umkm.QRTokenVersion + 1
+	qrCode, err := utils.GenerateQRCode(s.verificationLink(utils.GenerateKartuToken(umkm.ID, version)), 256)
+	if err != nil {
+		return dto.KartuQRCode{}, err
+	}
+	url, err := s.storeQRCode(ctx, utils.GenerateFileName(umkm.User.Name, "qrcode_"), qrCode)
+	if err != nil {
+		return dto.KartuQRCode{}, fmt.Errorf("failed to upload QR code: %w", err)
+	}
+
+	if err := s.kartuRepo.UpdateQRCode(ctx, umkm.ID, umkm.QRTokenVersion, version, url); err != nil {
+		return dto.KartuQRCode{}, err
+	}
+
+	// The old image may still hold the plaintext kartu number
+	if umkm.QRCode != "" {
+		if err := s.deleteQRCode(ctx, umkm.QRCode); err != nil {
+			log.Log.Warnf("failed to delete replaced QR code of UMKM ID %d: %v", umkm.ID, err)
+		}
+	}
+
+	return dto.KartuQRCode{
+		UMKMID:  umkm.ID,
+		Version: version,
+		QRCode:  url,
+	}, nil
+}
+
+// RegenerateLegacyQRCodes replaces QR codes that still hold the plaintext kartu
+// number, and those never issued because the upload failed at registration.
+func (s *kartuService) RegenerateLegacyQRCodes(ctx context.Context) (int, error) {
+	umkms, err := s.kartuRepo.GetLegacyQRUMKMs(ctx, legacyQRBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	regenerated := 0
+	for _, umkm := range umkms {
+		if _, err := s.RotateQRCode(ctx, umkm.ID); err != nil {
+			log.Log.Errorf("failed to regenerate QR code of UMKM ID %d: %v", umkm.ID, err)
+			continue
+		}
+		regenerated++
+	}
+	return regenerated, nil
+}
+
+func (s *kartuService) verificationLink(token string) string {
+	if s.verifyURL == "" {
+		return token
+	}
+	return s.verifyURL + "/" + token
+}
+
+// resolveKartuQR returns the UMKM a kartu QR code was issued to. The QR code
+// holds either the verification link or the bare token; tokens of a rotated
+// QR code no longer resolve.
+func resolveKartuQR(ctx context.Context, kartuRepo repository.KartuRepository, qrData string) (model.UMKM, error) {
+	token := strings.TrimSpace(qrData)
+	if i := strings.LastIndex(token, "/"); i >= 0 {
+		token = token[i+1:]
+	}
+	if token == "" {
+		return model.UMKM{}, errors.New("kartu QR code is required")
+	}
+
+	umkmID, version, err := utils.VerifyKartuToken(token)
+	if err != nil {
+		return model.UMKM{}, err
+	}
+	umkm, err := kartuRepo.GetUMKMByID(ctx, umkmID)
+	if err != nil {
+		return model.UMKM{}, errors.New("invalid kartu QR code")
+	}
+	if version != umkm.QRTokenVersion {
+		return model.UMKM{}, errors.New("kartu QR code has been revoked")
+	}
+	return umkm, nil
+}
+
+func (s *kartuService) GetKartu(ctx context.Context, umkmID int) (dto.Kartu, error) {
+	umkm, err := s.kartuRepo.GetUMKMByID(ctx, umkmID)
+	if err != nil {
+		return dto.Kartu{}, err
+	}
+	return s.mapKartu(ctx, umkm)
+}
+
+// SuspendKartu blocks an active kartu, e.g. while misuse is investigated. A
+// suspended kartu fails every program's eligibility and cannot be renewed.
+func (s *kartuService) SuspendKartu(ctx context.Context, userID, umkmID int, request dto.KartuActionRequest) (dto.Kartu, error) {
+	notes := strings.TrimSpace(request.Notes)
+	if notes == "" {
+		return dto.Kartu{}, errors.New("notes is required to suspend a kartu")
+	}
+
+	umkm, err := s.kartuRepo.GetUMKMByID(ctx, umkmID)
+	if err != nil {
+		return dto.Kartu{}, err
+	}
+	if kartuStatus(umkm, time.Now()) != constant.KartuStatusActive {
+		return dto.Kartu{}, errors.New("only an active kartu can be suspended")
+	}
+
+	updated := umkm
+	updated.KartuStatus = constant.KartuStatusSuspended
+	if err := s.saveChange(ctx, &userID, constant.KartuActionSuspended, umkm, updated, notes, nil); err != nil {
+		return dto.Kartu{}, err
+	}
+
+	s.notify(ctx, updated, constant.NotificationKartuSuspended, constant.NotificationTitleKartuSuspended,
+		fmt.Sprintf(constant.NotificationMessageKartuSuspended, kartuTypeName(updated.KartuType), notes))
+	return s.mapKartu(ctx, updated)
+}
+
+// ReactivateKartu lifts a suspension. A kartu that ran past its expiry date
+// while suspended comes back expired, ready to be renewed.
+func (s *kartuService) ReactivateKartu(ctx context.Context, userID, umkmID int, request dto.KartuActionRequest) (dto.Kartu, error) {
+	umkm, err := s.kartuRepo.GetUMKMByID(ctx, umkmID)
+	if err != nil {
+		return dto.Kartu{}, err
+	}
+	if umkm.KartuStatus != constant.KartuStatusSuspended {
+		return dto.Kartu{}, errors.New("only a suspended kartu can be reactivated")
+	}
+
+	// Lifted as if never suspended; an expired kartu comes back expired
+	updated := umkm
+	updated.KartuStatus = kartuStatus(model.UMKM{KartuStatus: constant.KartuStatusActive, KartuExpiryDate: umkm.KartuExpiryDate}, time.Now())
+	if err := s.saveChange(ctx, &userID, constant.KartuActionReactivated, umkm, updated, strings.TrimSpace(request.Notes), nil); err != nil {
+		return dto.Kartu{}, err
+	}
+
+	if updated.KartuStatus == constant.KartuStatusExpired {
+		s.notify(ctx, updated, constant.NotificationKartuExpired, constant.NotificationTitleKartuExpired,
+			fmt.Sprintf(constant.NotificationMessageKartuExpired, kartuTypeName(updated.KartuType), updated.KartuExpiryDate.Format("02-01-2006")))
+	} else {
+		s.notify(ctx, updated, constant.NotificationKartuReactivated, constant.NotificationTitleKartuReactivated,
+			fmt.Sprintf(constant.NotificationMessageKartuReactivated, kartuTypeName(updated.KartuType)))
+	}
+	return s.mapKartu(ctx, updated)
+}
+
+// UpgradeKartu moves an active Kartu Afirmatif to Kartu Produktif. The expiry
+// date is kept.
+func (s *kartuService) UpgradeKartu(ctx context.Context, userID, umkmID int, request dto.KartuActionRequest) (dto.Kartu, error) {
+	umkm, err := s.kartuRepo.GetUMKMByID(ctx, umkmID)
+	if err != nil {
+		return dto.Kartu{}, err
+	}
+	if umkm.KartuType != constant.KartuTypeAfirmatif {
+		return dto.Kartu{}, errors.New("only a Kartu Afirmatif can be upgraded to Kartu Produktif")
+	}
+	if kartuStatus(umkm, time.Now()) != constant.KartuStatusActive {
+		return dto.Kartu{}, errors.New("only an active kartu can be upgraded")
+	}
+
+	updated := umkm
+	updated.KartuType = constant.KartuTypeProduktif
+	if err := s.saveChange(ctx, &userID, constant.KartuActionUpgraded, umkm, updated, strings.TrimSpace(request.Notes), nil); err != nil {
+		return dto.Kartu{}, err
+	}
+
+	s.notify(ctx, updated, constant.NotificationKartuUpgraded, constant.NotificationTitleKartuUpgraded,
+		fmt.Sprintf(constant.NotificationMessageKartuUpgraded, kartuTypeName(umkm.KartuType), kartuTypeName(updated.KartuType)))
+	return s.mapKartu(ctx, updated)
+}
+
+// ExpireKartus records the expiry of active kartu past their expiry date and
+// asks their UMKMs to renew. Eligibility already treats them as expired; this
+// keeps the stored status and dashboards in line.
+func (s *kartuService) ExpireKartus(ctx context.Context) (int, error) {
+	umkms, err := s.kartuRepo.GetExpiredKartus(ctx, startOfDay(time.Now()), kartuExpiryBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	for _, umkm := range umkms {
+		updated := umkm
+		updated.KartuStatus = constant.KartuStatusExpired
+		err := s.saveChange(ctx, nil, constant.KartuActionExpired, umkm, updated, "", nil)
+		if errors.Is(err, repository.ErrKartuConflict) {
+			// Renewed or suspended since it was loaded; leave it to that change
+			continue
+		}
+		if err != nil {
+			return expired, err
+		}
+
+		s.notify(ctx, updated, constant.NotificationKartuExpired, constant.NotificationTitleKartuExpired,
+			fmt.Sprintf(constant.NotificationMessageKartuExpired, kartuTypeName(updated.KartuType), updated.KartuExpiryDate.Format("02-01-2006")))
+		expired++
+	}
+	return expired, nil
+}
+
+// RequestRenewal lets a UMKM ask for its kartu to be extended, from
+// kartuRenewalWindowDays before expiry onwards.
+func (s *kartuService) RequestRenewal(ctx context.Context, umkmID int, request dto.KartuRenewalRequest) (dto.KartuRenewal, error) {
+	umkm, err := s.kartuRepo.GetUMKMByID(ctx, umkmID)
+	if err != nil {
+		return dto.KartuRenewal{}, err
+	}
+	if _, err := s.kartuRepo.GetPendingRenewal(ctx, umkm.ID); err == nil {
+		return dto.KartuRenewal{}, errors.New("a kartu renewal request is already pending")
+	}
+	if umkm.KartuStatus == constant.KartuStatusSuspended {
+		return dto.KartuRenewal{}, errors.New("a suspended kartu cannot be renewed")
+	}
+	if !canRenewKartu(umkm, time.Now()) {
+		return dto.KartuRenewal{}, fmt.Errorf("kartu can be renewed from %d days before it expires", kartuRenewalWindowDays)
+	}
+
+	renewal, err := s.kartuRepo.CreateRenewal(ctx, model.KartuRenewal{
+		UMKMID: umkm.ID,
+		Status: constant.KartuRenewalStatusPending,
+		Notes:  strings.TrimSpace(request.Notes),
+	})
+	if err != nil {
+		return dto.KartuRenewal{}, err
+	}
+
+	renewal.UMKM = umkm
+	return mapKartuRenewal(renewal), nil
+}
+
+func (s *kartuService) GetRenewals(ctx context.Context, params dto.KartuRenewalQueryParams) ([]dto.KartuRenewal, error) {
+	switch params.Status {
+	case "", constant.KartuRenewalStatusPending, constant.KartuRenewalStatusApproved, constant.KartuRenewalStatusRejected:
+	default:
+		return nil, errors.New("invalid status, must be pending, approved or rejected")
+	}
+
+	renewals, err := s.kartuRepo.GetRenewals(ctx, params.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]dto.KartuRenewal, 0, len(renewals))
+	for _, renewal := range renewals {
+		result = append(result, mapKartuRenewal(renewal))
+	}
+	return result, nil
+}
+
+// ApproveRenewal extends the kartu by the validity period, counted from its
+// current expiry date or, once expired, from today.
+func (s *kartuService) ApproveRenewal(ctx context.Context, userID, renewalID int, request dto.KartuActionRequest) (dto.KartuRenewal, error) {
+	renewal, err := s.pendingRenewal(ctx, renewalID)
+	if err != nil {
+		return dto.KartuRenewal{}, err
+	}
+
+	umkm, err := s.kartuRepo.GetUMKMByID(ctx, renewal.UMKMID)
+	if err != nil {
+		return dto.KartuRenewal{}, err
+	}
+	if umkm.KartuStatus == constant.KartuStatusSuspended {
+		return dto.KartuRenewal{}, errors.New("a suspended kartu cannot be renewed")
+	}
+
+	now := time.Now()
+	from := startOfDay(now)
+	if umkm.KartuExpiryDate != nil && daysUntil(now, *umkm.KartuExpiryDate) > 0 {
+		from = startOfDay(*umkm.KartuExpiryDate)
+	}
+	expiryDate := from.AddDate(0, s.validityMonths, 0)
+
+	updated := umkm
+	updated.KartuStatus = constant.KartuStatusActive
+	updated.KartuExpiryDate = &expiryDate
+
+	renewal.Status = constant.KartuRenewalStatusApproved
+	renewal.ReviewNotes = strings.TrimSpace(request.Notes)
+	renewal.ReviewedBy = &userID
+	renewal.ReviewedAt = &now
+	if err := s.saveChange(ctx, &userID, constant.KartuActionRenewed, umkm, updated, renewal.ReviewNotes, &renewal); err != nil {
+		return dto.KartuRenewal{}, err
+	}
+
+	s.notify(ctx, updated, constant.NotificationKartuRenewed, constant.NotificationTitleKartuRenewed,
+		fmt.Sprintf(constant.NotificationMessageKartuRenewed, kartuTypeName(updated.KartuType), expiryDate.Format("02-01-2006")))
+
+	renewal.UMKM = updated
+	return mapKartuRenewal(renewal), nil
+}
+
+func (s *kartuService) RejectRenewal(ctx context.Context, userID, renewalID int, request dto.KartuActionRequest) (dto.KartuRenewal, error) {
+	notes := strings.TrimSpace(request.Notes)
+	if notes == "" {
+		return dto.KartuRenewal{}, errors.New("notes is required to reject a renewal")
+	}
+
+	renewal, err := s.pendingRenewal(ctx, renewalID)
+	if err != nil {
+		return dto.KartuRenewal{}, err
+	}
+
+	now := time.Now()
+	renewal.Status = constant.KartuRenewalStatusRejected
+	renewal.ReviewNotes = notes
+	renewal.ReviewedBy = &userID
+	renewal.ReviewedAt = &now
+	if err := s.kartuRepo.UpdateRenewal(ctx, renewal); err != nil {
+		return dto.KartuRenewal{}, err
+	}
+
+	s.notify(ctx, renewal.UMKM, constant.NotificationKartuRenewalRejected, constant.NotificationTitleKartuRenewalRejected,
+		fmt.Sprintf(constant.NotificationMessageKartuRenewalRejected, notes))
+	return mapKartuRenewal(renewal), nil
+}
+
+func (s *kartuService) pendingRenewal(ctx context.Context, renewalID int) (model.KartuRenewal, error) {
+	renewal, err := s.kartuRepo.GetRenewalByID(ctx, renewalID)
+	if err != nil {
+		return model.KartuRenewal{}, err
+	}
+	if renewal.Status != constant.KartuRenewalStatusPending {
+		return model.KartuRenewal{}, errors.New("kartu renewal has already been reviewed")
+	}
+	return renewal, nil
+}
+
+// saveChange stores a kartu change with its history entry; actionedBy is nil
+// for changes made by the system.
+func (s *kartuService) saveChange(ctx context.Context, actionedBy *int, action string, before, after model.UMKM, notes string, renewal *model.KartuRenewal) error {
+	now := time.Now()
+	return s.kartuRepo.SaveKartuChange(ctx, before, after, model.KartuHistory{
+		UMKMID:         before.ID,
+		Action:         action,
+		FromType:       before.KartuType,
+		ToType:         after.KartuType,
+		FromStatus:     kartuStatus(before, now),
+		ToStatus:       after.KartuStatus,
+		FromExpiryDate: before.KartuExpiryDate,
+		ToExpiryDate:   after.KartuExpiryDate,
+		Notes:          notes,
+		ActionedBy:     actionedBy,
+		ActionedAt:     now,
+	}, renewal)
+}
+
+func (s *kartuService) notify(ctx context.Context, umkm model.UMKM, notificationType, title, message string) {
+	metadata, err := json.Marshal(map[string]any{"umkm_id": umkm.ID, "kartu_type": umkm.KartuType, "kartu_status": umkm.KartuStatus})
+	if err != nil {
+		return
+	}
+
+	if err := s.notificationRepo.CreateNotification(ctx, model.Notification{
+		UMKMID:   umkm.ID,
+		Type:     notificationType,
+		Title:    title,
+		Message:  message,
+		Metadata: string(metadata),
+	}); err != nil {
+		log.Log.Errorf("failed to send %s notification to UMKM ID %d: %v", notificationType, umkm.ID, err)
+	}
+}
+
+func (s *kartuService) mapKartu(ctx context.Context, umkm model.UMKM) (dto.Kartu, error) {
+	histories, err := s.kartuRepo.GetKartuHistories(ctx, umkm.ID)
+	if err != nil {
+		return dto.Kartu{}, err
+	}
+
+	now := time.Now()
+	kartu := dto.Kartu{
+		UMKMID:       umkm.ID,
+		BusinessName: umkm.BusinessName,
+		OwnerName:    umkm.User.Name,
+		KartuType:    umkm.KartuType,
+		Status:       kartuStatus(umkm, now),
+		CanRenew:     canRenewKartu(umkm, now),
+		QRCode:       umkm.QRCode,
+		History:      make([]dto.KartuHistory, 0, len(histories)),
+	}
+	if umkm.KartuIssuedDate != nil {
+		kartu.IssuedDate = umkm.KartuIssuedDate.Format("2006-01-02")
+	}
+	if umkm.KartuExpiryDate != nil {
+		days := daysUntil(now, *umkm.KartuExpiryDate)
+		kartu.ExpiryDate = umkm.KartuExpiryDate.Format("2006-01-02")
+		kartu.DaysUntilExpiry = &days
+	}
+	if renewal, err := s.kartuRepo.GetPendingRenewal(ctx, umkm.ID); err == nil {
+		renewal.UMKM = umkm
+		pending := mapKartuRenewal(renewal)
+		kartu.PendingRenewal = &pending
+		kartu.CanRenew = false
+	}
+
+	for _, history := range histories {
+		mapped := dto.KartuHistory{
+			ID:         history.ID,
+			Action:     history.Action,
+			FromType:   history.FromType,
+			ToType:     history.ToType,
+			FromStatus: history.FromStatus,
+			ToStatus:   history.ToStatus,
+			Notes:      history.Notes,
+			ActionedAt: history.ActionedAt.Format("2006-01-02 15:04:05"),
+		}
+		if history.FromExpiryDate != nil {
+			mapped.FromExpiryDate = history.FromExpiryDate.Format("2006-01-02")
+		}
+		if history.ToExpiryDate != nil {
+			mapped.ToExpiryDate = history.ToExpiryDate.Format("2006-01-02")
+		}
+		if history.User != nil {
+			mapped.ActionedBy = history.User.Name
+		}
+		kartu.History = append(kartu.History, mapped)
+	}
+	return kartu, nil
+}
+
+func mapKartuRenewal(renewal model.KartuRenewal) dto.KartuRenewal {
+	mapped := dto.KartuRenewal{
+		ID:           renewal.ID,
+		UMKMID:       renewal.UMKMID,
+		BusinessName: renewal.UMKM.BusinessName,
+		KartuType:    renewal.UMKM.KartuType,
+		Status:       renewal.Status,
+		Notes:        renewal.Notes,
+		ReviewNotes:  renewal.ReviewNotes,
+		CreatedAt:    renewal.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+	if renewal.UMKM.KartuExpiryDate != nil {
+		mapped.ExpiryDate = renewal.UMKM.KartuExpiryDate.Format("2006-01-02")
+	}
+	if renewal.ReviewedAt != nil {
+		mapped.ReviewedAt = renewal.ReviewedAt.Format("2006-01-02 15:04:05")
+	}
+	return mapped
+}
+
+// kartuStatus is the status of a kartu on the given day. An active kartu past
+// its expiry date is expired even before the expiry worker records it.
+func kartuStatus(umkm model.UMKM, now time.Time) string {
+	switch {
+	case umkm.KartuStatus == constant.KartuStatusSuspended, umkm.KartuStatus == constant.KartuStatusExpired:
+		return umkm.KartuStatus
+	case umkm.KartuExpiryDate != nil && daysUntil(now, *umkm.KartuExpiryDate) < 0:
+		return constant.KartuStatusExpired
+	}
+	return constant.KartuStatusActive
+}
+
+// canRenewKartu reports whether a kartu is expired or within
+// kartuRenewalWindowDays of expiry. Suspended kartu are never renewed.
+func canRenewKartu(umkm model.UMKM, now time.Time) bool {
+	switch kartuStatus(umkm, now) {
+	case constant.KartuStatusExpired:
+		return true
+	case constant.KartuStatusActive:
+		return umkm.KartuExpiryDate != nil && daysUntil(now, *umkm.KartuExpiryDate) <= kartuRenewalWindowDays
+	}
+	return false
+}
+
+func kartuTypeName(kartuType string) string {
+	switch kartuType {
+	case constant.KartuTypeProduktif:
+		return "Produktif"
+	case constant.KartuTypeAfirmatif:
+		return "Afirmatif"
+	}
+	return kartuType
+}
@@ -0,0 +1,652 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"UMKMGo-backend/config/env"
+	"UMKMGo-backend/internal/repository"
+	"UMKMGo-backend/internal/types/dto"
+	"UMKMGo-backend/internal/types/model"
+	"UMKMGo-backend/internal/utils"
+	"UMKMGo-backend/internal/utils/constant"
+)
+
+// Mock Kartu Repository
+type mockKartuRepo struct {
+	umkms     map[int]model.UMKM
+	histories []model.KartuHistory
+	renewals  map[int]model.KartuRenewal
+}
+
+func newMockKartuRepo() *mockKartuRepo {
+	return &mockKartuRepo{
+		umkms:    make(map[int]model.UMKM),
+		renewals: make(map[int]model.KartuRenewal),
+	}
+}
+
+func (m *mockKartuRepo) GetUMKMByID(ctx context.Context, id int) (model.UMKM, error) {
+	umkm, exists := m.umkms[id]
+	if !exists {
+		return model.UMKM{}, errors.New("UMKM not found")
+	}
+	return umkm, nil
+}
+
+func (m *mockKartuRepo) GetLegacyQRUMKMs(ctx context.Context, limit int) ([]model.UMKM, error) {
+	var umkms []model.UMKM
+	for _, umkm := range m.umkms {
+		if umkm.QRTokenVersion == 0 {
+			umkms = append(umkms, umkm)
+		}
+	}
+	sort.Slice(umkms, func(i, j int) bool { return umkms[i].ID < umkms[j].ID })
+	if len(umkms) > limit {
+		umkms = umkms[:limit]
+	}
+	return umkms, nil
+}
+
+func (m *mockKartuRepo) UpdateQRCode(ctx context.Context, id, fromVersion, toVersion int, qrCode string) error {
+	umkm, exists := m.umkms[id]
+	if !exists || umkm.QRTokenVersion != fromVersion {
+		return errors.New("QR code was rotated by another request")
+	}
+	umkm.QRCode = qrCode
+	umkm.QRTokenVersion = toVersion
+	m.umkms[id] = umkm
+	return nil
+}
+
+func (m *mockKartuRepo) SaveKartuChange(ctx context.Context, before, after model.UMKM, history model.KartuHistory, renewal *model.KartuRenewal) error {
+	current := m.umkms[before.ID]
+	if current.KartuStatus != before.KartuStatus || current.KartuType != before.KartuType || !sameKartuDate(current.KartuExpiryDate, before.KartuExpiryDate) {
+		return repository.ErrKartuConflict
+	}
+	if renewal != nil && m.renewals[renewal.ID].Status != constant.KartuRenewalStatusPending {
+		return repository.ErrKartuConflict
+	}
+	m.umkms[after.ID] = after
+	history.ID = len(m.histories) + 1
+	m.histories = append(m.histories, history)
+	if renewal != nil {
+		m.renewals[renewal.ID] = *renewal
+	}
+	return nil
+}
+
+func (m *mockKartuRepo) GetKartuHistories(ctx context.Context, umkmID int) ([]model.KartuHistory, error) {
+	var histories []model.KartuHistory
+	for _, history := range m.histories {
+		if history.UMKMID == umkmID {
+			histories = append(histories, history)
+		}
+	}
+	return histories, nil
+}
+
+func (m *mockKartuRepo) GetExpiredKartus(ctx context.Context, today time.Time, limit int) ([]model.UMKM, error) {
+	var umkms []model.UMKM
+	for _, umkm := range m.umkms {
+		if umkm.KartuStatus == constant.KartuStatusActive && umkm.KartuExpiryDate != nil && umkm.KartuExpiryDate.Before(today) {
+			umkms = append(umkms, umkm)
+		}
+	}
+	sort.Slice(umkms, func(i, j int) bool { return umkms[i].ID < umkms[j].ID })
+	if len(umkms) > limit {
+		umkms = umkms[:limit]
+	}
+	return umkms, nil
+}
+
+func (m *mockKartuRepo) GetRenewals(ctx context.Context, status string) ([]model.KartuRenewal, error) {
+	var renewals []model.KartuRenewal
+	for _, renewal := range m.renewals {
+		if status == "" || renewal.Status == status {
+			renewal.UMKM = m.umkms[renewal.UMKMID]
+			renewals = append(renewals, renewal)
+		}
+	}
+	sort.Slice(renewals, func(i, j int) bool { return renewals[i].ID < renewals[j].ID })
+	return renewals, nil
+}
+
+func (m *mockKartuRepo) GetRenewalByID(ctx context.Context, id int) (model.KartuRenewal, error) {
+	renewal, exists := m.renewals[id]
+	if !exists {
+		return model.KartuRenewal{}, errors.New("kartu renewal not found")
+	}
+	renewal.UMKM = m.umkms[renewal.UMKMID]
+	return renewal, nil
+}
+
+func (m *mockKartuRepo) GetPendingRenewal(ctx context.Context, umkmID int) (model.KartuRenewal, error) {
+	for _, renewal := range m.renewals {
+		if renewal.UMKMID == umkmID && renewal.Status == constant.KartuRenewalStatusPending {
+			return renewal, nil
+		}
+	}
+	return model.KartuRenewal{}, errors.New("kartu renewal not found")
+}
+
+func (m *mockKartuRepo) CreateRenewal(ctx context.Context, renewal model.KartuRenewal) (model.KartuRenewal, error) {
+	renewal.ID = len(m.renewals) + 1
+	renewal.CreatedAt = time.Now()
+	m.renewals[renewal.ID] = renewal
+	return renewal, nil
+}
+
+func (m *mockKartuRepo) UpdateRenewal(ctx context.Context, renewal model.KartuRenewal) error {
+	if m.renewals[renewal.ID].Status != constant.KartuRenewalStatusPending {
+		return repository.ErrKartuConflict
+	}
+	renewal.UMKM = model.UMKM{}
+	m.renewals[renewal.ID] = renewal
+	return nil
+}
+
+func sameKartuDate(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+// setupKartuService builds a service around UMKM 1 with a signed QR code
+// (version 1) and UMKM 2 whose QR code still holds the plaintext kartu number.
+func setupKartuService() (*kartuService, *mockKartuRepo, *[]string) {
+	env.Cfg.Kartu.QRSecret = "test-kartu-secret"
+
+	kartuRepo := newMockKartuRepo()
+	kartuRepo.umkms[1] = model.UMKM{
+		ID:             1,
+		UserID:         11,
+		BusinessName:   "Usaha A",
+		KartuType:      "produktif",
+		KartuNumber:    "vault:v1:1234567890123456",
+		QRCode:         "http://minio/umkmgo-umkms/qrcode_a_v1.png",
+		QRTokenVersion: 1,
+		User:           model.User{Name: "Pemilik A"},
+		City:           model.City{Name: "Bandung"},
+		Province:       model.Province{Name: "Jawa Barat"},
+	}
+	kartuRepo.umkms[2] = model.UMKM{
+		ID:          2,
+		UserID:      12,
+		KartuNumber: "vault:v1:6543210987654321",
+		QRCode:      "http://minio/umkmgo-umkms/qrcode_b_legacy.png",
+		User:        model.User{Name: "Pemilik B"},
+	}
+
+	deleted := &[]string{}
+	service := &kartuService{
+		kartuRepo:        kartuRepo,
+		notificationRepo: newMockNotificationRepo(),
+		verifyURL:        "https://umkmgo.id/verify/kartu",
+		validityMonths:   60,
+		storeQRCode: func(ctx context.Context, prefix, base64Data string) (string, error) {
+			return "http://minio/umkmgo-umkms/" + prefix + ".png", nil
+		},
+		deleteQRCode: func(ctx context.Context, url string) error {
+			*deleted = append(*deleted, url)
+			return nil
+		},
+		maskKartu: func(ctx context.Context, umkm model.UMKM) (string, error) {
+			return utils.MaskMiddle(strings.TrimPrefix(umkm.KartuNumber, "vault:v1:")), nil
+		},
+	}
+	return service, kartuRepo, deleted
+}
+
+func TestKartuToken(t *testing.T) {
+	env.Cfg.Kartu.QRSecret = "test-kartu-secret"
+	token := utils.GenerateKartuToken(15, 3)
+
+	t.Run("Round trips the UMKM and version", func(t *testing.T) {
+		umkmID, version, err := utils.VerifyKartuToken(token)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if umkmID != 15 || version != 3 {
+			t.Errorf("Expected UMKM 15 version 3, got %d version %d", umkmID, version)
+		}
+	})
+
+	t.Run("Rejects tampered tokens", func(t *testing.T) {
+		signature := token[strings.LastIndex(token, ".")+1:]
+		for _, forged := range []string{"16.3." + signature, "15.4." + signature, "15.3", "1234567890123456", ""} {
+			if _, _, err := utils.VerifyKartuToken(forged); err == nil {
+				t.Errorf("Expected %q to be rejected", forged)
+			}
+		}
+	})
+
+	t.Run("Rejects tokens signed with another secret", func(t *testing.T) {
+		env.Cfg.Kartu.QRSecret = "another-secret"
+		defer func() { env.Cfg.Kartu.QRSecret = "test-kartu-secret" }()
+		if _, _, err := utils.VerifyKartuToken(token); err == nil {
+			t.Error("Expected token to be rejected")
+		}
+	})
+}
+
+func TestVerifyKartu(t *testing.T) {
+	ctx := context.Background()
+	service, _, _ := setupKartuService()
+
+	t.Run("Returns a masked card view for the verification link", func(t *testing.T) {
+		verification, err := service.VerifyKartu(ctx, "https://umkmgo.id/verify/kartu/"+utils.GenerateKartuToken(1, 1))
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !verification.Valid || verification.BusinessName != "Usaha A" || verification.City != "Bandung" || verification.Province != "Jawa Barat" {
+			t.Errorf("Unexpected verification %+v", verification)
+		}
+		if verification.KartuNumber == "1234567890123456" || !strings.Contains(verification.KartuNumber, "XXXXXXXX") {
+			t.Errorf("Expected a masked kartu number, got %s", verification.KartuNumber)
+		}
+	})
+
+	t.Run("Rejects invalid QR codes", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			qrData string
+			want   string
+		}{
+			{"plaintext kartu number", "1234567890123456", "invalid kartu QR code"},
+			{"revoked version", utils.GenerateKartuToken(1, 0), "has been revoked"},
+			{"legacy UMKM", utils.GenerateKartuToken(2, 1), "has been revoked"},
+			{"unknown UMKM", utils.GenerateKartuToken(99, 1), "invalid kartu QR code"},
+			{"empty", " ", "kartu QR code is required"},
+		}
+		for _, tt := range tests {
+			_, err := service.VerifyKartu(ctx, tt.qrData)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("%s: expected error containing %q, got %v", tt.name, tt.want, err)
+			}
+		}
+	})
+
+	t.Run("Resolves suspended and expired kartu as invalid", func(t *testing.T) {
+		expired := time.Now().AddDate(0, 0, -1)
+		for status, umkm := range map[string]model.UMKM{
+			constant.KartuStatusSuspended: {KartuStatus: constant.KartuStatusSuspended},
+			constant.KartuStatusExpired:   {KartuStatus: constant.KartuStatusActive, KartuExpiryDate: &expired},
+		} {
+			kartu := service.kartuRepo.(*mockKartuRepo).umkms[1]
+			kartu.KartuStatus = umkm.KartuStatus
+			kartu.KartuExpiryDate = umkm.KartuExpiryDate
+			service.kartuRepo.(*mockKartuRepo).umkms[1] = kartu
+
+			verification, err := service.VerifyKartu(ctx, utils.GenerateKartuToken(1, 1))
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if verification.Valid || verification.Status != status {
+				t.Errorf("Expected an invalid %s kartu, got %+v", status, verification)
+			}
+		}
+	})
+}
+
+func TestRotateQRCode(t *testing.T) {
+	ctx := context.Background()
+	service, repo, deleted := setupKartuService()
+	oldToken := utils.GenerateKartuToken(1, 1)
+
+	qrCode, err := service.RotateQRCode(ctx, 1)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if qrCode.Version != 2 || repo.umkms[1].QRTokenVersion != 2 || repo.umkms[1].QRCode != qrCode.QRCode {
+		t.Errorf("Expected version 2 to be stored, got %+v", qrCode)
+	}
+	if len(*deleted) != 1 || (*deleted)[0] != "http://minio/umkmgo-umkms/qrcode_a_v1.png" {
+		t.Errorf("Expected the old QR image to be deleted, got %v", *deleted)
+	}
+
+	if _, err := service.VerifyKartu(ctx, oldToken); err == nil || !strings.Contains(err.Error(), "revoked") {
+		t.Errorf("Expected the old token to be revoked, got %v", err)
+	}
+	if _, err := service.VerifyKartu(ctx, utils.GenerateKartuToken(1, 2)); err != nil {
+		t.Errorf("Expected the new token to verify, got %v", err)
+	}
+
+	if _, err := service.RotateQRCode(ctx, 99); err == nil {
+		t.Error("Expected error for unknown UMKM")
+	}
+}
+
+func TestRegenerateLegacyQRCodes(t *testing.T) {
+	ctx := context.Background()
+	service, repo, deleted := setupKartuService()
+	for id := 3; id <= legacyQRBatchSize+3; id++ {
+		repo.umkms[id] = model.UMKM{ID: id, User: model.User{Name: fmt.Sprintf("Pemilik %d", id)}}
+	}
+
+	regenerated, err := service.RegenerateLegacyQRCodes(ctx)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if regenerated != legacyQRBatchSize {
+		t.Errorf("Expected a batch of %d, got %d", legacyQRBatchSize, regenerated)
+	}
+	if repo.umkms[2].QRTokenVersion != 1 || repo.umkms[1].QRTokenVersion != 1 {
+		t.Errorf("Expected only legacy QR codes to be regenerated, got %+v and %+v", repo.umkms[1], repo.umkms[2])
+	}
+	if len(*deleted) != 1 || (*deleted)[0] != "http://minio/umkmgo-umkms/qrcode_b_legacy.png" {
+		t.Errorf("Expected the plaintext QR image to be deleted, got %v", *deleted)
+	}
+
+	// The next run picks up the rest
+	regenerated, _ = service.RegenerateLegacyQRCodes(ctx)
+	if regenerated != 2 {
+		t.Errorf("Expected the remaining 2, got %d", regenerated)
+	}
+	if legacy, _ := repo.GetLegacyQRUMKMs(ctx, legacyQRBatchSize); len(legacy) != 0 {
+		t.Errorf("Expected no legacy QR codes left, got %d", len(legacy))
+	}
+}
+
+// setupKartuLifecycle adds UMKM 3 with an active Kartu Afirmatif expiring in
+// the given number of days.
+func setupKartuLifecycle(expiresInDays int) (*kartuService, *mockKartuRepo, *mockNotificationRepo) {
+	service, repo, _ := setupKartuService()
+	issued := startOfDay(time.Now()).AddDate(-5, 0, expiresInDays)
+	expiry := startOfDay(time.Now()).AddDate(0, 0, expiresInDays)
+	repo.umkms[3] = model.UMKM{
+		ID:              3,
+		UserID:          13,
+		BusinessName:    "Usaha C",
+		KartuType:       constant.KartuTypeAfirmatif,
+		KartuStatus:     constant.KartuStatusActive,
+		KartuIssuedDate: &issued,
+		KartuExpiryDate: &expiry,
+		User:            model.User{Name: "Pemilik C"},
+	}
+	return service, repo, service.notificationRepo.(*mockNotificationRepo)
+}
+
+func TestKartuLifecycle(t *testing.T) {
+	ctx := context.Background()
+	service, repo, notifications := setupKartuLifecycle(365)
+
+	if _, err := service.SuspendKartu(ctx, 7, 3, dto.KartuActionRequest{Notes: " "}); err == nil {
+		t.Error("Expected notes to be required to suspend")
+	}
+
+	kartu, err := service.SuspendKartu(ctx, 7, 3, dto.KartuActionRequest{Notes: "Laporan penyalahgunaan"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if kartu.Status != constant.KartuStatusSuspended || repo.umkms[3].KartuStatus != constant.KartuStatusSuspended {
+		t.Errorf("Expected the kartu to be suspended, got %+v", kartu)
+	}
+	if len(notifications.notifications) != 1 || notifications.notifications[0].Type != constant.NotificationKartuSuspended {
+		t.Errorf("Expected a suspension notification, got %+v", notifications.notifications)
+	}
+
+	if _, err := service.SuspendKartu(ctx, 7, 3, dto.KartuActionRequest{Notes: "Lagi"}); err == nil {
+		t.Error("Expected a suspended kartu not to be suspended again")
+	}
+	if _, err := service.UpgradeKartu(ctx, 7, 3, dto.KartuActionRequest{}); err == nil {
+		t.Error("Expected a suspended kartu not to be upgraded")
+	}
+
+	if kartu, err = service.ReactivateKartu(ctx, 7, 3, dto.KartuActionRequest{}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if kartu.Status != constant.KartuStatusActive {
+		t.Errorf("Expected the kartu to be active again, got %s", kartu.Status)
+	}
+	if _, err := service.ReactivateKartu(ctx, 7, 3, dto.KartuActionRequest{}); err == nil {
+		t.Error("Expected an active kartu not to be reactivated")
+	}
+
+	if kartu, err = service.UpgradeKartu(ctx, 7, 3, dto.KartuActionRequest{}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if kartu.KartuType != constant.KartuTypeProduktif || kartu.ExpiryDate != repo.umkms[3].KartuExpiryDate.Format("2006-01-02") {
+		t.Errorf("Expected a Kartu Produktif keeping its expiry date, got %+v", kartu)
+	}
+	if _, err := service.UpgradeKartu(ctx, 7, 3, dto.KartuActionRequest{}); err == nil {
+		t.Error("Expected a Kartu Produktif not to be upgraded")
+	}
+
+	actions := []string{}
+	for _, history := range kartu.History {
+		actions = append(actions, history.Action)
+	}
+	if strings.Join(actions, ",") != "suspended,reactivated,upgraded" {
+		t.Errorf("Expected the history to record every change, got %v", actions)
+	}
+	if last := repo.histories[2]; last.FromType != constant.KartuTypeAfirmatif || last.ToType != constant.KartuTypeProduktif || *last.ActionedBy != 7 {
+		t.Errorf("Unexpected upgrade history %+v", last)
+	}
+}
+
+func TestReactivateExpiredKartu(t *testing.T) {
+	ctx := context.Background()
+	service, repo, notifications := setupKartuLifecycle(-3)
+	umkm := repo.umkms[3]
+	umkm.KartuStatus = constant.KartuStatusSuspended
+	repo.umkms[3] = umkm
+
+	kartu, err := service.ReactivateKartu(ctx, 7, 3, dto.KartuActionRequest{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if kartu.Status != constant.KartuStatusExpired || !kartu.CanRenew {
+		t.Errorf("Expected an expired kartu ready for renewal, got %+v", kartu)
+	}
+	if notifications.notifications[0].Type != constant.NotificationKartuExpired {
+		t.Errorf("Expected an expiry notification, got %s", notifications.notifications[0].Type)
+	}
+}
+
+func TestKartuRenewal(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Only opens within the renewal window", func(t *testing.T) {
+		service, _, _ := setupKartuLifecycle(kartuRenewalWindowDays + 30)
+		if _, err := service.RequestRenewal(ctx, 3, dto.KartuRenewalRequest{}); err == nil {
+			t.Error("Expected renewal to be refused outside the window")
+		}
+	})
+
+	t.Run("Is refused for a suspended kartu", func(t *testing.T) {
+		service, repo, _ := setupKartuLifecycle(10)
+		umkm := repo.umkms[3]
+		umkm.KartuStatus = constant.KartuStatusSuspended
+		repo.umkms[3] = umkm
+		if _, err := service.RequestRenewal(ctx, 3, dto.KartuRenewalRequest{}); err == nil || !strings.Contains(err.Error(), "suspended") {
+			t.Errorf("Expected renewal to be refused, got %v", err)
+		}
+	})
+
+	t.Run("Extends from the current expiry date", func(t *testing.T) {
+		service, repo, notifications := setupKartuLifecycle(30)
+		expiry := *repo.umkms[3].KartuExpiryDate
+
+		renewal, err := service.RequestRenewal(ctx, 3, dto.KartuRenewalRequest{Notes: "Mohon diperpanjang"})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if renewal.Status != constant.KartuRenewalStatusPending || renewal.BusinessName != "Usaha C" {
+			t.Errorf("Unexpected renewal %+v", renewal)
+		}
+		if _, err := service.RequestRenewal(ctx, 3, dto.KartuRenewalRequest{}); err == nil {
+			t.Error("Expected a second pending renewal to be refused")
+		}
+
+		kartu, _ := service.GetKartu(ctx, 3)
+		if kartu.PendingRenewal == nil || kartu.CanRenew {
+			t.Errorf("Expected the pending renewal on the kartu, got %+v", kartu)
+		}
+		if pending, _ := service.GetRenewals(ctx, dto.KartuRenewalQueryParams{Status: constant.KartuRenewalStatusPending}); len(pending) != 1 {
+			t.Errorf("Expected 1 pending renewal, got %d", len(pending))
+		}
+
+		approved, err := service.ApproveRenewal(ctx, 7, renewal.ID, dto.KartuActionRequest{})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		want := expiry.AddDate(0, 60, 0).Format("2006-01-02")
+		if approved.Status != constant.KartuRenewalStatusApproved || approved.ExpiryDate != want || repo.umkms[3].KartuExpiryDate.Format("2006-01-02") != want {
+			t.Errorf("Expected the kartu to be valid until %s, got %+v", want, approved)
+		}
+		if repo.histories[0].Action != constant.KartuActionRenewed || notifications.notifications[0].Type != constant.NotificationKartuRenewed {
+			t.Errorf("Expected the renewal to be recorded and notified, got %+v", repo.histories)
+		}
+
+		if _, err := service.ApproveRenewal(ctx, 7, renewal.ID, dto.KartuActionRequest{}); err == nil || !strings.Contains(err.Error(), "already been reviewed") {
+			t.Errorf("Expected a reviewed renewal to be refused, got %v", err)
+		}
+	})
+
+	t.Run("Extends an expired kartu from today", func(t *testing.T) {
+		service, repo, _ := setupKartuLifecycle(-10)
+		renewal, err := service.RequestRenewal(ctx, 3, dto.KartuRenewalRequest{})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if _, err := service.ApproveRenewal(ctx, 7, renewal.ID, dto.KartuActionRequest{}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		umkm := repo.umkms[3]
+		if want := startOfDay(time.Now()).AddDate(0, 60, 0); !umkm.KartuExpiryDate.Equal(want) || umkm.KartuStatus != constant.KartuStatusActive {
+			t.Errorf("Expected an active kartu valid until %s, got %+v", want.Format("2006-01-02"), umkm)
+		}
+	})
+
+	t.Run("Rejection needs notes", func(t *testing.T) {
+		service, repo, notifications := setupKartuLifecycle(30)
+		renewal, _ := service.RequestRenewal(ctx, 3, dto.KartuRenewalRequest{})
+		if _, err := service.RejectRenewal(ctx, 7, renewal.ID, dto.KartuActionRequest{}); err == nil {
+			t.Error("Expected notes to be required to reject")
+		}
+
+		rejected, err := service.RejectRenewal(ctx, 7, renewal.ID, dto.KartuActionRequest{Notes: "Dokumen tidak lengkap"})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if rejected.Status != constant.KartuRenewalStatusRejected || len(repo.histories) != 0 {
+			t.Errorf("Expected a rejection without kartu change, got %+v", rejected)
+		}
+		if notifications.notifications[0].Type != constant.NotificationKartuRenewalRejected {
+			t.Errorf("Expected a rejection notification, got %s", notifications.notifications[0].Type)
+		}
+		if _, err := service.RequestRenewal(ctx, 3, dto.KartuRenewalRequest{}); err != nil {
+			t.Errorf("Expected a new request after rejection, got %v", err)
+		}
+	})
+
+	t.Run("Rejects unknown status filters", func(t *testing.T) {
+		service, _, _ := setupKartuLifecycle(30)
+		if _, err := service.GetRenewals(ctx, dto.KartuRenewalQueryParams{Status: "done"}); err == nil {
+			t.Error("Expected an invalid status error")
+		}
+	})
+}
+
+func TestExpireKartus(t *testing.T) {
+	ctx := context.Background()
+	service, repo, notifications := setupKartuLifecycle(-1)
+	expiry := startOfDay(time.Now()).AddDate(0, 0, -30)
+	repo.umkms[4] = model.UMKM{ID: 4, KartuType: constant.KartuTypeProduktif, KartuStatus: constant.KartuStatusSuspended, KartuExpiryDate: &expiry}
+	future := startOfDay(time.Now()).AddDate(1, 0, 0)
+	repo.umkms[5] = model.UMKM{ID: 5, KartuType: constant.KartuTypeProduktif, KartuStatus: constant.KartuStatusActive, KartuExpiryDate: &future}
+
+	expired, err := service.ExpireKartus(ctx)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if expired != 1 || repo.umkms[3].KartuStatus != constant.KartuStatusExpired {
+		t.Errorf("Expected only UMKM 3 to expire, got %d", expired)
+	}
+	if history := repo.histories[0]; history.Action != constant.KartuActionExpired || history.ActionedBy != nil || history.FromStatus != constant.KartuStatusExpired {
+		t.Errorf("Expected a system expiry history, got %+v", history)
+	}
+	if len(notifications.notifications) != 1 || notifications.notifications[0].UMKMID != 3 {
+		t.Errorf("Expected an expiry notification to UMKM 3, got %+v", notifications.notifications)
+	}
+
+	if expired, _ = service.ExpireKartus(ctx); expired != 0 {
+		t.Errorf("Expected nothing left to expire, got %d", expired)
+	}
+}
+
+func TestKartuChangeConflicts(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("A stale expiry does not undo a renewal", func(t *testing.T) {
+		service, repo, _ := setupKartuLifecycle(-1)
+		stale := repo.umkms[3]
+		renewal, _ := service.RequestRenewal(ctx, 3, dto.KartuRenewalRequest{})
+		if _, err := service.ApproveRenewal(ctx, 7, renewal.ID, dto.KartuActionRequest{}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		expired := stale
+		expired.KartuStatus = constant.KartuStatusExpired
+		err := service.saveChange(ctx, nil, constant.KartuActionExpired, stale, expired, "", nil)
+		if !errors.Is(err, repository.ErrKartuConflict) {
+			t.Fatalf("Expected ErrKartuConflict, got %v", err)
+		}
+		if umkm := repo.umkms[3]; umkm.KartuStatus != constant.KartuStatusActive || len(repo.histories) != 1 {
+			t.Errorf("Expected the renewal to stand, got %+v", umkm)
+		}
+	})
+
+	t.Run("A renewal is only approved once", func(t *testing.T) {
+		service, repo, _ := setupKartuLifecycle(10)
+		created, _ := service.RequestRenewal(ctx, 3, dto.KartuRenewalRequest{})
+		stale := repo.renewals[created.ID]
+		before := repo.umkms[3]
+		if _, err := service.ApproveRenewal(ctx, 7, created.ID, dto.KartuActionRequest{}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		stale.Status = constant.KartuRenewalStatusApproved
+		err := service.saveChange(ctx, nil, constant.KartuActionRenewed, repo.umkms[3], repo.umkms[3], "", &stale)
+		if !errors.Is(err, repository.ErrKartuConflict) {
+			t.Errorf("Expected ErrKartuConflict for a second approval, got %v", err)
+		}
+		if _, err := service.RejectRenewal(ctx, 7, created.ID, dto.KartuActionRequest{Notes: "late"}); err == nil {
+			t.Error("Expected a reviewed renewal to stay approved")
+		}
+		if !repo.umkms[3].KartuExpiryDate.Equal(before.KartuExpiryDate.AddDate(0, 60, 0)) {
+			t.Errorf("Expected a single extension, got %v", repo.umkms[3].KartuExpiryDate)
+		}
+	})
+}
+
+func TestKartuEligibility(t *testing.T) {
+	program := model.Program{ID: 1, Type: "training"}
+	expired := time.Now().AddDate(0, 0, -1)
+
+	tests := []struct {
+		name     string
+		umkm     model.UMKM
+		eligible bool
+	}{
+		{"active", model.UMKM{KartuStatus: constant.KartuStatusActive}, true},
+		{"suspended", model.UMKM{KartuStatus: constant.KartuStatusSuspended}, false},
+		{"past expiry", model.UMKM{KartuStatus: constant.KartuStatusActive, KartuExpiryDate: &expired}, false},
+	}
+	for _, tt := range tests {
+		result := evaluateEligibility(program, &tt.umkm, nil, time.Now())
+		if result.Eligible != tt.eligible {
+			t.Errorf("%s: expected eligible %v, got %+v", tt.name, tt.eligible, result)
+		}
+		if !tt.eligible && result.UnmetRules[0].Rule != "kartu_status" {
+			t.Errorf("%s: expected the kartu_status rule to be unmet, got %+v", tt.name, result.UnmetRules)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"math"
+	"time"
+
+	"UMKMGo-backend/internal/types/model"
+	"UMKMGo-backend/internal/utils/constant"
+)
+
+// loanTerms are the inputs of a repayment schedule. AnnualRate is the program's
+// interest rate in percent per year.
+type loanTerms struct {
+	Principal    float64
+	AnnualRate   float64
+	TenureMonths int
+	Method       string
+	StartDate    time.Time
+}
+
+// plannedInstallment is one row of a repayment schedule. Balance is the
+// principal still owed once the installment is paid.
+type plannedInstallment struct {
+	Number    int
+	DueDate   time.Time
+	Principal float64
+	Interest  float64
+	Amount    float64
+	Balance   float64
+}
+
+// loanTermsFor resolves the schedule inputs of a funding program. Programs
+// without an interest method use annuity, and without a rate charge nothing.
+func loanTermsFor(program model.Program, amount float64, tenureMonths int, startDate time.Time) loanTerms {
+	terms := loanTerms{
+		Principal:    amount,
+		TenureMonths: tenureMonths,
+		Method:       constant.InterestMethodAnnuity,
+		StartDate:    startDate,
+	}
+	if program.InterestRate != nil {
+		terms.AnnualRate = *program.InterestRate
+	}
+	if program.InterestMethod != nil {
+		terms.Method = *program.InterestMethod
+	}
+	return terms
+}
+
+// newLoan builds an active loan with its installments and totals from the terms.
+// Approval stores it; the funding simulation shows it without saving.
+func newLoan(applicationID int, terms loanTerms) model.Loan {
+	loan := model.Loan{
+		ApplicationID:  applicationID,
+		Principal:      terms.Principal,
+		InterestRate:   terms.AnnualRate,
+		InterestMethod: terms.Method,
+		TenureMonths:   terms.TenureMonths,
+		Status:         constant.LoanStatusActive,
+		StartDate:      terms.StartDate,
+	}
+	for _, planned := range amortize(terms) {
+		loan.Installments = append(loan.Installments, model.LoanInstallment{
+			Number:    planned.Number,
+			DueDate:   planned.DueDate,
+			Principal: planned.Principal,
+			Interest:  planned.Interest,
+			Amount:    planned.Amount,
+			Status:    constant.InstallmentStatusUnpaid,
+		})
+		loan.TotalInterest = roundCents(loan.TotalInterest + planned.Interest)
+		loan.TotalPayable = roundCents(loan.TotalPayable + planned.Amount)
+	}
+	return loan
+}
+
+// amortize builds the monthly repayment schedule, the first installment due one
+// month after the start date. Flat interest is charged on the original principal
+// every month; annuity (effective) interest is charged on the remaining balance
+// with an equal installment. Amounts are rounded to cents and the last
+// installment absorbs the rounding, so the principals add up to the loan.
+func amortize(terms loanTerms) []plannedInstallment {
+	n := terms.TenureMonths
+	if n <= 0 {
+		return nil
+	}
+	monthlyRate := terms.AnnualRate / 100 / 12
+
+	var payment float64
+	if terms.Method == constant.InterestMethodAnnuity && monthlyRate > 0 {
+		payment = roundCents(terms.Principal * monthlyRate / (1 - math.Pow(1+monthlyRate, -float64(n))))
+	}
+	flatPrincipal := roundCents(terms.Principal / float64(n))
+	flatInterest := roundCents(terms.Principal * monthlyRate)
+
+	schedule := make([]plannedInstallment, 0, n)
+	balance := terms.Principal
+	for number := 1; number <= n; number++ {
+		var principal, interest float64
+		switch {
+		case terms.Method == constant.InterestMethodFlat:
+			principal, interest = flatPrincipal, flatInterest
+		case monthlyRate > 0:
+			interest = roundCents(balance * monthlyRate)
+			principal = roundCents(payment - interest)
+		default:
+			principal = flatPrincipal
+		}
+		if number == n {
+			principal = balance
+		}
+		balance = roundCents(balance - principal)
+
+		schedule = append(schedule, plannedInstallment{
+			Number:    number,
+			DueDate:   addMonths(terms.StartDate, number),
+			Principal: roundCents(principal),
+			Interest:  interest,
+			Amount:    roundCents(principal + interest),
+			Balance:   balance,
+		})
+	}
+	return schedule
+}
+
+// addMonths moves a date by whole months, keeping the day of month where it
+// exists and using the last day of shorter months otherwise.
+func addMonths(date time.Time, months int) time.Time {
+	year, month, day := date.Date()
+	firstOfMonth := time.Date(year, month+time.Month(months), 1, 0, 0, 0, 0, date.Location())
+	lastDay := firstOfMonth.AddDate(0, 1, -1).Day()
+	return firstOfMonth.AddDate(0, 0, min(day, lastDay)-1)
+}
+
+func roundCents(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -0,0 +1,239 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"UMKMGo-backend/internal/repository"
+	"UMKMGo-backend/internal/types/dto"
+	"UMKMGo-backend/internal/types/model"
+	"UMKMGo-backend/internal/utils/constant"
+)
+
+type LoanService interface {
+	GetLoans(ctx context.Context, params dto.LoanQueryParams) ([]dto.Loan, error)
+	GetLoanByApplicationID(ctx context.Context, applicationID int) (dto.Loan, error)
+	RecordRepayment(ctx context.Context, userID, loanID int, request dto.LoanRepaymentRequest) (dto.Loan, error)
+}
+
+type loanService struct {
+	loanRepo repository.LoanRepository
+	uow      repository.UnitOfWork
+}
+
+func NewLoanService(loanRepo repository.LoanRepository, uow repository.UnitOfWork) LoanService {
+	return &loanService{
+		loanRepo: loanRepo,
+		uow:      uow,
+	}
+}
+
+func (s *loanService) GetLoans(ctx context.Context, params dto.LoanQueryParams) ([]dto.Loan, error) {
+	now := time.Now()
+	var overdueAt *time.Time
+	if params.Overdue {
+		today := startOfDay(now)
+		overdueAt = &today
+	}
+
+	loans, err := s.loanRepo.GetLoans(ctx, params.Status, overdueAt)
+	if err != nil {
+		return nil, err
+	}
+
+	// The list is a summary; the schedule is on the detail
+	result := make([]dto.Loan, 0, len(loans))
+	for _, loan := range loans {
+		summary := mapLoanToDTO(loan, now)
+		summary.Installments = nil
+		result = append(result, summary)
+	}
+	return result, nil
+}
+
+func (s *loanService) GetLoanByApplicationID(ctx context.Context, applicationID int) (dto.Loan, error) {
+	loan, err := s.loanRepo.GetLoanByApplicationID(ctx, applicationID)
+	if err != nil {
+		return dto.Loan{}, err
+	}
+	return mapLoanToDTO(loan, time.Now()), nil
+}
+
+// RecordRepayment books a payment against one installment of an active loan. A
+// payment may cover an installment partially but never more than what is left
+// of it; the loan is paid off once every installment is paid.
+func (s *loanService) RecordRepayment(ctx context.Context, userID, loanID int, request dto.LoanRepaymentRequest) (dto.Loan, error) {
+	if request.Amount <= 0 {
+		return dto.Loan{}, errors.New("repayment amount must be greater than zero")
+	}
+
+	now := time.Now()
+	paidAt := now
+	if request.PaidAt != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", request.PaidAt, now.Location())
+		if err != nil {
+			return dto.Loan{}, errors.New("invalid paid_at format, use YYYY-MM-DD")
+		}
+		if parsed.After(now) {
+			return dto.Loan{}, errors.New("paid_at cannot be in the future")
+		}
+		paidAt = parsed
+	}
+	amount := roundCents(request.Amount)
+
+	err := s.uow.Do(ctx, func(repos repository.TxRepositories) error {
+		loan, err := repos.Loans.LockLoan(ctx, loanID)
+		if err != nil {
+			return err
+		}
+		if loan.Status != constant.LoanStatusActive {
+			return fmt.Errorf("loan is %s and no longer accepts repayments", loan.Status)
+		}
+
+		index := -1
+		for i, installment := range loan.Installments {
+			if installment.ID == request.InstallmentID {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			return errors.New("installment not found in this loan")
+		}
+
+		installment := loan.Installments[index]
+		remaining := roundCents(installment.Amount - installment.PaidAmount)
+		if remaining <= 0 {
+			return fmt.Errorf("installment %d is already paid", installment.Number)
+		}
+		if amount > remaining {
+			return fmt.Errorf("repayment exceeds the remaining %.2f of installment %d", remaining, installment.Number)
+		}
+
+		if _, err := repos.Loans.CreateRepayment(ctx, model.LoanRepayment{
+			LoanID:        loan.ID,
+			InstallmentID: installment.ID,
+			Amount:        amount,
+			PaidAt:        paidAt,
+			Reference:     request.Reference,
+			Notes:         request.Notes,
+			RecordedBy:    userID,
+		}); err != nil {
+			return err
+		}
+
+		installment.PaidAmount = roundCents(installment.PaidAmount + amount)
+		installment.Status = constant.InstallmentStatusPartial
+		if installment.PaidAmount >= installment.Amount {
+			installment.Status = constant.InstallmentStatusPaid
+			installment.PaidAt = &paidAt
+		}
+		if err := repos.Loans.UpdateInstallmentPayment(ctx, installment); err != nil {
+			return err
+		}
+		loan.Installments[index] = installment
+
+		loan.PaidAmount = roundCents(loan.PaidAmount + amount)
+		if allInstallmentsPaid(loan.Installments) {
+			loan.Status = constant.LoanStatusPaidOff
+		}
+		return repos.Loans.UpdateLoanPayment(ctx, loan)
+	})
+	if err != nil {
+		return dto.Loan{}, err
+	}
+
+	loan, err := s.loanRepo.GetLoanByID(ctx, loanID)
+	if err != nil {
+		return dto.Loan{}, err
+	}
+	return mapLoanToDTO(loan, time.Now()), nil
+}
+
+func allInstallmentsPaid(installments []model.LoanInstallment) bool {
+	for _, installment := range installments {
+		if installment.Status != constant.InstallmentStatusPaid {
+			return false
+		}
+	}
+	return true
+}
+
+// mapLoanToDTO maps a loan with its running balance as of now. Overdue is not
+// stored: an installment of an active loan is overdue once its due date has
+// passed while it is not fully paid.
+func mapLoanToDTO(loan model.Loan, now time.Time) dto.Loan {
+	today := startOfDay(now)
+	result := dto.Loan{
+		ID:                 loan.ID,
+		ApplicationID:      loan.ApplicationID,
+		BusinessName:       loan.Application.UMKM.BusinessName,
+		ProgramTitle:       loan.Application.Program.Title,
+		Principal:          loan.Principal,
+		InterestRate:       loan.InterestRate,
+		InterestMethod:     loan.InterestMethod,
+		TenureMonths:       loan.TenureMonths,
+		TotalInterest:      loan.TotalInterest,
+		TotalPayable:       loan.TotalPayable,
+		PaidAmount:         loan.PaidAmount,
+		OutstandingBalance: roundCents(loan.TotalPayable - loan.PaidAmount),
+		Status:             loan.Status,
+		StartDate:          loan.StartDate.Format("2006-01-02"),
+	}
+	if loan.Status == constant.LoanStatusCancelled {
+		result.OutstandingBalance = 0
+	}
+
+	balance := loan.Principal
+	for _, installment := range loan.Installments {
+		balance = roundCents(balance - installment.Principal)
+		item := dto.LoanInstallment{
+			ID:         installment.ID,
+			Number:     installment.Number,
+			DueDate:    installment.DueDate.Format("2006-01-02"),
+			Principal:  installment.Principal,
+			Interest:   installment.Interest,
+			Amount:     installment.Amount,
+			Balance:    balance,
+			PaidAmount: installment.PaidAmount,
+			Remaining:  roundCents(installment.Amount - installment.PaidAmount),
+			Status:     installment.Status,
+		}
+		if installment.PaidAt != nil {
+			item.PaidAt = installment.PaidAt.Format("2006-01-02")
+		}
+
+		if loan.Status == constant.LoanStatusActive && installment.Status != constant.InstallmentStatusPaid {
+			dueDate := startOfDay(installment.DueDate)
+			if dueDate.Before(today) {
+				item.Status = constant.InstallmentStatusOverdue
+				item.DaysOverdue = int(today.Sub(dueDate).Hours() / 24)
+				result.OverdueAmount = roundCents(result.OverdueAmount + item.Remaining)
+				result.OverdueInstallments++
+			} else if result.NextDueDate == "" {
+				result.NextDueDate = item.DueDate
+			}
+		}
+		result.Installments = append(result.Installments, item)
+	}
+
+	for _, repayment := range loan.Repayments {
+		result.Repayments = append(result.Repayments, dto.LoanRepayment{
+			ID:             repayment.ID,
+			InstallmentID:  repayment.InstallmentID,
+			Amount:         repayment.Amount,
+			PaidAt:         repayment.PaidAt.Format("2006-01-02"),
+			Reference:      repayment.Reference,
+			Notes:          repayment.Notes,
+			RecordedBy:     repayment.RecordedBy,
+			RecordedByName: repayment.User.Name,
+		})
+	}
+	return result
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,422 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"UMKMGo-backend/internal/repository"
+	"UMKMGo-backend/internal/types/dto"
+	"UMKMGo-backend/internal/types/model"
+	"UMKMGo-backend/internal/utils/constant"
+)
+
+// Mock Loan Repository
+type mockLoanRepo struct {
+	loans      map[int]model.Loan
+	repayments []model.LoanRepayment
+}
+
+func newMockLoanRepo() *mockLoanRepo {
+	return &mockLoanRepo{
+		loans: make(map[int]model.Loan),
+	}
+}
+
+func (m *mockLoanRepo) CreateLoan(ctx context.Context, loan model.Loan) (model.Loan, error) {
+	for _, existing := range m.loans {
+		if existing.ApplicationID == loan.ApplicationID {
+			return model.Loan{}, errors.New("failed to create loan")
+		}
+	}
+	loan.ID = len(m.loans) + 1
+	for i := range loan.Installments {
+		loan.Installments[i].ID = loan.ID*100 + i + 1
+		loan.Installments[i].LoanID = loan.ID
+	}
+	m.loans[loan.ID] = loan
+	return loan, nil
+}
+
+func (m *mockLoanRepo) GetLoanByID(ctx context.Context, id int) (model.Loan, error) {
+	loan, exists := m.loans[id]
+	if !exists {
+		return model.Loan{}, errors.New("loan not found")
+	}
+	loan.Repayments = nil
+	for _, repayment := range m.repayments {
+		if repayment.LoanID == id {
+			loan.Repayments = append(loan.Repayments, repayment)
+		}
+	}
+	return loan, nil
+}
+
+func (m *mockLoanRepo) GetLoanByApplicationID(ctx context.Context, applicationID int) (model.Loan, error) {
+	for _, loan := range m.loans {
+		if loan.ApplicationID == applicationID {
+			return m.GetLoanByID(ctx, loan.ID)
+		}
+	}
+	return model.Loan{}, errors.New("loan not found")
+}
+
+func (m *mockLoanRepo) GetLoans(ctx context.Context, status string, overdueAt *time.Time) ([]model.Loan, error) {
+	var loans []model.Loan
+	for _, loan := range m.loans {
+		if status != "" && loan.Status != status {
+			continue
+		}
+		if overdueAt != nil {
+			overdue := false
+			for _, installment := range loan.Installments {
+				if installment.Status != constant.InstallmentStatusPaid && installment.DueDate.Before(*overdueAt) {
+					overdue = true
+				}
+			}
+			if !overdue {
+				continue
+			}
+		}
+		loans = append(loans, loan)
+	}
+	return loans, nil
+}
+
+func (m *mockLoanRepo) LockLoan(ctx context.Context, id int) (model.Loan, error) {
+	loan, exists := m.loans[id]
+	if !exists {
+		return model.Loan{}, errors.New("loan not found")
+	}
+	loan.Installments = append([]model.LoanInstallment(nil), loan.Installments...)
+	return loan, nil
+}
+
+func (m *mockLoanRepo) CancelLoan(ctx context.Context, applicationID int) error {
+	for id, loan := range m.loans {
+		if loan.ApplicationID == applicationID && loan.Status == constant.LoanStatusActive {
+			if loan.PaidAmount > 0 || m.hasRepayments(loan.ID) {
+				return repository.ErrLoanInRepayment
+			}
+			loan.Status = constant.LoanStatusCancelled
+			m.loans[id] = loan
+		}
+	}
+	return nil
+}
+
+func (m *mockLoanRepo) hasRepayments(loanID int) bool {
+	for _, repayment := range m.repayments {
+		if repayment.LoanID == loanID {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *mockLoanRepo) CreateRepayment(ctx context.Context, repayment model.LoanRepayment) (model.LoanRepayment, error) {
+	repayment.ID = len(m.repayments) + 1
+	m.repayments = append(m.repayments, repayment)
+	return repayment, nil
+}
+
+func (m *mockLoanRepo) UpdateInstallmentPayment(ctx context.Context, installment model.LoanInstallment) error {
+	loan := m.loans[installment.LoanID]
+	for i := range loan.Installments {
+		if loan.Installments[i].ID == installment.ID {
+			loan.Installments[i] = installment
+		}
+	}
+	m.loans[loan.ID] = loan
+	return nil
+}
+
+func (m *mockLoanRepo) UpdateLoanPayment(ctx context.Context, loan model.Loan) error {
+	current := m.loans[loan.ID]
+	current.PaidAmount = loan.PaidAmount
+	current.Status = loan.Status
+	m.loans[loan.ID] = current
+	return nil
+}
+
+func TestAmortize(t *testing.T) {
+	start := time.Date(2026, 1, 15, 0, 0, 0, 0, time.Local)
+	sumPrincipal := func(schedule []plannedInstallment) (principal, interest float64) {
+		for _, installment := range schedule {
+			principal += installment.Principal
+			interest += installment.Interest
+		}
+		return roundCents(principal), roundCents(interest)
+	}
+
+	t.Run("Flat interest is charged on the original principal", func(t *testing.T) {
+		schedule := amortize(loanTerms{Principal: 12000000, AnnualRate: 12, TenureMonths: 12, Method: constant.InterestMethodFlat, StartDate: start})
+		if len(schedule) != 12 {
+			t.Fatalf("Expected 12 installments, got %d", len(schedule))
+		}
+		for _, installment := range schedule {
+			if installment.Principal != 1000000 || installment.Interest != 120000 || installment.Amount != 1120000 {
+				t.Fatalf("Expected 1000000 + 120000 per installment, got %+v", installment)
+			}
+		}
+		principal, interest := sumPrincipal(schedule)
+		if principal != 12000000 || interest != 1440000 {
+			t.Errorf("Expected principal 12000000 and interest 1440000, got %.2f and %.2f", principal, interest)
+		}
+		if schedule[11].Balance != 0 {
+			t.Errorf("Expected the last installment to clear the balance, got %.2f", schedule[11].Balance)
+		}
+	})
+
+	t.Run("Annuity pays equal installments with interest on the balance", func(t *testing.T) {
+		schedule := amortize(loanTerms{Principal: 12000000, AnnualRate: 12, TenureMonths: 12, Method: constant.InterestMethodAnnuity, StartDate: start})
+		if schedule[0].Interest != 120000 {
+			t.Errorf("Expected first interest 120000, got %.2f", schedule[0].Interest)
+		}
+		if schedule[0].Amount != 1066185.46 {
+			t.Errorf("Expected installment 1066185.46, got %.2f", schedule[0].Amount)
+		}
+		for _, installment := range schedule {
+			if math.Abs(installment.Amount-schedule[0].Amount) > 0.1 {
+				t.Errorf("Expected equal installments, got %.2f at %d", installment.Amount, installment.Number)
+			}
+		}
+		if schedule[1].Interest >= schedule[0].Interest {
+			t.Error("Expected interest to fall as the balance is repaid")
+		}
+		principal, _ := sumPrincipal(schedule)
+		if principal != 12000000 || schedule[11].Balance != 0 {
+			t.Errorf("Expected principal to add up to 12000000, got %.2f", principal)
+		}
+	})
+
+	t.Run("Zero rate spreads the principal without interest", func(t *testing.T) {
+		schedule := amortize(loanTerms{Principal: 10000000, TenureMonths: 3, Method: constant.InterestMethodAnnuity, StartDate: start})
+		principal, interest := sumPrincipal(schedule)
+		if principal != 10000000 || interest != 0 {
+			t.Errorf("Expected 10000000 without interest, got %.2f and %.2f", principal, interest)
+		}
+		if schedule[2].Principal != 3333333.34 {
+			t.Errorf("Expected the last installment to absorb rounding, got %.2f", schedule[2].Principal)
+		}
+	})
+
+	t.Run("Due dates stay on the day of month or its last day", func(t *testing.T) {
+		schedule := amortize(loanTerms{Principal: 3000000, TenureMonths: 3, StartDate: time.Date(2026, 1, 31, 0, 0, 0, 0, time.Local)})
+		expected := []string{"2026-02-28", "2026-03-31", "2026-04-30"}
+		for i, installment := range schedule {
+			if got := installment.DueDate.Format("2006-01-02"); got != expected[i] {
+				t.Errorf("Expected installment %d due %s, got %s", installment.Number, expected[i], got)
+			}
+		}
+	})
+
+	t.Run("Programs default to annuity", func(t *testing.T) {
+		rate := 6.0
+		terms := loanTermsFor(model.Program{InterestRate: &rate}, 5000000, 6, start)
+		if terms.Method != constant.InterestMethodAnnuity || terms.AnnualRate != 6 {
+			t.Errorf("Expected annuity at 6%%, got %s at %.2f", terms.Method, terms.AnnualRate)
+		}
+	})
+}
+
+func TestLoanOnFundingApproval(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func() (*applicationsService, *mockApplicationsRepo, *mockLoanRepo) {
+		service, mockRepo, _ := setupApplicationsService()
+		loanRepo := newMockLoanRepo()
+		service.uow = newMockUnitOfWork(repository.TxRepositories{
+			Applications:       mockRepo,
+			Notifications:      newMockNotificationRepo(),
+			AdminNotifications: newMockAdminNotificationRepo(),
+			Loans:              loanRepo,
+		})
+
+		rate, method := 12.0, constant.InterestMethodFlat
+		program := mockRepo.programs[2]
+		program.InterestRate = &rate
+		program.InterestMethod = &method
+		mockRepo.programs[2] = program
+
+		mockRepo.applications[1] = model.Application{
+			ID:        1,
+			UMKMID:    1,
+			ProgramID: 2,
+			Type:      "funding",
+			Status:    constant.ApplicationStatusFinal,
+			UMKM:      mockRepo.umkms[1],
+			Program:   program,
+			FundingApplication: &model.FundingApplication{
+				ApplicationID:         1,
+				RequestedAmount:       6000000,
+				RequestedTenureMonths: 6,
+			},
+		}
+		return service, mockRepo, loanRepo
+	}
+
+	t.Run("Approval schedules the requested loan", func(t *testing.T) {
+		service, _, loanRepo := setup()
+		if _, err := service.FinalApprove(ctx, 1, 1, 0); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		loan, err := loanRepo.GetLoanByApplicationID(ctx, 1)
+		if err != nil {
+			t.Fatalf("Expected a loan, got %v", err)
+		}
+		if loan.Principal != 6000000 || loan.InterestMethod != constant.InterestMethodFlat || len(loan.Installments) != 6 {
+			t.Errorf("Expected a 6 month flat loan of 6000000, got %+v", loan)
+		}
+		if loan.TotalInterest != 360000 || loan.TotalPayable != 6360000 || loan.Status != constant.LoanStatusActive {
+			t.Errorf("Expected interest 360000 and payable 6360000, got %.2f and %.2f", loan.TotalInterest, loan.TotalPayable)
+		}
+		if loan.Installments[0].DueDate != addMonths(startOfDay(time.Now()), 1) {
+			t.Errorf("Expected the first installment a month after approval, got %v", loan.Installments[0].DueDate)
+		}
+	})
+
+	t.Run("Withdrawing an approval cancels the loan", func(t *testing.T) {
+		service, mockRepo, loanRepo := setup()
+		if _, err := service.FinalApprove(ctx, 1, 1, 0); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		workflow := newApplicationWorkflow(service.uow, newSLACalendar(newMockSLARepo(), newMockHolidayRepo()))
+		if _, err := workflow.Fire(ctx, eventWithdraw, mockRepo.applications[1], 1, "no longer needed"); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if loan, _ := loanRepo.GetLoanByApplicationID(ctx, 1); loan.Status != constant.LoanStatusCancelled {
+			t.Errorf("Expected cancelled loan, got %s", loan.Status)
+		}
+	})
+
+	t.Run("Withdrawing after a repayment keeps the loan", func(t *testing.T) {
+		service, mockRepo, loanRepo := setup()
+		if _, err := service.FinalApprove(ctx, 1, 1, 0); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		loan, _ := loanRepo.GetLoanByApplicationID(ctx, 1)
+		loanService := NewLoanService(loanRepo, newMockUnitOfWork(repository.TxRepositories{Loans: loanRepo}))
+		if _, err := loanService.RecordRepayment(ctx, 1, loan.ID, dto.LoanRepaymentRequest{InstallmentID: loan.Installments[0].ID, Amount: 500000}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		workflow := newApplicationWorkflow(service.uow, newSLACalendar(newMockSLARepo(), newMockHolidayRepo()))
+		_, err := workflow.Fire(ctx, eventWithdraw, mockRepo.applications[1], 1, "no longer needed")
+		if !errors.Is(err, repository.ErrLoanInRepayment) {
+			t.Fatalf("Expected ErrLoanInRepayment, got %v", err)
+		}
+		if loan, _ := loanRepo.GetLoanByApplicationID(ctx, 1); loan.Status != constant.LoanStatusActive || loan.PaidAmount != 500000 {
+			t.Errorf("Expected the loan to stay active with 500000 paid, got %s and %.2f", loan.Status, loan.PaidAmount)
+		}
+	})
+}
+
+func TestRecordRepayment(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func(start time.Time) (LoanService, *mockLoanRepo, model.Loan) {
+		loanRepo := newMockLoanRepo()
+		terms := loanTerms{Principal: 3000000, TenureMonths: 3, Method: constant.InterestMethodAnnuity, StartDate: start}
+		loan := model.Loan{ApplicationID: 1, Principal: 3000000, TenureMonths: 3, TotalPayable: 3000000, Status: constant.LoanStatusActive, StartDate: start}
+		for _, planned := range amortize(terms) {
+			loan.Installments = append(loan.Installments, model.LoanInstallment{
+				Number:    planned.Number,
+				DueDate:   planned.DueDate,
+				Principal: planned.Principal,
+				Amount:    planned.Amount,
+				Status:    constant.InstallmentStatusUnpaid,
+			})
+		}
+		loan, _ = loanRepo.CreateLoan(ctx, loan)
+		service := NewLoanService(loanRepo, newMockUnitOfWork(repository.TxRepositories{Loans: loanRepo}))
+		return service, loanRepo, loan
+	}
+
+	t.Run("Partial then full payment settles the installment", func(t *testing.T) {
+		service, _, loan := setup(startOfDay(time.Now()))
+		installmentID := loan.Installments[0].ID
+
+		result, err := service.RecordRepayment(ctx, 1, loan.ID, dto.LoanRepaymentRequest{InstallmentID: installmentID, Amount: 400000})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if result.Installments[0].Status != constant.InstallmentStatusPartial || result.Installments[0].Remaining != 600000 {
+			t.Errorf("Expected partial installment with 600000 left, got %+v", result.Installments[0])
+		}
+		if result.OutstandingBalance != 2600000 {
+			t.Errorf("Expected outstanding 2600000, got %.2f", result.OutstandingBalance)
+		}
+
+		result, err = service.RecordRepayment(ctx, 1, loan.ID, dto.LoanRepaymentRequest{InstallmentID: installmentID, Amount: 600000})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if result.Installments[0].Status != constant.InstallmentStatusPaid || result.Installments[0].PaidAt == "" {
+			t.Errorf("Expected paid installment, got %+v", result.Installments[0])
+		}
+		if len(result.Repayments) != 2 || result.NextDueDate != result.Installments[1].DueDate {
+			t.Errorf("Expected 2 repayments and the next installment due, got %d and %s", len(result.Repayments), result.NextDueDate)
+		}
+	})
+
+	t.Run("Overpaying an installment is rejected", func(t *testing.T) {
+		service, _, loan := setup(startOfDay(time.Now()))
+		_, err := service.RecordRepayment(ctx, 1, loan.ID, dto.LoanRepaymentRequest{InstallmentID: loan.Installments[0].ID, Amount: 1000000.01})
+		if err == nil {
+			t.Error("Expected error for repayment above the installment")
+		}
+	})
+
+	t.Run("Installment of another loan is rejected", func(t *testing.T) {
+		service, _, loan := setup(startOfDay(time.Now()))
+		_, err := service.RecordRepayment(ctx, 1, loan.ID, dto.LoanRepaymentRequest{InstallmentID: 999, Amount: 1000})
+		if err == nil {
+			t.Error("Expected error for unknown installment")
+		}
+	})
+
+	t.Run("Paying every installment pays off the loan", func(t *testing.T) {
+		service, loanRepo, loan := setup(startOfDay(time.Now()))
+		for _, installment := range loan.Installments {
+			if _, err := service.RecordRepayment(ctx, 1, loan.ID, dto.LoanRepaymentRequest{InstallmentID: installment.ID, Amount: installment.Amount}); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+		}
+		if loanRepo.loans[loan.ID].Status != constant.LoanStatusPaidOff {
+			t.Errorf("Expected paid off loan, got %s", loanRepo.loans[loan.ID].Status)
+		}
+
+		_, err := service.RecordRepayment(ctx, 1, loan.ID, dto.LoanRepaymentRequest{InstallmentID: loan.Installments[0].ID, Amount: 1000})
+		if err == nil {
+			t.Error("Expected error for repayment on a paid off loan")
+		}
+	})
+
+	t.Run("Unpaid installments past due are overdue", func(t *testing.T) {
+		service, _, _ := setup(startOfDay(time.Now()).AddDate(0, -2, -5))
+
+		loans, err := service.GetLoans(ctx, dto.LoanQueryParams{Overdue: true})
+		if err != nil || len(loans) != 1 {
+			t.Fatalf("Expected 1 overdue loan, got %d (%v)", len(loans), err)
+		}
+		if loans[0].OverdueInstallments != 2 || loans[0].OverdueAmount != 2000000 {
+			t.Errorf("Expected 2 overdue installments of 2000000, got %d and %.2f", loans[0].OverdueInstallments, loans[0].OverdueAmount)
+		}
+
+		loan, err := service.GetLoanByApplicationID(ctx, 1)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if loan.Installments[0].Status != constant.InstallmentStatusOverdue || loan.Installments[0].DaysOverdue <= 0 {
+			t.Errorf("Expected the first installment overdue, got %+v", loan.Installments[0])
+		}
+		if loan.Installments[2].Status != constant.InstallmentStatusUnpaid || loan.NextDueDate != loan.Installments[2].DueDate {
+			t.Errorf("Expected the last installment still upcoming, got %+v", loan.Installments[2])
+		}
+	})
+}
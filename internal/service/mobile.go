@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -24,10 +25,11 @@ type MobileService interface {
 	GetDashboard(ctx context.Context, userID int) (dto.DashboardData, error)
 
 	// Programs
-	GetTrainingPrograms(ctx context.Context) ([]dto.ProgramListMobile, error)
-	GetCertificationPrograms(ctx context.Context) ([]dto.ProgramListMobile, error)
-	GetFundingPrograms(ctx context.Context) ([]dto.ProgramListMobile, error)
-	GetProgramDetail(ctx context.Context, id int) (dto.ProgramDetailMobile, error)
+	GetTrainingPrograms(ctx context.Context, userID int) ([]dto.ProgramListMobile, error)
+	GetCertificationPrograms(ctx context.Context, userID int) ([]dto.ProgramListMobile, error)
+	GetFundingPrograms(ctx context.Context, userID int) ([]dto.ProgramListMobile, error)
+	GetProgramDetail(ctx context.Context, userID, id int) (dto.ProgramDetailMobile, error)
+	SimulateFunding(ctx context.Context, programID int, request dto.FundingSimulationRequest) (dto.FundingSimulation, error)
 
 	// UMKM Profile
 	GetUMKMProfile(ctx context.Context, userID int) (dto.UMKMProfile, error)
@@ -44,7 +46,20 @@ type MobileService interface {
 	GetApplicationList(ctx context.Context, userID int) ([]dto.ApplicationListMobile, error)
 	GetApplicationDetail(ctx context.Context, id int) (dto.ApplicationDetailMobile, error)
 	GetUMKMProfileWithDecryption(ctx context.Context, userID int, purpose string) (dto.UMKMProfile, error)
-	ReviseApplication(ctx context.Context, userID, applicationID int, documents []dto.UploadDocumentRequest) error
+	ReviseApplication(ctx context.Context, userID, applicationID int, request dto.ReviseApplicationRequest) error
+	WithdrawApplication(ctx context.Context, userID, applicationID int, request dto.WithdrawApplicationRequest) error
+	GetApplicationLoan(ctx context.Context, userID, applicationID int) (dto.Loan, error)
+
+	// Certificates
+	GetMyCertificates(ctx context.Context, userID int) ([]dto.TrainingCertificate, error)
+	GetMyCertifications(ctx context.Context, userID int) ([]dto.Certification, error)
+
+	// Application Drafts
+	CreateApplicationDraft(ctx context.Context, userID int, request dto.CreateApplicationDraft) (dto.ApplicationDetailMobile, error)
+	UpdateApplicationDraft(ctx context.Context, userID, applicationID int, request dto.ApplicationDraftFields) (dto.ApplicationDetailMobile, error)
+	ValidateApplicationDraft(ctx context.Context, userID, applicationID int) (dto.DraftValidationResult, error)
+	SubmitApplicationDraft(ctx context.Context, userID, applicationID int) error
+	DeleteApplicationDraft(ctx context.Context, userID, applicationID int) error
 
 	// Notifications
 	GetNotificationsByUMKMID(ctx context.Context, umkmID int) ([]dto.NotificationResponse, error)
@@ -58,24 +73,34 @@ type MobileService interface {
 }
 
 type mobileService struct {
-	mobileRepo       repository.MobileRepository
-	programRepo      repository.ProgramsRepository
-	notificationRepo repository.NotificationRepository
-	vaultLogRepo     repository.VaultDecryptLogRepository
-	applicationRepo  repository.ApplicationsRepository
-	slaRepo          repository.SLARepository
-	minio            *storage.MinIOManager
+	mobileRepo        repository.MobileRepository
+	programRepo       repository.ProgramsRepository
+	notificationRepo  repository.NotificationRepository
+	vaultLogRepo      repository.VaultDecryptLogRepository
+	applicationRepo   repository.ApplicationsRepository
+	slaRepo           repository.SLARepository
+	holidayRepo       repository.HolidayRepository
+	loanRepo          repository.LoanRepository
+	trainingRepo      repository.TrainingRepository
+	certificationRepo repository.CertificationRepository
+	uow               repository.UnitOfWork
+	minio             *storage.MinIOManager
 }
 
-func NewMobileService(mobileRepo repository.MobileRepository, programRepo repository.ProgramsRepository, notificationRepo repository.NotificationRepository, vaultLogRepo repository.VaultDecryptLogRepository, applicationRepo repository.ApplicationsRepository, slaRepo repository.SLARepository, minio *storage.MinIOManager) MobileService {
+func NewMobileService(mobileRepo repository.MobileRepository, programRepo repository.ProgramsRepository, notificationRepo repository.NotificationRepository, vaultLogRepo repository.VaultDecryptLogRepository, applicationRepo repository.ApplicationsRepository, slaRepo repository.SLARepository, holidayRepo repository.HolidayRepository, loanRepo repository.LoanRepository, trainingRepo repository.TrainingRepository, certificationRepo repository.CertificationRepository, uow repository.UnitOfWork, minio *storage.MinIOManager) MobileService {
 	return &mobileService{
-		mobileRepo:       mobileRepo,
-		programRepo:      programRepo,
-		notificationRepo: notificationRepo,
-		vaultLogRepo:     vaultLogRepo,
-		applicationRepo:  applicationRepo,
-		slaRepo:          slaRepo,
-		minio:            minio,
+		mobileRepo:        mobileRepo,
+		programRepo:       programRepo,
+		notificationRepo:  notificationRepo,
+		vaultLogRepo:      vaultLogRepo,
+		applicationRepo:   applicationRepo,
+		slaRepo:           slaRepo,
+		holidayRepo:       holidayRepo,
+		loanRepo:          loanRepo,
+		trainingRepo:      trainingRepo,
+		certificationRepo: certificationRepo,
+		uow:               uow,
+		minio:             minio,
 	}
 }
 
@@ -123,34 +148,67 @@ func (s *mobileService) GetDashboard(ctx context.Context, userID int) (dto.Dashb
 }
 
 // Programs
-func (s *mobileService) GetTrainingPrograms(ctx context.Context) ([]dto.ProgramListMobile, error) {
+func (s *mobileService) GetTrainingPrograms(ctx context.Context, userID int) ([]dto.ProgramListMobile, error) {
 	programs, err := s.mobileRepo.GetProgramsByType(ctx, "training")
 	if err != nil {
 		return nil, err
 	}
 
-	return s.mapProgramsToDTO(programs), nil
+	return s.mapProgramsToDTO(ctx, userID, programs)
 }
 
-func (s *mobileService) GetCertificationPrograms(ctx context.Context) ([]dto.ProgramListMobile, error) {
+func (s *mobileService) GetCertificationPrograms(ctx context.Context, userID int) ([]dto.ProgramListMobile, error) {
 	programs, err := s.mobileRepo.GetProgramsByType(ctx, "certification")
 	if err != nil {
 		return nil, err
 	}
 
-	return s.mapProgramsToDTO(programs), nil
+	return s.mapProgramsToDTO(ctx, userID, programs)
 }
 
-func (s *mobileService) GetFundingPrograms(ctx context.Context) ([]dto.ProgramListMobile, error) {
+func (s *mobileService) GetFundingPrograms(ctx context.Context, userID int) ([]dto.ProgramListMobile, error) {
 	programs, err := s.mobileRepo.GetProgramsByType(ctx, "funding")
 	if err != nil {
 		return nil, err
 	}
 
-	return s.mapProgramsToDTO(programs), nil
+	return s.mapProgramsToDTO(ctx, userID, programs)
+}
+
+// SimulateFunding shows the installments of a funding program for an amount and
+// tenure as if approved today. It uses the calculator that opens the loan on
+// approval, so the numbers match once the application is approved the same day.
+func (s *mobileService) SimulateFunding(ctx context.Context, programID int, request dto.FundingSimulationRequest) (dto.FundingSimulation, error) {
+	program, err := s.mobileRepo.GetProgramDetailByID(ctx, programID)
+	if err != nil {
+		return dto.FundingSimulation{}, err
+	}
+	if program.Type != "funding" {
+		return dto.FundingSimulation{}, errors.New("program type must be funding")
+	}
+	if err := validateFundingRequest(program, request.RequestedAmount, request.RequestedTenureMonths); err != nil {
+		return dto.FundingSimulation{}, err
+	}
+
+	now := time.Now()
+	terms := loanTermsFor(program, request.RequestedAmount, request.RequestedTenureMonths, startOfDay(now))
+	schedule := mapLoanToDTO(newLoan(0, terms), now)
+
+	return dto.FundingSimulation{
+		ProgramID:          program.ID,
+		ProgramTitle:       program.Title,
+		Principal:          schedule.Principal,
+		InterestRate:       schedule.InterestRate,
+		InterestMethod:     schedule.InterestMethod,
+		TenureMonths:       schedule.TenureMonths,
+		MonthlyInstallment: schedule.Installments[0].Amount,
+		TotalInterest:      schedule.TotalInterest,
+		TotalPayable:       schedule.TotalPayable,
+		Installments:       schedule.Installments,
+	}, nil
 }
 
-func (s *mobileService) GetProgramDetail(ctx context.Context, id int) (dto.ProgramDetailMobile, error) {
+func (s *mobileService) GetProgramDetail(ctx context.Context, userID, id int) (dto.ProgramDetailMobile, error) {
 	program, err := s.mobileRepo.GetProgramDetailByID(ctx, id)
 	if err != nil {
 		return dto.ProgramDetailMobile{}, err
@@ -182,11 +240,19 @@ func (s *mobileService) GetProgramDetail(ctx context.Context, id int) (dto.Progr
 		requirementNames = []string{}
 	}
 
-	return dto.ProgramDetailMobile{
-		ProgramListMobile: s.mapProgramToDTO(program),
+	usage, err := s.applicationRepo.GetProgramUsage(ctx, []int{program.ID})
+	if err != nil {
+		return dto.ProgramDetailMobile{}, err
+	}
+
+	detail := dto.ProgramDetailMobile{
+		ProgramListMobile: s.mapProgramToDTO(program, usage[program.ID]),
 		Benefits:          benefitNames,
 		Requirements:      requirementNames,
-	}, nil
+	}
+	detail.Eligibility = s.eligibilityFor(ctx, userID, program)
+
+	return detail, nil
 }
 
 // UMKM Profile
@@ -411,8 +477,8 @@ func (s *mobileService) UploadDocument(ctx context.Context, userID int, doc dto.
 // Applications
 // Training Application
 func (s *mobileService) CreateTrainingApplication(ctx context.Context, userID int, request dto.CreateApplicationTraining) error {
-	// Validate program
-	program, err := s.mobileRepo.GetProgramByID(ctx, request.ProgramID)
+	// Validate program is open for applications
+	program, err := s.openProgram(ctx, request.ProgramID)
 	if err != nil {
 		return err
 	}
@@ -421,8 +487,12 @@ func (s *mobileService) CreateTrainingApplication(ctx context.Context, userID in
 		return errors.New("program type must be training")
 	}
 
-	// Check if already applied
-	if s.mobileRepo.IsApplicationExists(ctx, userID, request.ProgramID) {
+	// Check if already applied; applications are keyed by UMKM, not by user
+	profile, err := s.mobileRepo.GetUMKMProfileByID(ctx, userID)
+	if err != nil {
+		return errors.New("UMKM profile not found, please complete your profile first")
+	}
+	if s.mobileRepo.IsApplicationExists(ctx, profile.ID, request.ProgramID) {
 		return errors.New("you have already applied for this program")
 	}
 
@@ -432,7 +502,15 @@ func (s *mobileService) CreateTrainingApplication(ctx context.Context, userID in
 		return errors.New("UMKM profile not found, please complete your profile first")
 	}
 
-	screeningExpiredAt, err := s.slaRepo.GetSLAByStatus(ctx, "screening")
+	// Check the program's eligibility rules against the profile and the form
+	eligibility, err := checkEligibility(program, umkm, model.Application{Type: "training"})
+	if err != nil {
+		return err
+	}
+
+	// Get screening deadline
+	submittedAt := time.Now()
+	expiredAt, err := newSLACalendar(s.slaRepo, s.holidayRepo).Deadline(ctx, "screening", "training", request.ProgramID, submittedAt)
 	if err != nil {
 		return err
 	}
@@ -443,48 +521,61 @@ func (s *mobileService) CreateTrainingApplication(ctx context.Context, userID in
 		ProgramID:   request.ProgramID,
 		Type:        "training",
 		Status:      "screening",
-		SubmittedAt: time.Now(),
-		ExpiredAt:   time.Now().AddDate(0, 0, screeningExpiredAt.MaxDays),
+		SubmittedAt: submittedAt,
+		ExpiredAt:   expiredAt,
+		Eligibility: eligibility,
 	}
 
-	createdApp, err := s.mobileRepo.CreateApplication(ctx, application)
-	if err != nil {
-		return err
-	}
+	// Persist application, type-specific data, history and notification atomically
+	var createdApp model.Application
+	err = s.uow.Do(ctx, func(repos repository.TxRepositories) error {
+		tx := s.withTx(repos)
 
-	// Create training-specific data
-	trainingApp := model.TrainingApplication{
-		ApplicationID:      createdApp.ID,
-		Motivation:         request.Motivation,
-		BusinessExperience: request.BusinessExperience,
-		LearningObjectives: request.LearningObjectives,
-		AvailabilityNotes:  request.AvailabilityNotes,
-	}
+		app, err := tx.mobileRepo.CreateApplication(ctx, application)
+		if err != nil {
+			return err
+		}
+		createdApp = app
+
+		// Create training-specific data
+		trainingApp := model.TrainingApplication{
+			ApplicationID:      createdApp.ID,
+			Motivation:         request.Motivation,
+			BusinessExperience: request.BusinessExperience,
+			LearningObjectives: request.LearningObjectives,
+			AvailabilityNotes:  request.AvailabilityNotes,
+		}
 
-	if err := s.mobileRepo.CreateTrainingApplication(ctx, trainingApp); err != nil {
-		return err
-	}
+		if err := tx.mobileRepo.CreateTrainingApplication(ctx, trainingApp); err != nil {
+			return err
+		}
 
-	// Create history
-	if err := s.createApplicationHistory(ctx, createdApp.ID, umkm.UserID, "submit", "Training application submitted"); err != nil {
-		return err
-	}
+		// Create history
+		if err := tx.createApplicationHistory(ctx, createdApp.ID, umkm.UserID, "submit", "Training application submitted"); err != nil {
+			return err
+		}
+
+		// Create notification
+		if err := tx.createNotification(ctx, umkm.ID, createdApp.ID, constant.NotificationSubmitted, constant.NotificationTitleSubmitted, constant.NotificationMessageSubmitted); err != nil {
+			return err
+		}
 
-	// Create notification
-	if err := s.createNotification(ctx, umkm.ID, createdApp.ID, constant.NotificationSubmitted, constant.NotificationTitleSubmitted, constant.NotificationMessageSubmitted); err != nil {
+		return nil
+	})
+	if err != nil {
 		return err
 	}
 
 	// Process and save documents
-	go s.processAndSaveDocuments(ctx, createdApp.ID, request.Documents)
+	go s.processAndSaveDocuments(ctx, createdApp.ID, 0, request.Documents)
 
 	return nil
 }
 
 // Certification Application
 func (s *mobileService) CreateCertificationApplication(ctx context.Context, userID int, request dto.CreateApplicationCertification) error {
-	// Validate program
-	program, err := s.mobileRepo.GetProgramByID(ctx, request.ProgramID)
+	// Validate program is open for applications
+	program, err := s.openProgram(ctx, request.ProgramID)
 	if err != nil {
 		return err
 	}
@@ -493,8 +584,12 @@ func (s *mobileService) CreateCertificationApplication(ctx context.Context, user
 		return errors.New("program type must be certification")
 	}
 
-	// Check if already applied
-	if s.mobileRepo.IsApplicationExists(ctx, userID, request.ProgramID) {
+	// Check if already applied; applications are keyed by UMKM, not by user
+	profile, err := s.mobileRepo.GetUMKMProfileByID(ctx, userID)
+	if err != nil {
+		return errors.New("UMKM profile not found, please complete your profile first")
+	}
+	if s.mobileRepo.IsApplicationExists(ctx, profile.ID, request.ProgramID) {
 		return errors.New("you have already applied for this program")
 	}
 
@@ -504,7 +599,18 @@ func (s *mobileService) CreateCertificationApplication(ctx context.Context, user
 		return errors.New("UMKM profile not found, please complete your profile first")
 	}
 
-	screeningExpiredAt, err := s.slaRepo.GetSLAByStatus(ctx, "screening")
+	// Check the program's eligibility rules against the profile and the form
+	eligibility, err := checkEligibility(program, umkm, model.Application{
+		Type:                     "certification",
+		CertificationApplication: &model.CertificationApplication{YearsOperating: request.YearsOperating},
+	})
+	if err != nil {
+		return err
+	}
+
+	// Get screening deadline
+	submittedAt := time.Now()
+	expiredAt, err := newSLACalendar(s.slaRepo, s.holidayRepo).Deadline(ctx, "screening", "certification", request.ProgramID, submittedAt)
 	if err != nil {
 		return err
 	}
@@ -515,74 +621,82 @@ func (s *mobileService) CreateCertificationApplication(ctx context.Context, user
 		ProgramID:   request.ProgramID,
 		Type:        "certification",
 		Status:      "screening",
-		SubmittedAt: time.Now(),
-		ExpiredAt:   time.Now().AddDate(0, 0, screeningExpiredAt.MaxDays),
+		SubmittedAt: submittedAt,
+		ExpiredAt:   expiredAt,
+		Eligibility: eligibility,
 	}
 
-	createdApp, err := s.mobileRepo.CreateApplication(ctx, application)
-	if err != nil {
-		return err
-	}
+	// Persist application, type-specific data, history and notification atomically
+	var createdApp model.Application
+	err = s.uow.Do(ctx, func(repos repository.TxRepositories) error {
+		tx := s.withTx(repos)
 
-	// Create certification-specific data
-	certApp := model.CertificationApplication{
-		ApplicationID:       createdApp.ID,
-		BusinessSector:      request.BusinessSector,
-		ProductOrService:    request.ProductOrService,
-		BusinessDescription: request.BusinessDescription,
-		YearsOperating:      request.YearsOperating,
-		CurrentStandards:    request.CurrentStandards,
-		CertificationGoals:  request.CertificationGoals,
-	}
+		app, err := tx.mobileRepo.CreateApplication(ctx, application)
+		if err != nil {
+			return err
+		}
+		createdApp = app
+
+		// Create certification-specific data
+		certApp := model.CertificationApplication{
+			ApplicationID:       createdApp.ID,
+			BusinessSector:      request.BusinessSector,
+			ProductOrService:    request.ProductOrService,
+			BusinessDescription: request.BusinessDescription,
+			YearsOperating:      request.YearsOperating,
+			CurrentStandards:    request.CurrentStandards,
+			CertificationGoals:  request.CertificationGoals,
+		}
 
-	if err := s.mobileRepo.CreateCertificationApplication(ctx, certApp); err != nil {
-		return err
-	}
+		if err := tx.mobileRepo.CreateCertificationApplication(ctx, certApp); err != nil {
+			return err
+		}
 
-	// Create history
-	if err := s.createApplicationHistory(ctx, createdApp.ID, umkm.UserID, "submit", "Certification application submitted"); err != nil {
-		return err
-	}
+		// Create history
+		if err := tx.createApplicationHistory(ctx, createdApp.ID, umkm.UserID, "submit", "Certification application submitted"); err != nil {
+			return err
+		}
+
+		// Create notification
+		if err := tx.createNotification(ctx, umkm.ID, createdApp.ID, constant.NotificationSubmitted, constant.NotificationTitleSubmitted, constant.NotificationMessageSubmitted); err != nil {
+			return err
+		}
 
-	// Create notification
-	if err := s.createNotification(ctx, umkm.ID, createdApp.ID, constant.NotificationSubmitted, constant.NotificationTitleSubmitted, constant.NotificationMessageSubmitted); err != nil {
+		return nil
+	})
+	if err != nil {
 		return err
 	}
 
 	// Process and save documents
-	go s.processAndSaveDocuments(ctx, createdApp.ID, request.Documents)
+	go s.processAndSaveDocuments(ctx, createdApp.ID, 0, request.Documents)
 
 	return nil
 }
 
 // Funding Application
 func (s *mobileService) CreateFundingApplication(ctx context.Context, userID int, request dto.CreateApplicationFunding) error {
-	// Validate program
-	program, err := s.mobileRepo.GetProgramByID(ctx, request.ProgramID)
+	// Validate program is open for applications
+	program, err := s.openProgram(ctx, request.ProgramID)
 	if err != nil {
 		return err
 	}
 
-	// Validate requested amount
-	if program.MinAmount != nil && request.RequestedAmount < *program.MinAmount {
-		return fmt.Errorf("requested amount must be at least %.2f", *program.MinAmount)
-	}
-	if program.MaxAmount != nil && request.RequestedAmount > *program.MaxAmount {
-		return fmt.Errorf("requested amount cannot exceed %.2f", *program.MaxAmount)
+	if err := validateFundingRequest(program, request.RequestedAmount, request.RequestedTenureMonths); err != nil {
+		return err
 	}
 
-	// Validate tenure
-	if program.MaxTenureMonths != nil && request.RequestedTenureMonths > *program.MaxTenureMonths {
-		return fmt.Errorf("requested tenure cannot exceed %d months", *program.MaxTenureMonths)
+	if program.Type != "funding" {
+		return errors.New("program type must be funding")
 	}
 
-	// Check if already applied
-	if s.mobileRepo.IsApplicationExists(ctx, userID, request.ProgramID) {
-		return errors.New("you have already applied for this program")
+	// Check if already applied; applications are keyed by UMKM, not by user
+	profile, err := s.mobileRepo.GetUMKMProfileByID(ctx, userID)
+	if err != nil {
+		return errors.New("UMKM profile not found, please complete your profile first")
 	}
-
-	if program.Type != "funding" {
-		return errors.New("program type must be funding")
+	if s.mobileRepo.IsApplicationExists(ctx, profile.ID, request.ProgramID) {
+		return errors.New("you have already applied for this program")
 	}
 
 	// Get UMKM with decryption
@@ -591,8 +705,21 @@ func (s *mobileService) CreateFundingApplication(ctx context.Context, userID int
 		return errors.New("UMKM profile not found, please complete your profile first")
 	}
 
-	// Get screening SLA
-	screeningExpiredAt, err := s.slaRepo.GetSLAByStatus(ctx, "screening")
+	// Check the program's eligibility rules against the profile and the form
+	eligibility, err := checkEligibility(program, umkm, model.Application{
+		Type: "funding",
+		FundingApplication: &model.FundingApplication{
+			YearsOperating: request.YearsOperating,
+			MonthlyRevenue: request.MonthlyRevenue,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	// Get screening deadline
+	submittedAt := time.Now()
+	expiredAt, err := newSLACalendar(s.slaRepo, s.holidayRepo).Deadline(ctx, "screening", "funding", request.ProgramID, submittedAt)
 	if err != nil {
 		return err
 	}
@@ -603,46 +730,59 @@ func (s *mobileService) CreateFundingApplication(ctx context.Context, userID int
 		ProgramID:   request.ProgramID,
 		Type:        "funding",
 		Status:      "screening",
-		SubmittedAt: time.Now(),
-		ExpiredAt:   time.Now().AddDate(0, 0, screeningExpiredAt.MaxDays),
+		SubmittedAt: submittedAt,
+		ExpiredAt:   expiredAt,
+		Eligibility: eligibility,
 	}
 
-	createdApp, err := s.mobileRepo.CreateApplication(ctx, application)
-	if err != nil {
-		return err
-	}
+	// Persist application, type-specific data, history and notification atomically
+	var createdApp model.Application
+	err = s.uow.Do(ctx, func(repos repository.TxRepositories) error {
+		tx := s.withTx(repos)
 
-	// Create funding-specific data
-	fundingApp := model.FundingApplication{
-		ApplicationID:         createdApp.ID,
-		BusinessSector:        request.BusinessSector,
-		BusinessDescription:   request.BusinessDescription,
-		YearsOperating:        request.YearsOperating,
-		RequestedAmount:       request.RequestedAmount,
-		FundPurpose:           request.FundPurpose,
-		BusinessPlan:          request.BusinessPlan,
-		RevenueProjection:     request.RevenueProjection,
-		MonthlyRevenue:        request.MonthlyRevenue,
-		RequestedTenureMonths: request.RequestedTenureMonths,
-		CollateralDescription: request.CollateralDescription,
-	}
+		app, err := tx.mobileRepo.CreateApplication(ctx, application)
+		if err != nil {
+			return err
+		}
+		createdApp = app
+
+		// Create funding-specific data
+		fundingApp := model.FundingApplication{
+			ApplicationID:         createdApp.ID,
+			BusinessSector:        request.BusinessSector,
+			BusinessDescription:   request.BusinessDescription,
+			YearsOperating:        request.YearsOperating,
+			RequestedAmount:       request.RequestedAmount,
+			FundPurpose:           request.FundPurpose,
+			BusinessPlan:          request.BusinessPlan,
+			RevenueProjection:     request.RevenueProjection,
+			MonthlyRevenue:        request.MonthlyRevenue,
+			RequestedTenureMonths: request.RequestedTenureMonths,
+			CollateralDescription: request.CollateralDescription,
+		}
 
-	if err := s.mobileRepo.CreateFundingApplication(ctx, fundingApp); err != nil {
-		return err
-	}
+		if err := tx.mobileRepo.CreateFundingApplication(ctx, fundingApp); err != nil {
+			return err
+		}
 
-	// Create history
-	if err := s.createApplicationHistory(ctx, createdApp.ID, umkm.UserID, "submit", "Funding application submitted"); err != nil {
-		return err
-	}
+		// Create history
+		if err := tx.createApplicationHistory(ctx, createdApp.ID, umkm.UserID, "submit", "Funding application submitted"); err != nil {
+			return err
+		}
+
+		// Create notification
+		if err := tx.createNotification(ctx, umkm.ID, createdApp.ID, constant.NotificationSubmitted, constant.NotificationTitleSubmitted, constant.NotificationMessageSubmitted); err != nil {
+			return err
+		}
 
-	// Create notification
-	if err := s.createNotification(ctx, umkm.ID, createdApp.ID, constant.NotificationSubmitted, constant.NotificationTitleSubmitted, constant.NotificationMessageSubmitted); err != nil {
+		return nil
+	})
+	if err != nil {
 		return err
 	}
 
 	// Process and save documents
-	go s.processAndSaveDocuments(ctx, createdApp.ID, request.Documents)
+	go s.processAndSaveDocuments(ctx, createdApp.ID, 0, request.Documents)
 
 	return nil
 }
@@ -702,6 +842,12 @@ func (s *mobileService) GetApplicationDetail(ctx context.Context, id int) (dto.A
 			ApplicationID: doc.ApplicationID,
 			Type:          doc.Type,
 			File:          doc.File,
+			ReviewStatus:  doc.ReviewStatus,
+			ReviewReason:  doc.ReviewReason,
+			ReviewedBy:    doc.ReviewedBy,
+			ReviewedAt:    formatOptionalTime(doc.ReviewedAt),
+			Version:       doc.Version,
+			RevisionRound: doc.RevisionRound,
 			CreatedAt:     doc.CreatedAt.Format("2006-01-02 15:04:05"),
 			UpdatedAt:     doc.UpdatedAt.Format("2006-01-02 15:04:05"),
 		})
@@ -718,24 +864,37 @@ func (s *mobileService) GetApplicationDetail(ctx context.Context, id int) (dto.A
 			ActionedAt:     hist.ActionedAt.Format("2006-01-02 15:04:05"),
 			ActionedBy:     hist.ActionedBy,
 			ActionedByName: hist.User.Name,
+			Changes:        historyChanges(hist),
 			CreatedAt:      hist.CreatedAt.Format("2006-01-02 15:04:05"),
 			UpdatedAt:      hist.UpdatedAt.Format("2006-01-02 15:04:05"),
 		})
 	}
 
+	usage, err := s.applicationRepo.GetProgramUsage(ctx, []int{application.ProgramID})
+	if err != nil {
+		return dto.ApplicationDetailMobile{}, err
+	}
+
+	waitlistPosition, err := s.waitlistPosition(ctx, application)
+	if err != nil {
+		return dto.ApplicationDetailMobile{}, err
+	}
+
 	// Create detailed response with specific application data
 	detail := dto.ApplicationDetailMobile{
-		ID:          application.ID,
-		UMKMID:      application.UMKMID,
-		ProgramID:   application.ProgramID,
-		Type:        application.Type,
-		Status:      application.Status,
-		SubmittedAt: application.SubmittedAt.Format("2006-01-02 15:04:05"),
-		ExpiredAt:   application.ExpiredAt.Format("2006-01-02 15:04:05"),
-		Documents:   documents,
-		Histories:   histories,
+		ID:               application.ID,
+		UMKMID:           application.UMKMID,
+		ProgramID:        application.ProgramID,
+		Type:             application.Type,
+		Status:           application.Status,
+		SubmittedAt:      application.SubmittedAt.Format("2006-01-02 15:04:05"),
+		ExpiredAt:        application.ExpiredAt.Format("2006-01-02 15:04:05"),
+		RevisionFields:   splitRevisionFields(application.RevisionFields),
+		WaitlistPosition: waitlistPosition,
+		Documents:        documents,
+		Histories:        histories,
 		Program: dto.ProgramDetailMobile{
-			ProgramListMobile: s.mapProgramToDTO(application.Program),
+			ProgramListMobile: s.mapProgramToDTO(application.Program, usage[application.ProgramID]),
 			Benefits:          benefitNames,
 			Requirements:      requirementNames,
 		},
@@ -783,57 +942,179 @@ func (s *mobileService) GetApplicationDetail(ctx context.Context, id int) (dto.A
 	return detail, nil
 }
 
-func (s *mobileService) ReviseApplication(ctx context.Context, userID, applicationID int, documents []dto.UploadDocumentRequest) error {
-	application, err := s.mobileRepo.GetApplicationDetailByID(ctx, applicationID)
+// ReviseApplication resubmits an application sent back for revision with the
+// replacement documents and corrected form fields. Corrections are validated like
+// a new application and their previous values are kept on the history entry.
+func (s *mobileService) ReviseApplication(ctx context.Context, userID, applicationID int, request dto.ReviseApplicationRequest) error {
+	umkm, application, err := s.getOwnApplication(ctx, userID, applicationID)
 	if err != nil {
 		return err
 	}
 
-	if application.Status != constant.ApplicationStatusRevised {
-		return errors.New("application is not in a revisable state")
+	workflow := newApplicationWorkflow(s.uow, newSLACalendar(s.slaRepo, s.holidayRepo))
+	if err := workflow.Check(eventResubmit, application); err != nil {
+		return err
 	}
 
 	documentsMap := make(map[string]string)
-	for _, doc := range documents {
+	for _, doc := range request.Documents {
 		documentsMap[doc.Type] = doc.Document
 	}
 
-	// Process and save documents
-	go s.processAndSaveDocuments(ctx, applicationID, documentsMap)
+	// When the reviewer flagged specific items, only those may be replaced and
+	// every flagged item must be
+	flaggedTypes := flaggedDocumentTypes(application)
+	flaggedFields := splitRevisionFields(application.RevisionFields)
+	targeted := len(flaggedTypes) > 0 || len(flaggedFields) > 0
+	if targeted {
+		for docType := range documentsMap {
+			if !slices.Contains(flaggedTypes, docType) {
+				return fmt.Errorf("document %s was not flagged for revision", docType)
+			}
+		}
+		for _, docType := range flaggedTypes {
+			if _, ok := documentsMap[docType]; !ok {
+				return fmt.Errorf("document %s must be replaced", docType)
+			}
+		}
+	}
 
-	// Update application status to 'resubmitted'
-	application.Status = constant.ApplicationStatusScreening
-	application.SubmittedAt = time.Now()
+	// Corrected fields go through the same checks as a new application
+	revised := application
+	applyFormFields(&revised, request.Fields)
+	changes := formChanges(application, revised)
+	if len(changes) > 0 {
+		if issues := formFieldIssues(revised, &application.Program); len(issues) > 0 {
+			return fmt.Errorf("%s %s", issues[0].Field, issues[0].Message)
+		}
+	}
+	if targeted {
+		for _, change := range changes {
+			if !slices.Contains(flaggedFields, change.Field) {
+				return fmt.Errorf("field %s was not flagged for revision", change.Field)
+			}
+		}
+		for _, field := range flaggedFields {
+			if !slices.ContainsFunc(changes, func(change dto.FieldChange) bool { return change.Field == field }) {
+				return fmt.Errorf("field %s must be corrected", field)
+			}
+		}
+	}
 
-	_, err = s.applicationRepo.UpdateApplication(ctx, application)
+	// The rules are evaluated again on the corrected form and the current profile;
+	// the result is recorded for the reviewers rather than blocking the resubmission
+	revised.Eligibility, err = encodeEligibilityResult(evaluateEligibility(application.Program, &umkm, &revised, time.Now()))
 	if err != nil {
 		return err
 	}
 
-	// Get UMKM with decryption
-	umkm, err := s.getUMKMWithDecryption(ctx, userID, "application_creation")
+	// Replacements are uploaded up front so a failed upload stops the resubmission
+	documents, err := s.uploadDocuments(ctx, application.ID, documentsMap)
 	if err != nil {
-		return errors.New("UMKM profile not found, please complete your profile first")
+		return err
 	}
 
-	// Create history
-	if err := s.createApplicationHistory(ctx, application.ID, umkm.UserID, "submit", "Application resubmitted after revision"); err != nil {
-		return err
+	// Corrections, the move back to screening, the new document versions, history
+	// and notification are committed together
+	return s.uow.Do(ctx, func(repos repository.TxRepositories) error {
+		if len(changes) > 0 {
+			if err := repos.Applications.UpdateApplicationForm(ctx, revised); err != nil {
+				return err
+			}
+		}
+
+		updated, err := workflow.FireInWithChanges(ctx, repos, eventResubmit, revised, umkm.UserID, "", changes)
+		if err != nil {
+			return err
+		}
+
+		// The documents sent become the next version of their type; earlier versions
+		// and the types not sent are kept
+		for i := range documents {
+			documents[i].RevisionRound = updated.RevisionRound
+		}
+		return repos.Mobile.CreateApplicationDocumentVersions(ctx, documents)
+	})
+}
+
+// WithdrawApplication lets the applicant cancel an application still waiting for
+// screening, or give up an approval or a place on the waitlist. The program can
+// be applied for again afterwards.
+func (s *mobileService) WithdrawApplication(ctx context.Context, userID, applicationID int, request dto.WithdrawApplicationRequest) error {
+	reason := strings.TrimSpace(request.Reason)
+	if len(reason) > 500 {
+		return errors.New("reason must be at most 500 characters")
 	}
 
-	// Create notification
-	if err := s.createNotification(ctx, umkm.ID, application.ID, constant.NotificationSubmitted, constant.NotificationTitleResubmitted, constant.NotificationMessageResubmitted); err != nil {
+	umkm, application, err := s.getOwnApplication(ctx, userID, applicationID)
+	if err != nil {
 		return err
 	}
 
-	// Delete previous documents
-	if err := s.mobileRepo.DeleteApplicationDocumentsByApplicationID(ctx, application.ID); err != nil {
+	workflow := newApplicationWorkflow(s.uow, newSLACalendar(s.slaRepo, s.holidayRepo))
+	if _, err := workflow.Fire(ctx, eventWithdraw, application, umkm.UserID, reason); err != nil {
 		return err
 	}
 
 	return nil
 }
 
+// GetApplicationLoan returns the repayment schedule of the UMKM's approved
+// funding application with what is paid, outstanding and overdue.
+func (s *mobileService) GetApplicationLoan(ctx context.Context, userID, applicationID int) (dto.Loan, error) {
+	_, application, err := s.getOwnApplication(ctx, userID, applicationID)
+	if err != nil {
+		return dto.Loan{}, err
+	}
+
+	loan, err := s.loanRepo.GetLoanByApplicationID(ctx, application.ID)
+	if err != nil {
+		return dto.Loan{}, err
+	}
+	return mapLoanToDTO(loan, time.Now()), nil
+}
+
+// GetMyCertificates lists the training certificates issued to the UMKM.
+func (s *mobileService) GetMyCertificates(ctx context.Context, userID int) ([]dto.TrainingCertificate, error) {
+	umkm, err := s.mobileRepo.GetUMKMProfileByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	certificates, err := s.trainingRepo.GetCertificatesByUMKMID(ctx, umkm.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]dto.TrainingCertificate, 0, len(certificates))
+	for _, certificate := range certificates {
+		result = append(result, mapTrainingCertificate(certificate))
+	}
+	return result, nil
+}
+
+// GetMyCertifications lists the certificates the UMKM earned through its
+// certification applications, with their expiry status.
+func (s *mobileService) GetMyCertifications(ctx context.Context, userID int) ([]dto.Certification, error) {
+	umkm, err := s.mobileRepo.GetUMKMProfileByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	certifications, err := s.certificationRepo.GetCertificationsByUMKMID(ctx, umkm.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	today := startOfDay(time.Now())
+	window := reminderWindow(env.Cfg.Certifications.ReminderDays)
+	result := make([]dto.Certification, 0, len(certifications))
+	for _, certification := range certifications {
+		result = append(result, mapCertificationToDTO(certification, today, window))
+	}
+	return result, nil
+}
+
 func (s *mobileService) GetNotificationsByUMKMID(ctx context.Context, umkmID int) ([]dto.NotificationResponse, error) {
 	notifications, err := s.notificationRepo.GetNotificationsByUMKMID(ctx, umkmID, 100, 0)
 	if err != nil {
@@ -981,73 +1262,233 @@ func (s *mobileService) getUMKMWithDecryption(ctx context.Context, userID int, p
 	return umkm, nil
 }
 
-func (s *mobileService) mapProgramsToDTO(programs []model.Program) []dto.ProgramListMobile {
+func (s *mobileService) mapProgramsToDTO(ctx context.Context, userID int, programs []model.Program) ([]dto.ProgramListMobile, error) {
+	programIDs := make([]int, 0, len(programs))
+	for _, p := range programs {
+		programIDs = append(programIDs, p.ID)
+	}
+	usage, err := s.applicationRepo.GetProgramUsage(ctx, programIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Eligibility is judged against the caller's profile, loaded once for the list
+	var umkm *model.UMKM
+	if profile, err := s.mobileRepo.GetUMKMProfileByID(ctx, userID); err == nil {
+		umkm = &profile
+	}
+
+	now := time.Now()
 	var result []dto.ProgramListMobile
 	for _, p := range programs {
-		result = append(result, s.mapProgramToDTO(p))
+		item := s.mapProgramToDTO(p, usage[p.ID])
+		eligibility := evaluateEligibility(p, umkm, nil, now)
+		item.Eligibility = &eligibility
+		result = append(result, item)
 	}
-	return result
+	return result, nil
 }
 
-func (s *mobileService) mapProgramToDTO(p model.Program) dto.ProgramListMobile {
+// waitlistPosition returns the 1-based place of a waitlisted application in its
+// program's waitlist, or nil when the application is not waitlisted.
+func (s *mobileService) waitlistPosition(ctx context.Context, application model.Application) (*int, error) {
+	if application.Status != constant.ApplicationStatusWaitlisted {
+		return nil, nil
+	}
+
+	waitlist, err := s.applicationRepo.GetWaitlist(ctx, application.ProgramID)
+	if err != nil {
+		return nil, err
+	}
+	for i, waiting := range waitlist {
+		if waiting.ID == application.ID {
+			position := i + 1
+			return &position, nil
+		}
+	}
+	return nil, nil
+}
+
+// mapProgramToDTO maps a program for the mobile app, including the seats and
+// funds its approved applications leave and whether it still takes applications.
+func (s *mobileService) mapProgramToDTO(p model.Program, usage dto.ProgramUsage) dto.ProgramListMobile {
+	var remainingSeats *int
+	if p.Capacity != nil {
+		seats := max(*p.Capacity-int(usage.ApprovedCount), 0)
+		remainingSeats = &seats
+	}
+	var remainingFunds *float64
+	if p.FundPool != nil {
+		funds := max(*p.FundPool-usage.ApprovedAmount, 0)
+		remainingFunds = &funds
+	}
+
+	// The listing uses the same gate as submission so both agree on whether the
+	// program is open
+	now := time.Now()
+	window := programWindowAt(p, now)
+	var closesAt *string
+	var closesIn *int64
+	if window.ClosesAt != nil {
+		formatted := window.ClosesAt.Format("2006-01-02 15:04:05")
+		closesAt = &formatted
+		if window.Open {
+			seconds := int64(window.ClosesAt.Sub(now).Seconds())
+			closesIn = &seconds
+		}
+	}
+
 	return dto.ProgramListMobile{
-		ID:                  p.ID,
-		Title:               p.Title,
-		Description:         p.Description,
-		Banner:              p.Banner,
-		Provider:            p.Provider,
-		ProviderLogo:        p.ProviderLogo,
-		Type:                p.Type,
-		TrainingType:        p.TrainingType,
-		Batch:               p.Batch,
-		BatchStartDate:      p.BatchStartDate,
-		BatchEndDate:        p.BatchEndDate,
-		Location:            p.Location,
-		MinAmount:           p.MinAmount,
-		MaxAmount:           p.MaxAmount,
-		InterestRate:        p.InterestRate,
-		MaxTenureMonths:     p.MaxTenureMonths,
-		ApplicationDeadline: p.ApplicationDeadline,
-		IsActive:            p.IsActive,
+		ID:                   p.ID,
+		Title:                p.Title,
+		Description:          p.Description,
+		Banner:               p.Banner,
+		Provider:             p.Provider,
+		ProviderLogo:         p.ProviderLogo,
+		Type:                 p.Type,
+		TrainingType:         p.TrainingType,
+		Batch:                p.Batch,
+		BatchStartDate:       p.BatchStartDate,
+		BatchEndDate:         p.BatchEndDate,
+		Location:             p.Location,
+		MinAmount:            p.MinAmount,
+		MaxAmount:            p.MaxAmount,
+		InterestRate:         p.InterestRate,
+		InterestMethod:       p.InterestMethod,
+		MinAttendancePercent: p.MinAttendancePercent,
+		MaxTenureMonths:      p.MaxTenureMonths,
+		Capacity:             p.Capacity,
+		RemainingSeats:       remainingSeats,
+		FundPool:             p.FundPool,
+		RemainingFunds:       remainingFunds,
+		ApplicationDeadline:  p.ApplicationDeadline,
+		IsActive:             p.IsActive,
+
+		IsOpenForApplication: window.Open,
+		ClosesAt:             closesAt,
+		ClosesInSeconds:      closesIn,
+		ClosedReasons:        window.Reasons,
 	}
 }
 
-func (s *mobileService) processAndSaveDocuments(ctx context.Context, applicationID int, providedDocs map[string]string) {
+// processAndSaveDocuments uploads the documents sent and stores them as new
+// versions tagged with the revision round they were submitted in.
+func (s *mobileService) processAndSaveDocuments(ctx context.Context, applicationID, revisionRound int, providedDocs map[string]string) {
 	var appDocuments []model.ApplicationDocument
-	var url string
 
 	// Add documents from request
 	for docType, docData := range providedDocs {
-		if !(strings.HasPrefix(docData, "http") || strings.HasPrefix(docData, "https")) {
-			res, err := s.minio.UploadFile(context.Background(), storage.UploadRequest{
-				Base64Data: docData,
-				BucketName: storage.ApplicationBucket,
-				Prefix:     fmt.Sprintf("app_%d_%s_", applicationID, docType),
-				Validation: storage.CreateImageValidationConfig(),
-			})
-			if err != nil {
-				log.Log.Errorf("failed to upload %s document, %v, for application ID %d", docType, err, applicationID)
-			}
-
-			log.Log.Infof("uploaded %s document for application ID %d: %s", docType, applicationID, res.URL)
-			url = res.URL
-		} else {
-			url = docData
+		url, err := s.uploadDocument(context.Background(), applicationID, docType, docData)
+		if err != nil {
+			log.Log.Errorf("failed to upload %s document, %v, for application ID %d", docType, err, applicationID)
+			continue
 		}
 
 		appDocuments = append(appDocuments, model.ApplicationDocument{
 			ApplicationID: applicationID,
 			Type:          docType,
 			File:          url,
+			RevisionRound: revisionRound,
 		})
 	}
 
-	err := s.mobileRepo.CreateApplicationDocuments(ctx, appDocuments)
+	err := s.mobileRepo.CreateApplicationDocumentVersions(ctx, appDocuments)
 	if err != nil {
 		log.Log.Errorf("failed to save application documents for application ID %d: %v", applicationID, err)
 	}
 }
 
+// uploadDocuments uploads every document sent and returns them ready to be
+// stored. Unlike processAndSaveDocuments it fails as soon as one upload fails.
+func (s *mobileService) uploadDocuments(ctx context.Context, applicationID int, providedDocs map[string]string) ([]model.ApplicationDocument, error) {
+	documents := make([]model.ApplicationDocument, 0, len(providedDocs))
+	for docType, docData := range providedDocs {
+		url, err := s.uploadDocument(ctx, applicationID, docType, docData)
+		if err != nil {
+			return nil, fmt.Errorf("failed to upload %s document: %w", docType, err)
+		}
+
+		documents = append(documents, model.ApplicationDocument{
+			ApplicationID: applicationID,
+			Type:          docType,
+			File:          url,
+		})
+	}
+	return documents, nil
+}
+
+// uploadDocument stores a base64 document in MinIO and returns its URL. Links
+// to files that are already stored are returned as they are.
+func (s *mobileService) uploadDocument(ctx context.Context, applicationID int, docType, docData string) (string, error) {
+	if strings.HasPrefix(docData, "http") {
+		return docData, nil
+	}
+
+	res, err := s.minio.UploadFile(ctx, storage.UploadRequest{
+		Base64Data: docData,
+		BucketName: storage.ApplicationBucket,
+		Prefix:     fmt.Sprintf("app_%d_%s_", applicationID, docType),
+		Validation: storage.CreateImageValidationConfig(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	log.Log.Infof("uploaded %s document for application ID %d: %s", docType, applicationID, res.URL)
+	return res.URL, nil
+}
+
+// validateFundingRequest checks a requested amount and tenure against the
+// limits of a funding program.
+func validateFundingRequest(program model.Program, amount float64, tenureMonths int) error {
+	// Validate requested amount
+	if amount <= 0 {
+		return errors.New("requested amount must be greater than zero")
+	}
+	if program.MinAmount != nil && amount < *program.MinAmount {
+		return fmt.Errorf("requested amount must be at least %.2f", *program.MinAmount)
+	}
+	if program.MaxAmount != nil && amount > *program.MaxAmount {
+		return fmt.Errorf("requested amount cannot exceed %.2f", *program.MaxAmount)
+	}
+
+	// Validate tenure
+	if tenureMonths <= 0 {
+		return errors.New("requested tenure must be at least 1 month")
+	}
+	if program.MaxTenureMonths != nil && tenureMonths > *program.MaxTenureMonths {
+		return fmt.Errorf("requested tenure cannot exceed %d months", *program.MaxTenureMonths)
+	}
+	return nil
+}
+
+// getOwnApplication loads an application of the signed-in UMKM. Applications of
+// other UMKMs are reported as not found.
+func (s *mobileService) getOwnApplication(ctx context.Context, userID, applicationID int) (model.UMKM, model.Application, error) {
+	umkm, err := s.mobileRepo.GetUMKMProfileByID(ctx, userID)
+	if err != nil {
+		return model.UMKM{}, model.Application{}, err
+	}
+
+	application, err := s.mobileRepo.GetApplicationDetailByID(ctx, applicationID)
+	if err != nil {
+		return model.UMKM{}, model.Application{}, err
+	}
+	if application.UMKMID != umkm.ID {
+		return model.UMKM{}, model.Application{}, errors.New("application not found")
+	}
+	return umkm, application, nil
+}
+
+// withTx returns a copy of the service whose repositories are bound to the transaction.
+func (s *mobileService) withTx(repos repository.TxRepositories) *mobileService {
+	txService := *s
+	txService.mobileRepo = repos.Mobile
+	txService.applicationRepo = repos.Applications
+	txService.notificationRepo = repos.Notifications
+	return &txService
+}
+
 func (s *mobileService) createApplicationHistory(ctx context.Context, applicationID, userID int, status, notes string) error {
 	history := model.ApplicationHistory{
 		ApplicationID: applicationID,
@@ -3,23 +3,28 @@ package service
 import (
 	"context"
 	"errors"
+	"slices"
 	"strings"
 	"testing"
 	"time"
 
+	"UMKMGo-backend/internal/repository"
 	"UMKMGo-backend/internal/types/dto"
 	"UMKMGo-backend/internal/types/model"
 	"UMKMGo-backend/internal/utils/constant"
+
+	"gorm.io/gorm"
 )
 
 // ==================== ADDITIONAL MOCK REPOSITORIES ====================
 
 // Mock Mobile Repository
 type mockMobileRepository struct {
-	programs     map[int]model.Program
-	umkms        map[int]model.UMKM
-	applications map[int]model.Application
-	news         map[int]model.News
+	programs         map[int]model.Program
+	umkms            map[int]model.UMKM
+	applications     map[int]model.Application
+	news             map[int]model.News
+	documentVersions []model.ApplicationDocument
 }
 
 func newMockMobileRepository() *mockMobileRepository {
@@ -116,6 +121,36 @@ func (m *mockMobileRepository) DeleteApplicationDocumentsByApplicationID(ctx con
 	return nil
 }
 
+func (m *mockMobileRepository) CreateApplicationDraft(ctx context.Context, app model.Application) (model.Application, error) {
+	app.ID = len(m.applications) + 1
+	app.Version = 1
+	m.applications[app.ID] = app
+	return app, nil
+}
+
+func (m *mockMobileRepository) UpdateApplicationDraft(ctx context.Context, app model.Application) error {
+	current, exists := m.applications[app.ID]
+	if !exists || current.Status != constant.ApplicationStatusDraft {
+		return errors.New("draft not found")
+	}
+	app.Version = current.Version + 1
+	m.applications[app.ID] = app
+	return nil
+}
+
+func (m *mockMobileRepository) DeleteApplicationDraft(ctx context.Context, id int) error {
+	if app, exists := m.applications[id]; !exists || app.Status != constant.ApplicationStatusDraft {
+		return errors.New("draft not found")
+	}
+	delete(m.applications, id)
+	return nil
+}
+
+func (m *mockMobileRepository) CreateApplicationDocumentVersions(ctx context.Context, docs []model.ApplicationDocument) error {
+	m.documentVersions = append(m.documentVersions, docs...)
+	return nil
+}
+
 func (m *mockMobileRepository) GetProgramByID(ctx context.Context, id int) (model.Program, error) {
 	if prog, exists := m.programs[id]; exists && prog.IsActive {
 		return prog, nil
@@ -123,13 +158,20 @@ func (m *mockMobileRepository) GetProgramByID(ctx context.Context, id int) (mode
 	return model.Program{}, errors.New("program not found")
 }
 
+func (m *mockMobileRepository) GetProgramForApplication(ctx context.Context, id int) (model.Program, error) {
+	if prog, exists := m.programs[id]; exists {
+		return prog, nil
+	}
+	return model.Program{}, errors.New("program not found")
+}
+
 func (m *mockMobileRepository) GetProgramRequirements(ctx context.Context, programID int) ([]model.ProgramRequirement, error) {
 	return []model.ProgramRequirement{}, nil
 }
 
 func (m *mockMobileRepository) IsApplicationExists(ctx context.Context, umkmID, programID int) bool {
 	for _, app := range m.applications {
-		if app.UMKMID == umkmID && app.ProgramID == programID && app.Status != "rejected" {
+		if app.UMKMID == umkmID && app.ProgramID == programID && app.Status != "rejected" && app.Status != "withdrawn" {
 			return true
 		}
 	}
@@ -459,7 +501,13 @@ func setupMobileServiceForTests() (*mobileService, *mockMobileRepository) {
 		vaultLogRepo:     mockVaultRepo,
 		applicationRepo:  mockAppRepo,
 		slaRepo:          mockSLARepo,
-		minio:            nil,
+		holidayRepo:      newMockHolidayRepo(),
+		uow: newMockUnitOfWork(repository.TxRepositories{
+			Applications:  mockAppRepo,
+			Notifications: mockNotifRepo,
+			Mobile:        mockMobileRepo,
+		}),
+		minio: nil,
 	}
 
 	return service, mockMobileRepo
@@ -512,7 +560,7 @@ func TestGetTrainingProgramsExtended(t *testing.T) {
 			IsActive: true,
 		}
 
-		result, err := service.GetTrainingPrograms(ctx)
+		result, err := service.GetTrainingPrograms(ctx, 1)
 		if err != nil {
 			t.Errorf("Expected no error, got %v", err)
 		}
@@ -537,7 +585,7 @@ func TestGetTrainingProgramsExtended(t *testing.T) {
 			IsActive: false,
 		}
 
-		result, err := service.GetTrainingPrograms(ctx)
+		result, err := service.GetTrainingPrograms(ctx, 1)
 		if err != nil {
 			t.Errorf("Expected no error, got %v", err)
 		}
@@ -557,7 +605,7 @@ func TestGetCertificationProgramsExtended(t *testing.T) {
 	ctx := context.Background()
 
 	t.Run("Get certification programs with details", func(t *testing.T) {
-		result, err := service.GetCertificationPrograms(ctx)
+		result, err := service.GetCertificationPrograms(ctx, 1)
 		if err != nil {
 			t.Errorf("Expected no error, got %v", err)
 		}
@@ -598,7 +646,7 @@ func TestGetFundingProgramsExtended(t *testing.T) {
 			InterestRate: &interestRate,
 		}
 
-		result, err := service.GetFundingPrograms(ctx)
+		result, err := service.GetFundingPrograms(ctx, 1)
 		if err != nil {
 			t.Errorf("Expected no error, got %v", err)
 		}
@@ -629,7 +677,7 @@ func TestGetProgramDetailExtended(t *testing.T) {
 	ctx := context.Background()
 
 	t.Run("Get program detail with benefits and requirements", func(t *testing.T) {
-		result, err := service.GetProgramDetail(ctx, 1)
+		result, err := service.GetProgramDetail(ctx, 1, 1)
 		if err != nil {
 			t.Errorf("Expected no error, got %v", err)
 		}
@@ -647,6 +695,34 @@ func TestGetProgramDetailExtended(t *testing.T) {
 		}
 	})
 
+	t.Run("Program detail shows remaining seats and funds", func(t *testing.T) {
+		capacity, fundPool := 2, 30000000.0
+		program := mockRepo.programs[1]
+		program.Capacity = &capacity
+		program.FundPool = &fundPool
+		mockRepo.programs[1] = program
+
+		mockAppRepo := service.applicationRepo.(*mockApplicationsRepo)
+		mockAppRepo.applications[1] = model.Application{
+			ID:                 1,
+			ProgramID:          1,
+			Status:             constant.ApplicationStatusApproved,
+			FundingApplication: &model.FundingApplication{RequestedAmount: 12500000},
+		}
+		mockAppRepo.applications[2] = model.Application{ID: 2, ProgramID: 1, Status: constant.ApplicationStatusWaitlisted}
+
+		result, err := service.GetProgramDetail(ctx, 1, 1)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if result.RemainingSeats == nil || *result.RemainingSeats != 1 {
+			t.Errorf("Expected 1 remaining seat, got %v", result.RemainingSeats)
+		}
+		if result.RemainingFunds == nil || *result.RemainingFunds != 17500000 {
+			t.Errorf("Expected 17500000 remaining funds, got %v", result.RemainingFunds)
+		}
+	})
+
 	t.Run("Get inactive program should fail", func(t *testing.T) {
 		mockRepo.programs[7] = model.Program{
 			ID:       7,
@@ -655,7 +731,7 @@ func TestGetProgramDetailExtended(t *testing.T) {
 			IsActive: false,
 		}
 
-		_, err := service.GetProgramDetail(ctx, 7)
+		_, err := service.GetProgramDetail(ctx, 1, 7)
 
 		if err == nil {
 			t.Error("Expected error for inactive program, got none")
@@ -1424,8 +1500,318 @@ func TestCreateFundingApplication(t *testing.T) {
 	})
 }
 
+func TestSimulateFunding(t *testing.T) {
+	service, mockRepo := setupMobileServiceForTests()
+	ctx := context.Background()
+
+	minAmount := 10000000.0
+	maxAmount := 50000000.0
+	maxTenure := 12
+	rate := 12.0
+	mockRepo.programs[3] = model.Program{
+		ID:              3,
+		Title:           "SME Funding",
+		Type:            "funding",
+		IsActive:        true,
+		MinAmount:       &minAmount,
+		MaxAmount:       &maxAmount,
+		MaxTenureMonths: &maxTenure,
+		InterestRate:    &rate,
+	}
+
+	t.Run("Simulation matches the loan opened on approval", func(t *testing.T) {
+		simulation, err := service.SimulateFunding(ctx, 3, dto.FundingSimulationRequest{RequestedAmount: 12000000, RequestedTenureMonths: 12})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if simulation.InterestMethod != constant.InterestMethodAnnuity || len(simulation.Installments) != 12 {
+			t.Fatalf("Expected 12 annuity installments, got %d %s", len(simulation.Installments), simulation.InterestMethod)
+		}
+		if simulation.MonthlyInstallment != 1066185.46 {
+			t.Errorf("Expected monthly installment 1066185.46, got %.2f", simulation.MonthlyInstallment)
+		}
+
+		// Approve the same request and compare with the stored schedule
+		appService, appRepo, _ := setupApplicationsService()
+		loanRepo := newMockLoanRepo()
+		appService.uow = newMockUnitOfWork(repository.TxRepositories{
+			Applications:  appRepo,
+			Notifications: newMockNotificationRepo(),
+			Loans:         loanRepo,
+		})
+		appRepo.programs[2] = mockRepo.programs[3]
+		appRepo.applications[1] = model.Application{
+			ID:                 1,
+			UMKMID:             1,
+			ProgramID:          2,
+			Type:               "funding",
+			Status:             constant.ApplicationStatusFinal,
+			FundingApplication: &model.FundingApplication{ApplicationID: 1, RequestedAmount: 12000000, RequestedTenureMonths: 12},
+		}
+		if _, err := appService.FinalApprove(ctx, 1, 1, 0); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		loan, err := loanRepo.GetLoanByApplicationID(ctx, 1)
+		if err != nil {
+			t.Fatalf("Expected a loan, got %v", err)
+		}
+		if loan.TotalPayable != simulation.TotalPayable || loan.TotalInterest != simulation.TotalInterest {
+			t.Errorf("Expected totals %.2f/%.2f, got %.2f/%.2f", simulation.TotalPayable, simulation.TotalInterest, loan.TotalPayable, loan.TotalInterest)
+		}
+		for i, installment := range loan.Installments {
+			simulated := simulation.Installments[i]
+			if installment.Amount != simulated.Amount || installment.Principal != simulated.Principal || installment.DueDate.Format("2006-01-02") != simulated.DueDate {
+				t.Errorf("Expected installment %d to match the simulation, got %+v and %+v", installment.Number, installment, simulated)
+			}
+		}
+	})
+
+	t.Run("Amount and tenure outside the program limits are rejected", func(t *testing.T) {
+		requests := []dto.FundingSimulationRequest{
+			{RequestedAmount: 5000000, RequestedTenureMonths: 6},
+			{RequestedAmount: 60000000, RequestedTenureMonths: 6},
+			{RequestedAmount: 20000000, RequestedTenureMonths: 24},
+			{RequestedAmount: 20000000, RequestedTenureMonths: 0},
+		}
+		for _, request := range requests {
+			if _, err := service.SimulateFunding(ctx, 3, request); err == nil {
+				t.Errorf("Expected error for %+v", request)
+			}
+		}
+	})
+
+	t.Run("Non-funding program is rejected", func(t *testing.T) {
+		_, err := service.SimulateFunding(ctx, 1, dto.FundingSimulationRequest{RequestedAmount: 20000000, RequestedTenureMonths: 6})
+		if err == nil {
+			t.Error("Expected error for training program")
+		}
+	})
+}
+
 // ==================== TEST GET APPLICATION LIST ====================
 
+func TestProgramSubmissionGate(t *testing.T) {
+	ctx := context.Background()
+	date := func(offsetDays int) string {
+		return time.Now().AddDate(0, 0, offsetDays).Format("2006-01-02")
+	}
+
+	t.Run("Gate reports every reason a program is closed", func(t *testing.T) {
+		batchStart := date(-1)
+		program := model.Program{
+			ApplicationDeadline: date(-2),
+			BatchStartDate:      &batchStart,
+			Base:                model.Base{DeletedAt: gorm.DeletedAt{Time: time.Now(), Valid: true}},
+		}
+
+		window := programWindowAt(program, time.Now())
+		if window.Open {
+			t.Fatal("Expected program to be closed")
+		}
+		var codes []string
+		for _, reason := range window.Reasons {
+			codes = append(codes, reason.Code)
+		}
+		expected := []string{
+			constant.ProgramClosedDeleted,
+			constant.ProgramClosedInactive,
+			constant.ProgramClosedDeadlinePassed,
+			constant.ProgramClosedBatchStarted,
+		}
+		if !slices.Equal(codes, expected) {
+			t.Errorf("Expected reasons %v, got %v", expected, codes)
+		}
+	})
+
+	t.Run("Program closes at the end of the deadline day or when the batch starts", func(t *testing.T) {
+		now := time.Now()
+		program := model.Program{IsActive: true, ApplicationDeadline: date(0)}
+
+		window := programWindowAt(program, now)
+		if !window.Open {
+			t.Fatalf("Expected program to be open on its deadline day, got %+v", window.Reasons)
+		}
+		deadline, _ := parseProgramDate(date(1))
+		if !window.ClosesAt.Equal(deadline) {
+			t.Errorf("Expected program to close at %v, got %v", deadline, window.ClosesAt)
+		}
+
+		batchStart := date(1)
+		program.ApplicationDeadline = date(10)
+		program.BatchStartDate = &batchStart
+		if window := programWindowAt(program, now); !window.ClosesAt.Equal(deadline) {
+			t.Errorf("Expected batch start to close the program first, got %v", window.ClosesAt)
+		}
+	})
+
+	t.Run("Applications for a closed program are refused with reasons", func(t *testing.T) {
+		service, mockRepo := setupMobileServiceForTests()
+		program := mockRepo.programs[1]
+		program.ApplicationDeadline = date(-1)
+		mockRepo.programs[1] = program
+
+		err := service.CreateTrainingApplication(ctx, 1, dto.CreateApplicationTraining{ProgramID: 1, Motivation: "Test motivation"})
+		var closedErr *ProgramClosedError
+		if !errors.As(err, &closedErr) {
+			t.Fatalf("Expected program closed error, got %v", err)
+		}
+		if len(closedErr.Reasons) != 1 || closedErr.Reasons[0].Code != constant.ProgramClosedDeadlinePassed {
+			t.Errorf("Expected deadline reason, got %+v", closedErr.Reasons)
+		}
+
+		_, err = service.CreateApplicationDraft(ctx, 1, dto.CreateApplicationDraft{ProgramID: 1})
+		if !errors.As(err, &closedErr) {
+			t.Errorf("Expected drafts to use the same gate, got %v", err)
+		}
+	})
+
+	t.Run("Listing exposes the gate result and countdown", func(t *testing.T) {
+		service, mockRepo := setupMobileServiceForTests()
+		program := mockRepo.programs[1]
+		program.ApplicationDeadline = date(3)
+		mockRepo.programs[1] = program
+
+		result, err := service.GetTrainingPrograms(ctx, 1)
+		if err != nil || len(result) != 1 {
+			t.Fatalf("Expected one program, got %v (%v)", result, err)
+		}
+		if !result[0].IsOpenForApplication || result[0].ClosesInSeconds == nil || *result[0].ClosesInSeconds <= 3*24*60*60 {
+			t.Errorf("Expected open program with a countdown past three days, got %+v", result[0])
+		}
+
+		program.ApplicationDeadline = date(-1)
+		mockRepo.programs[1] = program
+		result, _ = service.GetTrainingPrograms(ctx, 1)
+		if result[0].IsOpenForApplication || result[0].ClosesInSeconds != nil || len(result[0].ClosedReasons) != 1 {
+			t.Errorf("Expected closed program without a countdown, got %+v", result[0])
+		}
+	})
+}
+
+func TestEligibilityRules(t *testing.T) {
+	ctx := context.Background()
+	number := func(v int) *int { return &v }
+	withRules := func(program model.Program, rules dto.EligibilityRules) model.Program {
+		encoded, _ := encodeEligibilityRules(&rules)
+		program.EligibilityRules = encoded
+		return program
+	}
+	unmet := func(result dto.EligibilityResult) []string {
+		var rules []string
+		for _, check := range result.UnmetRules {
+			rules = append(rules, check.Rule)
+		}
+		return rules
+	}
+
+	t.Run("Profile rules are checked and form rules wait for the application", func(t *testing.T) {
+		program := withRules(model.Program{Type: "certification"}, dto.EligibilityRules{
+			KartuTypes:        []string{"produktif"},
+			Genders:           []string{"female"},
+			MinAge:            number(18),
+			MaxAge:            number(30),
+			RequiredDocuments: []string{constant.DocumentTypeNib, constant.DocumentTypeBusinessPermit},
+			MinYearsOperating: number(2),
+		})
+		birthDate := time.Now().AddDate(-35, 0, 0)
+		umkm := model.UMKM{KartuType: "afirmatif", Gender: "female", BirthDate: birthDate, NIB: "http://example.com/nib.pdf"}
+
+		result := evaluateEligibility(program, &umkm, nil, time.Now())
+		if result.Eligible {
+			t.Fatal("Expected applicant not to be eligible")
+		}
+		expected := []string{"kartu_types", "age", "required_documents"}
+		if !slices.Equal(unmet(result), expected) {
+			t.Errorf("Expected unmet rules %v, got %v", expected, unmet(result))
+		}
+		if last := result.Checks[len(result.Checks)-1]; last.Rule != "min_years_operating" || !last.Pending {
+			t.Errorf("Expected years operating to be pending, got %+v", last)
+		}
+
+		years := 1
+		application := model.Application{CertificationApplication: &model.CertificationApplication{YearsOperating: &years}}
+		result = evaluateEligibility(program, &umkm, &application, time.Now())
+		if !slices.Contains(unmet(result), "min_years_operating") {
+			t.Errorf("Expected years operating to be unmet at submission, got %v", unmet(result))
+		}
+	})
+
+	t.Run("Rules are validated against the program type", func(t *testing.T) {
+		revenue := 1000000.0
+		if err := validateEligibilityRules("training", &dto.EligibilityRules{MinMonthlyRevenue: &revenue}); err == nil {
+			t.Error("Expected revenue band to be refused on a training program")
+		}
+		if err := validateEligibilityRules("funding", &dto.EligibilityRules{RequiredDocuments: []string{"ktp"}}); err == nil {
+			t.Error("Expected unknown document to be refused")
+		}
+		if err := validateEligibilityRules("funding", &dto.EligibilityRules{MinMonthlyRevenue: &revenue, KartuTypes: []string{"afirmatif"}}); err != nil {
+			t.Errorf("Expected valid rules, got %v", err)
+		}
+	})
+
+	t.Run("Program listing shows the caller's eligibility", func(t *testing.T) {
+		service, mockRepo := setupMobileServiceForTests()
+		mockRepo.programs[1] = withRules(mockRepo.programs[1], dto.EligibilityRules{Genders: []string{"male"}})
+
+		result, err := service.GetTrainingPrograms(ctx, 1)
+		if err != nil || len(result) != 1 {
+			t.Fatalf("Expected one program, got %v (%v)", result, err)
+		}
+		if result[0].Eligibility == nil || result[0].Eligibility.Eligible || len(result[0].Eligibility.UnmetRules) != 1 {
+			t.Errorf("Expected the gender rule to be unmet, got %+v", result[0].Eligibility)
+		}
+
+		detail, _ := service.GetProgramDetail(ctx, 1, 1)
+		if detail.Eligibility == nil || detail.Eligibility.Eligible {
+			t.Errorf("Expected detail to agree with the listing, got %+v", detail.Eligibility)
+		}
+	})
+
+	t.Run("Submission records the result and refuses unmet rules", func(t *testing.T) {
+		service, mockRepo := setupMobileServiceForTests()
+		mockAppRepo := service.applicationRepo.(*mockApplicationsRepo)
+		umkm := mockRepo.umkms[1]
+		umkm.KartuType = "afirmatif"
+		mockRepo.umkms[1] = umkm
+
+		submitDraft := func() error {
+			motivation := "Ingin belajar pemasaran digital"
+			draft, err := service.CreateApplicationDraft(ctx, 1, dto.CreateApplicationDraft{
+				ProgramID: 1,
+				ApplicationDraftFields: dto.ApplicationDraftFields{
+					ApplicationFormFields: dto.ApplicationFormFields{Motivation: &motivation},
+				},
+			})
+			if err != nil {
+				return err
+			}
+			created := mockRepo.applications[draft.ID]
+			created.Documents = []model.ApplicationDocument{{ApplicationID: draft.ID, Type: "ktp", File: "http://example.com/ktp.jpg"}}
+			mockRepo.applications[draft.ID] = created
+			mockAppRepo.applications[draft.ID] = created
+			return service.SubmitApplicationDraft(ctx, 1, draft.ID)
+		}
+
+		mockRepo.programs[1] = withRules(mockRepo.programs[1], dto.EligibilityRules{KartuTypes: []string{"afirmatif"}})
+		if err := submitDraft(); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		eligibility := applicationEligibility(mockAppRepo.applications[1])
+		if eligibility == nil || !eligibility.Eligible || len(eligibility.Checks) != 1 {
+			t.Errorf("Expected the eligibility to be recorded, got %+v", eligibility)
+		}
+
+		delete(mockRepo.applications, 1)
+		mockRepo.programs[1] = withRules(mockRepo.programs[1], dto.EligibilityRules{KartuTypes: []string{"produktif"}})
+		err := submitDraft()
+		var incompleteErr *DraftIncompleteError
+		if !errors.As(err, &incompleteErr) || incompleteErr.Issues[0].Field != "kartu_types" {
+			t.Errorf("Expected the unmet rule to block submission, got %v", err)
+		}
+	})
+}
+
 func TestGetApplicationListExtended(t *testing.T) {
 	service, mockRepo := setupMobileServiceForTests()
 	ctx := context.Background()
@@ -1723,7 +2109,7 @@ func TestReviseApplication(t *testing.T) {
 			{Type: "proposal", Document: "http://example.com/proposal.pdf"},
 		}
 
-		err := service.ReviseApplication(ctx, 1, 1, documents)
+		err := service.ReviseApplication(ctx, 1, 1, dto.ReviseApplicationRequest{Documents: documents})
 		// Will fail due to vault, but structure is tested
 		if err != nil {
 			t.Log("Expected error due to vault:", err)
@@ -1741,7 +2127,7 @@ func TestReviseApplication(t *testing.T) {
 			{Type: "ktp", Document: "http://example.com/ktp.pdf"},
 		}
 
-		err := service.ReviseApplication(ctx, 1, 2, documents)
+		err := service.ReviseApplication(ctx, 1, 2, dto.ReviseApplicationRequest{Documents: documents})
 		if err == nil {
 			t.Error("Expected error for non-revised application, got none")
 		}
@@ -1755,13 +2141,550 @@ func TestReviseApplication(t *testing.T) {
 	t.Run("Revise non-existing application", func(t *testing.T) {
 		documents := []dto.UploadDocumentRequest{}
 
-		err := service.ReviseApplication(ctx, 1, 999, documents)
+		err := service.ReviseApplication(ctx, 1, 999, dto.ReviseApplicationRequest{Documents: documents})
 		if err == nil {
 			t.Error("Expected error for non-existing application, got none")
 		}
 	})
 }
 
+func TestReviseFlaggedItems(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func() (*mobileService, *mockApplicationsRepo) {
+		service, mockRepo := setupMobileServiceForTests()
+		mockAppRepo := service.applicationRepo.(*mockApplicationsRepo)
+		application := model.Application{
+			ID:             1,
+			UMKMID:         1,
+			ProgramID:      1,
+			Type:           "training",
+			Status:         constant.ApplicationStatusRevised,
+			RevisionFields: "motivation",
+			Documents: []model.ApplicationDocument{
+				{ID: 11, ApplicationID: 1, Type: "ktp", ReviewStatus: constant.DocumentReviewRejected, ReviewReason: "Foto KTP buram"},
+				{ID: 12, ApplicationID: 1, Type: "nib", ReviewStatus: constant.DocumentReviewAccepted},
+			},
+		}
+		mockRepo.applications[1] = application
+		mockAppRepo.applications[1] = application
+		return service, mockAppRepo
+	}
+
+	t.Run("Only flagged documents can be replaced", func(t *testing.T) {
+		service, mockAppRepo := setup()
+
+		err := service.ReviseApplication(ctx, 1, 1, dto.ReviseApplicationRequest{Documents: []dto.UploadDocumentRequest{
+			{Type: "ktp", Document: "http://example.com/ktp.jpg"},
+			{Type: "nib", Document: "http://example.com/nib.pdf"},
+		}})
+		if err == nil || err.Error() != "document nib was not flagged for revision" {
+			t.Errorf("Expected not flagged error, got %v", err)
+		}
+		if mockAppRepo.applications[1].Status != constant.ApplicationStatusRevised {
+			t.Error("Expected application to stay revised")
+		}
+	})
+
+	t.Run("Every flagged document must be replaced", func(t *testing.T) {
+		service, _ := setup()
+
+		err := service.ReviseApplication(ctx, 1, 1, dto.ReviseApplicationRequest{})
+		if err == nil || err.Error() != "document ktp must be replaced" {
+			t.Errorf("Expected missing replacement error, got %v", err)
+		}
+	})
+}
+
+func TestReviseDocuments(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func() (*mobileService, *mockMobileRepository, *mockApplicationsRepo) {
+		service, mockRepo := setupMobileServiceForTests()
+		mockAppRepo := service.applicationRepo.(*mockApplicationsRepo)
+		service.uow = newMockUnitOfWork(repository.TxRepositories{
+			Applications:       mockAppRepo,
+			Notifications:      service.notificationRepo,
+			AdminNotifications: newMockAdminNotificationRepo(),
+			Mobile:             mockRepo,
+		})
+
+		application := model.Application{
+			ID:            1,
+			UMKMID:        1,
+			ProgramID:     1,
+			Type:          "training",
+			Status:        constant.ApplicationStatusRevised,
+			RevisionRound: 1,
+			Documents: []model.ApplicationDocument{
+				{ID: 11, ApplicationID: 1, Type: "ktp", ReviewStatus: constant.DocumentReviewRejected, ReviewReason: "Foto KTP buram"},
+			},
+		}
+		mockRepo.applications[1] = application
+		mockAppRepo.applications[1] = application
+		return service, mockRepo, mockAppRepo
+	}
+
+	t.Run("Replacements are stored with the resubmission", func(t *testing.T) {
+		service, mockRepo, mockAppRepo := setup()
+
+		err := service.ReviseApplication(ctx, 1, 1, dto.ReviseApplicationRequest{Documents: []dto.UploadDocumentRequest{
+			{Type: "ktp", Document: "http://example.com/ktp-baru.jpg"},
+		}})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		application := mockAppRepo.applications[1]
+		if application.Status != constant.ApplicationStatusScreening {
+			t.Errorf("Expected screening status, got %s", application.Status)
+		}
+		if len(mockRepo.documentVersions) != 1 {
+			t.Fatalf("Expected 1 new document version, got %d", len(mockRepo.documentVersions))
+		}
+		if document := mockRepo.documentVersions[0]; document.File != "http://example.com/ktp-baru.jpg" || document.RevisionRound != application.RevisionRound {
+			t.Errorf("Expected the replacement in round %d, got %+v", application.RevisionRound, document)
+		}
+	})
+
+	t.Run("A failed upload stops the resubmission", func(t *testing.T) {
+		service, mockRepo, mockAppRepo := setup()
+
+		err := service.ReviseApplication(ctx, 1, 1, dto.ReviseApplicationRequest{Documents: []dto.UploadDocumentRequest{
+			{Type: "ktp", Document: "not a base64 file"},
+		}})
+		if err == nil || !strings.HasPrefix(err.Error(), "failed to upload ktp document") {
+			t.Errorf("Expected upload error, got %v", err)
+		}
+		if mockAppRepo.applications[1].Status != constant.ApplicationStatusRevised || len(mockRepo.documentVersions) != 0 {
+			t.Error("Expected application to stay revised without new documents")
+		}
+	})
+}
+
+func TestReviseFormFields(t *testing.T) {
+	ctx := context.Background()
+	minAmount, maxAmount, maxTenure := 1000000.0, 50000000.0, 24
+
+	setup := func(revisionFields string) (*mobileService, *mockApplicationsRepo) {
+		service, mockRepo := setupMobileServiceForTests()
+		mockAppRepo := service.applicationRepo.(*mockApplicationsRepo)
+		service.uow = newMockUnitOfWork(repository.TxRepositories{
+			Applications:       mockAppRepo,
+			Notifications:      service.notificationRepo,
+			AdminNotifications: newMockAdminNotificationRepo(),
+			Mobile:             mockRepo,
+		})
+
+		application := model.Application{
+			ID:             1,
+			UMKMID:         1,
+			ProgramID:      3,
+			Type:           "funding",
+			Status:         constant.ApplicationStatusRevised,
+			RevisionFields: revisionFields,
+			Program:        model.Program{ID: 3, Type: "funding", MinAmount: &minAmount, MaxAmount: &maxAmount, MaxTenureMonths: &maxTenure},
+			FundingApplication: &model.FundingApplication{
+				ID:                    5,
+				ApplicationID:         1,
+				BusinessSector:        "Kuliner",
+				BusinessDescription:   "Warung makan",
+				RequestedAmount:       60000000,
+				FundPurpose:           "Modal kerja",
+				RequestedTenureMonths: 12,
+			},
+		}
+		mockRepo.applications[1] = application
+		mockAppRepo.applications[1] = application
+		return service, mockAppRepo
+	}
+	amount := func(value float64) *float64 { return &value }
+
+	t.Run("Flagged field is corrected and its previous value kept", func(t *testing.T) {
+		service, mockAppRepo := setup("requested_amount")
+
+		err := service.ReviseApplication(ctx, 1, 1, dto.ReviseApplicationRequest{
+			Fields: dto.ApplicationFormFields{RequestedAmount: amount(40000000)},
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		application := mockAppRepo.applications[1]
+		if application.Status != constant.ApplicationStatusScreening || application.RevisionFields != "" {
+			t.Errorf("Expected screening application without revision fields, got %s %q", application.Status, application.RevisionFields)
+		}
+		if application.FundingApplication.RequestedAmount != 40000000 {
+			t.Errorf("Expected corrected amount, got %.2f", application.FundingApplication.RequestedAmount)
+		}
+
+		histories := mockAppRepo.histories[1]
+		if len(histories) != 1 {
+			t.Fatalf("Expected 1 history, got %d", len(histories))
+		}
+		changes := historyChanges(histories[0])
+		if len(changes) != 1 || changes[0].Field != "requested_amount" || changes[0].Previous != 60000000.0 || changes[0].Current != 40000000.0 {
+			t.Errorf("Unexpected recorded changes %+v", changes)
+		}
+	})
+
+	t.Run("Corrections are validated against the program", func(t *testing.T) {
+		service, mockAppRepo := setup("requested_amount")
+
+		err := service.ReviseApplication(ctx, 1, 1, dto.ReviseApplicationRequest{
+			Fields: dto.ApplicationFormFields{RequestedAmount: amount(55000000)},
+		})
+		if err == nil || err.Error() != "requested_amount cannot exceed 50000000.00" {
+			t.Errorf("Expected max amount error, got %v", err)
+		}
+		if mockAppRepo.applications[1].Status != constant.ApplicationStatusRevised {
+			t.Error("Expected application to stay revised")
+		}
+	})
+
+	t.Run("Only flagged fields can be changed", func(t *testing.T) {
+		service, _ := setup("requested_amount")
+		purpose := "Beli peralatan"
+
+		err := service.ReviseApplication(ctx, 1, 1, dto.ReviseApplicationRequest{
+			Fields: dto.ApplicationFormFields{RequestedAmount: amount(40000000), FundPurpose: &purpose},
+		})
+		if err == nil || err.Error() != "field fund_purpose was not flagged for revision" {
+			t.Errorf("Expected not flagged error, got %v", err)
+		}
+	})
+
+	t.Run("Every flagged field must be corrected", func(t *testing.T) {
+		service, _ := setup("requested_amount")
+
+		err := service.ReviseApplication(ctx, 1, 1, dto.ReviseApplicationRequest{})
+		if err == nil || err.Error() != "field requested_amount must be corrected" {
+			t.Errorf("Expected missing correction error, got %v", err)
+		}
+	})
+
+	t.Run("Any revisable field can change when nothing was flagged", func(t *testing.T) {
+		service, mockAppRepo := setup("")
+		tenure := 18
+
+		err := service.ReviseApplication(ctx, 1, 1, dto.ReviseApplicationRequest{
+			Fields: dto.ApplicationFormFields{RequestedAmount: amount(30000000), RequestedTenureMonths: &tenure},
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(historyChanges(mockAppRepo.histories[1][0])) != 2 {
+			t.Error("Expected both corrections recorded")
+		}
+	})
+}
+
+func TestWithdrawApplication(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func(status string) (*mobileService, *mockMobileRepository, *mockApplicationsRepo, *mockAdminNotificationRepo) {
+		service, mockRepo := setupMobileServiceForTests()
+		mockAppRepo := service.applicationRepo.(*mockApplicationsRepo)
+		mockAdminRepo := newMockAdminNotificationRepo()
+		service.uow = newMockUnitOfWork(repository.TxRepositories{
+			Applications:       mockAppRepo,
+			Notifications:      service.notificationRepo,
+			AdminNotifications: mockAdminRepo,
+			Mobile:             mockRepo,
+		})
+
+		application := model.Application{
+			ID:        1,
+			UMKMID:    1,
+			ProgramID: 1,
+			Type:      "training",
+			Status:    status,
+			Program:   mockRepo.programs[1],
+		}
+		mockRepo.applications[1] = application
+		mockAppRepo.applications[1] = application
+		return service, mockRepo, mockAppRepo, mockAdminRepo
+	}
+
+	t.Run("Withdraw screening application", func(t *testing.T) {
+		service, mockRepo, mockAppRepo, mockAdminRepo := setup(constant.ApplicationStatusScreening)
+
+		err := service.WithdrawApplication(ctx, 1, 1, dto.WithdrawApplicationRequest{Reason: "Jadwal bentrok"})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if mockAppRepo.applications[1].Status != constant.ApplicationStatusWithdrawn {
+			t.Errorf("Expected withdrawn status, got %s", mockAppRepo.applications[1].Status)
+		}
+		histories := mockAppRepo.histories[1]
+		if len(histories) != 1 || histories[0].Status != constant.ApplicationActionWithdraw || histories[0].Notes != "Jadwal bentrok" {
+			t.Errorf("Expected withdraw history with the reason, got %+v", histories)
+		}
+		// SCREENING_TRAINING is held by user 10 in the mock
+		if len(mockAdminRepo.notifications) != 1 || mockAdminRepo.notifications[0].UserID != 10 || mockAdminRepo.notifications[0].Type != constant.AdminNotificationWithdrawn {
+			t.Errorf("Expected screening reviewer to be notified, got %+v", mockAdminRepo.notifications)
+		}
+
+		// The program can be applied for again
+		mockRepo.applications[1] = mockAppRepo.applications[1]
+		if mockRepo.IsApplicationExists(ctx, 1, 1) {
+			t.Error("Expected withdrawn application not to count as already applied")
+		}
+	})
+
+	t.Run("Withdraw revised application without reason", func(t *testing.T) {
+		service, _, mockAppRepo, _ := setup(constant.ApplicationStatusRevised)
+
+		if err := service.WithdrawApplication(ctx, 1, 1, dto.WithdrawApplicationRequest{}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if notes := mockAppRepo.histories[1][0].Notes; notes != "Withdrawn by applicant" {
+			t.Errorf("Expected default notes, got %s", notes)
+		}
+	})
+
+	t.Run("Cannot withdraw after screening", func(t *testing.T) {
+		service, _, _, _ := setup(constant.ApplicationStatusFinal)
+
+		err := service.WithdrawApplication(ctx, 1, 1, dto.WithdrawApplicationRequest{})
+		var transitionErr *InvalidTransitionError
+		if !errors.As(err, &transitionErr) {
+			t.Errorf("Expected invalid transition error, got %v", err)
+		}
+	})
+
+	t.Run("Withdrawing an approval promotes the waitlist in order", func(t *testing.T) {
+		service, _, mockAppRepo, _ := setup(constant.ApplicationStatusApproved)
+		capacity := 1
+		program := mockAppRepo.programs[1]
+		program.Capacity = &capacity
+		mockAppRepo.programs[1] = program
+
+		first, second := time.Now().Add(-time.Hour), time.Now()
+		for id, waitlistedAt := range map[int]time.Time{2: second, 3: first} {
+			mockAppRepo.applications[id] = model.Application{
+				ID:           id,
+				UMKMID:       id,
+				ProgramID:    1,
+				Type:         "training",
+				Status:       constant.ApplicationStatusWaitlisted,
+				WaitlistedAt: &waitlistedAt,
+			}
+		}
+
+		if err := service.WithdrawApplication(ctx, 1, 1, dto.WithdrawApplicationRequest{}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if status := mockAppRepo.applications[3].Status; status != constant.ApplicationStatusApproved {
+			t.Errorf("Expected head of the waitlist to be approved, got %s", status)
+		}
+		if status := mockAppRepo.applications[2].Status; status != constant.ApplicationStatusWaitlisted {
+			t.Errorf("Expected the rest of the waitlist to keep waiting, got %s", status)
+		}
+		if history := mockAppRepo.histories[3]; len(history) != 1 || history[0].Status != constant.ApplicationActionPromoteFromWaitlist || history[0].ActionedBy != nil {
+			t.Errorf("Expected system promotion history, got %+v", history)
+		}
+	})
+
+	t.Run("Cannot withdraw another UMKM's application", func(t *testing.T) {
+		service, _, mockAppRepo, _ := setup(constant.ApplicationStatusScreening)
+
+		err := service.WithdrawApplication(ctx, 2, 1, dto.WithdrawApplicationRequest{})
+		if err == nil || err.Error() != "application not found" {
+			t.Errorf("Expected application not found, got %v", err)
+		}
+		if mockAppRepo.applications[1].Status != constant.ApplicationStatusScreening {
+			t.Error("Expected application to be left untouched")
+		}
+	})
+}
+
+func TestApplicationDrafts(t *testing.T) {
+	ctx := context.Background()
+	text := func(v string) *string { return &v }
+
+	setup := func() (*mobileService, *mockMobileRepository, *mockApplicationsRepo) {
+		service, mockRepo := setupMobileServiceForTests()
+		minAmount, maxAmount, maxTenure := 5000000.0, 50000000.0, 24
+		program := mockRepo.programs[3]
+		program.MinAmount = &minAmount
+		program.MaxAmount = &maxAmount
+		program.MaxTenureMonths = &maxTenure
+		mockRepo.programs[3] = program
+		return service, mockRepo, service.applicationRepo.(*mockApplicationsRepo)
+	}
+
+	t.Run("Draft is saved step by step and validated against the program", func(t *testing.T) {
+		service, mockRepo, _ := setup()
+
+		draft, err := service.CreateApplicationDraft(ctx, 1, dto.CreateApplicationDraft{
+			ProgramID: 3,
+			ApplicationDraftFields: dto.ApplicationDraftFields{
+				ApplicationFormFields: dto.ApplicationFormFields{BusinessSector: text("Kuliner")},
+			},
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if draft.Status != constant.ApplicationStatusDraft || draft.Type != "funding" {
+			t.Fatalf("Expected funding draft, got %s %s", draft.Type, draft.Status)
+		}
+
+		amount := 100000000.0
+		if _, err := service.UpdateApplicationDraft(ctx, 1, draft.ID, dto.ApplicationDraftFields{ApplicationFormFields: dto.ApplicationFormFields{
+			BusinessDescription: text("Warung makan"),
+			RequestedAmount:     &amount,
+		}}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		funding := mockRepo.applications[draft.ID].FundingApplication
+		if funding.BusinessSector != "Kuliner" || funding.BusinessDescription != "Warung makan" {
+			t.Errorf("Expected earlier fields to be kept, got %+v", funding)
+		}
+
+		result, err := service.ValidateApplicationDraft(ctx, 1, draft.ID)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		fields := map[string]string{}
+		for _, issue := range result.Issues {
+			fields[issue.Field] = issue.Message
+		}
+		if result.Valid || fields["requested_amount"] != "cannot exceed 50000000.00" || fields["fund_purpose"] != "is required" ||
+			fields["requested_tenure_months"] != "is required" || fields["documents"] == "" {
+			t.Errorf("Unexpected validation result %+v", result)
+		}
+	})
+
+	t.Run("Submit moves a complete draft into screening", func(t *testing.T) {
+		service, mockRepo, mockAppRepo := setup()
+
+		draft, _ := service.CreateApplicationDraft(ctx, 1, dto.CreateApplicationDraft{
+			ProgramID: 1,
+			ApplicationDraftFields: dto.ApplicationDraftFields{
+				ApplicationFormFields: dto.ApplicationFormFields{Motivation: text("Ingin belajar pemasaran digital")},
+			},
+		})
+		created := mockRepo.applications[draft.ID]
+		created.SubmittedAt = time.Now().AddDate(0, 0, -10)
+		created.Documents = []model.ApplicationDocument{{ApplicationID: draft.ID, Type: "ktp", File: "http://example.com/ktp.jpg"}}
+		mockRepo.applications[draft.ID] = created
+		mockAppRepo.applications[draft.ID] = created
+
+		if err := service.SubmitApplicationDraft(ctx, 1, draft.ID); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		submitted := mockAppRepo.applications[draft.ID]
+		if submitted.Status != constant.ApplicationStatusScreening {
+			t.Errorf("Expected screening status, got %s", submitted.Status)
+		}
+		if time.Since(submitted.SubmittedAt) > time.Minute || !submitted.ExpiredAt.After(submitted.SubmittedAt) {
+			t.Errorf("Expected SLA to be stamped at submission, got %v / %v", submitted.SubmittedAt, submitted.ExpiredAt)
+		}
+		histories := mockAppRepo.histories[draft.ID]
+		if len(histories) != 1 || histories[0].Status != constant.ApplicationActionSubmit {
+			t.Errorf("Expected submit history, got %+v", histories)
+		}
+		notifications := service.notificationRepo.(*mockNotificationRepoForMobile).notifications
+		if len(notifications) != 1 || notifications[0].Type != constant.NotificationSubmitted {
+			t.Errorf("Expected submitted notification, got %+v", notifications)
+		}
+	})
+
+	t.Run("Incomplete draft cannot be submitted", func(t *testing.T) {
+		service, _, mockAppRepo := setup()
+
+		draft, _ := service.CreateApplicationDraft(ctx, 1, dto.CreateApplicationDraft{ProgramID: 2})
+
+		err := service.SubmitApplicationDraft(ctx, 1, draft.ID)
+		var incompleteErr *DraftIncompleteError
+		if !errors.As(err, &incompleteErr) || len(incompleteErr.Issues) != 5 {
+			t.Fatalf("Expected draft incomplete error with 5 issues, got %v", err)
+		}
+		if _, exists := mockAppRepo.applications[draft.ID]; exists {
+			t.Error("Expected the draft not to be moved to screening")
+		}
+	})
+
+	t.Run("Only one draft or application per program", func(t *testing.T) {
+		service, _, _ := setup()
+
+		if _, err := service.CreateApplicationDraft(ctx, 1, dto.CreateApplicationDraft{ProgramID: 1}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		_, err := service.CreateApplicationDraft(ctx, 1, dto.CreateApplicationDraft{ProgramID: 1})
+		if err == nil || err.Error() != "you have already applied for this program" {
+			t.Errorf("Expected already applied error, got %v", err)
+		}
+	})
+
+	t.Run("Existing applications are matched by UMKM, not by user", func(t *testing.T) {
+		service, mockRepo, _ := setup()
+		umkm := mockRepo.umkms[2]
+		umkm.ID, umkm.UserID = 12, 7
+		mockRepo.umkms[7] = umkm
+		mockRepo.applications[50] = model.Application{ID: 50, UMKMID: 12, ProgramID: 1, Status: constant.ApplicationStatusScreening}
+
+		_, err := service.CreateApplicationDraft(ctx, 7, dto.CreateApplicationDraft{ProgramID: 1})
+		if err == nil || err.Error() != "you have already applied for this program" {
+			t.Errorf("Expected already applied error, got %v", err)
+		}
+		err = service.CreateTrainingApplication(ctx, 7, dto.CreateApplicationTraining{
+			ProgramID:  1,
+			Motivation: "Test motivation",
+			Documents:  map[string]string{"ktp": "http://example.com/ktp.pdf"},
+		})
+		if err == nil || err.Error() != "you have already applied for this program" {
+			t.Errorf("Expected already applied error, got %v", err)
+		}
+	})
+
+	t.Run("Drafts of other UMKMs and submitted applications cannot be edited", func(t *testing.T) {
+		service, mockRepo, _ := setup()
+
+		draft, _ := service.CreateApplicationDraft(ctx, 1, dto.CreateApplicationDraft{ProgramID: 1})
+		if _, err := service.UpdateApplicationDraft(ctx, 2, draft.ID, dto.ApplicationDraftFields{}); err == nil || err.Error() != "application not found" {
+			t.Errorf("Expected application not found, got %v", err)
+		}
+		if err := service.DeleteApplicationDraft(ctx, 2, draft.ID); err == nil {
+			t.Error("Expected error deleting another UMKM's draft")
+		}
+
+		submitted := mockRepo.applications[draft.ID]
+		submitted.Status = constant.ApplicationStatusScreening
+		mockRepo.applications[draft.ID] = submitted
+		if _, err := service.UpdateApplicationDraft(ctx, 1, draft.ID, dto.ApplicationDraftFields{}); err == nil || err.Error() != "only draft applications can be edited" {
+			t.Errorf("Expected draft only error, got %v", err)
+		}
+		var transitionErr *InvalidTransitionError
+		if err := service.SubmitApplicationDraft(ctx, 1, draft.ID); !errors.As(err, &transitionErr) {
+			t.Errorf("Expected invalid transition error, got %v", err)
+		}
+	})
+
+	t.Run("Idle drafts expire", func(t *testing.T) {
+		mockAppRepo := newMockApplicationsRepo()
+		now := time.Now()
+		mockAppRepo.applications[1] = model.Application{ID: 1, Status: constant.ApplicationStatusDraft, ExpiredAt: now.Add(-time.Hour)}
+		mockAppRepo.applications[2] = model.Application{ID: 2, Status: constant.ApplicationStatusDraft, ExpiredAt: now.AddDate(0, 0, 1)}
+		mockAppRepo.applications[3] = model.Application{ID: 3, Status: constant.ApplicationStatusScreening, ExpiredAt: now.Add(-time.Hour)}
+
+		expired, err := NewDraftExpiryService(mockAppRepo).ExpireDrafts(ctx)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if expired != 1 || len(mockAppRepo.applications) != 2 {
+			t.Errorf("Expected only the idle draft to be removed, removed %d", expired)
+		}
+		if _, exists := mockAppRepo.applications[3]; !exists {
+			t.Error("Expected overdue submitted application to be kept")
+		}
+	})
+}
+
 // ==================== TEST NOTIFICATIONS ====================
 
 func TestGetNotificationsByUMKMID(t *testing.T) {
@@ -2099,7 +3022,7 @@ func TestProcessAndSaveDocuments(t *testing.T) {
 		}
 
 		// Should not panic or error
-		service.processAndSaveDocuments(ctx, 1, documents)
+		service.processAndSaveDocuments(ctx, 1, 0, documents)
 
 		// Give goroutine time to complete
 		time.Sleep(100 * time.Millisecond)
@@ -2109,7 +3032,7 @@ func TestProcessAndSaveDocuments(t *testing.T) {
 		documents := map[string]string{}
 
 		// Should handle gracefully
-		service.processAndSaveDocuments(ctx, 1, documents)
+		service.processAndSaveDocuments(ctx, 1, 0, documents)
 		time.Sleep(100 * time.Millisecond)
 	})
 
@@ -2119,7 +3042,7 @@ func TestProcessAndSaveDocuments(t *testing.T) {
 			"npwp": "http://example.com/npwp.pdf",
 		}
 
-		service.processAndSaveDocuments(ctx, 1, documents)
+		service.processAndSaveDocuments(ctx, 1, 0, documents)
 		time.Sleep(100 * time.Millisecond)
 	})
 }
@@ -2198,9 +3121,9 @@ func TestMobileServiceEdgeCasesExtended(t *testing.T) {
 		// Clear all programs
 		mockRepo.programs = make(map[int]model.Program)
 
-		training, err1 := service.GetTrainingPrograms(ctx)
-		cert, err2 := service.GetCertificationPrograms(ctx)
-		funding, err3 := service.GetFundingPrograms(ctx)
+		training, err1 := service.GetTrainingPrograms(ctx, 1)
+		cert, err2 := service.GetCertificationPrograms(ctx, 1)
+		funding, err3 := service.GetFundingPrograms(ctx, 1)
 
 		if err1 != nil || err2 != nil || err3 != nil {
 			t.Error("Expected no errors for empty lists")
@@ -2253,7 +3176,7 @@ func BenchmarkGetTrainingPrograms(b *testing.B) {
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		_, _ = service.GetTrainingPrograms(ctx)
+		_, _ = service.GetTrainingPrograms(ctx, 1)
 	}
 }
 
@@ -2273,7 +3196,7 @@ func BenchmarkGetProgramDetail(b *testing.B) {
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		_, _ = service.GetProgramDetail(ctx, 1)
+		_, _ = service.GetProgramDetail(ctx, 1, 1)
 	}
 }
 
@@ -2541,6 +3464,15 @@ func TestGetPublishedNews(t *testing.T) {
 			mockVaultLogRepo,
 			mockApplicationRepo,
 			mockSLARepo,
+			newMockHolidayRepo(),
+			newMockLoanRepo(),
+			newMockTrainingRepo(),
+			newMockCertificationRepo(),
+			newMockUnitOfWork(repository.TxRepositories{
+				Applications:  mockApplicationRepo,
+				Notifications: mockNotifRepo,
+				Mobile:        customMockRepo,
+			}),
 			nil,
 		)
 
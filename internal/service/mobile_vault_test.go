@@ -11,6 +11,7 @@ import (
 
 	"UMKMGo-backend/config/env"
 	"UMKMGo-backend/config/vault"
+	"UMKMGo-backend/internal/repository"
 	"UMKMGo-backend/internal/types/dto"
 	"UMKMGo-backend/internal/types/model"
 
@@ -910,7 +911,7 @@ func TestReviseApplication_WithMockedVault(t *testing.T) {
 	}
 
 	// Call the service - this will execute getUMKMWithDecryption and all code after it
-	err := service.ReviseApplication(ctx, userID, applicationID, documents)
+	err := service.ReviseApplication(ctx, userID, applicationID, dto.ReviseApplicationRequest{Documents: documents})
 	if err != nil {
 		t.Fatalf("Expected no error, got: %v", err)
 	}
@@ -936,7 +937,13 @@ func setupMobileServiceWithAllRepos() (*mobileService, *mockMobileRepository, *m
 		vaultLogRepo:     mockVaultRepo,
 		applicationRepo:  mockAppRepo,
 		slaRepo:          mockSLARepo,
-		minio:            nil,
+		holidayRepo:      newMockHolidayRepo(),
+		uow: newMockUnitOfWork(repository.TxRepositories{
+			Applications:  mockAppRepo,
+			Notifications: mockNotifRepo,
+			Mobile:        mockMobileRepo,
+		}),
+		minio: nil,
 	}
 
 	return service, mockMobileRepo, mockAppRepo
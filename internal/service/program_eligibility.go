@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"UMKMGo-backend/internal/types/dto"
+	"UMKMGo-backend/internal/types/model"
+	"UMKMGo-backend/internal/utils/constant"
+)
+
+// ProgramClosedError is returned when an application is started for a program
+// that does not accept applications. Reasons lists every check that failed.
+type ProgramClosedError struct {
+	ProgramID int
+	Reasons   []dto.ProgramClosedReason
+}
+
+func (e *ProgramClosedError) Error() string {
+	return fmt.Sprintf("program is not open for applications: %s", e.Reasons[0].Message)
+}
+
+// programWindow is the outcome of the submission gate for one program.
+type programWindow struct {
+	Open     bool
+	ClosesAt *time.Time
+	Reasons  []dto.ProgramClosedReason
+}
+
+// programWindowAt is the submission gate shared by the create endpoints, draft
+// submission and the mobile program listings. Applications close at the end of
+// the deadline day or when the batch starts, whichever comes first.
+func programWindowAt(program model.Program, now time.Time) programWindow {
+	var reasons []dto.ProgramClosedReason
+	closed := func(code, message string) {
+		reasons = append(reasons, dto.ProgramClosedReason{Code: code, Message: message})
+	}
+
+	if program.DeletedAt.Valid {
+		closed(constant.ProgramClosedDeleted, "program has been removed")
+	}
+	if !program.IsActive {
+		closed(constant.ProgramClosedInactive, "program is not active")
+	}
+
+	var closesAt *time.Time
+	if deadline, ok := parseProgramDate(program.ApplicationDeadline); ok {
+		end := deadline.AddDate(0, 0, 1)
+		closesAt = &end
+		if !now.Before(end) {
+			closed(constant.ProgramClosedDeadlinePassed, "application deadline has passed")
+		}
+	}
+	if program.BatchStartDate != nil {
+		if start, ok := parseProgramDate(*program.BatchStartDate); ok {
+			if closesAt == nil || start.Before(*closesAt) {
+				closesAt = &start
+			}
+			if !now.Before(start) {
+				closed(constant.ProgramClosedBatchStarted, "program batch has already started")
+			}
+		}
+	}
+
+	return programWindow{
+		Open:     len(reasons) == 0,
+		ClosesAt: closesAt,
+		Reasons:  reasons,
+	}
+}
+
+// parseProgramDate reads a program date column, which the driver may return as
+// a plain date or a full timestamp, as midnight local time.
+func parseProgramDate(value string) (time.Time, bool) {
+	if len(value) < len("2006-01-02") {
+		return time.Time{}, false
+	}
+	date, err := time.ParseInLocation("2006-01-02", value[:len("2006-01-02")], time.Local)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return date, true
+}
+
+// openProgram loads the program an application is started for and passes it
+// through the submission gate.
+func (s *mobileService) openProgram(ctx context.Context, programID int) (model.Program, error) {
+	program, err := s.mobileRepo.GetProgramForApplication(ctx, programID)
+	if err != nil {
+		return model.Program{}, err
+	}
+
+	if window := programWindowAt(program, time.Now()); !window.Open {
+		return model.Program{}, &ProgramClosedError{ProgramID: program.ID, Reasons: window.Reasons}
+	}
+	return program, nil
+}
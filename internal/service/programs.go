@@ -12,6 +12,7 @@ import (
 	"UMKMGo-backend/internal/types/dto"
 	"UMKMGo-backend/internal/types/model"
 	"UMKMGo-backend/internal/utils"
+	"UMKMGo-backend/internal/utils/constant"
 )
 
 type ProgramsService interface {
@@ -63,30 +64,35 @@ func (s *programsService) GetAllPrograms(ctx context.Context) ([]dto.Programs, e
 		}
 
 		programDTO := dto.Programs{
-			ID:                  program.ID,
-			Title:               program.Title,
-			Description:         program.Description,
-			Banner:              program.Banner,
-			Provider:            program.Provider,
-			ProviderLogo:        program.ProviderLogo,
-			Type:                program.Type,
-			TrainingType:        program.TrainingType,
-			Batch:               program.Batch,
-			BatchStartDate:      program.BatchStartDate,
-			BatchEndDate:        program.BatchEndDate,
-			Location:            program.Location,
-			MinAmount:           program.MinAmount,
-			MaxAmount:           program.MaxAmount,
-			InterestRate:        program.InterestRate,
-			MaxTenureMonths:     program.MaxTenureMonths,
-			ApplicationDeadline: program.ApplicationDeadline,
-			IsActive:            program.IsActive,
-			CreatedBy:           program.CreatedBy,
-			CreatedByName:       program.Users.Name,
-			CreatedAt:           program.CreatedAt.Format("2006-01-02 15:04:05"),
-			UpdatedAt:           program.UpdatedAt.Format("2006-01-02 15:04:05"),
-			Benefits:            benefitNames,
-			Requirements:        requirementNames,
+			ID:                   program.ID,
+			Title:                program.Title,
+			Description:          program.Description,
+			Banner:               program.Banner,
+			Provider:             program.Provider,
+			ProviderLogo:         program.ProviderLogo,
+			Type:                 program.Type,
+			TrainingType:         program.TrainingType,
+			Batch:                program.Batch,
+			BatchStartDate:       program.BatchStartDate,
+			BatchEndDate:         program.BatchEndDate,
+			Location:             program.Location,
+			MinAmount:            program.MinAmount,
+			MaxAmount:            program.MaxAmount,
+			InterestRate:         program.InterestRate,
+			InterestMethod:       program.InterestMethod,
+			MinAttendancePercent: program.MinAttendancePercent,
+			MaxTenureMonths:      program.MaxTenureMonths,
+			Capacity:             program.Capacity,
+			FundPool:             program.FundPool,
+			EligibilityRules:     programEligibilityRules(program),
+			ApplicationDeadline:  program.ApplicationDeadline,
+			IsActive:             program.IsActive,
+			CreatedBy:            program.CreatedBy,
+			CreatedByName:        program.Users.Name,
+			CreatedAt:            program.CreatedAt.Format("2006-01-02 15:04:05"),
+			UpdatedAt:            program.UpdatedAt.Format("2006-01-02 15:04:05"),
+			Benefits:             benefitNames,
+			Requirements:         requirementNames,
 		}
 		programsDTO = append(programsDTO, programDTO)
 	}
@@ -115,30 +121,35 @@ func (s *programsService) GetProgramByID(ctx context.Context, id int) (dto.Progr
 	}
 
 	return dto.Programs{
-		ID:                  program.ID,
-		Title:               program.Title,
-		Description:         program.Description,
-		Banner:              program.Banner,
-		Provider:            program.Provider,
-		ProviderLogo:        program.ProviderLogo,
-		Type:                program.Type,
-		TrainingType:        program.TrainingType,
-		Batch:               program.Batch,
-		BatchStartDate:      program.BatchStartDate,
-		BatchEndDate:        program.BatchEndDate,
-		Location:            program.Location,
-		MinAmount:           program.MinAmount,
-		MaxAmount:           program.MaxAmount,
-		InterestRate:        program.InterestRate,
-		MaxTenureMonths:     program.MaxTenureMonths,
-		ApplicationDeadline: program.ApplicationDeadline,
-		IsActive:            program.IsActive,
-		CreatedBy:           program.CreatedBy,
-		CreatedByName:       program.Users.Name,
-		CreatedAt:           program.CreatedAt.Format("2006-01-02 15:04:05"),
-		UpdatedAt:           program.UpdatedAt.Format("2006-01-02 15:04:05"),
-		Benefits:            benefitNames,
-		Requirements:        requirementNames,
+		ID:                   program.ID,
+		Title:                program.Title,
+		Description:          program.Description,
+		Banner:               program.Banner,
+		Provider:             program.Provider,
+		ProviderLogo:         program.ProviderLogo,
+		Type:                 program.Type,
+		TrainingType:         program.TrainingType,
+		Batch:                program.Batch,
+		BatchStartDate:       program.BatchStartDate,
+		BatchEndDate:         program.BatchEndDate,
+		Location:             program.Location,
+		MinAmount:            program.MinAmount,
+		MaxAmount:            program.MaxAmount,
+		InterestRate:         program.InterestRate,
+		InterestMethod:       program.InterestMethod,
+		MinAttendancePercent: program.MinAttendancePercent,
+		MaxTenureMonths:      program.MaxTenureMonths,
+		Capacity:             program.Capacity,
+		FundPool:             program.FundPool,
+		EligibilityRules:     programEligibilityRules(program),
+		ApplicationDeadline:  program.ApplicationDeadline,
+		IsActive:             program.IsActive,
+		CreatedBy:            program.CreatedBy,
+		CreatedByName:        program.Users.Name,
+		CreatedAt:            program.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt:            program.UpdatedAt.Format("2006-01-02 15:04:05"),
+		Benefits:             benefitNames,
+		Requirements:         requirementNames,
 	}, nil
 }
 
@@ -160,6 +171,15 @@ func (s *programsService) CreateProgram(ctx context.Context, program dto.Program
 		}
 	}
 
+	if err := validateProgramLimits(program); err != nil {
+		return dto.Programs{}, err
+	}
+
+	eligibilityRules, err := encodeProgramEligibility(program)
+	if err != nil {
+		return dto.Programs{}, err
+	}
+
 	// Check if user exists
 	if program.CreatedBy > 0 {
 		_, err := s.userRepository.GetUserByID(ctx, program.CreatedBy)
@@ -198,24 +218,29 @@ func (s *programsService) CreateProgram(ctx context.Context, program dto.Program
 
 	// Create program
 	newProgram := model.Program{
-		Title:               program.Title,
-		Description:         program.Description,
-		Banner:              program.Banner,
-		Provider:            program.Provider,
-		ProviderLogo:        program.ProviderLogo,
-		Type:                program.Type,
-		TrainingType:        program.TrainingType,
-		Batch:               program.Batch,
-		BatchStartDate:      program.BatchStartDate,
-		BatchEndDate:        program.BatchEndDate,
-		Location:            program.Location,
-		MinAmount:           program.MinAmount,
-		MaxAmount:           program.MaxAmount,
-		InterestRate:        program.InterestRate,
-		MaxTenureMonths:     program.MaxTenureMonths,
-		ApplicationDeadline: program.ApplicationDeadline,
-		IsActive:            true,
-		CreatedBy:           program.CreatedBy,
+		Title:                program.Title,
+		Description:          program.Description,
+		Banner:               program.Banner,
+		Provider:             program.Provider,
+		ProviderLogo:         program.ProviderLogo,
+		Type:                 program.Type,
+		TrainingType:         program.TrainingType,
+		Batch:                program.Batch,
+		BatchStartDate:       program.BatchStartDate,
+		BatchEndDate:         program.BatchEndDate,
+		Location:             program.Location,
+		MinAmount:            program.MinAmount,
+		MaxAmount:            program.MaxAmount,
+		InterestRate:         program.InterestRate,
+		InterestMethod:       program.InterestMethod,
+		MinAttendancePercent: program.MinAttendancePercent,
+		MaxTenureMonths:      program.MaxTenureMonths,
+		Capacity:             program.Capacity,
+		FundPool:             program.FundPool,
+		EligibilityRules:     eligibilityRules,
+		ApplicationDeadline:  program.ApplicationDeadline,
+		IsActive:             true,
+		CreatedBy:            program.CreatedBy,
 	}
 
 	createdProgram, err := s.programRepository.CreateProgram(ctx, newProgram)
@@ -280,6 +305,15 @@ func (s *programsService) UpdateProgram(ctx context.Context, id int, program dto
 		return dto.Programs{}, errors.New("type must be training, certification, or funding")
 	}
 
+	if err := validateProgramLimits(program); err != nil {
+		return dto.Programs{}, err
+	}
+
+	eligibilityRules, err := encodeProgramEligibility(program)
+	if err != nil {
+		return dto.Programs{}, err
+	}
+
 	// If banner is provided, upload to MinIO
 	if !(strings.HasPrefix(program.Banner, "http") || strings.HasPrefix(program.Banner, "https")) {
 		res, err := s.minio.UploadFile(ctx, storage.UploadRequest{
@@ -343,7 +377,12 @@ func (s *programsService) UpdateProgram(ctx context.Context, id int, program dto
 	existingProgram.MinAmount = program.MinAmount
 	existingProgram.MaxAmount = program.MaxAmount
 	existingProgram.InterestRate = program.InterestRate
+	existingProgram.InterestMethod = program.InterestMethod
+	existingProgram.MinAttendancePercent = program.MinAttendancePercent
 	existingProgram.MaxTenureMonths = program.MaxTenureMonths
+	existingProgram.Capacity = program.Capacity
+	existingProgram.FundPool = program.FundPool
+	existingProgram.EligibilityRules = eligibilityRules
 	existingProgram.ApplicationDeadline = program.ApplicationDeadline
 
 	updatedProgram, err := s.programRepository.UpdateProgram(ctx, existingProgram)
@@ -456,3 +495,46 @@ func (s *programsService) DeactivateProgram(ctx context.Context, id int) (dto.Pr
 		IsActive: updatedProgram.IsActive,
 	}, nil
 }
+
+// encodeProgramEligibility validates the eligibility rules sent for a program and
+// encodes them for storage.
+func encodeProgramEligibility(program dto.Programs) (*string, error) {
+	if err := validateEligibilityRules(program.Type, program.EligibilityRules); err != nil {
+		return nil, err
+	}
+	return encodeEligibilityRules(program.EligibilityRules)
+}
+
+// validateProgramLimits checks the optional capacity, fund pool, interest
+// method and attendance rule of a program. Only funding programs have a fund
+// pool and interest method, and only training programs an attendance rule.
+func validateProgramLimits(program dto.Programs) error {
+	if program.Capacity != nil && *program.Capacity <= 0 {
+		return errors.New("capacity must be greater than 0")
+	}
+	if program.FundPool != nil {
+		if program.Type != "funding" {
+			return errors.New("fund pool is only available for funding programs")
+		}
+		if *program.FundPool <= 0 {
+			return errors.New("fund pool must be greater than 0")
+		}
+	}
+	if program.InterestMethod != nil {
+		if program.Type != "funding" {
+			return errors.New("interest method is only available for funding programs")
+		}
+		if *program.InterestMethod != constant.InterestMethodFlat && *program.InterestMethod != constant.InterestMethodAnnuity {
+			return errors.New("interest method must be flat or annuity")
+		}
+	}
+	if program.MinAttendancePercent != nil {
+		if program.Type != "training" {
+			return errors.New("minimum attendance is only available for training programs")
+		}
+		if *program.MinAttendancePercent < 1 || *program.MinAttendancePercent > 100 {
+			return errors.New("minimum attendance must be between 1 and 100 percent")
+		}
+	}
+	return nil
+}
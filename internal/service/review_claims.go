@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"UMKMGo-backend/config/redis"
+)
+
+// ReviewClaimError reports that a reviewer does not hold, or cannot take, the
+// claim on an application. HolderID is 0 when nobody holds the claim.
+type ReviewClaimError struct {
+	ApplicationID int
+	HolderID      int
+	message       string
+}
+
+func (e *ReviewClaimError) Error() string {
+	return e.message
+}
+
+// reviewClaims is a lease-based lock per application and review stage. The key
+// holds the reviewer's user ID and expires with the lease, so an abandoned
+// claim frees itself.
+type reviewClaims struct {
+	redis redis.RedisRepository
+}
+
+func newReviewClaims(redisRepo redis.RedisRepository) *reviewClaims {
+	return &reviewClaims{
+		redis: redisRepo,
+	}
+}
+
+func reviewClaimKey(stage string, applicationID int) string {
+	return fmt.Sprintf("review:claim:%s:%d", stage, applicationID)
+}
+
+// Acquire takes the claim for userID, or renews the lease when userID already
+// holds it.
+func (c *reviewClaims) Acquire(ctx context.Context, stage string, applicationID, userID int, lease time.Duration) error {
+	key := reviewClaimKey(stage, applicationID)
+
+	ok, err := c.redis.SetNX(ctx, key, strconv.Itoa(userID), lease)
+	if err != nil {
+		return err
+	}
+	if ok {
+		return nil
+	}
+
+	holderID, err := c.Holder(ctx, stage, applicationID)
+	if err != nil {
+		return err
+	}
+	if holderID != userID {
+		return &ReviewClaimError{
+			ApplicationID: applicationID,
+			HolderID:      holderID,
+			message:       "application is already claimed by another reviewer",
+		}
+	}
+
+	return c.redis.Expire(ctx, key, lease)
+}
+
+// Holder returns the user ID holding the claim, or 0 when it is free.
+func (c *reviewClaims) Holder(ctx context.Context, stage string, applicationID int) (int, error) {
+	exists, err := c.redis.Exists(ctx, reviewClaimKey(stage, applicationID))
+	if err != nil {
+		return 0, err
+	}
+	if exists == 0 {
+		return 0, nil
+	}
+
+	value, err := c.redis.Get(ctx, reviewClaimKey(stage, applicationID))
+	if err != nil {
+		// Expired between the two calls
+		return 0, nil
+	}
+
+	holderID, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, nil
+	}
+	return holderID, nil
+}
+
+// Require fails unless userID currently holds the claim.
+func (c *reviewClaims) Require(ctx context.Context, stage string, applicationID, userID int) error {
+	holderID, err := c.Holder(ctx, stage, applicationID)
+	if err != nil {
+		return err
+	}
+	if holderID == 0 {
+		return &ReviewClaimError{
+			ApplicationID: applicationID,
+			message:       "application must be claimed before a decision can be made",
+		}
+	}
+	if holderID != userID {
+		return &ReviewClaimError{
+			ApplicationID: applicationID,
+			HolderID:      holderID,
+			message:       "application is claimed by another reviewer",
+		}
+	}
+	return nil
+}
+
+// Release drops the claim if userID holds it. The holder is checked and the
+// key deleted atomically, so a lease that expired and was taken by another
+// reviewer in the meantime is left alone.
+func (c *reviewClaims) Release(ctx context.Context, stage string, applicationID, userID int) error {
+	released, err := c.redis.DelIfEqual(ctx, reviewClaimKey(stage, applicationID), strconv.Itoa(userID))
+	if err != nil {
+		return err
+	}
+	if released {
+		return nil
+	}
+
+	// Not ours; report who holds it instead
+	return c.Require(ctx, stage, applicationID, userID)
+}
+
+// Claimed returns the holder of every live claim in a stage, keyed by application ID.
+func (c *reviewClaims) Claimed(ctx context.Context, stage string) (map[int]int, error) {
+	prefix := fmt.Sprintf("review:claim:%s:", stage)
+
+	keys, err := c.redis.Scan(ctx, prefix+"*", 100)
+	if err != nil {
+		return nil, err
+	}
+
+	var stageKeys []string
+	for _, key := range keys {
+		if strings.HasPrefix(key, prefix) {
+			stageKeys = append(stageKeys, key)
+		}
+	}
+
+	claimed := make(map[int]int, len(stageKeys))
+	if len(stageKeys) == 0 {
+		return claimed, nil
+	}
+
+	values, err := c.redis.MGet(ctx, stageKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, key := range stageKeys {
+		value, ok := values[i].(string)
+		if !ok {
+			continue
+		}
+		applicationID, err := strconv.Atoi(strings.TrimPrefix(key, prefix))
+		if err != nil {
+			continue
+		}
+		holderID, err := strconv.Atoi(value)
+		if err != nil {
+			continue
+		}
+		claimed[applicationID] = holderID
+	}
+
+	return claimed, nil
+}
@@ -0,0 +1,321 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"UMKMGo-backend/config/redis"
+	"UMKMGo-backend/internal/repository"
+	"UMKMGo-backend/internal/types/dto"
+	"UMKMGo-backend/internal/types/model"
+	"UMKMGo-backend/internal/utils/constant"
+)
+
+type ReviewQueueService interface {
+	GetQueue(ctx context.Context, userID, roleID int, stage string) ([]dto.ReviewQueueItem, error)
+	ClaimNext(ctx context.Context, userID, roleID int, stage string) (dto.ReviewClaim, error)
+	ClaimApplication(ctx context.Context, userID, roleID, applicationID int) (dto.ReviewClaim, error)
+	ReleaseClaim(ctx context.Context, userID, applicationID int) error
+	AssignApplications(ctx context.Context, stage string) (dto.ReviewAssignmentReport, error)
+}
+
+type reviewQueueService struct {
+	applicationRepository       repository.ApplicationsRepository
+	userRepository              repository.UsersRepository
+	adminNotificationRepository repository.AdminNotificationRepository
+	redisRepository             redis.RedisRepository
+	claims                      *reviewClaims
+	lease                       time.Duration
+	strategy                    string
+}
+
+func NewReviewQueueService(applicationRepo repository.ApplicationsRepository, userRepo repository.UsersRepository, adminNotificationRepo repository.AdminNotificationRepository, redisRepo redis.RedisRepository, leaseMinutes int, strategy string) ReviewQueueService {
+	if leaseMinutes <= 0 {
+		leaseMinutes = 30
+	}
+	if strategy != constant.ReviewAssignmentRoundRobin {
+		strategy = constant.ReviewAssignmentLeastLoaded
+	}
+
+	return &reviewQueueService{
+		applicationRepository:       applicationRepo,
+		userRepository:              userRepo,
+		adminNotificationRepository: adminNotificationRepo,
+		redisRepository:             redisRepo,
+		claims:                      newReviewClaims(redisRepo),
+		lease:                       time.Duration(leaseMinutes) * time.Minute,
+		strategy:                    strategy,
+	}
+}
+
+// applicationTypes are the program types a reviewer can hold review permissions for.
+var applicationTypes = []string{"training", "certification", "funding"}
+
+// GetQueue lists the applications a reviewer may work at a stage, most urgent first.
+func (s *reviewQueueService) GetQueue(ctx context.Context, userID, roleID int, stage string) ([]dto.ReviewQueueItem, error) {
+	types, err := s.reviewerTypes(ctx, roleID, stage)
+	if err != nil {
+		return nil, err
+	}
+
+	applications, err := s.applicationRepository.GetReviewQueue(ctx, stage, types)
+	if err != nil {
+		return nil, err
+	}
+
+	claimed, err := s.claims.Claimed(ctx, stage)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	items := make([]dto.ReviewQueueItem, 0, len(applications))
+	for _, application := range applications {
+		item := dto.ReviewQueueItem{
+			ApplicationID: application.ID,
+			Type:          application.Type,
+			Status:        application.Status,
+			ProgramName:   application.Program.Title,
+			BusinessName:  application.UMKM.BusinessName,
+			SubmittedAt:   application.SubmittedAt.Format("2006-01-02 15:04:05"),
+			ExpiredAt:     application.ExpiredAt.Format("2006-01-02 15:04:05"),
+			IsOverdue:     isApplicationOverdue(application, now),
+		}
+		if holderID, ok := claimed[application.ID]; ok {
+			item.ClaimedBy = &holderID
+			item.ClaimedByMe = holderID == userID
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// ClaimNext hands the reviewer an application they already hold at this stage,
+// otherwise the most urgent unclaimed one.
+func (s *reviewQueueService) ClaimNext(ctx context.Context, userID, roleID int, stage string) (dto.ReviewClaim, error) {
+	types, err := s.reviewerTypes(ctx, roleID, stage)
+	if err != nil {
+		return dto.ReviewClaim{}, err
+	}
+
+	applications, err := s.applicationRepository.GetReviewQueue(ctx, stage, types)
+	if err != nil {
+		return dto.ReviewClaim{}, err
+	}
+
+	claimed, err := s.claims.Claimed(ctx, stage)
+	if err != nil {
+		return dto.ReviewClaim{}, err
+	}
+
+	for _, application := range applications {
+		if claimed[application.ID] == userID {
+			return s.acquire(ctx, stage, application.ID, userID)
+		}
+	}
+
+	for _, application := range applications {
+		if _, ok := claimed[application.ID]; ok {
+			continue
+		}
+
+		claim, err := s.acquire(ctx, stage, application.ID, userID)
+		if err != nil {
+			// Another reviewer took it in the meantime
+			var claimErr *ReviewClaimError
+			if errors.As(err, &claimErr) {
+				continue
+			}
+			return dto.ReviewClaim{}, err
+		}
+		return claim, nil
+	}
+
+	return dto.ReviewClaim{}, errors.New("no applications waiting in the queue")
+}
+
+// ClaimApplication claims a specific application, or renews the lease if the
+// reviewer already holds it.
+func (s *reviewQueueService) ClaimApplication(ctx context.Context, userID, roleID, applicationID int) (dto.ReviewClaim, error) {
+	application, err := s.applicationRepository.GetApplicationByID(ctx, applicationID)
+	if err != nil {
+		return dto.ReviewClaim{}, err
+	}
+
+	stage, err := reviewStage(application.Status)
+	if err != nil {
+		return dto.ReviewClaim{}, err
+	}
+
+	types, err := s.reviewerTypes(ctx, roleID, stage)
+	if err != nil {
+		return dto.ReviewClaim{}, err
+	}
+	if !slices.Contains(types, application.Type) {
+		return dto.ReviewClaim{}, errors.New("you do not have permission to review this application")
+	}
+
+	return s.acquire(ctx, stage, application.ID, userID)
+}
+
+func (s *reviewQueueService) ReleaseClaim(ctx context.Context, userID, applicationID int) error {
+	application, err := s.applicationRepository.GetApplicationByID(ctx, applicationID)
+	if err != nil {
+		return err
+	}
+
+	stage, err := reviewStage(application.Status)
+	if err != nil {
+		return err
+	}
+
+	return s.claims.Release(ctx, stage, application.ID, userID)
+}
+
+// AssignApplications claims every unclaimed application at a stage on behalf of
+// a reviewer holding the matching permission, using the configured strategy.
+func (s *reviewQueueService) AssignApplications(ctx context.Context, stage string) (dto.ReviewAssignmentReport, error) {
+	report := dto.ReviewAssignmentReport{
+		Stage:       stage,
+		Strategy:    s.strategy,
+		Assignments: []dto.ReviewClaim{},
+	}
+
+	if _, err := reviewStage(stage); err != nil {
+		return report, err
+	}
+
+	applications, err := s.applicationRepository.GetReviewQueue(ctx, stage, applicationTypes)
+	if err != nil {
+		return report, err
+	}
+
+	claimed, err := s.claims.Claimed(ctx, stage)
+	if err != nil {
+		return report, err
+	}
+
+	loads := make(map[int]int)
+	for _, holderID := range claimed {
+		loads[holderID]++
+	}
+
+	reviewers := make(map[string][]int)
+	var notifications []model.AdminNotification
+	for _, application := range applications {
+		if _, ok := claimed[application.ID]; ok {
+			continue
+		}
+
+		permissionCode := reviewPermissionCode(stage, application.Type)
+		candidates, ok := reviewers[permissionCode]
+		if !ok {
+			if candidates, err = s.adminNotificationRepository.GetUserIDsByPermissions(ctx, []string{permissionCode}); err != nil {
+				return report, err
+			}
+			slices.Sort(candidates)
+			reviewers[permissionCode] = candidates
+		}
+		if len(candidates) == 0 {
+			report.Skipped++
+			continue
+		}
+
+		reviewerID, err := s.pickReviewer(ctx, permissionCode, candidates, loads)
+		if err != nil {
+			return report, err
+		}
+
+		claim, err := s.acquire(ctx, stage, application.ID, reviewerID)
+		if err != nil {
+			report.Skipped++
+			continue
+		}
+		loads[reviewerID]++
+
+		report.Assigned++
+		report.Assignments = append(report.Assignments, claim)
+		notifications = append(notifications, buildAdminNotifications([]int{reviewerID}, application, constant.AdminNotificationReviewAssigned,
+			constant.AdminNotificationTitleReviewAssigned,
+			fmt.Sprintf(constant.AdminNotificationMessageReviewAssigned, application.ID, application.Program.Title, stage, application.ExpiredAt.Format("2006-01-02 15:04:05")),
+			"{}")...)
+	}
+
+	if err := s.adminNotificationRepository.CreateAdminNotifications(ctx, notifications); err != nil {
+		return report, err
+	}
+
+	return report, nil
+}
+
+func (s *reviewQueueService) acquire(ctx context.Context, stage string, applicationID, userID int) (dto.ReviewClaim, error) {
+	if err := s.claims.Acquire(ctx, stage, applicationID, userID, s.lease); err != nil {
+		return dto.ReviewClaim{}, err
+	}
+
+	return dto.ReviewClaim{
+		ApplicationID:  applicationID,
+		Stage:          stage,
+		ReviewerID:     userID,
+		LeaseExpiresAt: time.Now().Add(s.lease).Format("2006-01-02 15:04:05"),
+	}, nil
+}
+
+// pickReviewer chooses among candidates sorted by user ID. Round-robin keeps a
+// rotating counter per permission in redis; least-loaded takes the reviewer with
+// the fewest live claims at the stage.
+func (s *reviewQueueService) pickReviewer(ctx context.Context, permissionCode string, candidates []int, loads map[int]int) (int, error) {
+	if s.strategy == constant.ReviewAssignmentRoundRobin {
+		counter, err := s.redisRepository.Incr(ctx, "review:round_robin:"+permissionCode)
+		if err != nil {
+			return 0, err
+		}
+		return candidates[int((counter-1)%int64(len(candidates)))], nil
+	}
+
+	reviewerID := candidates[0]
+	for _, candidate := range candidates[1:] {
+		if loads[candidate] < loads[reviewerID] {
+			reviewerID = candidate
+		}
+	}
+	return reviewerID, nil
+}
+
+// reviewerTypes returns the application types the role may review at a stage.
+func (s *reviewQueueService) reviewerTypes(ctx context.Context, roleID int, stage string) ([]string, error) {
+	if _, err := reviewStage(stage); err != nil {
+		return nil, err
+	}
+
+	permissions, err := s.userRepository.GetListPermissionsByRoleID(ctx, roleID)
+	if err != nil {
+		return nil, err
+	}
+
+	var types []string
+	for _, applicationType := range applicationTypes {
+		if slices.Contains(permissions, reviewPermissionCode(stage, applicationType)) {
+			types = append(types, applicationType)
+		}
+	}
+	if len(types) == 0 {
+		return nil, errors.New("you do not have permission to review this stage")
+	}
+
+	return types, nil
+}
+
+// reviewStage maps an application status to the review stage that works it.
+func reviewStage(status string) (string, error) {
+	switch status {
+	case constant.ApplicationStatusScreening, constant.ApplicationStatusFinal:
+		return status, nil
+	default:
+		return "", errors.New("application is not awaiting review")
+	}
+}
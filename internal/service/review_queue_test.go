@@ -0,0 +1,253 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"UMKMGo-backend/internal/types/model"
+	"UMKMGo-backend/internal/utils/constant"
+)
+
+// ==================== TEST FUNCTIONS ====================
+
+func setupReviewQueueService(strategy string) (*reviewQueueService, *mockApplicationsRepo, *mockAdminNotificationRepo) {
+	mockAppRepo := newMockApplicationsRepo()
+	mockUserRepo := &mockUsersRepositoryForTests{
+		rolePermissions: map[int][]string{
+			2: {"SCREENING_TRAINING", "SCREENING_FUNDING"},
+			3: {"FINAL_FUNDING"},
+		},
+	}
+	mockAdminRepo := newMockAdminNotificationRepo()
+
+	service := NewReviewQueueService(mockAppRepo, mockUserRepo, mockAdminRepo, newMockRedisRepository(), 30, strategy).(*reviewQueueService)
+	return service, mockAppRepo, mockAdminRepo
+}
+
+func addQueuedApplication(repo *mockApplicationsRepo, id int, applicationType, status string, expiredAt time.Time) {
+	programID := 1
+	if applicationType == "funding" {
+		programID = 2
+	}
+	repo.applications[id] = model.Application{
+		ID:          id,
+		UMKMID:      1,
+		ProgramID:   programID,
+		Type:        applicationType,
+		Status:      status,
+		SubmittedAt: expiredAt.AddDate(0, 0, -7),
+		ExpiredAt:   expiredAt,
+		Program:     repo.programs[programID],
+		UMKM:        repo.umkms[1],
+	}
+}
+
+func TestReviewQueueClaims(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	t.Run("Queue is ordered by deadline and limited to the reviewer's permissions", func(t *testing.T) {
+		service, mockRepo, _ := setupReviewQueueService(constant.ReviewAssignmentLeastLoaded)
+		addQueuedApplication(mockRepo, 1, "training", "screening", now.AddDate(0, 0, 3))
+		addQueuedApplication(mockRepo, 2, "funding", "screening", now.AddDate(0, 0, -1))
+		addQueuedApplication(mockRepo, 3, "certification", "screening", now.AddDate(0, 0, 1))
+		addQueuedApplication(mockRepo, 4, "funding", "final", now.AddDate(0, 0, 1))
+
+		queue, err := service.GetQueue(ctx, 20, 2, "screening")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(queue) != 2 || queue[0].ApplicationID != 2 || queue[1].ApplicationID != 1 {
+			t.Fatalf("Unexpected queue %+v", queue)
+		}
+		if !queue[0].IsOverdue {
+			t.Error("Expected overdue application to be flagged")
+		}
+	})
+
+	t.Run("Reviewer without permission cannot see the stage", func(t *testing.T) {
+		service, _, _ := setupReviewQueueService(constant.ReviewAssignmentLeastLoaded)
+
+		_, err := service.GetQueue(ctx, 30, 3, "screening")
+		if err == nil || err.Error() != "you do not have permission to review this stage" {
+			t.Errorf("Expected permission error, got %v", err)
+		}
+	})
+
+	t.Run("Claim next skips applications held by others", func(t *testing.T) {
+		service, mockRepo, _ := setupReviewQueueService(constant.ReviewAssignmentLeastLoaded)
+		addQueuedApplication(mockRepo, 1, "training", "screening", now.AddDate(0, 0, 1))
+		addQueuedApplication(mockRepo, 2, "training", "screening", now.AddDate(0, 0, 2))
+
+		first, err := service.ClaimNext(ctx, 20, 2, "screening")
+		if err != nil || first.ApplicationID != 1 {
+			t.Fatalf("Expected application 1, got %+v (%v)", first, err)
+		}
+
+		second, err := service.ClaimNext(ctx, 21, 2, "screening")
+		if err != nil || second.ApplicationID != 2 {
+			t.Fatalf("Expected application 2, got %+v (%v)", second, err)
+		}
+
+		// The first reviewer gets their own claim back instead of a new one
+		again, err := service.ClaimNext(ctx, 20, 2, "screening")
+		if err != nil || again.ApplicationID != 1 {
+			t.Fatalf("Expected application 1 again, got %+v (%v)", again, err)
+		}
+
+		_, err = service.ClaimNext(ctx, 22, 2, "screening")
+		if err == nil || err.Error() != "no applications waiting in the queue" {
+			t.Errorf("Expected empty queue error, got %v", err)
+		}
+	})
+
+	t.Run("Claiming a held application conflicts", func(t *testing.T) {
+		service, mockRepo, _ := setupReviewQueueService(constant.ReviewAssignmentLeastLoaded)
+		addQueuedApplication(mockRepo, 1, "training", "screening", now.AddDate(0, 0, 1))
+
+		if _, err := service.ClaimApplication(ctx, 20, 2, 1); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		_, err := service.ClaimApplication(ctx, 21, 2, 1)
+		var claimErr *ReviewClaimError
+		if !errors.As(err, &claimErr) || claimErr.HolderID != 20 {
+			t.Errorf("Expected claim held by 20, got %v", err)
+		}
+	})
+
+	t.Run("Release frees the claim for another reviewer", func(t *testing.T) {
+		service, mockRepo, _ := setupReviewQueueService(constant.ReviewAssignmentLeastLoaded)
+		addQueuedApplication(mockRepo, 1, "training", "screening", now.AddDate(0, 0, 1))
+
+		service.ClaimApplication(ctx, 20, 2, 1)
+		if err := service.ReleaseClaim(ctx, 21, 1); err == nil {
+			t.Error("Expected error releasing another reviewer's claim")
+		}
+		if err := service.ReleaseClaim(ctx, 20, 1); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if _, err := service.ClaimApplication(ctx, 21, 2, 1); err != nil {
+			t.Errorf("Expected claim after release, got %v", err)
+		}
+	})
+
+	t.Run("Release after the lease expired keeps the new holder's claim", func(t *testing.T) {
+		service, mockRepo, _ := setupReviewQueueService(constant.ReviewAssignmentLeastLoaded)
+		addQueuedApplication(mockRepo, 1, "training", "screening", now.AddDate(0, 0, 1))
+
+		service.ClaimApplication(ctx, 20, 2, 1)
+		// The lease runs out and another reviewer picks the application up
+		delete(service.claims.redis.(*mockRedisRepository).data, reviewClaimKey("screening", 1))
+		if _, err := service.ClaimApplication(ctx, 21, 2, 1); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		err := service.ReleaseClaim(ctx, 20, 1)
+		var claimErr *ReviewClaimError
+		if !errors.As(err, &claimErr) || claimErr.HolderID != 21 {
+			t.Errorf("Expected claim held by 21, got %v", err)
+		}
+		if holderID, _ := service.claims.Holder(ctx, "screening", 1); holderID != 21 {
+			t.Errorf("Expected 21 to keep the claim, got %d", holderID)
+		}
+	})
+}
+
+func TestReviewQueueDecisionRequiresClaim(t *testing.T) {
+	ctx := context.Background()
+	service, mockRepo, _ := setupApplicationsService()
+	mockRepo.applications[1] = model.Application{ID: 1, UMKMID: 1, ProgramID: 1, Type: "training", Status: "screening"}
+
+	_, err := service.ScreeningApprove(ctx, 2, 1, 0)
+	var claimErr *ReviewClaimError
+	if !errors.As(err, &claimErr) || claimErr.HolderID != 1 {
+		t.Fatalf("Expected claim held by admin 1, got %v", err)
+	}
+
+	if _, err := service.ScreeningApprove(ctx, 1, 1, 0); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	holderID, _ := service.claims.Holder(ctx, "screening", 1)
+	if holderID != 0 {
+		t.Errorf("Expected claim to be released after the decision, held by %d", holderID)
+	}
+}
+
+func TestReviewQueueAssignApplications(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	setup := func(strategy string) (*reviewQueueService, *mockAdminNotificationRepo) {
+		service, mockRepo, mockAdminRepo := setupReviewQueueService(strategy)
+		for id := 1; id <= 4; id++ {
+			addQueuedApplication(mockRepo, id, "funding", "final", now.AddDate(0, 0, id))
+		}
+		// No reviewer holds FINAL_TRAINING
+		addQueuedApplication(mockRepo, 5, "training", "final", now.AddDate(0, 0, 5))
+		return service, mockAdminRepo
+	}
+
+	t.Run("Round robin alternates between reviewers", func(t *testing.T) {
+		service, mockAdminRepo := setup(constant.ReviewAssignmentRoundRobin)
+		// Reviewer 11 already holds a claim, round robin ignores load
+		service.claims.Acquire(ctx, "final", 99, 11, time.Hour)
+
+		report, err := service.AssignApplications(ctx, "final")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if report.Assigned != 4 || report.Skipped != 1 {
+			t.Fatalf("Expected 4 assigned and 1 skipped, got %+v", report)
+		}
+
+		expected := []int{11, 12, 11, 12}
+		for i, assignment := range report.Assignments {
+			if assignment.ReviewerID != expected[i] {
+				t.Errorf("Assignment %d: expected reviewer %d, got %d", i, expected[i], assignment.ReviewerID)
+			}
+		}
+		if len(mockAdminRepo.notifications) != 4 || mockAdminRepo.notifications[0].Type != constant.AdminNotificationReviewAssigned {
+			t.Errorf("Expected 4 review assigned notifications, got %+v", mockAdminRepo.notifications)
+		}
+	})
+
+	t.Run("Least loaded balances existing claims", func(t *testing.T) {
+		service, _ := setup(constant.ReviewAssignmentLeastLoaded)
+		service.claims.Acquire(ctx, "final", 99, 11, time.Hour)
+
+		report, err := service.AssignApplications(ctx, "final")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		loads := map[int]int{11: 1}
+		for _, assignment := range report.Assignments {
+			loads[assignment.ReviewerID]++
+		}
+		if loads[11] != 3 || loads[12] != 2 {
+			t.Errorf("Expected balanced load 3/2, got %v", loads)
+		}
+		if report.Assignments[0].ReviewerID != 12 {
+			t.Errorf("Expected the idle reviewer to be picked first, got %d", report.Assignments[0].ReviewerID)
+		}
+	})
+
+	t.Run("Claimed applications are not reassigned", func(t *testing.T) {
+		service, _ := setup(constant.ReviewAssignmentLeastLoaded)
+		service.claims.Acquire(ctx, "final", 1, 30, time.Hour)
+
+		report, err := service.AssignApplications(ctx, "final")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		for _, assignment := range report.Assignments {
+			if assignment.ApplicationID == 1 {
+				t.Error("Expected claimed application to be left alone")
+			}
+		}
+	})
+}
@@ -2,20 +2,27 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
 	"UMKMGo-backend/internal/repository"
 	"UMKMGo-backend/internal/types/dto"
 	"UMKMGo-backend/internal/types/model"
+	"UMKMGo-backend/internal/utils/constant"
 )
 
 type SLAService interface {
 	GetSLAScreening(ctx context.Context) (dto.SLA, error)
 	GetSLAFinal(ctx context.Context) (dto.SLA, error)
-	UpdateSLAScreening(ctx context.Context, slaDTO dto.SLA) (dto.SLA, error)
-	UpdateSLAFinal(ctx context.Context, slaDTO dto.SLA) (dto.SLA, error)
+	UpdateSLAScreening(ctx context.Context, userID int, slaDTO dto.SLA) (dto.SLA, error)
+	UpdateSLAFinal(ctx context.Context, userID int, slaDTO dto.SLA) (dto.SLA, error)
+	GetSLARules(ctx context.Context, status, applicationType string, programID int) ([]dto.SLA, error)
+	CreateSLARule(ctx context.Context, userID int, slaDTO dto.SLA) (dto.SLA, error)
+	DeleteSLARule(ctx context.Context, userID, id int) error
+	GetSLAChangeLogs(ctx context.Context, slaID int) ([]dto.SLAChangeLog, error)
 	ExportApplications(ctx context.Context, request dto.ExportRequest) ([]byte, string, error)
 	ExportPrograms(ctx context.Context, request dto.ExportRequest) ([]byte, string, error)
 }
@@ -36,13 +43,7 @@ func (s *slaService) GetSLAScreening(ctx context.Context) (dto.SLA, error) {
 		return dto.SLA{}, err
 	}
 
-	return dto.SLA{
-		ID:          sla.ID,
-		Status:      sla.Status,
-		MaxDays:     sla.MaxDays,
-		Description: sla.Description,
-		UpdatedAt:   sla.UpdatedAt.Format("2006-01-02 15:04:05"),
-	}, nil
+	return toSLADTO(sla), nil
 }
 
 func (s *slaService) GetSLAFinal(ctx context.Context) (dto.SLA, error) {
@@ -51,71 +52,176 @@ func (s *slaService) GetSLAFinal(ctx context.Context) (dto.SLA, error) {
 		return dto.SLA{}, err
 	}
 
-	return dto.SLA{
-		ID:          sla.ID,
-		Status:      sla.Status,
-		MaxDays:     sla.MaxDays,
-		Description: sla.Description,
-		UpdatedAt:   sla.UpdatedAt.Format("2006-01-02 15:04:05"),
-	}, nil
+	return toSLADTO(sla), nil
 }
 
-func (s *slaService) UpdateSLAScreening(ctx context.Context, slaDTO dto.SLA) (dto.SLA, error) {
-	if slaDTO.MaxDays <= 0 {
-		return dto.SLA{}, errors.New("max_days must be greater than 0")
+func (s *slaService) UpdateSLAScreening(ctx context.Context, userID int, slaDTO dto.SLA) (dto.SLA, error) {
+	return s.updateStageDefault(ctx, userID, "screening", slaDTO)
+}
+
+func (s *slaService) UpdateSLAFinal(ctx context.Context, userID int, slaDTO dto.SLA) (dto.SLA, error) {
+	return s.updateStageDefault(ctx, userID, "final", slaDTO)
+}
+
+// updateStageDefault never edits a rule in place. It stores a new version that
+// takes over from its effective_from, so applications submitted earlier keep
+// resolving the rule they were submitted under.
+func (s *slaService) updateStageDefault(ctx context.Context, userID int, status string, slaDTO dto.SLA) (dto.SLA, error) {
+	if err := validateSLARule(slaDTO); err != nil {
+		return dto.SLA{}, err
 	}
 
-	existingSLA, err := s.slaRepository.GetSLAByStatus(ctx, "screening")
+	effectiveFrom, err := parseEffectiveFrom(slaDTO.EffectiveFrom)
 	if err != nil {
 		return dto.SLA{}, err
 	}
 
-	existingSLA.MaxDays = slaDTO.MaxDays
+	existingSLA, err := s.slaRepository.GetSLAByStatus(ctx, status)
+	if err != nil {
+		return dto.SLA{}, err
+	}
+
+	newSLA := model.SLA{
+		Status:        existingSLA.Status,
+		MaxDays:       slaDTO.MaxDays,
+		DayType:       existingSLA.DayType,
+		EffectiveFrom: effectiveFrom,
+		Description:   existingSLA.Description,
+		CreatedBy:     &userID,
+	}
 	if slaDTO.Description != "" {
-		existingSLA.Description = slaDTO.Description
+		newSLA.Description = slaDTO.Description
+	}
+	if slaDTO.DayType != "" {
+		newSLA.DayType = slaDTO.DayType
 	}
 
-	updatedSLA, err := s.slaRepository.UpdateSLA(ctx, existingSLA)
+	changeLog, err := newSLAChangeLog(constant.SLAChangeActionUpdate, userID, &existingSLA, &newSLA)
 	if err != nil {
 		return dto.SLA{}, err
 	}
 
-	return dto.SLA{
-		ID:          updatedSLA.ID,
-		Status:      updatedSLA.Status,
-		MaxDays:     updatedSLA.MaxDays,
-		Description: updatedSLA.Description,
-		UpdatedAt:   updatedSLA.UpdatedAt.Format("2006-01-02 15:04:05"),
-	}, nil
+	createdSLA, err := s.slaRepository.CreateSLA(ctx, newSLA, changeLog)
+	if err != nil {
+		return dto.SLA{}, err
+	}
+
+	return toSLADTO(createdSLA), nil
 }
 
-func (s *slaService) UpdateSLAFinal(ctx context.Context, slaDTO dto.SLA) (dto.SLA, error) {
-	if slaDTO.MaxDays <= 0 {
-		return dto.SLA{}, errors.New("max_days must be greater than 0")
+func (s *slaService) GetSLARules(ctx context.Context, status, applicationType string, programID int) ([]dto.SLA, error) {
+	slas, err := s.slaRepository.GetSLARules(ctx, status, applicationType, programID)
+	if err != nil {
+		return nil, err
+	}
+
+	slasDTO := make([]dto.SLA, 0, len(slas))
+	for _, sla := range slas {
+		slasDTO = append(slasDTO, toSLADTO(sla))
+	}
+
+	return slasDTO, nil
+}
+
+// CreateSLARule adds a program type rule or a per-program override for a stage.
+func (s *slaService) CreateSLARule(ctx context.Context, userID int, slaDTO dto.SLA) (dto.SLA, error) {
+	if slaDTO.Status != "screening" && slaDTO.Status != "final" {
+		return dto.SLA{}, errors.New("status must be screening or final")
+	}
+	if err := validateSLARule(slaDTO); err != nil {
+		return dto.SLA{}, err
+	}
+	if slaDTO.ApplicationType == "" && slaDTO.ProgramID == nil {
+		return dto.SLA{}, errors.New("application_type or program_id is required")
+	}
+	if slaDTO.ApplicationType != "" && slaDTO.ApplicationType != "training" && slaDTO.ApplicationType != "certification" && slaDTO.ApplicationType != "funding" {
+		return dto.SLA{}, errors.New("application_type must be training, certification or funding")
 	}
 
-	existingSLA, err := s.slaRepository.GetSLAByStatus(ctx, "final")
+	effectiveFrom, err := parseEffectiveFrom(slaDTO.EffectiveFrom)
 	if err != nil {
 		return dto.SLA{}, err
 	}
 
-	existingSLA.MaxDays = slaDTO.MaxDays
-	if slaDTO.Description != "" {
-		existingSLA.Description = slaDTO.Description
+	newSLA := model.SLA{
+		Status:        slaDTO.Status,
+		ProgramID:     slaDTO.ProgramID,
+		MaxDays:       slaDTO.MaxDays,
+		DayType:       slaDTO.DayType,
+		EffectiveFrom: effectiveFrom,
+		Description:   slaDTO.Description,
+		CreatedBy:     &userID,
+	}
+	if slaDTO.ApplicationType != "" {
+		newSLA.ApplicationType = &slaDTO.ApplicationType
+	}
+	if newSLA.DayType == "" {
+		newSLA.DayType = constant.SLADayTypeCalendar
+	}
+
+	changeLog, err := newSLAChangeLog(constant.SLAChangeActionCreate, userID, nil, &newSLA)
+	if err != nil {
+		return dto.SLA{}, err
 	}
 
-	updatedSLA, err := s.slaRepository.UpdateSLA(ctx, existingSLA)
+	createdSLA, err := s.slaRepository.CreateSLA(ctx, newSLA, changeLog)
 	if err != nil {
 		return dto.SLA{}, err
 	}
 
-	return dto.SLA{
-		ID:          updatedSLA.ID,
-		Status:      updatedSLA.Status,
-		MaxDays:     updatedSLA.MaxDays,
-		Description: updatedSLA.Description,
-		UpdatedAt:   updatedSLA.UpdatedAt.Format("2006-01-02 15:04:05"),
-	}, nil
+	return toSLADTO(createdSLA), nil
+}
+
+// DeleteSLARule retires a program type rule or program override. Deadlines that
+// were already stamped are not recalculated.
+func (s *slaService) DeleteSLARule(ctx context.Context, userID, id int) error {
+	sla, err := s.slaRepository.GetSLAByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if sla.ApplicationType == nil && sla.ProgramID == nil {
+		return errors.New("stage default SLA cannot be deleted")
+	}
+
+	changeLog, err := newSLAChangeLog(constant.SLAChangeActionDelete, userID, &sla, nil)
+	if err != nil {
+		return err
+	}
+
+	return s.slaRepository.DeleteSLA(ctx, sla, changeLog)
+}
+
+func (s *slaService) GetSLAChangeLogs(ctx context.Context, slaID int) ([]dto.SLAChangeLog, error) {
+	changeLogs, err := s.slaRepository.GetSLAChangeLogs(ctx, slaID)
+	if err != nil {
+		return nil, err
+	}
+
+	changeLogsDTO := make([]dto.SLAChangeLog, 0, len(changeLogs))
+	for _, changeLog := range changeLogs {
+		var oldValue, newValue map[string]any
+		_ = json.Unmarshal([]byte(changeLog.OldValue), &oldValue)
+		_ = json.Unmarshal([]byte(changeLog.NewValue), &newValue)
+
+		changedByName := ""
+		if changeLog.User != nil {
+			changedByName = changeLog.User.Name
+		}
+
+		changeLogsDTO = append(changeLogsDTO, dto.SLAChangeLog{
+			ID:            changeLog.ID,
+			SLAID:         changeLog.SLAID,
+			Action:        changeLog.Action,
+			OldValue:      oldValue,
+			NewValue:      newValue,
+			ChangedBy:     changeLog.ChangedBy,
+			ChangedByName: changedByName,
+			CreatedAt:     changeLog.CreatedAt.Format("2006-01-02 15:04:05"),
+		})
+	}
+
+	return changeLogsDTO, nil
 }
 
 func (s *slaService) ExportApplications(ctx context.Context, request dto.ExportRequest) ([]byte, string, error) {
@@ -124,10 +230,14 @@ func (s *slaService) ExportApplications(ctx context.Context, request dto.ExportR
 		return nil, "", err
 	}
 
+	// Every application of a program shares its type, so the export holds each
+	// ranked program in full
+	_, ranks := rankApplications(applications)
+
 	if request.FileType == "pdf" {
-		return s.generateApplicationsPDF(applications, request.ApplicationType)
+		return s.generateApplicationsPDF(applications, ranks, request.ApplicationType)
 	}
-	return s.generateApplicationsExcel(applications, request.ApplicationType)
+	return s.generateApplicationsExcel(applications, ranks, request.ApplicationType)
 }
 
 func (s *slaService) ExportPrograms(ctx context.Context, request dto.ExportRequest) ([]byte, string, error) {
@@ -143,7 +253,7 @@ func (s *slaService) ExportPrograms(ctx context.Context, request dto.ExportReque
 }
 
 // Helper functions for PDF generation
-func (s *slaService) generateApplicationsPDF(applications []model.Application, appType string) ([]byte, string, error) {
+func (s *slaService) generateApplicationsPDF(applications []model.Application, ranks map[int]int, appType string) ([]byte, string, error) {
 	// Simple text-based PDF content
 	content := "LAPORAN PENGAJUAN UMKM\n"
 	content += fmt.Sprintf("Tanggal: %s\n\n", time.Now().Format("2006-01-02 15:04:05"))
@@ -155,6 +265,12 @@ func (s *slaService) generateApplicationsPDF(applications []model.Application, a
 		content += fmt.Sprintf("%d. UMKM: %s\n", i+1, app.UMKM.BusinessName)
 		content += fmt.Sprintf("   Program: %s\n", app.Program.Title)
 		content += fmt.Sprintf("   Status: %s\n", app.Status)
+		if app.ScoreTotal != nil {
+			content += fmt.Sprintf("   Skor: %.2f\n", *app.ScoreTotal)
+		}
+		if rank, ok := ranks[app.ID]; ok {
+			content += fmt.Sprintf("   Peringkat: %d\n", rank)
+		}
 		content += fmt.Sprintf("   Tanggal Pengajuan: %s\n\n", app.SubmittedAt.Format("2006-01-02"))
 	}
 
@@ -181,16 +297,25 @@ func (s *slaService) generateProgramsPDF(programs []model.Program, appType strin
 }
 
 // Helper functions for Excel generation (CSV format)
-func (s *slaService) generateApplicationsExcel(applications []model.Application, appType string) ([]byte, string, error) {
-	content := "No,UMKM,Program,Status,Tanggal Pengajuan\n"
+func (s *slaService) generateApplicationsExcel(applications []model.Application, ranks map[int]int, appType string) ([]byte, string, error) {
+	content := "No,UMKM,Program,Status,Tanggal Pengajuan,Skor,Peringkat\n"
 
 	for i, app := range applications {
-		content += fmt.Sprintf("%d,%s,%s,%s,%s\n",
+		var score, rank string
+		if app.ScoreTotal != nil {
+			score = fmt.Sprintf("%.2f", *app.ScoreTotal)
+		}
+		if r, ok := ranks[app.ID]; ok {
+			rank = strconv.Itoa(r)
+		}
+		content += fmt.Sprintf("%d,%s,%s,%s,%s,%s,%s\n",
 			i+1,
 			app.UMKM.BusinessName,
 			app.Program.Title,
 			app.Status,
-			app.SubmittedAt.Format("2006-01-02"))
+			app.SubmittedAt.Format("2006-01-02"),
+			score,
+			rank)
 	}
 
 	filename := fmt.Sprintf("applications_%s_%s.csv", appType, time.Now().Format("20060102_150405"))
@@ -216,3 +341,96 @@ func (s *slaService) generateProgramsExcel(programs []model.Program, appType str
 	filename := fmt.Sprintf("programs_%s_%s.csv", appType, time.Now().Format("20060102_150405"))
 	return []byte(content), filename, nil
 }
+
+func validateSLARule(slaDTO dto.SLA) error {
+	if slaDTO.MaxDays <= 0 {
+		return errors.New("max_days must be greater than 0")
+	}
+	if slaDTO.DayType != "" && slaDTO.DayType != constant.SLADayTypeCalendar && slaDTO.DayType != constant.SLADayTypeWorking {
+		return errors.New("day_type must be calendar or working")
+	}
+	return nil
+}
+
+// parseEffectiveFrom defaults to now and refuses past dates, which would change
+// the rules for applications that are already in flight.
+func parseEffectiveFrom(value string) (time.Time, error) {
+	now := time.Now()
+	if value == "" {
+		return now, nil
+	}
+
+	effectiveFrom, err := time.ParseInLocation("2006-01-02 15:04:05", value, time.Local)
+	if err != nil {
+		if effectiveFrom, err = time.ParseInLocation("2006-01-02", value, time.Local); err != nil {
+			return time.Time{}, errors.New("effective_from must be in YYYY-MM-DD or YYYY-MM-DD HH:mm:ss format")
+		}
+	}
+
+	if effectiveFrom.Before(now.Add(-time.Minute)) {
+		return time.Time{}, errors.New("effective_from cannot be in the past")
+	}
+	if effectiveFrom.Before(now) {
+		return now, nil
+	}
+
+	return effectiveFrom, nil
+}
+
+func newSLAChangeLog(action string, userID int, oldSLA, newSLA *model.SLA) (model.SLAChangeLog, error) {
+	oldValue, err := json.Marshal(slaSnapshot(oldSLA))
+	if err != nil {
+		return model.SLAChangeLog{}, err
+	}
+	newValue, err := json.Marshal(slaSnapshot(newSLA))
+	if err != nil {
+		return model.SLAChangeLog{}, err
+	}
+
+	changeLog := model.SLAChangeLog{
+		Action:    action,
+		OldValue:  string(oldValue),
+		NewValue:  string(newValue),
+		ChangedBy: &userID,
+	}
+	if oldSLA != nil {
+		changeLog.SLAID = oldSLA.ID
+	}
+
+	return changeLog, nil
+}
+
+func slaSnapshot(sla *model.SLA) map[string]any {
+	if sla == nil {
+		return map[string]any{}
+	}
+
+	return map[string]any{
+		"id":               sla.ID,
+		"status":           sla.Status,
+		"application_type": sla.ApplicationType,
+		"program_id":       sla.ProgramID,
+		"max_days":         sla.MaxDays,
+		"day_type":         sla.DayType,
+		"effective_from":   sla.EffectiveFrom.Format("2006-01-02 15:04:05"),
+		"description":      sla.Description,
+	}
+}
+
+func toSLADTO(sla model.SLA) dto.SLA {
+	slaDTO := dto.SLA{
+		ID:            sla.ID,
+		Status:        sla.Status,
+		ProgramID:     sla.ProgramID,
+		MaxDays:       sla.MaxDays,
+		DayType:       sla.DayType,
+		EffectiveFrom: sla.EffectiveFrom.Format("2006-01-02 15:04:05"),
+		Description:   sla.Description,
+		CreatedBy:     sla.CreatedBy,
+		UpdatedAt:     sla.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}
+	if sla.ApplicationType != nil {
+		slaDTO.ApplicationType = *sla.ApplicationType
+	}
+	return slaDTO
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"UMKMGo-backend/internal/repository"
+	"UMKMGo-backend/internal/types/model"
+	"UMKMGo-backend/internal/utils/constant"
+)
+
+// slaCalendar turns a stage SLA into a concrete deadline. Working-day SLAs skip
+// weekends and every date in the holiday calendar, including cuti bersama.
+type slaCalendar struct {
+	slaRepo     repository.SLARepository
+	holidayRepo repository.HolidayRepository
+}
+
+func newSLACalendar(slaRepo repository.SLARepository, holidayRepo repository.HolidayRepository) *slaCalendar {
+	return &slaCalendar{
+		slaRepo:     slaRepo,
+		holidayRepo: holidayRepo,
+	}
+}
+
+// Deadline returns when the given SLA stage ("screening" or "final") runs out for
+// a clock started at from. The rule is the one in force at from for the
+// application's program, so later SLA changes leave in-flight applications alone.
+func (c *slaCalendar) Deadline(ctx context.Context, stage, applicationType string, programID int, from time.Time) (time.Time, error) {
+	sla, err := c.slaRepo.GetEffectiveSLA(ctx, stage, applicationType, programID, from)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if sla.DayType != constant.SLADayTypeWorking {
+		return from.AddDate(0, 0, sla.MaxDays), nil
+	}
+
+	return c.AddWorkingDays(ctx, from, sla.MaxDays)
+}
+
+// AddWorkingDays moves from forward by the given number of working days, keeping
+// the time of day. Holidays are loaded for a window wide enough to cover weekends
+// and long breaks such as Lebaran; the window grows if the result runs past it.
+func (c *slaCalendar) AddWorkingDays(ctx context.Context, from time.Time, days int) (time.Time, error) {
+	window := days*2 + 30
+	for {
+		until := from.AddDate(0, 0, window)
+		holidays, err := c.holidayRepo.GetHolidaysBetween(ctx, from, until)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		deadline := addWorkingDays(from, days, holidayDates(holidays))
+		if !deadline.After(until) {
+			return deadline, nil
+		}
+		window *= 2
+	}
+}
+
+func addWorkingDays(from time.Time, days int, holidays map[string]bool) time.Time {
+	current := from
+	for days > 0 {
+		current = current.AddDate(0, 0, 1)
+		if isWorkingDay(current, holidays) {
+			days--
+		}
+	}
+	return current
+}
+
+func isWorkingDay(day time.Time, holidays map[string]bool) bool {
+	if day.Weekday() == time.Saturday || day.Weekday() == time.Sunday {
+		return false
+	}
+	return !holidays[day.Format("2006-01-02")]
+}
+
+func holidayDates(holidays []model.Holiday) map[string]bool {
+	dates := make(map[string]bool, len(holidays))
+	for _, holiday := range holidays {
+		dates[holiday.Date.Format("2006-01-02")] = true
+	}
+	return dates
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"UMKMGo-backend/internal/types/model"
+	"UMKMGo-backend/internal/utils/constant"
+)
+
+func TestSLACalendarDeadline(t *testing.T) {
+	ctx := context.Background()
+	// Monday, with Nyepi and cuti bersama later in the week
+	from := time.Date(2026, 3, 16, 10, 0, 0, 0, time.Local)
+
+	setup := func(dayType string) *slaCalendar {
+		mockSLARepo := newMockSLARepo()
+		mockSLARepo.slas["screening"] = model.SLA{ID: 1, Status: "screening", MaxDays: 7, DayType: dayType}
+
+		mockHolidayRepo := newMockHolidayRepo()
+		mockHolidayRepo.addHoliday("2026-03-19", "Hari Suci Nyepi")
+		mockHolidayRepo.addHoliday("2026-03-20", "Cuti Bersama")
+
+		return newSLACalendar(mockSLARepo, mockHolidayRepo)
+	}
+
+	t.Run("Calendar days count weekends and holidays", func(t *testing.T) {
+		deadline, err := setup(constant.SLADayTypeCalendar).Deadline(ctx, "screening", "training", 1, from)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		expected := time.Date(2026, 3, 23, 10, 0, 0, 0, time.Local)
+		if !deadline.Equal(expected) {
+			t.Errorf("Expected %v, got %v", expected, deadline)
+		}
+	})
+
+	t.Run("Working days skip weekends and holidays", func(t *testing.T) {
+		deadline, err := setup(constant.SLADayTypeWorking).Deadline(ctx, "screening", "training", 1, from)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		expected := time.Date(2026, 3, 27, 10, 0, 0, 0, time.Local)
+		if !deadline.Equal(expected) {
+			t.Errorf("Expected %v, got %v", expected, deadline)
+		}
+	})
+
+	t.Run("Unknown stage returns error", func(t *testing.T) {
+		_, err := setup(constant.SLADayTypeWorking).Deadline(ctx, "unknown", "training", 1, from)
+		if err == nil {
+			t.Error("Expected error for unknown SLA stage")
+		}
+	})
+}
+
+func TestAddWorkingDays(t *testing.T) {
+	// Friday
+	from := time.Date(2026, 8, 14, 9, 0, 0, 0, time.Local)
+	holidays := map[string]bool{"2026-08-17": true}
+
+	deadline := addWorkingDays(from, 1, holidays)
+	expected := time.Date(2026, 8, 18, 9, 0, 0, 0, time.Local)
+	if !deadline.Equal(expected) {
+		t.Errorf("Expected %v, got %v", expected, deadline)
+	}
+
+	if !addWorkingDays(from, 0, holidays).Equal(from) {
+		t.Error("Expected zero working days to keep the start time")
+	}
+}
@@ -0,0 +1,230 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"UMKMGo-backend/internal/repository"
+	"UMKMGo-backend/internal/types/dto"
+	"UMKMGo-backend/internal/types/model"
+	"UMKMGo-backend/internal/utils/constant"
+)
+
+type SLAMonitorService interface {
+	CheckBreaches(ctx context.Context) (dto.SLABreachReport, error)
+}
+
+type slaMonitorService struct {
+	applicationRepository       repository.ApplicationsRepository
+	adminNotificationRepository repository.AdminNotificationRepository
+	slaRepo                     repository.SLARepository
+	holidayRepo                 repository.HolidayRepository
+	uow                         repository.UnitOfWork
+	policy                      string
+}
+
+func NewSLAMonitorService(applicationRepo repository.ApplicationsRepository, adminNotificationRepo repository.AdminNotificationRepository, slaRepo repository.SLARepository, holidayRepo repository.HolidayRepository, uow repository.UnitOfWork, policy string) SLAMonitorService {
+	if policy != constant.SLABreachPolicyEscalate && policy != constant.SLABreachPolicyReject {
+		policy = constant.SLABreachPolicyNotify
+	}
+
+	return &slaMonitorService{
+		applicationRepository:       applicationRepo,
+		adminNotificationRepository: adminNotificationRepo,
+		slaRepo:                     slaRepo,
+		holidayRepo:                 holidayRepo,
+		uow:                         uow,
+		policy:                      policy,
+	}
+}
+
+// monitoredStatuses are the statuses whose expired_at is still running.
+var monitoredStatuses = []string{
+	constant.ApplicationStatusScreening,
+	constant.ApplicationStatusRevised,
+	constant.ApplicationStatusFinal,
+}
+
+// CheckBreaches flags every active application past its deadline, records the
+// breach in its history, notifies the responsible reviewers and applies the
+// configured breach policy. Failures on one application do not stop the run.
+func (s *slaMonitorService) CheckBreaches(ctx context.Context) (dto.SLABreachReport, error) {
+	now := time.Now()
+	report := dto.SLABreachReport{
+		Policy:    s.policy,
+		CheckedAt: now.Format("2006-01-02 15:04:05"),
+	}
+
+	applications, err := s.applicationRepository.GetOverdueApplications(ctx, monitoredStatuses, now)
+	if err != nil {
+		return report, err
+	}
+
+	recipients := make(map[string][]int)
+	for _, application := range applications {
+		if err := s.handleBreach(ctx, application, now, recipients, &report); err != nil {
+			report.Failed++
+			report.Errors = append(report.Errors, fmt.Sprintf("application %d: %v", application.ID, err))
+			continue
+		}
+		report.Breached++
+	}
+
+	return report, nil
+}
+
+func (s *slaMonitorService) handleBreach(ctx context.Context, application model.Application, now time.Time, recipients map[string][]int, report *dto.SLABreachReport) error {
+	stage := slaStage(application.Status)
+	deadline := application.ExpiredAt.Format("2006-01-02 15:04:05")
+
+	reviewerIDs, err := s.recipientsFor(ctx, recipients, reviewPermissionCode(stage, application.Type))
+	if err != nil {
+		return err
+	}
+
+	escalate := s.policy == constant.SLABreachPolicyEscalate
+	var escalatedAt *time.Time
+	var superadminIDs []int
+	if escalate {
+		escalatedAt = &now
+		if superadminIDs, err = s.recipientsFor(ctx, recipients, "role:"+constant.RoleSuperAdmin); err != nil {
+			return err
+		}
+	}
+
+	metadata, err := json.Marshal(map[string]any{
+		"stage":      stage,
+		"expired_at": deadline,
+		"policy":     s.policy,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = s.uow.Do(ctx, func(repos repository.TxRepositories) error {
+		if err := repos.Applications.MarkApplicationOverdue(ctx, application.ID, now, escalatedAt); err != nil {
+			return err
+		}
+
+		// Create history
+		history := model.ApplicationHistory{
+			ApplicationID: application.ID,
+			Status:        constant.ApplicationActionSLABreached,
+			Notes:         fmt.Sprintf("SLA for %s stage exceeded, due at %s", stage, deadline),
+		}
+		if err := repos.Applications.CreateApplicationHistory(ctx, history); err != nil {
+			return err
+		}
+
+		notifications := buildAdminNotifications(reviewerIDs, application, constant.AdminNotificationSLABreached,
+			constant.AdminNotificationTitleSLABreached,
+			fmt.Sprintf(constant.AdminNotificationMessageSLABreached, application.ID, application.Program.Title, stage, deadline),
+			string(metadata))
+
+		if escalate {
+			history := model.ApplicationHistory{
+				ApplicationID: application.ID,
+				Status:        constant.ApplicationActionEscalate,
+				Notes:         "Escalated to superadmin after SLA breach",
+			}
+			if err := repos.Applications.CreateApplicationHistory(ctx, history); err != nil {
+				return err
+			}
+
+			notifications = append(notifications, buildAdminNotifications(superadminIDs, application, constant.AdminNotificationSLAEscalated,
+				constant.AdminNotificationTitleSLAEscalated,
+				fmt.Sprintf(constant.AdminNotificationMessageSLAEscalated, application.ID, application.Program.Title, stage, deadline),
+				string(metadata))...)
+		}
+
+		if err := repos.AdminNotifications.CreateAdminNotifications(ctx, notifications); err != nil {
+			return err
+		}
+
+		if s.policy != constant.SLABreachPolicyReject {
+			return nil
+		}
+
+		// Rejected in the same transaction as the breach mark, so a failed
+		// reject leaves the application to be picked up again on the next run.
+		// The breach flags stay on the row that the workflow saves, at the
+		// version the breach mark left it
+		application.IsOverdue = true
+		application.OverdueAt = &now
+		application.Version++
+
+		workflow := newApplicationWorkflow(s.uow, newSLACalendar(s.slaRepo, s.holidayRepo))
+		_, err := workflow.FireIn(ctx, repos, eventSLAAutoReject, application, 0, "")
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if escalate {
+		report.Escalated++
+	}
+	if s.policy == constant.SLABreachPolicyReject {
+		report.Rejected++
+	}
+
+	return nil
+}
+
+// recipientsFor resolves admin user IDs for a permission code (or "role:<name>")
+// once per run.
+func (s *slaMonitorService) recipientsFor(ctx context.Context, cache map[string][]int, key string) ([]int, error) {
+	if ids, ok := cache[key]; ok {
+		return ids, nil
+	}
+
+	var ids []int
+	var err error
+	if roleName, ok := strings.CutPrefix(key, "role:"); ok {
+		ids, err = s.adminNotificationRepository.GetUserIDsByRole(ctx, roleName)
+	} else {
+		ids, err = s.adminNotificationRepository.GetUserIDsByPermissions(ctx, []string{key})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	cache[key] = ids
+	return ids, nil
+}
+
+// slaStage maps an application status to the SLA stage whose deadline applies.
+// Revised applications still run on the screening clock; approved and waitlisted
+// ones stay with the final-stage reviewers.
+func slaStage(status string) string {
+	switch status {
+	case constant.ApplicationStatusFinal, constant.ApplicationStatusApproved, constant.ApplicationStatusWaitlisted:
+		return constant.ApplicationStatusFinal
+	}
+	return constant.ApplicationStatusScreening
+}
+
+// reviewPermissionCode returns the permission that reviews an application type at
+// the given stage, e.g. SCREENING_TRAINING or FINAL_FUNDING.
+func reviewPermissionCode(stage, applicationType string) string {
+	return strings.ToUpper(stage) + "_" + strings.ToUpper(applicationType)
+}
+
+func buildAdminNotifications(userIDs []int, application model.Application, notifType, title, message, metadata string) []model.AdminNotification {
+	notifications := make([]model.AdminNotification, 0, len(userIDs))
+	for _, userID := range userIDs {
+		notifications = append(notifications, model.AdminNotification{
+			UserID:        userID,
+			ApplicationID: &application.ID,
+			Type:          notifType,
+			Title:         title,
+			Message:       message,
+			IsRead:        false,
+			Metadata:      metadata,
+		})
+	}
+	return notifications
+}
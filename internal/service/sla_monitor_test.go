@@ -0,0 +1,250 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"UMKMGo-backend/internal/repository"
+	"UMKMGo-backend/internal/types/model"
+	"UMKMGo-backend/internal/utils/constant"
+)
+
+// ==================== MOCK ADMIN NOTIFICATION REPOSITORY ====================
+
+type mockAdminNotificationRepo struct {
+	notifications []model.AdminNotification
+	permissions   map[string][]int
+	roles         map[string][]int
+}
+
+func newMockAdminNotificationRepo() *mockAdminNotificationRepo {
+	return &mockAdminNotificationRepo{
+		notifications: []model.AdminNotification{},
+		permissions: map[string][]int{
+			"SCREENING_TRAINING": {10},
+			"FINAL_FUNDING":      {11, 12},
+		},
+		roles: map[string][]int{
+			constant.RoleSuperAdmin: {1},
+		},
+	}
+}
+
+func (m *mockAdminNotificationRepo) CreateAdminNotifications(ctx context.Context, notifications []model.AdminNotification) error {
+	m.notifications = append(m.notifications, notifications...)
+	return nil
+}
+
+func (m *mockAdminNotificationRepo) GetAdminNotificationsByUserID(ctx context.Context, userID int, limit, offset int) ([]model.AdminNotification, error) {
+	var result []model.AdminNotification
+	for _, n := range m.notifications {
+		if n.UserID == userID {
+			result = append(result, n)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockAdminNotificationRepo) GetUnreadCount(ctx context.Context, userID int) (int64, error) {
+	var count int64
+	for _, n := range m.notifications {
+		if n.UserID == userID && !n.IsRead {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (m *mockAdminNotificationRepo) MarkAsRead(ctx context.Context, notificationID int, userID int) error {
+	return nil
+}
+
+func (m *mockAdminNotificationRepo) MarkAllAsRead(ctx context.Context, userID int) error {
+	return nil
+}
+
+func (m *mockAdminNotificationRepo) GetUserIDsByPermissions(ctx context.Context, permissionCodes []string) ([]int, error) {
+	var ids []int
+	for _, code := range permissionCodes {
+		ids = append(ids, m.permissions[code]...)
+	}
+	return ids, nil
+}
+
+func (m *mockAdminNotificationRepo) GetUserIDsByRole(ctx context.Context, roleName string) ([]int, error) {
+	return m.roles[roleName], nil
+}
+
+// countingUnitOfWork counts the transactions started through it.
+type countingUnitOfWork struct {
+	repository.UnitOfWork
+	calls int
+}
+
+func (u *countingUnitOfWork) Do(ctx context.Context, fn func(repos repository.TxRepositories) error) error {
+	u.calls++
+	return u.UnitOfWork.Do(ctx, fn)
+}
+
+func setupSLAMonitorService(policy string) (*slaMonitorService, *mockApplicationsRepo, *mockAdminNotificationRepo, *mockNotificationRepo) {
+	mockAppRepo := newMockApplicationsRepo()
+	mockAdminNotifRepo := newMockAdminNotificationRepo()
+	mockNotifRepo := newMockNotificationRepo()
+	mockSLARepo := newMockSLARepo()
+
+	past := time.Now().Add(-24 * time.Hour)
+	mockAppRepo.applications[1] = model.Application{
+		ID: 1, UMKMID: 1, Type: "training", Status: constant.ApplicationStatusScreening,
+		SubmittedAt: past.AddDate(0, 0, -7), ExpiredAt: past,
+	}
+	mockAppRepo.applications[2] = model.Application{
+		ID: 2, UMKMID: 2, Type: "funding", Status: constant.ApplicationStatusFinal,
+		SubmittedAt: past.AddDate(0, 0, -14), ExpiredAt: past,
+	}
+	mockAppRepo.applications[3] = model.Application{
+		ID: 3, UMKMID: 3, Type: "training", Status: constant.ApplicationStatusScreening,
+		SubmittedAt: time.Now(), ExpiredAt: time.Now().AddDate(0, 0, 7),
+	}
+	mockAppRepo.applications[4] = model.Application{
+		ID: 4, UMKMID: 4, Type: "training", Status: constant.ApplicationStatusApproved,
+		SubmittedAt: past.AddDate(0, 0, -7), ExpiredAt: past,
+	}
+
+	service := NewSLAMonitorService(mockAppRepo, mockAdminNotifRepo, mockSLARepo, newMockHolidayRepo(), newMockUnitOfWork(repository.TxRepositories{
+		Applications:       mockAppRepo,
+		Notifications:      mockNotifRepo,
+		AdminNotifications: mockAdminNotifRepo,
+	}), policy).(*slaMonitorService)
+
+	return service, mockAppRepo, mockAdminNotifRepo, mockNotifRepo
+}
+
+func TestCheckBreaches(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Notify policy flags overdue applications and notifies reviewers", func(t *testing.T) {
+		service, mockAppRepo, mockAdminNotifRepo, _ := setupSLAMonitorService(constant.SLABreachPolicyNotify)
+
+		report, err := service.CheckBreaches(ctx)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if report.Breached != 2 {
+			t.Errorf("Expected 2 breached applications, got %d", report.Breached)
+		}
+		if !mockAppRepo.applications[1].IsOverdue || !mockAppRepo.applications[2].IsOverdue {
+			t.Error("Expected overdue applications to be flagged")
+		}
+		if mockAppRepo.applications[3].IsOverdue || mockAppRepo.applications[4].IsOverdue {
+			t.Error("Expected on-time and decided applications to be left alone")
+		}
+		if len(mockAppRepo.histories[1]) != 1 || mockAppRepo.histories[1][0].Status != constant.ApplicationActionSLABreached {
+			t.Error("Expected sla_breached history entry")
+		}
+		if mockAppRepo.histories[1][0].ActionedBy != nil {
+			t.Error("Expected system history entry without actor")
+		}
+
+		// SCREENING_TRAINING -> user 10, FINAL_FUNDING -> users 11 and 12
+		if len(mockAdminNotifRepo.notifications) != 3 {
+			t.Errorf("Expected 3 admin notifications, got %d", len(mockAdminNotifRepo.notifications))
+		}
+
+		// A second run must not notify again
+		report, _ = service.CheckBreaches(ctx)
+		if report.Breached != 0 {
+			t.Errorf("Expected already flagged applications to be skipped, got %d", report.Breached)
+		}
+	})
+
+	t.Run("Escalate policy notifies superadmins", func(t *testing.T) {
+		service, mockAppRepo, mockAdminNotifRepo, _ := setupSLAMonitorService(constant.SLABreachPolicyEscalate)
+
+		report, err := service.CheckBreaches(ctx)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if report.Escalated != 2 {
+			t.Errorf("Expected 2 escalations, got %d", report.Escalated)
+		}
+		if mockAppRepo.applications[1].EscalatedAt == nil {
+			t.Error("Expected escalated_at to be set")
+		}
+
+		escalations := 0
+		for _, n := range mockAdminNotifRepo.notifications {
+			if n.Type == constant.AdminNotificationSLAEscalated && n.UserID == 1 {
+				escalations++
+			}
+		}
+		if escalations != 2 {
+			t.Errorf("Expected 2 escalation notifications for superadmin, got %d", escalations)
+		}
+	})
+
+	t.Run("Reject policy auto-rejects through the workflow", func(t *testing.T) {
+		service, mockAppRepo, _, mockNotifRepo := setupSLAMonitorService(constant.SLABreachPolicyReject)
+
+		report, err := service.CheckBreaches(ctx)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if report.Rejected != 2 {
+			t.Errorf("Expected 2 rejections, got %d", report.Rejected)
+		}
+
+		app := mockAppRepo.applications[2]
+		if app.Status != constant.ApplicationStatusRejected {
+			t.Errorf("Expected status 'rejected', got '%s'", app.Status)
+		}
+		if !app.IsOverdue {
+			t.Error("Expected rejected application to keep its overdue flag")
+		}
+
+		histories := mockAppRepo.histories[2]
+		if histories[len(histories)-1].Status != constant.ApplicationActionAutoReject {
+			t.Errorf("Expected last history 'auto_reject', got '%s'", histories[len(histories)-1].Status)
+		}
+		if len(mockNotifRepo.notifications) != 2 {
+			t.Errorf("Expected 2 UMKM notifications, got %d", len(mockNotifRepo.notifications))
+		}
+	})
+
+	t.Run("Reject policy marks and rejects in one transaction", func(t *testing.T) {
+		service, mockAppRepo, _, _ := setupSLAMonitorService(constant.SLABreachPolicyReject)
+		uow := &countingUnitOfWork{UnitOfWork: service.uow}
+		service.uow = uow
+
+		if _, err := service.CheckBreaches(ctx); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if uow.calls != 2 {
+			t.Errorf("Expected one transaction per breached application, got %d", uow.calls)
+		}
+		if mockAppRepo.applications[1].Status != constant.ApplicationStatusRejected {
+			t.Errorf("Expected status 'rejected', got '%s'", mockAppRepo.applications[1].Status)
+		}
+	})
+
+	t.Run("Unknown policy falls back to notify", func(t *testing.T) {
+		service, _, _, _ := setupSLAMonitorService("something")
+		if service.policy != constant.SLABreachPolicyNotify {
+			t.Errorf("Expected policy 'notify', got '%s'", service.policy)
+		}
+	})
+}
+
+func TestIsApplicationOverdue(t *testing.T) {
+	now := time.Now()
+
+	if !isApplicationOverdue(model.Application{Status: constant.ApplicationStatusFinal, ExpiredAt: now.Add(-time.Hour)}, now) {
+		t.Error("Expected final application past deadline to be overdue")
+	}
+	if isApplicationOverdue(model.Application{Status: constant.ApplicationStatusScreening, ExpiredAt: now.Add(time.Hour)}, now) {
+		t.Error("Expected application before deadline not to be overdue")
+	}
+	if isApplicationOverdue(model.Application{Status: constant.ApplicationStatusApproved, IsOverdue: true}, now) {
+		t.Error("Expected decided application not to be reported overdue")
+	}
+}
@@ -15,6 +15,9 @@ import (
 
 type mockSLARepository struct {
 	slas         map[string]model.SLA
+	rules        []model.SLA
+	changeLogs   []model.SLAChangeLog
+	nextID       int
 	applications []model.Application
 	programs     []model.Program
 	shouldError  bool
@@ -102,6 +105,7 @@ func newMockSLARepository() *mockSLARepository {
 				IsActive: false,
 			},
 		},
+		nextID:      3,
 		shouldError: false,
 	}
 }
@@ -116,15 +120,90 @@ func (m *mockSLARepository) GetSLAByStatus(ctx context.Context, status string) (
 	return model.SLA{}, errors.New("SLA not found")
 }
 
-func (m *mockSLARepository) UpdateSLA(ctx context.Context, sla model.SLA) (model.SLA, error) {
+func (m *mockSLARepository) GetEffectiveSLA(ctx context.Context, status, applicationType string, programID int, at time.Time) (model.SLA, error) {
+	return m.GetSLAByStatus(ctx, status)
+}
+
+func (m *mockSLARepository) GetSLAByID(ctx context.Context, id int) (model.SLA, error) {
+	for _, sla := range m.rules {
+		if sla.ID == id {
+			return sla, nil
+		}
+	}
+	for _, sla := range m.slas {
+		if sla.ID == id {
+			return sla, nil
+		}
+	}
+	return model.SLA{}, errors.New("SLA not found")
+}
+
+func (m *mockSLARepository) GetSLARules(ctx context.Context, status, applicationType string, programID int) ([]model.SLA, error) {
+	if m.shouldError {
+		return nil, errors.New("database error")
+	}
+
+	var filtered []model.SLA
+	for _, sla := range m.rules {
+		if status != "" && sla.Status != status {
+			continue
+		}
+		if applicationType != "" && (sla.ApplicationType == nil || *sla.ApplicationType != applicationType) {
+			continue
+		}
+		filtered = append(filtered, sla)
+	}
+	return filtered, nil
+}
+
+func (m *mockSLARepository) CreateSLA(ctx context.Context, sla model.SLA, changeLog model.SLAChangeLog) (model.SLA, error) {
 	if m.shouldError {
 		return model.SLA{}, errors.New("database error")
 	}
+	sla.ID = m.nextID
+	m.nextID++
+	sla.CreatedAt = time.Now()
 	sla.UpdatedAt = time.Now()
-	m.slas[sla.Status] = sla
+	m.rules = append(m.rules, sla)
+	if sla.ApplicationType == nil && sla.ProgramID == nil {
+		m.slas[sla.Status] = sla
+	}
+
+	changeLog.SLAID = sla.ID
+	m.changeLogs = append(m.changeLogs, changeLog)
 	return sla, nil
 }
 
+func (m *mockSLARepository) DeleteSLA(ctx context.Context, sla model.SLA, changeLog model.SLAChangeLog) error {
+	if m.shouldError {
+		return errors.New("database error")
+	}
+	for i, rule := range m.rules {
+		if rule.ID == sla.ID {
+			m.rules = append(m.rules[:i], m.rules[i+1:]...)
+			break
+		}
+	}
+
+	changeLog.SLAID = sla.ID
+	m.changeLogs = append(m.changeLogs, changeLog)
+	return nil
+}
+
+func (m *mockSLARepository) GetSLAChangeLogs(ctx context.Context, slaID int) ([]model.SLAChangeLog, error) {
+	if m.shouldError {
+		return nil, errors.New("database error")
+	}
+
+	var filtered []model.SLAChangeLog
+	for _, changeLog := range m.changeLogs {
+		if slaID == 0 || changeLog.SLAID == slaID {
+			filtered = append(filtered, changeLog)
+		}
+	}
+	return filtered, nil
+}
+
 func (m *mockSLARepository) GetApplicationsForExport(ctx context.Context, applicationType string) ([]model.Application, error) {
 	if m.shouldError {
 		return nil, errors.New("database error")
@@ -260,7 +339,7 @@ func TestUpdateSLAScreening(t *testing.T) {
 			Description: "Updated screening phase - 10 days",
 		}
 
-		result, err := service.UpdateSLAScreening(ctx, slaDTO)
+		result, err := service.UpdateSLAScreening(ctx, 1, slaDTO)
 		if err != nil {
 			t.Errorf("Expected no error, got %v", err)
 		}
@@ -279,7 +358,7 @@ func TestUpdateSLAScreening(t *testing.T) {
 			MaxDays: 0,
 		}
 
-		_, err := service.UpdateSLAScreening(ctx, slaDTO)
+		_, err := service.UpdateSLAScreening(ctx, 1, slaDTO)
 
 		if err == nil {
 			t.Error("Expected error for zero max days, got none")
@@ -295,7 +374,7 @@ func TestUpdateSLAScreening(t *testing.T) {
 			MaxDays: -5,
 		}
 
-		_, err := service.UpdateSLAScreening(ctx, slaDTO)
+		_, err := service.UpdateSLAScreening(ctx, 1, slaDTO)
 
 		if err == nil {
 			t.Error("Expected error for negative max days, got none")
@@ -307,7 +386,7 @@ func TestUpdateSLAScreening(t *testing.T) {
 			MaxDays: 8,
 		}
 
-		result, err := service.UpdateSLAScreening(ctx, slaDTO)
+		result, err := service.UpdateSLAScreening(ctx, 1, slaDTO)
 		if err != nil {
 			t.Errorf("Expected no error, got %v", err)
 		}
@@ -321,7 +400,7 @@ func TestUpdateSLAScreening(t *testing.T) {
 		mockRepo.shouldError = true
 		slaDTO := dto.SLA{MaxDays: 10}
 
-		_, err := service.UpdateSLAScreening(ctx, slaDTO)
+		_, err := service.UpdateSLAScreening(ctx, 1, slaDTO)
 
 		if err == nil {
 			t.Error("Expected error, got none")
@@ -341,7 +420,7 @@ func TestUpdateSLAFinal(t *testing.T) {
 			Description: "Updated final phase - 20 days",
 		}
 
-		result, err := service.UpdateSLAFinal(ctx, slaDTO)
+		result, err := service.UpdateSLAFinal(ctx, 1, slaDTO)
 		if err != nil {
 			t.Errorf("Expected no error, got %v", err)
 		}
@@ -356,7 +435,7 @@ func TestUpdateSLAFinal(t *testing.T) {
 			MaxDays: 0,
 		}
 
-		_, err := service.UpdateSLAFinal(ctx, slaDTO)
+		_, err := service.UpdateSLAFinal(ctx, 1, slaDTO)
 
 		if err == nil {
 			t.Error("Expected error for zero max days, got none")
@@ -367,7 +446,7 @@ func TestUpdateSLAFinal(t *testing.T) {
 		mockRepo.shouldError = true
 		slaDTO := dto.SLA{MaxDays: 20}
 
-		_, err := service.UpdateSLAFinal(ctx, slaDTO)
+		_, err := service.UpdateSLAFinal(ctx, 1, slaDTO)
 
 		if err == nil {
 			t.Error("Expected error, got none")
@@ -376,6 +455,109 @@ func TestUpdateSLAFinal(t *testing.T) {
 	})
 }
 
+// Test SLA versioning and rules
+func TestSLARuleVersioning(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Update creates a new version with change log", func(t *testing.T) {
+		service, mockRepo := setupSLAService()
+
+		result, err := service.UpdateSLAScreening(ctx, 7, dto.SLA{MaxDays: 5})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if result.ID == 1 {
+			t.Error("Expected a new SLA version instead of an in-place update")
+		}
+		if len(mockRepo.changeLogs) != 1 {
+			t.Fatalf("Expected 1 change log, got %d", len(mockRepo.changeLogs))
+		}
+
+		changeLog := mockRepo.changeLogs[0]
+		if changeLog.Action != "update" || changeLog.ChangedBy == nil || *changeLog.ChangedBy != 7 {
+			t.Errorf("Unexpected change log %+v", changeLog)
+		}
+		if !strings.Contains(changeLog.OldValue, `"max_days":7`) || !strings.Contains(changeLog.NewValue, `"max_days":5`) {
+			t.Errorf("Expected old and new values to be recorded, got %s -> %s", changeLog.OldValue, changeLog.NewValue)
+		}
+	})
+
+	t.Run("Reject effective date in the past", func(t *testing.T) {
+		service, _ := setupSLAService()
+
+		_, err := service.UpdateSLAFinal(ctx, 1, dto.SLA{MaxDays: 10, EffectiveFrom: "2020-01-01"})
+		if err == nil || err.Error() != "effective_from cannot be in the past" {
+			t.Errorf("Expected past effective date error, got %v", err)
+		}
+	})
+
+	t.Run("Future effective date is kept", func(t *testing.T) {
+		service, _ := setupSLAService()
+		effectiveFrom := time.Now().AddDate(0, 1, 0).Format("2006-01-02")
+
+		result, err := service.UpdateSLAFinal(ctx, 1, dto.SLA{MaxDays: 10, EffectiveFrom: effectiveFrom})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !strings.HasPrefix(result.EffectiveFrom, effectiveFrom) {
+			t.Errorf("Expected effective_from %s, got %s", effectiveFrom, result.EffectiveFrom)
+		}
+	})
+
+	t.Run("Create program type rule", func(t *testing.T) {
+		service, mockRepo := setupSLAService()
+
+		result, err := service.CreateSLARule(ctx, 1, dto.SLA{Status: "final", ApplicationType: "funding", MaxDays: 30, DayType: "working"})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if result.ApplicationType != "funding" || result.DayType != "working" {
+			t.Errorf("Unexpected rule %+v", result)
+		}
+
+		rules, _ := service.GetSLARules(ctx, "final", "funding", 0)
+		if len(rules) != 1 {
+			t.Errorf("Expected 1 funding rule, got %d", len(rules))
+		}
+		if mockRepo.changeLogs[0].Action != "create" {
+			t.Errorf("Expected create change log, got %s", mockRepo.changeLogs[0].Action)
+		}
+	})
+
+	t.Run("Rule requires a scope", func(t *testing.T) {
+		service, _ := setupSLAService()
+
+		_, err := service.CreateSLARule(ctx, 1, dto.SLA{Status: "screening", MaxDays: 3})
+		if err == nil || err.Error() != "application_type or program_id is required" {
+			t.Errorf("Expected scope error, got %v", err)
+		}
+	})
+
+	t.Run("Delete override but not stage default", func(t *testing.T) {
+		service, mockRepo := setupSLAService()
+		programID := 10
+		rule, _ := service.CreateSLARule(ctx, 1, dto.SLA{Status: "screening", ProgramID: &programID, MaxDays: 2})
+
+		if err := service.DeleteSLARule(ctx, 1, rule.ID); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(mockRepo.rules) != 0 {
+			t.Error("Expected override to be deleted")
+		}
+
+		err := service.DeleteSLARule(ctx, 1, 1)
+		if err == nil || err.Error() != "stage default SLA cannot be deleted" {
+			t.Errorf("Expected stage default error, got %v", err)
+		}
+
+		changeLogs, _ := service.GetSLAChangeLogs(ctx, rule.ID)
+		if len(changeLogs) != 2 || changeLogs[1].Action != "delete" {
+			t.Errorf("Expected create and delete change logs, got %+v", changeLogs)
+		}
+	})
+}
+
 // Test ExportApplications
 func TestExportApplications(t *testing.T) {
 	service, mockRepo := setupSLAService()
@@ -618,7 +800,7 @@ func TestSLAServiceEdgeCases(t *testing.T) {
 			Description: "One year SLA",
 		}
 
-		result, err := service.UpdateSLAScreening(ctx, slaDTO)
+		result, err := service.UpdateSLAScreening(ctx, 1, slaDTO)
 		if err != nil {
 			t.Errorf("Expected no error, got %v", err)
 		}
@@ -628,6 +810,26 @@ func TestSLAServiceEdgeCases(t *testing.T) {
 		}
 	})
 
+	t.Run("Export includes rubric score and rank", func(t *testing.T) {
+		total := 82.5
+		mockRepo.applications[0].ScoreTotal = &total
+		defer func() { mockRepo.applications[0].ScoreTotal = nil }()
+
+		data, _, err := service.ExportApplications(ctx, dto.ExportRequest{FileType: "excel", ApplicationType: "all"})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		content := string(data)
+		if !strings.Contains(content, "Tanggal Pengajuan,Skor,Peringkat") || !strings.Contains(content, ",82.50,1\n") {
+			t.Errorf("Expected score and rank columns, got %s", content)
+		}
+
+		data, _, _ = service.ExportApplications(ctx, dto.ExportRequest{FileType: "pdf", ApplicationType: "all"})
+		if !strings.Contains(string(data), "Skor: 82.50") || !strings.Contains(string(data), "Peringkat: 1") {
+			t.Error("Expected score and rank in the text report")
+		}
+	})
+
 	t.Run("Export with special characters in business names", func(t *testing.T) {
 		mockRepo.applications[0].UMKM.BusinessName = "Test & Company, Ltd."
 
@@ -651,8 +853,8 @@ func TestSLAServiceEdgeCases(t *testing.T) {
 		screeningSLA := dto.SLA{MaxDays: 5}
 		finalSLA := dto.SLA{MaxDays: 15}
 
-		result1, err1 := service.UpdateSLAScreening(ctx, screeningSLA)
-		result2, err2 := service.UpdateSLAFinal(ctx, finalSLA)
+		result1, err1 := service.UpdateSLAScreening(ctx, 1, screeningSLA)
+		result2, err2 := service.UpdateSLAFinal(ctx, 1, finalSLA)
 
 		if err1 != nil || err2 != nil {
 			t.Error("Expected no errors")
@@ -793,7 +995,7 @@ func TestSLAConcurrentOperations(t *testing.T) {
 
 		for _, days := range updates {
 			slaDTO := dto.SLA{MaxDays: days}
-			result, err := service.UpdateSLAScreening(ctx, slaDTO)
+			result, err := service.UpdateSLAScreening(ctx, 1, slaDTO)
 			if err != nil {
 				t.Errorf("Expected no error for update to %d days, got %v", days, err)
 			}
@@ -823,7 +1025,7 @@ func BenchmarkUpdateSLA(b *testing.B) {
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		_, _ = service.UpdateSLAScreening(ctx, slaDTO)
+		_, _ = service.UpdateSLAScreening(ctx, 1, slaDTO)
 	}
 }
 
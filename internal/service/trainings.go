@@ -0,0 +1,750 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"UMKMGo-backend/config/env"
+	"UMKMGo-backend/config/log"
+	"UMKMGo-backend/config/storage"
+	"UMKMGo-backend/internal/repository"
+	"UMKMGo-backend/internal/types/dto"
+	"UMKMGo-backend/internal/types/model"
+	"UMKMGo-backend/internal/utils"
+	"UMKMGo-backend/internal/utils/constant"
+)
+
+const (
+	sessionTimeLayout = "2006-01-02 15:04"
+	// checkInOpensBefore is how early participants can check in to a session
+	checkInOpensBefore = time.Hour
+)
+
+type TrainingService interface {
+	// Sessions
+	GetSessions(ctx context.Context, programID int) ([]dto.TrainingSession, error)
+	CreateSession(ctx context.Context, programID int, request dto.TrainingSessionRequest) (dto.TrainingSession, error)
+	UpdateSession(ctx context.Context, sessionID int, request dto.TrainingSessionRequest) (dto.TrainingSession, error)
+	DeleteSession(ctx context.Context, sessionID int) error
+
+	// Attendance
+	GetSessionAttendance(ctx context.Context, sessionID int) (dto.SessionAttendance, error)
+	RecordAttendance(ctx context.Context, userID, sessionID int, request dto.AttendanceRequest) (dto.SessionAttendance, error)
+	CheckIn(ctx context.Context, userID, sessionID int, request dto.CheckInRequest) (dto.CheckInResult, error)
+	GetParticipants(ctx context.Context, programID int) ([]dto.TrainingParticipant, error)
+
+	// Certificates
+	IssueCertificates(ctx context.Context, programID int) ([]dto.TrainingCertificate, error)
+	VerifyCertificate(ctx context.Context, code string) (dto.CertificateVerification, error)
+}
+
+type trainingService struct {
+	trainingRepo     repository.TrainingRepository
+	programRepo      repository.ProgramsRepository
+	notificationRepo repository.NotificationRepository
+	kartuRepo        repository.KartuRepository
+	// storeCertificate uploads a certificate PDF and returns its URL
+	storeCertificate func(ctx context.Context, prefix string, pdf []byte) (string, error)
+}
+
+func NewTrainingService(trainingRepo repository.TrainingRepository, programRepo repository.ProgramsRepository, notificationRepo repository.NotificationRepository, kartuRepo repository.KartuRepository, minio *storage.MinIOManager) TrainingService {
+	return &trainingService{
+		trainingRepo:     trainingRepo,
+		programRepo:      programRepo,
+		notificationRepo: notificationRepo,
+		kartuRepo:        kartuRepo,
+		storeCertificate: func(ctx context.Context, prefix string, pdf []byte) (string, error) {
+			res, err := minio.UploadFile(ctx, storage.UploadRequest{
+				Base64Data: base64.StdEncoding.EncodeToString(pdf),
+				BucketName: storage.ApplicationBucket,
+				Prefix:     prefix,
+			})
+			if err != nil {
+				return "", err
+			}
+			return res.URL, nil
+		},
+	}
+}
+
+// Sessions
+func (s *trainingService) GetSessions(ctx context.Context, programID int) ([]dto.TrainingSession, error) {
+	sessions, err := s.trainingRepo.GetSessions(ctx, programID)
+	if err != nil {
+		return nil, err
+	}
+	attendances, err := s.trainingRepo.GetProgramAttendances(ctx, programID)
+	if err != nil {
+		return nil, err
+	}
+
+	present := make(map[int]int)
+	for _, attendance := range attendances {
+		if attendance.Status == constant.AttendanceStatusPresent {
+			present[attendance.SessionID]++
+		}
+	}
+
+	result := make([]dto.TrainingSession, 0, len(sessions))
+	for _, session := range sessions {
+		item := mapTrainingSession(session)
+		item.PresentCount = present[session.ID]
+		result = append(result, item)
+	}
+	return result, nil
+}
+
+func (s *trainingService) CreateSession(ctx context.Context, programID int, request dto.TrainingSessionRequest) (dto.TrainingSession, error) {
+	program, err := s.programRepo.GetProgramByID(ctx, programID)
+	if err != nil {
+		return dto.TrainingSession{}, err
+	}
+	if err := s.ensureScheduleOpen(ctx, programID); err != nil {
+		return dto.TrainingSession{}, err
+	}
+
+	session := model.TrainingSession{ProgramID: program.ID}
+	if err := applySessionRequest(&session, program, request); err != nil {
+		return dto.TrainingSession{}, err
+	}
+
+	created, err := s.trainingRepo.CreateSession(ctx, session)
+	if err != nil {
+		return dto.TrainingSession{}, err
+	}
+	return mapTrainingSession(created), nil
+}
+
+func (s *trainingService) UpdateSession(ctx context.Context, sessionID int, request dto.TrainingSessionRequest) (dto.TrainingSession, error) {
+	session, err := s.trainingRepo.GetSessionByID(ctx, sessionID)
+	if err != nil {
+		return dto.TrainingSession{}, err
+	}
+	if err := s.ensureScheduleOpen(ctx, session.ProgramID); err != nil {
+		return dto.TrainingSession{}, err
+	}
+
+	if err := applySessionRequest(&session, session.Program, request); err != nil {
+		return dto.TrainingSession{}, err
+	}
+
+	updated, err := s.trainingRepo.UpdateSession(ctx, session)
+	if err != nil {
+		return dto.TrainingSession{}, err
+	}
+	return mapTrainingSession(updated), nil
+}
+
+func (s *trainingService) DeleteSession(ctx context.Context, sessionID int) error {
+	session, err := s.trainingRepo.GetSessionByID(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	if err := s.ensureScheduleOpen(ctx, session.ProgramID); err != nil {
+		return err
+	}
+	return s.trainingRepo.DeleteSession(ctx, session.ID)
+}
+
+// ensureScheduleOpen keeps the sessions of a program fixed once certificates
+// have been issued, since completion was judged against them.
+func (s *trainingService) ensureScheduleOpen(ctx context.Context, programID int) error {
+	certificates, err := s.trainingRepo.GetProgramCertificates(ctx, programID)
+	if err != nil {
+		return err
+	}
+	if len(certificates) > 0 {
+		return errors.New("sessions cannot be changed after certificates have been issued")
+	}
+	return nil
+}
+
+// applySessionRequest validates a session against its training program: the
+// mode must fit the program's training type, the session must fall within the
+// batch dates and offline sessions need a location.
+func applySessionRequest(session *model.TrainingSession, program model.Program, request dto.TrainingSessionRequest) error {
+	if program.Type != "training" {
+		return errors.New("sessions are only available for training programs")
+	}
+
+	title := strings.TrimSpace(request.Title)
+	if title == "" {
+		return errors.New("title is required")
+	}
+
+	startsAt, err := time.ParseInLocation(sessionTimeLayout, request.StartsAt, time.Local)
+	if err != nil {
+		return errors.New("invalid starts_at format, use YYYY-MM-DD HH:mm")
+	}
+	endsAt, err := time.ParseInLocation(sessionTimeLayout, request.EndsAt, time.Local)
+	if err != nil {
+		return errors.New("invalid ends_at format, use YYYY-MM-DD HH:mm")
+	}
+	if !endsAt.After(startsAt) {
+		return errors.New("ends_at must be after starts_at")
+	}
+	if program.BatchStartDate != nil {
+		if batchStart, ok := parseProgramDate(*program.BatchStartDate); ok && startsAt.Before(batchStart) {
+			return fmt.Errorf("session cannot start before the batch starts on %s", batchStart.Format("2006-01-02"))
+		}
+	}
+	if program.BatchEndDate != nil {
+		if batchEnd, ok := parseProgramDate(*program.BatchEndDate); ok && !endsAt.Before(batchEnd.AddDate(0, 0, 1)) {
+			return fmt.Errorf("session cannot end after the batch ends on %s", batchEnd.Format("2006-01-02"))
+		}
+	}
+
+	trainingType := constant.SessionModeOffline
+	if program.TrainingType != nil {
+		trainingType = *program.TrainingType
+	}
+	mode := request.Mode
+	if mode == "" {
+		mode = trainingType
+	}
+	switch {
+	case mode != constant.SessionModeOnline && mode != constant.SessionModeOffline:
+		return errors.New("mode must be online or offline")
+	case trainingType != "hybrid" && mode != trainingType:
+		return fmt.Errorf("sessions of an %s program must be %s", trainingType, trainingType)
+	}
+
+	location := request.Location
+	if mode == constant.SessionModeOffline && (location == nil || strings.TrimSpace(*location) == "") {
+		location = program.Location
+	}
+	if mode == constant.SessionModeOffline && (location == nil || strings.TrimSpace(*location) == "") {
+		return errors.New("location is required for offline sessions")
+	}
+
+	session.Title = title
+	session.Mode = mode
+	session.StartsAt = startsAt
+	session.EndsAt = endsAt
+	session.Location = location
+	session.MeetingURL = request.MeetingURL
+	return nil
+}
+
+// Attendance
+func (s *trainingService) GetSessionAttendance(ctx context.Context, sessionID int) (dto.SessionAttendance, error) {
+	session, err := s.trainingRepo.GetSessionByID(ctx, sessionID)
+	if err != nil {
+		return dto.SessionAttendance{}, err
+	}
+	participants, err := s.trainingRepo.GetParticipants(ctx, session.ProgramID)
+	if err != nil {
+		return dto.SessionAttendance{}, err
+	}
+	attendances, err := s.trainingRepo.GetSessionAttendances(ctx, session.ID)
+	if err != nil {
+		return dto.SessionAttendance{}, err
+	}
+
+	records := make(map[int]model.TrainingAttendance)
+	for _, attendance := range attendances {
+		records[attendance.ApplicationID] = attendance
+	}
+
+	result := dto.SessionAttendance{Session: mapTrainingSession(session)}
+	for _, participant := range participants {
+		item := dto.ParticipantAttendance{
+			ApplicationID: participant.ID,
+			BusinessName:  participant.UMKM.BusinessName,
+			OwnerName:     participant.UMKM.User.Name,
+		}
+		if record, ok := records[participant.ID]; ok {
+			item.Status = record.Status
+			item.Notes = record.Notes
+			if record.CheckedInAt != nil {
+				item.CheckedInAt = record.CheckedInAt.Format("2006-01-02 15:04:05")
+			}
+			if record.Status == constant.AttendanceStatusPresent {
+				result.Session.PresentCount++
+			}
+		}
+		result.Participants = append(result.Participants, item)
+	}
+	return result, nil
+}
+
+// RecordAttendance saves the attendance of participants for a session that has
+// started. Once the batch is over it also issues certificates to participants
+// who completed the training.
+func (s *trainingService) RecordAttendance(ctx context.Context, userID, sessionID int, request dto.AttendanceRequest) (dto.SessionAttendance, error) {
+	if len(request.Records) == 0 {
+		return dto.SessionAttendance{}, errors.New("records cannot be empty")
+	}
+
+	session, err := s.trainingRepo.GetSessionByID(ctx, sessionID)
+	if err != nil {
+		return dto.SessionAttendance{}, err
+	}
+	now := time.Now()
+	if now.Before(session.StartsAt) {
+		return dto.SessionAttendance{}, errors.New("attendance can be recorded once the session has started")
+	}
+
+	participants, err := s.trainingRepo.GetParticipants(ctx, session.ProgramID)
+	if err != nil {
+		return dto.SessionAttendance{}, err
+	}
+	enrolled := make(map[int]bool)
+	for _, participant := range participants {
+		enrolled[participant.ID] = true
+	}
+
+	seen := make(map[int]bool)
+	attendances := make([]model.TrainingAttendance, 0, len(request.Records))
+	for _, record := range request.Records {
+		if !enrolled[record.ApplicationID] {
+			return dto.SessionAttendance{}, fmt.Errorf("application %d is not an approved participant of this training", record.ApplicationID)
+		}
+		if seen[record.ApplicationID] {
+			return dto.SessionAttendance{}, fmt.Errorf("application %d is listed more than once", record.ApplicationID)
+		}
+		seen[record.ApplicationID] = true
+
+		attendance := model.TrainingAttendance{
+			SessionID:     session.ID,
+			ApplicationID: record.ApplicationID,
+			Status:        record.Status,
+			Notes:         strings.TrimSpace(record.Notes),
+			RecordedBy:    &userID,
+		}
+		switch record.Status {
+		case constant.AttendanceStatusPresent:
+			attendance.CheckedInAt = &now
+		case constant.AttendanceStatusAbsent, constant.AttendanceStatusExcused:
+		default:
+			return dto.SessionAttendance{}, fmt.Errorf("invalid attendance status '%s', must be present, absent or excused", record.Status)
+		}
+		attendances = append(attendances, attendance)
+	}
+
+	if err := s.trainingRepo.SaveAttendances(ctx, attendances); err != nil {
+		return dto.SessionAttendance{}, err
+	}
+
+	// Attendance is saved either way; issuing can be retried by an admin
+	if err := s.issueCertificatesIfOver(ctx, session.ProgramID, now); err != nil {
+		log.Log.Errorf("failed to issue certificates for program ID %d: %v", session.ProgramID, err)
+	}
+
+	return s.GetSessionAttendance(ctx, session.ID)
+}
+
+// CheckIn records a participant as present at an offline session by the QR
+// code on their UMKM card. The QR holds a signed kartu token that resolves to
+// the UMKM it was issued to; revoked or tampered tokens and card holders
+// outside the batch are rejected.
+func (s *trainingService) CheckIn(ctx context.Context, userID, sessionID int, request dto.CheckInRequest) (dto.CheckInResult, error) {
+	qrData := strings.TrimSpace(request.QRData)
+	if qrData == "" {
+		return dto.CheckInResult{}, errors.New("qr_data is required")
+	}
+
+	session, err := s.trainingRepo.GetSessionByID(ctx, sessionID)
+	if err != nil {
+		return dto.CheckInResult{}, err
+	}
+	if session.Mode != constant.SessionModeOffline {
+		return dto.CheckInResult{}, errors.New("QR check-in is only available for offline sessions")
+	}
+	now := time.Now()
+	if now.Before(session.StartsAt.Add(-checkInOpensBefore)) {
+		return dto.CheckInResult{}, fmt.Errorf("check-in opens at %s", session.StartsAt.Add(-checkInOpensBefore).Format(sessionTimeLayout))
+	}
+	if now.After(session.EndsAt) {
+		return dto.CheckInResult{}, errors.New("session has ended, record attendance instead")
+	}
+
+	participant, err := s.resolveParticipant(ctx, session.ProgramID, qrData)
+	if err != nil {
+		return dto.CheckInResult{}, err
+	}
+	result := dto.CheckInResult{
+		SessionID:     session.ID,
+		ApplicationID: participant.ID,
+		BusinessName:  participant.UMKM.BusinessName,
+		OwnerName:     participant.UMKM.User.Name,
+	}
+
+	attendances, err := s.trainingRepo.GetSessionAttendances(ctx, session.ID)
+	if err != nil {
+		return dto.CheckInResult{}, err
+	}
+	for _, attendance := range attendances {
+		if attendance.ApplicationID == participant.ID && attendance.Status == constant.AttendanceStatusPresent && attendance.CheckedInAt != nil {
+			result.CheckedInAt = attendance.CheckedInAt.Format("2006-01-02 15:04:05")
+			result.AlreadyCheckedIn = true
+			return result, nil
+		}
+	}
+
+	if err := s.trainingRepo.SaveAttendances(ctx, []model.TrainingAttendance{{
+		SessionID:     session.ID,
+		ApplicationID: participant.ID,
+		Status:        constant.AttendanceStatusPresent,
+		CheckedInAt:   &now,
+		Notes:         "QR check-in",
+		RecordedBy:    &userID,
+	}}); err != nil {
+		return dto.CheckInResult{}, err
+	}
+
+	result.CheckedInAt = now.Format("2006-01-02 15:04:05")
+	return result, nil
+}
+
+// resolveParticipant finds the approved application of the program held by the
+// UMKM the scanned kartu QR code was issued to.
+func (s *trainingService) resolveParticipant(ctx context.Context, programID int, qrData string) (model.Application, error) {
+	umkm, err := resolveKartuQR(ctx, s.kartuRepo, qrData)
+	if err != nil {
+		return model.Application{}, err
+	}
+
+	participants, err := s.trainingRepo.GetParticipants(ctx, programID)
+	if err != nil {
+		return model.Application{}, err
+	}
+	for _, participant := range participants {
+		if participant.UMKMID == umkm.ID {
+			return participant, nil
+		}
+	}
+	return model.Application{}, errors.New("card holder is not an approved participant of this training batch")
+}
+
+func (s *trainingService) GetParticipants(ctx context.Context, programID int) ([]dto.TrainingParticipant, error) {
+	program, err := s.programRepo.GetProgramByID(ctx, programID)
+	if err != nil {
+		return nil, err
+	}
+	progress, err := s.programProgress(ctx, program)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]dto.TrainingParticipant, 0, len(progress))
+	for _, item := range progress {
+		result = append(result, item.TrainingParticipant)
+	}
+	return result, nil
+}
+
+// participantProgress is a participant's attendance progress with the
+// application it belongs to.
+type participantProgress struct {
+	dto.TrainingParticipant
+	application model.Application
+	issued      bool
+}
+
+func (s *trainingService) programProgress(ctx context.Context, program model.Program) ([]participantProgress, error) {
+	if program.Type != "training" {
+		return nil, errors.New("attendance is only tracked for training programs")
+	}
+
+	sessions, err := s.trainingRepo.GetSessions(ctx, program.ID)
+	if err != nil {
+		return nil, err
+	}
+	participants, err := s.trainingRepo.GetParticipants(ctx, program.ID)
+	if err != nil {
+		return nil, err
+	}
+	attendances, err := s.trainingRepo.GetProgramAttendances(ctx, program.ID)
+	if err != nil {
+		return nil, err
+	}
+	certificates, err := s.trainingRepo.GetProgramCertificates(ctx, program.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	byApplication := make(map[int][]model.TrainingAttendance)
+	for _, attendance := range attendances {
+		byApplication[attendance.ApplicationID] = append(byApplication[attendance.ApplicationID], attendance)
+	}
+	issued := make(map[int]model.TrainingCertificate)
+	for _, certificate := range certificates {
+		issued[certificate.ApplicationID] = certificate
+	}
+
+	minPercent := minAttendancePercent(program)
+	result := make([]participantProgress, 0, len(participants))
+	for _, participant := range participants {
+		recorded, present, percent, completed := trainingCompletion(len(sessions), byApplication[participant.ID], minPercent)
+		item := participantProgress{
+			TrainingParticipant: dto.TrainingParticipant{
+				ApplicationID:        participant.ID,
+				BusinessName:         participant.UMKM.BusinessName,
+				OwnerName:            participant.UMKM.User.Name,
+				SessionsTotal:        len(sessions),
+				SessionsRecorded:     recorded,
+				SessionsPresent:      present,
+				AttendancePercent:    percent,
+				MinAttendancePercent: minPercent,
+				Completed:            completed,
+			},
+			application: participant,
+		}
+		if certificate, ok := issued[participant.ID]; ok {
+			certificate.Application = participant
+			mapped := mapTrainingCertificate(certificate)
+			item.Certificate = &mapped
+			item.Completed = true
+			item.issued = true
+		}
+		result = append(result, item)
+	}
+	return result, nil
+}
+
+// minAttendancePercent is the share of sessions a participant must attend.
+// Programs without a rule require every session.
+func minAttendancePercent(program model.Program) int {
+	if program.MinAttendancePercent == nil {
+		return 100
+	}
+	return *program.MinAttendancePercent
+}
+
+// trainingCompletion judges a participant against the sessions of a program. A
+// participant completes once every session has an attendance record and the
+// share attended reaches the minimum; excused sessions count as not attended.
+func trainingCompletion(sessionCount int, attendances []model.TrainingAttendance, minPercent int) (recorded, present int, percent float64, completed bool) {
+	recorded = len(attendances)
+	for _, attendance := range attendances {
+		if attendance.Status == constant.AttendanceStatusPresent {
+			present++
+		}
+	}
+	if sessionCount == 0 {
+		return recorded, present, 0, false
+	}
+
+	percent = math.Round(float64(present)/float64(sessionCount)*10000) / 100
+	completed = recorded >= sessionCount && percent >= float64(minPercent)
+	return recorded, present, percent, completed
+}
+
+// Certificates
+
+// issueCertificatesIfOver issues certificates only once the batch is over, so
+// participants are not judged against a schedule that is still being filled in.
+func (s *trainingService) issueCertificatesIfOver(ctx context.Context, programID int, now time.Time) error {
+	program, err := s.programRepo.GetProgramByID(ctx, programID)
+	if err != nil {
+		return err
+	}
+	sessions, err := s.trainingRepo.GetSessions(ctx, programID)
+	if err != nil {
+		return err
+	}
+	if !trainingOver(program, sessions, now) {
+		return nil
+	}
+	_, err = s.IssueCertificates(ctx, programID)
+	return err
+}
+
+// trainingOver reports whether no more sessions can follow: the batch end date
+// has passed or, for programs without one, every scheduled session has ended.
+func trainingOver(program model.Program, sessions []model.TrainingSession, now time.Time) bool {
+	if program.BatchEndDate != nil {
+		if batchEnd, ok := parseProgramDate(*program.BatchEndDate); ok {
+			return !now.Before(batchEnd.AddDate(0, 0, 1))
+		}
+	}
+	if len(sessions) == 0 {
+		return false
+	}
+	for _, session := range sessions {
+		if now.Before(session.EndsAt) {
+			return false
+		}
+	}
+	return true
+}
+
+// IssueCertificates issues a certificate to every participant of the program
+// who completed the training and has none yet. Each certificate gets a PDF in
+// MinIO and the UMKM is notified.
+func (s *trainingService) IssueCertificates(ctx context.Context, programID int) ([]dto.TrainingCertificate, error) {
+	program, err := s.programRepo.GetProgramByID(ctx, programID)
+	if err != nil {
+		return nil, err
+	}
+	progress, err := s.programProgress(ctx, program)
+	if err != nil {
+		return nil, err
+	}
+
+	var issued []dto.TrainingCertificate
+	for _, participant := range progress {
+		if !participant.Completed || participant.issued {
+			continue
+		}
+		participant.application.Program = program
+
+		certificate, err := s.issueCertificate(ctx, participant.application, participant.AttendancePercent)
+		if err != nil {
+			return issued, err
+		}
+		issued = append(issued, mapTrainingCertificate(certificate))
+	}
+	return issued, nil
+}
+
+func (s *trainingService) issueCertificate(ctx context.Context, application model.Application, attendancePercent float64) (model.TrainingCertificate, error) {
+	code, err := utils.GenerateVerificationCode(12)
+	if err != nil {
+		return model.TrainingCertificate{}, err
+	}
+	now := time.Now()
+	certificate := model.TrainingCertificate{
+		ApplicationID:     application.ID,
+		CertificateNumber: fmt.Sprintf("UMKMGO/TRN/%d/%06d", now.Year(), application.ID),
+		VerificationCode:  code,
+		AttendancePercent: attendancePercent,
+		IssuedAt:          now,
+		Application:       application,
+	}
+
+	pdf, err := utils.GenerateCertificatePDF(certificatePDF(certificate))
+	if err != nil {
+		return model.TrainingCertificate{}, err
+	}
+	certificate.FileURL, err = s.storeCertificate(ctx, utils.GenerateFileName(application.UMKM.BusinessName, "certificate_"), pdf)
+	if err != nil {
+		return model.TrainingCertificate{}, fmt.Errorf("failed to upload certificate: %w", err)
+	}
+
+	created, err := s.trainingRepo.CreateCertificate(ctx, certificate)
+	if err != nil {
+		return model.TrainingCertificate{}, err
+	}
+	created.Application = application
+
+	metadata, err := json.Marshal(map[string]any{"certificate_id": created.ID, "verification_code": created.VerificationCode})
+	if err != nil {
+		return model.TrainingCertificate{}, err
+	}
+	if err := s.notificationRepo.CreateNotification(ctx, model.Notification{
+		UMKMID:        application.UMKMID,
+		ApplicationID: &application.ID,
+		Type:          constant.NotificationCertificateIssued,
+		Title:         constant.NotificationTitleCertificateIssued,
+		Message:       fmt.Sprintf(constant.NotificationMessageCertificateIssued, application.Program.Title, attendancePercent),
+		Metadata:      string(metadata),
+	}); err != nil {
+		log.Log.Errorf("failed to notify certificate for application ID %d: %v", application.ID, err)
+	}
+	return created, nil
+}
+
+// certificatePDF lays out a training certificate. The QR code leads to the
+// public verification page, or holds the bare code when none is configured.
+func certificatePDF(certificate model.TrainingCertificate) utils.CertificatePDF {
+	application := certificate.Application
+	program := application.Program
+
+	period := ""
+	if program.BatchStartDate != nil && program.BatchEndDate != nil {
+		start, startOK := parseProgramDate(*program.BatchStartDate)
+		end, endOK := parseProgramDate(*program.BatchEndDate)
+		if startOK && endOK {
+			period = fmt.Sprintf("%s - %s", start.Format("02 January 2006"), end.Format("02 January 2006"))
+		}
+	}
+
+	lines := []utils.CertificateLine{
+		{Text: "SERTIFIKAT", Size: 40, Bold: true},
+		{Text: "Nomor: " + certificate.CertificateNumber, Size: 12, Gap: 8},
+		{Text: "Diberikan kepada", Size: 14, Gap: 36},
+		{Text: application.UMKM.User.Name, Size: 28, Bold: true, Gap: 14},
+		{Text: application.UMKM.BusinessName, Size: 14, Gap: 8},
+		{Text: "atas keberhasilan menyelesaikan pelatihan", Size: 14, Gap: 30},
+		{Text: program.Title, Size: 20, Bold: true, Gap: 10},
+	}
+	if program.Provider != "" {
+		lines = append(lines, utils.CertificateLine{Text: "Diselenggarakan oleh " + program.Provider, Size: 12, Gap: 10})
+	}
+	if period != "" {
+		lines = append(lines, utils.CertificateLine{Text: period, Size: 12, Gap: 6})
+	}
+
+	qrData := certificate.VerificationCode
+	if env.Cfg.Certificates.VerifyURL != "" {
+		qrData = strings.TrimRight(env.Cfg.Certificates.VerifyURL, "/") + "/" + certificate.VerificationCode
+	}
+
+	return utils.CertificatePDF{
+		Lines: lines,
+		Footer: []string{
+			fmt.Sprintf("Kehadiran: %.2f%%", certificate.AttendancePercent),
+			"Diterbitkan: " + certificate.IssuedAt.Format("02 January 2006"),
+			"Kode Verifikasi: " + certificate.VerificationCode,
+		},
+		QRData: qrData,
+	}
+}
+
+// VerifyCertificate looks a certificate up by its verification code for anyone
+// holding the printed certificate.
+func (s *trainingService) VerifyCertificate(ctx context.Context, code string) (dto.CertificateVerification, error) {
+	certificate, err := s.trainingRepo.GetCertificateByCode(ctx, strings.ToUpper(strings.TrimSpace(code)))
+	if err != nil {
+		return dto.CertificateVerification{}, err
+	}
+
+	return dto.CertificateVerification{
+		Valid:             true,
+		CertificateNumber: certificate.CertificateNumber,
+		RecipientName:     certificate.Application.UMKM.User.Name,
+		BusinessName:      certificate.Application.UMKM.BusinessName,
+		ProgramTitle:      certificate.Application.Program.Title,
+		Provider:          certificate.Application.Program.Provider,
+		IssuedAt:          certificate.IssuedAt.Format("2006-01-02"),
+	}, nil
+}
+
+func mapTrainingSession(session model.TrainingSession) dto.TrainingSession {
+	return dto.TrainingSession{
+		ID:         session.ID,
+		ProgramID:  session.ProgramID,
+		Title:      session.Title,
+		Mode:       session.Mode,
+		StartsAt:   session.StartsAt.Format(sessionTimeLayout),
+		EndsAt:     session.EndsAt.Format(sessionTimeLayout),
+		Location:   session.Location,
+		MeetingURL: session.MeetingURL,
+	}
+}
+
+func mapTrainingCertificate(certificate model.TrainingCertificate) dto.TrainingCertificate {
+	application := certificate.Application
+	return dto.TrainingCertificate{
+		ID:                certificate.ID,
+		ApplicationID:     certificate.ApplicationID,
+		ProgramID:         application.ProgramID,
+		ProgramTitle:      application.Program.Title,
+		Provider:          application.Program.Provider,
+		RecipientName:     application.UMKM.User.Name,
+		BusinessName:      application.UMKM.BusinessName,
+		CertificateNumber: certificate.CertificateNumber,
+		VerificationCode:  certificate.VerificationCode,
+		AttendancePercent: certificate.AttendancePercent,
+		FileURL:           certificate.FileURL,
+		IssuedAt:          certificate.IssuedAt.Format("2006-01-02"),
+	}
+}
@@ -0,0 +1,681 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"UMKMGo-backend/config/env"
+	"UMKMGo-backend/internal/types/dto"
+	"UMKMGo-backend/internal/types/model"
+	"UMKMGo-backend/internal/utils"
+	"UMKMGo-backend/internal/utils/constant"
+)
+
+// Mock Training Repository
+type mockTrainingRepo struct {
+	sessions     map[int]model.TrainingSession
+	applications map[int]model.Application
+	attendances  []model.TrainingAttendance
+	certificates []model.TrainingCertificate
+}
+
+func newMockTrainingRepo() *mockTrainingRepo {
+	return &mockTrainingRepo{
+		sessions:     make(map[int]model.TrainingSession),
+		applications: make(map[int]model.Application),
+	}
+}
+
+func (m *mockTrainingRepo) GetSessions(ctx context.Context, programID int) ([]model.TrainingSession, error) {
+	var sessions []model.TrainingSession
+	for _, session := range m.sessions {
+		if session.ProgramID == programID {
+			sessions = append(sessions, session)
+		}
+	}
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].StartsAt.Before(sessions[j].StartsAt) })
+	return sessions, nil
+}
+
+func (m *mockTrainingRepo) GetSessionByID(ctx context.Context, id int) (model.TrainingSession, error) {
+	session, exists := m.sessions[id]
+	if !exists {
+		return model.TrainingSession{}, errors.New("training session not found")
+	}
+	return session, nil
+}
+
+func (m *mockTrainingRepo) CreateSession(ctx context.Context, session model.TrainingSession) (model.TrainingSession, error) {
+	session.ID = len(m.sessions) + 1
+	m.sessions[session.ID] = session
+	return session, nil
+}
+
+func (m *mockTrainingRepo) UpdateSession(ctx context.Context, session model.TrainingSession) (model.TrainingSession, error) {
+	m.sessions[session.ID] = session
+	return session, nil
+}
+
+func (m *mockTrainingRepo) DeleteSession(ctx context.Context, id int) error {
+	delete(m.sessions, id)
+	return nil
+}
+
+func (m *mockTrainingRepo) GetParticipants(ctx context.Context, programID int) ([]model.Application, error) {
+	var applications []model.Application
+	for _, application := range m.applications {
+		if application.ProgramID == programID && application.Type == "training" && application.Status == "approved" {
+			applications = append(applications, application)
+		}
+	}
+	sort.Slice(applications, func(i, j int) bool { return applications[i].ID < applications[j].ID })
+	return applications, nil
+}
+
+func (m *mockTrainingRepo) GetProgramAttendances(ctx context.Context, programID int) ([]model.TrainingAttendance, error) {
+	var attendances []model.TrainingAttendance
+	for _, attendance := range m.attendances {
+		if m.sessions[attendance.SessionID].ProgramID == programID {
+			attendances = append(attendances, attendance)
+		}
+	}
+	return attendances, nil
+}
+
+func (m *mockTrainingRepo) GetSessionAttendances(ctx context.Context, sessionID int) ([]model.TrainingAttendance, error) {
+	var attendances []model.TrainingAttendance
+	for _, attendance := range m.attendances {
+		if attendance.SessionID == sessionID {
+			attendances = append(attendances, attendance)
+		}
+	}
+	return attendances, nil
+}
+
+func (m *mockTrainingRepo) SaveAttendances(ctx context.Context, attendances []model.TrainingAttendance) error {
+	for _, attendance := range attendances {
+		replaced := false
+		for i, existing := range m.attendances {
+			if existing.SessionID == attendance.SessionID && existing.ApplicationID == attendance.ApplicationID {
+				m.attendances[i] = attendance
+				replaced = true
+			}
+		}
+		if !replaced {
+			m.attendances = append(m.attendances, attendance)
+		}
+	}
+	return nil
+}
+
+func (m *mockTrainingRepo) GetProgramCertificates(ctx context.Context, programID int) ([]model.TrainingCertificate, error) {
+	var certificates []model.TrainingCertificate
+	for _, certificate := range m.certificates {
+		if m.applications[certificate.ApplicationID].ProgramID == programID {
+			certificates = append(certificates, certificate)
+		}
+	}
+	return certificates, nil
+}
+
+func (m *mockTrainingRepo) GetCertificatesByUMKMID(ctx context.Context, umkmID int) ([]model.TrainingCertificate, error) {
+	var certificates []model.TrainingCertificate
+	for _, certificate := range m.certificates {
+		if application := m.applications[certificate.ApplicationID]; application.UMKMID == umkmID {
+			certificate.Application = application
+			certificates = append(certificates, certificate)
+		}
+	}
+	return certificates, nil
+}
+
+func (m *mockTrainingRepo) GetCertificateByCode(ctx context.Context, code string) (model.TrainingCertificate, error) {
+	for _, certificate := range m.certificates {
+		if certificate.VerificationCode == code {
+			certificate.Application = m.applications[certificate.ApplicationID]
+			return certificate, nil
+		}
+	}
+	return model.TrainingCertificate{}, errors.New("certificate not found")
+}
+
+func (m *mockTrainingRepo) CreateCertificate(ctx context.Context, certificate model.TrainingCertificate) (model.TrainingCertificate, error) {
+	certificate.ID = len(m.certificates) + 1
+	certificate.Application = model.Application{}
+	m.certificates = append(m.certificates, certificate)
+	return certificate, nil
+}
+
+// setupTrainingService builds a service around an offline training program
+// (ID 1) running over the last week, with two approved participants
+// (applications 1 and 2) and one rejected application (3). Application IDs
+// match the UMKM IDs their kartu QR codes resolve to.
+func setupTrainingService() (*trainingService, *mockTrainingRepo, *mockProgramsRepository, *mockNotificationRepo, *[][]byte) {
+	trainingRepo := newMockTrainingRepo()
+	programRepo := newMockProgramsRepository()
+	notificationRepo := newMockNotificationRepo()
+	kartuRepo := newMockKartuRepo()
+	env.Cfg.Kartu.QRSecret = "test-kartu-secret"
+
+	trainingType := "offline"
+	location := "Balai Latihan Kerja"
+	batchStart := time.Now().AddDate(0, 0, -7).Format("2006-01-02")
+	batchEnd := time.Now().AddDate(0, 0, 7).Format("2006-01-02")
+	program := model.Program{
+		ID:             1,
+		Title:          "Pelatihan Pemasaran Digital",
+		Type:           "training",
+		Provider:       "Dinas Koperasi",
+		TrainingType:   &trainingType,
+		Location:       &location,
+		BatchStartDate: &batchStart,
+		BatchEndDate:   &batchEnd,
+		IsActive:       true,
+	}
+	programRepo.programs[1] = program
+
+	for id, status := range map[int]string{1: "approved", 2: "approved", 3: "rejected"} {
+		trainingRepo.applications[id] = model.Application{
+			ID:        id,
+			UMKMID:    id,
+			ProgramID: 1,
+			Type:      "training",
+			Status:    status,
+			UMKM: model.UMKM{
+				ID:           id,
+				BusinessName: "Usaha " + string(rune('A'+id-1)),
+				User:         model.User{Name: "Pemilik " + string(rune('A'+id-1))},
+			},
+			Program: program,
+		}
+	}
+
+	// UMKM 4 holds a kartu but never applied; every kartu QR code is on version 2
+	for id := 1; id <= 4; id++ {
+		kartuRepo.umkms[id] = model.UMKM{ID: id, QRTokenVersion: 2}
+	}
+
+	uploads := &[][]byte{}
+	service := &trainingService{
+		trainingRepo:     trainingRepo,
+		programRepo:      programRepo,
+		notificationRepo: notificationRepo,
+		kartuRepo:        kartuRepo,
+		storeCertificate: func(ctx context.Context, prefix string, pdf []byte) (string, error) {
+			*uploads = append(*uploads, pdf)
+			return "http://minio/applications/" + prefix + ".pdf", nil
+		},
+	}
+	return service, trainingRepo, programRepo, notificationRepo, uploads
+}
+
+// addPastSessions schedules sessions that have already started, one per day.
+func addPastSessions(repo *mockTrainingRepo, programID, count int) []int {
+	var ids []int
+	for i := 0; i < count; i++ {
+		startsAt := time.Now().Add(-time.Duration(count-i) * 24 * time.Hour)
+		session, _ := repo.CreateSession(context.Background(), model.TrainingSession{
+			ProgramID: programID,
+			Title:     "Sesi",
+			Mode:      constant.SessionModeOffline,
+			StartsAt:  startsAt,
+			EndsAt:    startsAt.Add(2 * time.Hour),
+		})
+		ids = append(ids, session.ID)
+	}
+	return ids
+}
+
+// endBatch moves the program's batch end date to yesterday.
+func endBatch(programRepo *mockProgramsRepository, programID int) {
+	program := programRepo.programs[programID]
+	batchEnd := time.Now().AddDate(0, 0, -1).Format("2006-01-02")
+	program.BatchEndDate = &batchEnd
+	programRepo.programs[programID] = program
+}
+
+func TestCreateTrainingSession(t *testing.T) {
+	ctx := context.Background()
+	tomorrow := time.Now().AddDate(0, 0, 1).Format("2006-01-02")
+
+	t.Run("Defaults mode and location to the program", func(t *testing.T) {
+		service, _, _, _, _ := setupTrainingService()
+
+		session, err := service.CreateSession(ctx, 1, dto.TrainingSessionRequest{
+			Title:    "Pembukaan",
+			StartsAt: tomorrow + " 09:00",
+			EndsAt:   tomorrow + " 12:00",
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if session.Mode != constant.SessionModeOffline {
+			t.Errorf("Expected offline mode, got %s", session.Mode)
+		}
+		if session.Location == nil || *session.Location != "Balai Latihan Kerja" {
+			t.Errorf("Expected program location, got %v", session.Location)
+		}
+	})
+
+	t.Run("Rejects invalid sessions", func(t *testing.T) {
+		service, _, _, _, _ := setupTrainingService()
+		outside := time.Now().AddDate(0, 0, 30).Format("2006-01-02")
+
+		tests := []struct {
+			name    string
+			request dto.TrainingSessionRequest
+			want    string
+		}{
+			{"online session in offline program", dto.TrainingSessionRequest{Title: "Sesi", Mode: "online", StartsAt: tomorrow + " 09:00", EndsAt: tomorrow + " 10:00"}, "must be offline"},
+			{"ends before start", dto.TrainingSessionRequest{Title: "Sesi", StartsAt: tomorrow + " 10:00", EndsAt: tomorrow + " 09:00"}, "ends_at must be after starts_at"},
+			{"after batch", dto.TrainingSessionRequest{Title: "Sesi", StartsAt: outside + " 09:00", EndsAt: outside + " 10:00"}, "after the batch ends"},
+			{"bad time format", dto.TrainingSessionRequest{Title: "Sesi", StartsAt: tomorrow, EndsAt: tomorrow}, "invalid starts_at format"},
+			{"missing title", dto.TrainingSessionRequest{StartsAt: tomorrow + " 09:00", EndsAt: tomorrow + " 10:00"}, "title is required"},
+		}
+		for _, tt := range tests {
+			_, err := service.CreateSession(ctx, 1, tt.request)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("%s: expected error containing %q, got %v", tt.name, tt.want, err)
+			}
+		}
+	})
+
+	t.Run("Hybrid program accepts both modes", func(t *testing.T) {
+		service, _, programRepo, _, _ := setupTrainingService()
+		program := programRepo.programs[1]
+		hybrid := "hybrid"
+		program.TrainingType = &hybrid
+		programRepo.programs[1] = program
+
+		session, err := service.CreateSession(ctx, 1, dto.TrainingSessionRequest{
+			Title:    "Sesi Daring",
+			Mode:     constant.SessionModeOnline,
+			StartsAt: tomorrow + " 19:00",
+			EndsAt:   tomorrow + " 21:00",
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if session.Mode != constant.SessionModeOnline || session.Location != nil {
+			t.Errorf("Expected online session without location, got %s %v", session.Mode, session.Location)
+		}
+	})
+
+	t.Run("Rejects non-training programs", func(t *testing.T) {
+		service, _, programRepo, _, _ := setupTrainingService()
+		programRepo.programs[2] = model.Program{ID: 2, Type: "funding"}
+
+		_, err := service.CreateSession(ctx, 2, dto.TrainingSessionRequest{Title: "Sesi", StartsAt: tomorrow + " 09:00", EndsAt: tomorrow + " 10:00"})
+		if err == nil {
+			t.Error("Expected error for funding program")
+		}
+	})
+
+	t.Run("Schedule is fixed once certificates are issued", func(t *testing.T) {
+		service, repo, _, _, _ := setupTrainingService()
+		ids := addPastSessions(repo, 1, 1)
+		repo.certificates = append(repo.certificates, model.TrainingCertificate{ID: 1, ApplicationID: 1})
+
+		if _, err := service.CreateSession(ctx, 1, dto.TrainingSessionRequest{Title: "Sesi", StartsAt: tomorrow + " 09:00", EndsAt: tomorrow + " 10:00"}); err == nil {
+			t.Error("Expected error creating a session")
+		}
+		if err := service.DeleteSession(ctx, ids[0]); err == nil {
+			t.Error("Expected error deleting a session")
+		}
+	})
+}
+
+func TestTrainingCompletion(t *testing.T) {
+	attend := func(statuses ...string) []model.TrainingAttendance {
+		var attendances []model.TrainingAttendance
+		for i, status := range statuses {
+			attendances = append(attendances, model.TrainingAttendance{SessionID: i + 1, Status: status})
+		}
+		return attendances
+	}
+	present, absent, excused := constant.AttendanceStatusPresent, constant.AttendanceStatusAbsent, constant.AttendanceStatusExcused
+
+	tests := []struct {
+		name          string
+		sessions      int
+		attendances   []model.TrainingAttendance
+		minPercent    int
+		wantPercent   float64
+		wantCompleted bool
+	}{
+		{"all present", 3, attend(present, present, present), 100, 100, true},
+		{"one absent with full attendance rule", 3, attend(present, absent, present), 100, 66.67, false},
+		{"one excused meets lower rule", 4, attend(present, excused, present, present), 75, 75, true},
+		{"sessions not yet recorded", 4, attend(present, present, present), 75, 75, false},
+		{"no sessions", 0, nil, 100, 0, false},
+	}
+	for _, tt := range tests {
+		_, _, percent, completed := trainingCompletion(tt.sessions, tt.attendances, tt.minPercent)
+		if percent != tt.wantPercent || completed != tt.wantCompleted {
+			t.Errorf("%s: expected %.2f%% completed=%v, got %.2f%% completed=%v", tt.name, tt.wantPercent, tt.wantCompleted, percent, completed)
+		}
+	}
+}
+
+func TestRecordAttendance(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Rejects invalid records", func(t *testing.T) {
+		service, repo, _, _, _ := setupTrainingService()
+		ids := addPastSessions(repo, 1, 1)
+		future, _ := repo.CreateSession(ctx, model.TrainingSession{ProgramID: 1, StartsAt: time.Now().Add(time.Hour), EndsAt: time.Now().Add(2 * time.Hour)})
+
+		tests := []struct {
+			name      string
+			sessionID int
+			records   []dto.AttendanceRecord
+			want      string
+		}{
+			{"not enrolled", ids[0], []dto.AttendanceRecord{{ApplicationID: 3, Status: "present"}}, "not an approved participant"},
+			{"invalid status", ids[0], []dto.AttendanceRecord{{ApplicationID: 1, Status: "late"}}, "invalid attendance status"},
+			{"duplicate", ids[0], []dto.AttendanceRecord{{ApplicationID: 1, Status: "present"}, {ApplicationID: 1, Status: "absent"}}, "more than once"},
+			{"session not started", future.ID, []dto.AttendanceRecord{{ApplicationID: 1, Status: "present"}}, "once the session has started"},
+			{"empty", ids[0], nil, "records cannot be empty"},
+		}
+		for _, tt := range tests {
+			_, err := service.RecordAttendance(ctx, 9, tt.sessionID, dto.AttendanceRequest{Records: tt.records})
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("%s: expected error containing %q, got %v", tt.name, tt.want, err)
+			}
+		}
+		if len(repo.attendances) != 0 {
+			t.Errorf("Expected nothing saved, got %d records", len(repo.attendances))
+		}
+	})
+
+	t.Run("Issues a certificate once the training is completed", func(t *testing.T) {
+		service, repo, programRepo, notificationRepo, uploads := setupTrainingService()
+		endBatch(programRepo, 1)
+		ids := addPastSessions(repo, 1, 2)
+
+		result, err := service.RecordAttendance(ctx, 9, ids[0], dto.AttendanceRequest{Records: []dto.AttendanceRecord{
+			{ApplicationID: 1, Status: "present"},
+			{ApplicationID: 2, Status: "present"},
+		}})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if result.Session.PresentCount != 2 || len(result.Participants) != 2 {
+			t.Errorf("Expected 2 present of 2 participants, got %d of %d", result.Session.PresentCount, len(result.Participants))
+		}
+		if repo.attendances[0].RecordedBy == nil || *repo.attendances[0].RecordedBy != 9 || repo.attendances[0].CheckedInAt == nil {
+			t.Error("Expected recorder and check-in time to be saved")
+		}
+		if len(repo.certificates) != 0 {
+			t.Fatalf("Expected no certificate before the last session, got %d", len(repo.certificates))
+		}
+
+		// Application 2 misses the last session, below the default full attendance
+		_, err = service.RecordAttendance(ctx, 9, ids[1], dto.AttendanceRequest{Records: []dto.AttendanceRecord{
+			{ApplicationID: 1, Status: "present"},
+			{ApplicationID: 2, Status: "absent"},
+		}})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(repo.certificates) != 1 {
+			t.Fatalf("Expected 1 certificate, got %d", len(repo.certificates))
+		}
+		certificate := repo.certificates[0]
+		if certificate.ApplicationID != 1 || certificate.AttendancePercent != 100 || certificate.FileURL == "" || len(certificate.VerificationCode) != 12 {
+			t.Errorf("Unexpected certificate %+v", certificate)
+		}
+		if len(*uploads) != 1 || !bytes.HasPrefix((*uploads)[0], []byte("%PDF-")) {
+			t.Error("Expected the certificate PDF to be uploaded")
+		}
+		if len(notificationRepo.notifications) != 1 || notificationRepo.notifications[0].Type != constant.NotificationCertificateIssued {
+			t.Errorf("Expected a certificate notification, got %+v", notificationRepo.notifications)
+		}
+
+		// Correcting attendance later does not issue a second certificate
+		if _, err := service.RecordAttendance(ctx, 9, ids[1], dto.AttendanceRequest{Records: []dto.AttendanceRecord{{ApplicationID: 1, Status: "present"}}}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(repo.certificates) != 1 {
+			t.Errorf("Expected certificate not to be reissued, got %d", len(repo.certificates))
+		}
+	})
+
+	t.Run("Minimum attendance rule of the program", func(t *testing.T) {
+		service, repo, programRepo, _, _ := setupTrainingService()
+		program := programRepo.programs[1]
+		minPercent := 50
+		program.MinAttendancePercent = &minPercent
+		programRepo.programs[1] = program
+		endBatch(programRepo, 1)
+		ids := addPastSessions(repo, 1, 2)
+
+		for i, status := range []string{"present", "excused"} {
+			if _, err := service.RecordAttendance(ctx, 9, ids[i], dto.AttendanceRequest{Records: []dto.AttendanceRecord{{ApplicationID: 2, Status: status}}}); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+		}
+
+		participants, err := service.GetParticipants(ctx, 1)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		for _, participant := range participants {
+			switch participant.ApplicationID {
+			case 1:
+				if participant.Completed || participant.SessionsRecorded != 0 {
+					t.Errorf("Expected application 1 incomplete, got %+v", participant)
+				}
+			case 2:
+				if !participant.Completed || participant.AttendancePercent != 50 || participant.Certificate == nil {
+					t.Errorf("Expected application 2 certified at 50%%, got %+v", participant)
+				}
+			}
+		}
+	})
+}
+
+func TestCertificatesWaitForTheBatch(t *testing.T) {
+	ctx := context.Background()
+	tomorrow := time.Now().AddDate(0, 0, 1).Format("2006-01-02")
+	allPresent := dto.AttendanceRequest{Records: []dto.AttendanceRecord{
+		{ApplicationID: 1, Status: "present"},
+		{ApplicationID: 2, Status: "present"},
+	}}
+
+	t.Run("Not while the batch is still running", func(t *testing.T) {
+		service, repo, _, _, _ := setupTrainingService()
+		// Only the first session is scheduled so far
+		ids := addPastSessions(repo, 1, 1)
+
+		if _, err := service.RecordAttendance(ctx, 9, ids[0], allPresent); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(repo.certificates) != 0 {
+			t.Fatalf("Expected no certificate before the batch ends, got %d", len(repo.certificates))
+		}
+		if _, err := service.CreateSession(ctx, 1, dto.TrainingSessionRequest{Title: "Sesi 2", StartsAt: tomorrow + " 09:00", EndsAt: tomorrow + " 11:00"}); err != nil {
+			t.Errorf("Expected the remaining sessions to be schedulable, got %v", err)
+		}
+	})
+
+	t.Run("Not while a session is still to come", func(t *testing.T) {
+		service, repo, programRepo, _, _ := setupTrainingService()
+		program := programRepo.programs[1]
+		program.BatchEndDate = nil
+		programRepo.programs[1] = program
+		ids := addPastSessions(repo, 1, 1)
+		repo.CreateSession(ctx, model.TrainingSession{ProgramID: 1, Title: "Sesi 2", StartsAt: time.Now().Add(24 * time.Hour), EndsAt: time.Now().Add(26 * time.Hour)})
+
+		if _, err := service.RecordAttendance(ctx, 9, ids[0], allPresent); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(repo.certificates) != 0 {
+			t.Errorf("Expected no certificate before the last session, got %d", len(repo.certificates))
+		}
+	})
+
+	t.Run("Once every session has ended without a batch end date", func(t *testing.T) {
+		service, repo, programRepo, _, _ := setupTrainingService()
+		program := programRepo.programs[1]
+		program.BatchEndDate = nil
+		programRepo.programs[1] = program
+		ids := addPastSessions(repo, 1, 1)
+
+		if _, err := service.RecordAttendance(ctx, 9, ids[0], allPresent); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(repo.certificates) != 2 {
+			t.Errorf("Expected 2 certificates, got %d", len(repo.certificates))
+		}
+	})
+}
+
+func TestCheckIn(t *testing.T) {
+	ctx := context.Background()
+
+	newSession := func(repo *mockTrainingRepo, mode string, startsIn time.Duration) int {
+		startsAt := time.Now().Add(startsIn)
+		session, _ := repo.CreateSession(ctx, model.TrainingSession{ProgramID: 1, Title: "Sesi", Mode: mode, StartsAt: startsAt, EndsAt: startsAt.Add(2 * time.Hour)})
+		return session.ID
+	}
+	kartuQR := func(umkmID int) string {
+		return utils.GenerateKartuToken(umkmID, 2)
+	}
+
+	t.Run("Resolves the card to the approved application", func(t *testing.T) {
+		service, repo, _, _, _ := setupTrainingService()
+		sessionID := newSession(repo, constant.SessionModeOffline, -30*time.Minute)
+
+		result, err := service.CheckIn(ctx, 9, sessionID, dto.CheckInRequest{QRData: " https://umkmgo.id/verify/kartu/" + kartuQR(2) + " "})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if result.ApplicationID != 2 || result.AlreadyCheckedIn || result.CheckedInAt == "" {
+			t.Errorf("Unexpected check-in %+v", result)
+		}
+		if len(repo.attendances) != 1 || repo.attendances[0].Status != constant.AttendanceStatusPresent || *repo.attendances[0].RecordedBy != 9 {
+			t.Errorf("Expected present attendance, got %+v", repo.attendances)
+		}
+
+		// Scanning again keeps the first check-in time
+		firstCheckIn := *repo.attendances[0].CheckedInAt
+		again, err := service.CheckIn(ctx, 9, sessionID, dto.CheckInRequest{QRData: kartuQR(2)})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !again.AlreadyCheckedIn || !repo.attendances[0].CheckedInAt.Equal(firstCheckIn) {
+			t.Errorf("Expected the earlier check-in to be kept, got %+v", again)
+		}
+	})
+
+	t.Run("Checking in to the last session does not issue the certificate", func(t *testing.T) {
+		service, repo, programRepo, _, _ := setupTrainingService()
+		program := programRepo.programs[1]
+		program.BatchEndDate = nil
+		programRepo.programs[1] = program
+		sessionID := newSession(repo, constant.SessionModeOffline, 30*time.Minute)
+
+		if _, err := service.CheckIn(ctx, 9, sessionID, dto.CheckInRequest{QRData: kartuQR(1)}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(repo.certificates) != 0 {
+			t.Errorf("Expected no certificate before the session is over, got %+v", repo.certificates)
+		}
+
+		// An admin can still issue it explicitly
+		if _, err := service.IssueCertificates(ctx, 1); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(repo.certificates) != 1 || repo.certificates[0].ApplicationID != 1 {
+			t.Errorf("Expected a certificate for application 1, got %+v", repo.certificates)
+		}
+	})
+
+	t.Run("Rejects invalid check-ins", func(t *testing.T) {
+		service, repo, _, _, _ := setupTrainingService()
+		offline := newSession(repo, constant.SessionModeOffline, 0)
+		online := newSession(repo, constant.SessionModeOnline, 0)
+		later := newSession(repo, constant.SessionModeOffline, 3*time.Hour)
+		ended := newSession(repo, constant.SessionModeOffline, -5*time.Hour)
+
+		tests := []struct {
+			name      string
+			sessionID int
+			qrData    string
+			want      string
+		}{
+			{"rejected application", offline, kartuQR(3), "not an approved participant"},
+			{"not enrolled", offline, kartuQR(4), "not an approved participant"},
+			{"revoked QR code", offline, utils.GenerateKartuToken(1, 1), "has been revoked"},
+			{"plaintext kartu number", offline, "1234567890123456", "invalid kartu QR code"},
+			{"online session", online, kartuQR(1), "only available for offline sessions"},
+			{"too early", later, kartuQR(1), "check-in opens at"},
+			{"ended", ended, kartuQR(1), "session has ended"},
+			{"empty", offline, "", "qr_data is required"},
+		}
+		for _, tt := range tests {
+			_, err := service.CheckIn(ctx, 9, tt.sessionID, dto.CheckInRequest{QRData: tt.qrData})
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("%s: expected error containing %q, got %v", tt.name, tt.want, err)
+			}
+		}
+		if len(repo.attendances) != 0 {
+			t.Errorf("Expected nothing recorded, got %+v", repo.attendances)
+		}
+	})
+}
+
+func TestVerifyCertificate(t *testing.T) {
+	ctx := context.Background()
+	service, repo, _, _, _ := setupTrainingService()
+	repo.certificates = append(repo.certificates, model.TrainingCertificate{
+		ID:                1,
+		ApplicationID:     1,
+		CertificateNumber: "UMKMGO/TRN/2025/000001",
+		VerificationCode:  "ABCDEFGH2345",
+		IssuedAt:          time.Date(2025, 12, 20, 10, 0, 0, 0, time.Local),
+	})
+
+	t.Run("Valid code", func(t *testing.T) {
+		result, err := service.VerifyCertificate(ctx, " abcdefgh2345 ")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !result.Valid || result.RecipientName != "Pemilik A" || result.ProgramTitle != "Pelatihan Pemasaran Digital" || result.IssuedAt != "2025-12-20" {
+			t.Errorf("Unexpected verification %+v", result)
+		}
+	})
+
+	t.Run("Unknown code", func(t *testing.T) {
+		if _, err := service.VerifyCertificate(ctx, "UNKNOWN"); err == nil {
+			t.Error("Expected error for unknown code")
+		}
+	})
+}
+
+func TestGetMyCertificates(t *testing.T) {
+	ctx := context.Background()
+	mobile, _ := setupMobileServiceForTests()
+	trainingRepo := newMockTrainingRepo()
+	trainingRepo.applications[1] = model.Application{ID: 1, UMKMID: 1, ProgramID: 1, Program: model.Program{ID: 1, Title: "Training Program"}}
+	trainingRepo.applications[2] = model.Application{ID: 2, UMKMID: 2, ProgramID: 1}
+	trainingRepo.certificates = []model.TrainingCertificate{
+		{ID: 1, ApplicationID: 1, CertificateNumber: "UMKMGO/TRN/2025/000001"},
+		{ID: 2, ApplicationID: 2, CertificateNumber: "UMKMGO/TRN/2025/000002"},
+	}
+	mobile.trainingRepo = trainingRepo
+
+	certificates, err := mobile.GetMyCertificates(ctx, 1)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(certificates) != 1 || certificates[0].ApplicationID != 1 || certificates[0].ProgramTitle != "Training Program" {
+		t.Errorf("Expected only the UMKM's certificate, got %+v", certificates)
+	}
+}
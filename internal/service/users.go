@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"UMKMGo-backend/config/env"
+	"UMKMGo-backend/config/log"
 	"UMKMGo-backend/config/redis"
 	"UMKMGo-backend/config/storage"
 	"UMKMGo-backend/config/vault"
@@ -49,10 +50,11 @@ type usersService struct {
 	otpRepository   repository.OTPRepository
 	redisRepository redis.RedisRepository
 	minio           *storage.MinIOManager
+	kartuService    KartuService
 }
 
-func NewUsersService(usersRepository repository.UsersRepository, otpRepository repository.OTPRepository, redisRepository redis.RedisRepository, minio *storage.MinIOManager) UsersService {
-	return &usersService{usersRepository, otpRepository, redisRepository, minio}
+func NewUsersService(usersRepository repository.UsersRepository, otpRepository repository.OTPRepository, redisRepository redis.RedisRepository, minio *storage.MinIOManager, kartuService KartuService) UsersService {
+	return &usersService{usersRepository, otpRepository, redisRepository, minio, kartuService}
 }
 
 func (user_serv *usersService) Register(ctx context.Context, user dto.Users) (dto.Users, error) {
@@ -652,37 +654,27 @@ func (user_serv *usersService) RegisterMobileProfile(ctx context.Context, user d
 		return nil, errors.New("failed to encrypt Kartu Number - " + err.Error())
 	}
 
-	// Generate QR Code from Kartu Number
-	qrCodeBase64, err := utils.GenerateQRCode(string(user.KartuNumber), 256)
-	if err != nil {
-		return nil, errors.New("failed to generate QR code: " + err.Error())
-	}
-
-	// Upload QR Code to MinIO
-	qrCode, err := user_serv.minio.UploadFile(ctx, storage.UploadRequest{
-		Base64Data: qrCodeBase64,
-		BucketName: storage.UMKMBucket,
-		Prefix:     utils.GenerateFileName(user.Fullname, "qrcode_"),
-	})
-	if err != nil {
-		return nil, errors.New("failed to upload QR code: " + err.Error())
-	}
+	// The kartu is issued today and valid for KARTU_VALIDITY_MONTHS
+	kartuIssuedDate := time.Now()
+	kartuExpiryDate := kartuIssuedDate.AddDate(0, env.Cfg.Kartu.ValidityMonths, 0)
 
 	res, err := user_serv.userRepository.CreateUMKM(ctx,
 		model.UMKM{
-			BusinessName: user.BusinessName,
-			NIK:          ciphertextNIK,
-			Gender:       user.Gender,
-			BirthDate:    birthDate,
-			Phone:        validPhone,
-			Address:      user.Address,
-			ProvinceID:   user.ProvinceID,
-			CityID:       user.CityID,
-			District:     user.District,
-			PostalCode:   user.PostalCode,
-			KartuType:    user.KartuType,
-			KartuNumber:  ciphertextKartuNumber,
-			QRCode:       qrCode.URL,
+			BusinessName:    user.BusinessName,
+			NIK:             ciphertextNIK,
+			Gender:          user.Gender,
+			BirthDate:       birthDate,
+			Phone:           validPhone,
+			Address:         user.Address,
+			ProvinceID:      user.ProvinceID,
+			CityID:          user.CityID,
+			District:        user.District,
+			PostalCode:      user.PostalCode,
+			KartuType:       user.KartuType,
+			KartuNumber:     ciphertextKartuNumber,
+			KartuStatus:     constant.KartuStatusActive,
+			KartuIssuedDate: &kartuIssuedDate,
+			KartuExpiryDate: &kartuExpiryDate,
 		},
 		model.User{
 			Name:        user.Fullname,
@@ -696,6 +688,12 @@ func (user_serv *usersService) RegisterMobileProfile(ctx context.Context, user d
 		return nil, err
 	}
 
+	// Issue the kartu QR code as a signed token, never the kartu number itself.
+	// If it fails here the QR migration job issues it later.
+	if _, err := user_serv.kartuService.RotateQRCode(ctx, res.ID); err != nil {
+		log.Log.Errorf("failed to issue QR code for UMKM ID %d: %v", res.ID, err)
+	}
+
 	OTP.Status = constant.OTPStatusUsed
 	if err := user_serv.otpRepository.UpdateOTP(ctx, *OTP); err != nil {
 		return nil, errors.New("failed to update OTP status")
@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"errors"
+	"strconv"
 	"testing"
 	"time"
 
@@ -87,6 +88,14 @@ func (m *mockRedisRepository) Del(ctx context.Context, keys ...string) (int64, e
 	return count, nil
 }
 
+func (m *mockRedisRepository) DelIfEqual(ctx context.Context, key, value string) (bool, error) {
+	if current, exists := m.data[key]; !exists || current != value {
+		return false, nil
+	}
+	delete(m.data, key)
+	return true, nil
+}
+
 func (m *mockRedisRepository) Exists(ctx context.Context, keys ...string) (int64, error) {
 	var count int64
 	for _, key := range keys {
@@ -98,7 +107,10 @@ func (m *mockRedisRepository) Exists(ctx context.Context, keys ...string) (int64
 }
 
 func (m *mockRedisRepository) Incr(ctx context.Context, key string) (int64, error) {
-	return 1, nil
+	value, _ := strconv.ParseInt(m.data[key], 10, 64)
+	value++
+	m.data[key] = strconv.FormatInt(value, 10)
+	return value, nil
 }
 
 func (m *mockRedisRepository) Expire(ctx context.Context, key string, exp time.Duration) error {
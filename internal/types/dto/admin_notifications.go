@@ -0,0 +1,12 @@
+package dto
+
+type AdminNotificationResponse struct {
+	ID            int    `json:"id"`
+	Type          string `json:"type"`
+	Title         string `json:"title"`
+	Message       string `json:"message"`
+	IsRead        bool   `json:"is_read"`
+	ApplicationID *int   `json:"application_id,omitempty"`
+	CreatedAt     string `json:"created_at"`
+	ReadAt        string `json:"read_at,omitempty"`
+}
@@ -8,6 +8,14 @@ type Applications struct {
 	Status            string                        `json:"status,omitempty"`
 	SubmittedAt       string                        `json:"submitted_at,omitempty"`
 	ExpiredAt         string                        `json:"expired_at,omitempty"`
+	IsOverdue         bool                          `json:"is_overdue"`
+	OverdueAt         string                        `json:"overdue_at,omitempty"`
+	EscalatedAt       string                        `json:"escalated_at,omitempty"`
+	Version           int                           `json:"version,omitempty"`
+	RevisionFields    []string                      `json:"revision_fields,omitempty"`
+	Eligibility       *EligibilityResult            `json:"eligibility,omitempty"`
+	ScoreTotal        *float64                      `json:"score_total,omitempty"`
+	Scorecard         *ApplicationScorecard         `json:"scorecard,omitempty"`
 	CreatedAt         string                        `json:"created_at,omitempty"`
 	UpdatedAt         string                        `json:"updated_at,omitempty"`
 	Documents         []ApplicationDocuments        `json:"documents,omitempty"`
@@ -19,31 +27,188 @@ type Applications struct {
 	FundingData       *FundingApplicationData       `json:"funding_data,omitempty"`
 }
 
+// Query Parameters
+type ApplicationQueryParams struct {
+	Page          int    `query:"page"`
+	Limit         int    `query:"limit"`
+	Type          string `query:"type"`
+	Status        string `query:"status"`
+	ProgramID     int    `query:"program_id"`
+	ProvinceID    int    `query:"province_id"`
+	CityID        int    `query:"city_id"`
+	KartuType     string `query:"kartu_type"`
+	SubmittedFrom string `query:"submitted_from"` // YYYY-MM-DD, inclusive
+	SubmittedTo   string `query:"submitted_to"`   // YYYY-MM-DD, inclusive
+	IsOverdue     *bool  `query:"is_overdue"`
+	Search        string `query:"search"` // business name or UMKM owner name
+	SortBy        string `query:"sort_by"`
+	SortOrder     string `query:"sort_order"`
+}
+
+type PaginationMeta struct {
+	Page       int   `json:"page"`
+	Limit      int   `json:"limit"`
+	Total      int64 `json:"total"`
+	TotalPages int   `json:"total_pages"`
+}
+
 type ApplicationDocuments struct {
 	ID            int    `json:"id,omitempty"`
 	ApplicationID int    `json:"application_id,omitempty"`
 	Type          string `json:"type" validate:"required,oneof=ktp nib npwp proposal portfolio rekening other"`
 	File          string `json:"file" validate:"required"`
+	ReviewStatus  string `json:"review_status,omitempty"`
+	ReviewReason  string `json:"review_reason,omitempty"`
+	ReviewedBy    *int   `json:"reviewed_by,omitempty"`
+	ReviewedAt    string `json:"reviewed_at,omitempty"`
+	Version       int    `json:"version,omitempty"`
+	RevisionRound int    `json:"revision_round"`
+	SupersededAt  string `json:"superseded_at,omitempty"`
 	CreatedAt     string `json:"created_at,omitempty"`
 	UpdatedAt     string `json:"updated_at,omitempty"`
 }
 
+// DocumentComparison sets the version of a document type sent with the original
+// submission against the current one, with every version in between.
+type DocumentComparison struct {
+	Type      string                 `json:"type"`
+	Submitted *ApplicationDocuments  `json:"submitted"`
+	Current   *ApplicationDocuments  `json:"current"`
+	Changed   bool                   `json:"changed"`
+	Versions  []ApplicationDocuments `json:"versions"`
+}
+
+type DocumentReviewRequest struct {
+	Status string `json:"status" validate:"required,oneof=accepted rejected"`
+	Reason string `json:"reason,omitempty"`
+}
+
 type ApplicationHistories struct {
-	ID             int    `json:"id,omitempty"`
-	ApplicationID  int    `json:"application_id,omitempty"`
-	Status         string `json:"status" validate:"required"`
-	Notes          string `json:"notes,omitempty"`
-	ActionedAt     string `json:"actioned_at,omitempty"`
-	ActionedBy     *int   `json:"actioned_by,omitempty"`
-	ActionedByName string `json:"actioned_by_name,omitempty"`
-	CreatedAt      string `json:"created_at,omitempty"`
-	UpdatedAt      string `json:"updated_at,omitempty"`
+	ID             int           `json:"id,omitempty"`
+	ApplicationID  int           `json:"application_id,omitempty"`
+	Status         string        `json:"status" validate:"required"`
+	Notes          string        `json:"notes,omitempty"`
+	ActionedAt     string        `json:"actioned_at,omitempty"`
+	ActionedBy     *int          `json:"actioned_by,omitempty"`
+	ActionedByName string        `json:"actioned_by_name,omitempty"`
+	Changes        []FieldChange `json:"changes,omitempty"`
+	CreatedAt      string        `json:"created_at,omitempty"`
+	UpdatedAt      string        `json:"updated_at,omitempty"`
+}
+
+// FieldChange is a form field corrected during a revision, with the value it replaced.
+type FieldChange struct {
+	Field    string `json:"field"`
+	Previous any    `json:"previous"`
+	Current  any    `json:"current"`
 }
 
 type ApplicationDecision struct {
 	ApplicationID int    `json:"application_id" validate:"required"`
 	Action        string `json:"action" validate:"required,oneof=approve reject revise"`
 	Notes         string `json:"notes,omitempty"`
+	Version       int    `json:"version,omitempty"`
+	// Revise only: documents and form fields the UMKM has to replace
+	DocumentIDs []int    `json:"document_ids,omitempty"`
+	Fields      []string `json:"fields,omitempty"`
+}
+
+type BulkApplicationDecision struct {
+	ApplicationIDs []int  `json:"application_ids" validate:"required,min=1"`
+	Action         string `json:"action" validate:"required,oneof=approve reject revise"`
+	Notes          string `json:"notes,omitempty"`
+	AllOrNothing   bool   `json:"all_or_nothing"`
+}
+
+type BulkDecisionResult struct {
+	ApplicationID int    `json:"application_id"`
+	Success       bool   `json:"success"`
+	Status        string `json:"status,omitempty"`
+	Version       int    `json:"version,omitempty"`
+	Message       string `json:"message,omitempty"`
+}
+
+type BulkDecisionReport struct {
+	Stage        string               `json:"stage"`
+	Action       string               `json:"action"`
+	AllOrNothing bool                 `json:"all_or_nothing"`
+	Succeeded    int                  `json:"succeeded"`
+	Failed       int                  `json:"failed"`
+	Results      []BulkDecisionResult `json:"results"`
+}
+
+// RubricCriterion is one criterion of a program's scoring rubric. Scores are
+// given between MinScore and MaxScore.
+type RubricCriterion struct {
+	ID          int     `json:"id,omitempty"`
+	Name        string  `json:"name" validate:"required"`
+	Description string  `json:"description,omitempty"`
+	Weight      float64 `json:"weight" validate:"required,gt=0"`
+	MinScore    float64 `json:"min_score"`
+	MaxScore    float64 `json:"max_score" validate:"required"`
+}
+
+type ProgramRubric struct {
+	ProgramID   int               `json:"program_id"`
+	Criteria    []RubricCriterion `json:"criteria" validate:"required,min=1,dive"`
+	TotalWeight float64           `json:"total_weight"`
+	Locked      bool              `json:"locked"` // applications are already scored against it
+}
+
+type ApplicationScoreInput struct {
+	CriterionID int     `json:"criterion_id" validate:"required"`
+	Score       float64 `json:"score"`
+	Notes       string  `json:"notes,omitempty"`
+}
+
+type ApplicationScoresRequest struct {
+	Scores []ApplicationScoreInput `json:"scores" validate:"required,min=1,dive"`
+}
+
+// ApplicationScore is the score of one rubric criterion; Score is nil until a
+// reviewer scores it.
+type ApplicationScore struct {
+	CriterionID  int      `json:"criterion_id"`
+	Criterion    string   `json:"criterion"`
+	Weight       float64  `json:"weight"`
+	MinScore     float64  `json:"min_score"`
+	MaxScore     float64  `json:"max_score"`
+	Score        *float64 `json:"score"`
+	Notes        string   `json:"notes,omitempty"`
+	ScoredBy     *int     `json:"scored_by,omitempty"`
+	ScoredByName string   `json:"scored_by_name,omitempty"`
+	ScoredAt     string   `json:"scored_at,omitempty"`
+}
+
+// ApplicationScorecard is an application's rubric scores with its total and its
+// rank within the program. Total and rank are set once every criterion is scored.
+type ApplicationScorecard struct {
+	ApplicationID int                `json:"application_id"`
+	ProgramID     int                `json:"program_id"`
+	Scores        []ApplicationScore `json:"scores"`
+	Complete      bool               `json:"complete"`
+	ScoreTotal    *float64           `json:"score_total"`
+	Rank          *int               `json:"rank"`
+	RankedCount   int                `json:"ranked_count"`
+}
+
+type ApplicationRanking struct {
+	Rank          int     `json:"rank"`
+	ApplicationID int     `json:"application_id"`
+	BusinessName  string  `json:"business_name"`
+	Status        string  `json:"status"`
+	ScoreTotal    float64 `json:"score_total"`
+	SubmittedAt   string  `json:"submitted_at"`
+}
+
+// BulkTopDecision approves the Count best ranked applications of a program that
+// are waiting at the given stage.
+type BulkTopDecision struct {
+	ProgramID    int    `json:"program_id" validate:"required"`
+	Stage        string `json:"stage" validate:"required,oneof=screening final"`
+	Count        int    `json:"count" validate:"required,min=1"`
+	Notes        string `json:"notes,omitempty"`
+	AllOrNothing bool   `json:"all_or_nothing"`
 }
 
 // Training Application Data
@@ -77,3 +242,35 @@ type FundingApplicationData struct {
 	RequestedTenureMonths int      `json:"requested_tenure_months"`
 	CollateralDescription string   `json:"collateral_description,omitempty"`
 }
+
+type ReviewQueueRequest struct {
+	Stage string `json:"stage" validate:"required,oneof=screening final"`
+}
+
+type ReviewQueueItem struct {
+	ApplicationID int    `json:"application_id"`
+	Type          string `json:"type"`
+	Status        string `json:"status"`
+	ProgramName   string `json:"program_name"`
+	BusinessName  string `json:"business_name"`
+	SubmittedAt   string `json:"submitted_at"`
+	ExpiredAt     string `json:"expired_at"`
+	IsOverdue     bool   `json:"is_overdue"`
+	ClaimedBy     *int   `json:"claimed_by"`
+	ClaimedByMe   bool   `json:"claimed_by_me"`
+}
+
+type ReviewClaim struct {
+	ApplicationID  int    `json:"application_id"`
+	Stage          string `json:"stage"`
+	ReviewerID     int    `json:"reviewer_id"`
+	LeaseExpiresAt string `json:"lease_expires_at"`
+}
+
+type ReviewAssignmentReport struct {
+	Stage       string        `json:"stage"`
+	Strategy    string        `json:"strategy"`
+	Assigned    int           `json:"assigned"`
+	Skipped     int           `json:"skipped"`
+	Assignments []ReviewClaim `json:"assignments"`
+}
@@ -0,0 +1,47 @@
+package dto
+
+type Certification struct {
+	ID                         int    `json:"id"`
+	ApplicationID              int    `json:"application_id"`
+	CertificationApplicationID int    `json:"certification_application_id"`
+	ProgramID                  int    `json:"program_id"`
+	ProgramTitle               string `json:"program_title"`
+	BusinessName               string `json:"business_name"`
+	OwnerName                  string `json:"owner_name"`
+	CertificateNumber          string `json:"certificate_number"`
+	IssuingBody                string `json:"issuing_body"`
+	IssuedDate                 string `json:"issued_date"`
+	ExpiryDate                 string `json:"expiry_date,omitempty"`
+	// DaysUntilExpiry is negative once expired and absent for certificates that do not expire
+	DaysUntilExpiry  *int   `json:"days_until_expiry,omitempty"`
+	Status           string `json:"status"`
+	DocumentURL      string `json:"document_url"`
+	VerificationCode string `json:"verification_code"`
+	QRCode           string `json:"qr_code"`
+}
+
+// CertificationRequest records the certificate issued for a certification
+// application. Document is a base64 scan, required when recording.
+type CertificationRequest struct {
+	CertificateNumber string `json:"certificate_number"`
+	IssuedDate        string `json:"issued_date"`
+	ExpiryDate        string `json:"expiry_date"`
+	Document          string `json:"document"`
+}
+
+type CertificationQueryParams struct {
+	Status string `query:"status"`
+}
+
+// CertificationVerification is the public answer for a certificate number or
+// QR code.
+type CertificationVerification struct {
+	Valid             bool   `json:"valid"`
+	Status            string `json:"status"`
+	CertificateNumber string `json:"certificate_number"`
+	CertificationName string `json:"certification_name"`
+	IssuingBody       string `json:"issuing_body"`
+	BusinessName      string `json:"business_name"`
+	IssuedDate        string `json:"issued_date"`
+	ExpiryDate        string `json:"expiry_date,omitempty"`
+}
@@ -10,6 +10,7 @@ type ApplicationStatusSummary struct {
 	InProcess         int64 `json:"in_process,omitempty"`
 	Approved          int64 `json:"approved,omitempty"`
 	Rejected          int64 `json:"rejected,omitempty"`
+	Overdue           int64 `json:"overdue,omitempty"`
 }
 
 type ApplicationStatusDetail struct {
@@ -0,0 +1,80 @@
+package dto
+
+// KartuVerification is the public answer for a scanned kartu QR code. The
+// kartu number is masked and nothing else about the owner is disclosed; only
+// an active kartu is valid.
+type KartuVerification struct {
+	Valid        bool   `json:"valid"`
+	Status       string `json:"status"`
+	BusinessName string `json:"business_name"`
+	KartuType    string `json:"kartu_type"`
+	KartuNumber  string `json:"kartu_number"`
+	City         string `json:"city"`
+	Province     string `json:"province"`
+}
+
+// KartuQRCode is a freshly issued kartu QR code; tokens of older versions no
+// longer resolve.
+type KartuQRCode struct {
+	UMKMID  int    `json:"umkm_id"`
+	Version int    `json:"version"`
+	QRCode  string `json:"qr_code"`
+}
+
+// Kartu is a UMKM's kartu with its status as of today. Status is active,
+// suspended or expired; an active kartu past its expiry date is expired.
+type Kartu struct {
+	UMKMID          int            `json:"umkm_id"`
+	BusinessName    string         `json:"business_name"`
+	OwnerName       string         `json:"owner_name"`
+	KartuType       string         `json:"kartu_type"`
+	Status          string         `json:"status"`
+	IssuedDate      string         `json:"issued_date,omitempty"`
+	ExpiryDate      string         `json:"expiry_date,omitempty"`
+	DaysUntilExpiry *int           `json:"days_until_expiry,omitempty"`
+	CanRenew        bool           `json:"can_renew"`
+	QRCode          string         `json:"qr_code"`
+	PendingRenewal  *KartuRenewal  `json:"pending_renewal,omitempty"`
+	History         []KartuHistory `json:"history"`
+}
+
+type KartuHistory struct {
+	ID             int    `json:"id"`
+	Action         string `json:"action"`
+	FromType       string `json:"from_type"`
+	ToType         string `json:"to_type"`
+	FromStatus     string `json:"from_status"`
+	ToStatus       string `json:"to_status"`
+	FromExpiryDate string `json:"from_expiry_date,omitempty"`
+	ToExpiryDate   string `json:"to_expiry_date,omitempty"`
+	Notes          string `json:"notes"`
+	ActionedBy     string `json:"actioned_by"`
+	ActionedAt     string `json:"actioned_at"`
+}
+
+type KartuRenewal struct {
+	ID           int    `json:"id"`
+	UMKMID       int    `json:"umkm_id"`
+	BusinessName string `json:"business_name,omitempty"`
+	KartuType    string `json:"kartu_type,omitempty"`
+	ExpiryDate   string `json:"expiry_date,omitempty"`
+	Status       string `json:"status"`
+	Notes        string `json:"notes"`
+	ReviewNotes  string `json:"review_notes,omitempty"`
+	ReviewedAt   string `json:"reviewed_at,omitempty"`
+	CreatedAt    string `json:"created_at"`
+}
+
+// KartuActionRequest carries the admin's reason for a kartu change or renewal
+// review. It is required to suspend a kartu or reject a renewal.
+type KartuActionRequest struct {
+	Notes string `json:"notes"`
+}
+
+type KartuRenewalRequest struct {
+	Notes string `json:"notes"`
+}
+
+type KartuRenewalQueryParams struct {
+	Status string `query:"status"`
+}
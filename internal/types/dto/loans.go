@@ -0,0 +1,84 @@
+package dto
+
+// Loan is the repayment schedule of an approved funding application with its
+// running balance. Amounts are in rupiah.
+type Loan struct {
+	ID                  int               `json:"id"`
+	ApplicationID       int               `json:"application_id"`
+	BusinessName        string            `json:"business_name,omitempty"`
+	ProgramTitle        string            `json:"program_title,omitempty"`
+	Principal           float64           `json:"principal"`
+	InterestRate        float64           `json:"interest_rate"`
+	InterestMethod      string            `json:"interest_method"`
+	TenureMonths        int               `json:"tenure_months"`
+	TotalInterest       float64           `json:"total_interest"`
+	TotalPayable        float64           `json:"total_payable"`
+	PaidAmount          float64           `json:"paid_amount"`
+	OutstandingBalance  float64           `json:"outstanding_balance"`
+	OverdueAmount       float64           `json:"overdue_amount"`
+	OverdueInstallments int               `json:"overdue_installments"`
+	NextDueDate         string            `json:"next_due_date,omitempty"`
+	Status              string            `json:"status"`
+	StartDate           string            `json:"start_date"`
+	Installments        []LoanInstallment `json:"installments,omitempty"`
+	Repayments          []LoanRepayment   `json:"repayments,omitempty"`
+}
+
+type LoanInstallment struct {
+	ID          int     `json:"id,omitempty"`
+	Number      int     `json:"number"`
+	DueDate     string  `json:"due_date"`
+	Principal   float64 `json:"principal"`
+	Interest    float64 `json:"interest"`
+	Amount      float64 `json:"amount"`
+	Balance     float64 `json:"balance"` // principal still owed after this installment
+	PaidAmount  float64 `json:"paid_amount"`
+	Remaining   float64 `json:"remaining"`
+	Status      string  `json:"status"`
+	DaysOverdue int     `json:"days_overdue,omitempty"`
+	PaidAt      string  `json:"paid_at,omitempty"`
+}
+
+type LoanRepayment struct {
+	ID             int     `json:"id"`
+	InstallmentID  int     `json:"installment_id"`
+	Amount         float64 `json:"amount"`
+	PaidAt         string  `json:"paid_at"`
+	Reference      string  `json:"reference,omitempty"`
+	Notes          string  `json:"notes,omitempty"`
+	RecordedBy     int     `json:"recorded_by"`
+	RecordedByName string  `json:"recorded_by_name,omitempty"`
+}
+
+type LoanRepaymentRequest struct {
+	InstallmentID int     `json:"installment_id" validate:"required"`
+	Amount        float64 `json:"amount" validate:"required,gt=0"`
+	PaidAt        string  `json:"paid_at,omitempty"` // YYYY-MM-DD, defaults to today
+	Reference     string  `json:"reference,omitempty"`
+	Notes         string  `json:"notes,omitempty"`
+}
+
+type LoanQueryParams struct {
+	Status  string `query:"status"`
+	Overdue bool   `query:"overdue"`
+}
+
+type FundingSimulationRequest struct {
+	RequestedAmount       float64 `json:"requested_amount" validate:"required"`
+	RequestedTenureMonths int     `json:"requested_tenure_months" validate:"required"`
+}
+
+// FundingSimulation is the repayment schedule a funding application would get if
+// approved today.
+type FundingSimulation struct {
+	ProgramID          int               `json:"program_id"`
+	ProgramTitle       string            `json:"program_title"`
+	Principal          float64           `json:"principal"`
+	InterestRate       float64           `json:"interest_rate"`
+	InterestMethod     string            `json:"interest_method"`
+	TenureMonths       int               `json:"tenure_months"`
+	MonthlyInstallment float64           `json:"monthly_installment"`
+	TotalInterest      float64           `json:"total_interest"`
+	TotalPayable       float64           `json:"total_payable"`
+	Installments       []LoanInstallment `json:"installments"`
+}
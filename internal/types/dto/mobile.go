@@ -2,24 +2,42 @@ package dto
 
 // Program List Response
 type ProgramListMobile struct {
-	ID                  int      `json:"id"`
-	Title               string   `json:"title"`
-	Description         string   `json:"description"`
-	Banner              string   `json:"banner"`
-	Provider            string   `json:"provider"`
-	ProviderLogo        string   `json:"provider_logo"`
-	Type                string   `json:"type"`
-	TrainingType        *string  `json:"training_type,omitempty"`
-	Batch               *int     `json:"batch,omitempty"`
-	BatchStartDate      *string  `json:"batch_start_date,omitempty"`
-	BatchEndDate        *string  `json:"batch_end_date,omitempty"`
-	Location            *string  `json:"location,omitempty"`
-	MinAmount           *float64 `json:"min_amount,omitempty"`
-	MaxAmount           *float64 `json:"max_amount,omitempty"`
-	InterestRate        *float64 `json:"interest_rate,omitempty"`
-	MaxTenureMonths     *int     `json:"max_tenure_months,omitempty"`
-	ApplicationDeadline string   `json:"application_deadline"`
-	IsActive            bool     `json:"is_active"`
+	ID                   int      `json:"id"`
+	Title                string   `json:"title"`
+	Description          string   `json:"description"`
+	Banner               string   `json:"banner"`
+	Provider             string   `json:"provider"`
+	ProviderLogo         string   `json:"provider_logo"`
+	Type                 string   `json:"type"`
+	TrainingType         *string  `json:"training_type,omitempty"`
+	Batch                *int     `json:"batch,omitempty"`
+	BatchStartDate       *string  `json:"batch_start_date,omitempty"`
+	BatchEndDate         *string  `json:"batch_end_date,omitempty"`
+	Location             *string  `json:"location,omitempty"`
+	MinAmount            *float64 `json:"min_amount,omitempty"`
+	MaxAmount            *float64 `json:"max_amount,omitempty"`
+	InterestRate         *float64 `json:"interest_rate,omitempty"`
+	InterestMethod       *string  `json:"interest_method,omitempty"`
+	MinAttendancePercent *int     `json:"min_attendance_percent,omitempty"`
+	MaxTenureMonths      *int     `json:"max_tenure_months,omitempty"`
+	Capacity             *int     `json:"capacity,omitempty"`
+	RemainingSeats       *int     `json:"remaining_seats,omitempty"`
+	FundPool             *float64 `json:"fund_pool,omitempty"`
+	RemainingFunds       *float64 `json:"remaining_funds,omitempty"`
+	ApplicationDeadline  string   `json:"application_deadline"`
+	IsActive             bool     `json:"is_active"`
+
+	IsOpenForApplication bool                  `json:"is_open_for_application"`
+	ClosesAt             *string               `json:"closes_at,omitempty"`
+	ClosesInSeconds      *int64                `json:"closes_in_seconds,omitempty"`
+	ClosedReasons        []ProgramClosedReason `json:"closed_reasons,omitempty"`
+	Eligibility          *EligibilityResult    `json:"eligibility,omitempty"`
+}
+
+// ProgramClosedReason explains why a program does not accept applications
+type ProgramClosedReason struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
 }
 
 // Program Detail Response
@@ -76,6 +94,18 @@ type UploadDocumentRequest struct {
 	Document string `json:"document" validate:"required"`
 }
 
+// Revise Application Request carries the replacement documents and corrected
+// form fields of an application sent back for revision
+type ReviseApplicationRequest struct {
+	Documents []UploadDocumentRequest `json:"documents,omitempty"`
+	Fields    ApplicationFormFields   `json:"fields"`
+}
+
+// Withdraw Application Request
+type WithdrawApplicationRequest struct {
+	Reason string `json:"reason,omitempty" validate:"max=500"`
+}
+
 // Create Application Request - Training
 type CreateApplicationTraining struct {
 	ProgramID          int               `json:"program_id" validate:"required"`
@@ -114,6 +144,62 @@ type CreateApplicationFunding struct {
 	Documents             map[string]string `json:"documents" validate:"required"`
 }
 
+// ApplicationFormFields holds the form fields of every application type. All are
+// optional and only the fields sent are changed. Fields that do not apply to the
+// application's program type are ignored.
+type ApplicationFormFields struct {
+	// Training
+	Motivation         *string `json:"motivation,omitempty"`
+	BusinessExperience *string `json:"business_experience,omitempty"`
+	LearningObjectives *string `json:"learning_objectives,omitempty"`
+	AvailabilityNotes  *string `json:"availability_notes,omitempty"`
+
+	// Certification and funding
+	BusinessSector      *string `json:"business_sector,omitempty"`
+	BusinessDescription *string `json:"business_description,omitempty"`
+	YearsOperating      *int    `json:"years_operating,omitempty"`
+
+	// Certification
+	ProductOrService   *string `json:"product_or_service,omitempty"`
+	CurrentStandards   *string `json:"current_standards,omitempty"`
+	CertificationGoals *string `json:"certification_goals,omitempty"`
+
+	// Funding
+	RequestedAmount       *float64 `json:"requested_amount,omitempty"`
+	FundPurpose           *string  `json:"fund_purpose,omitempty"`
+	BusinessPlan          *string  `json:"business_plan,omitempty"`
+	RevenueProjection     *float64 `json:"revenue_projection,omitempty"`
+	MonthlyRevenue        *float64 `json:"monthly_revenue,omitempty"`
+	RequestedTenureMonths *int     `json:"requested_tenure_months,omitempty"`
+	CollateralDescription *string  `json:"collateral_description,omitempty"`
+}
+
+// ApplicationDraftFields is what a draft save carries, so a long form can be
+// saved step by step.
+type ApplicationDraftFields struct {
+	ApplicationFormFields
+
+	// Documents replaces the listed document types only
+	Documents map[string]string `json:"documents,omitempty"`
+}
+
+// Create Application Draft Request
+type CreateApplicationDraft struct {
+	ProgramID int `json:"program_id" validate:"required"`
+	ApplicationDraftFields
+}
+
+type DraftValidationIssue struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// DraftValidationResult lists what still blocks a draft from being submitted.
+type DraftValidationResult struct {
+	Valid  bool                   `json:"valid"`
+	Issues []DraftValidationIssue `json:"issues"`
+}
+
 // Application List Response
 type ApplicationListMobile struct {
 	ID          int    `json:"id"`
@@ -134,6 +220,8 @@ type ApplicationDetailMobile struct {
 	Status            string                        `json:"status"`
 	SubmittedAt       string                        `json:"submitted_at"`
 	ExpiredAt         string                        `json:"expired_at"`
+	RevisionFields    []string                      `json:"revision_fields,omitempty"`
+	WaitlistPosition  *int                          `json:"waitlist_position,omitempty"`
 	Documents         []ApplicationDocuments        `json:"documents"`
 	Histories         []ApplicationHistories        `json:"histories"`
 	Program           ProgramDetailMobile           `json:"program"`
@@ -1,30 +1,74 @@
 package dto
 
 type Programs struct {
-	ID                  int      `json:"id,omitempty"`
-	Title               string   `json:"title" validate:"required"`
-	Description         string   `json:"description,omitempty"`
-	Banner              string   `json:"banner,omitempty"`
-	Provider            string   `json:"provider,omitempty"`
-	ProviderLogo        string   `json:"provider_logo,omitempty"`
-	Type                string   `json:"type" validate:"required,oneof=training certification funding"`
-	TrainingType        *string  `json:"training_type,omitempty" validate:"omitempty,oneof=online offline hybrid"`
-	Batch               *int     `json:"batch,omitempty"`
-	BatchStartDate      *string  `json:"batch_start_date,omitempty"`
-	BatchEndDate        *string  `json:"batch_end_date,omitempty"`
-	Location            *string  `json:"location,omitempty"`
-	MinAmount           *float64 `json:"min_amount,omitempty"`
-	MaxAmount           *float64 `json:"max_amount,omitempty"`
-	InterestRate        *float64 `json:"interest_rate,omitempty"`
-	MaxTenureMonths     *int     `json:"max_tenure_months,omitempty"`
-	ApplicationDeadline string   `json:"application_deadline" validate:"required"`
-	IsActive            bool     `json:"is_active"`
-	CreatedBy           int      `json:"created_by,omitempty"`
-	CreatedByName       string   `json:"created_by_name,omitempty"`
-	CreatedAt           string   `json:"created_at,omitempty"`
-	UpdatedAt           string   `json:"updated_at,omitempty"`
-	Benefits            []string `json:"benefits,omitempty"`
-	Requirements        []string `json:"requirements,omitempty"`
+	ID                   int               `json:"id,omitempty"`
+	Title                string            `json:"title" validate:"required"`
+	Description          string            `json:"description,omitempty"`
+	Banner               string            `json:"banner,omitempty"`
+	Provider             string            `json:"provider,omitempty"`
+	ProviderLogo         string            `json:"provider_logo,omitempty"`
+	Type                 string            `json:"type" validate:"required,oneof=training certification funding"`
+	TrainingType         *string           `json:"training_type,omitempty" validate:"omitempty,oneof=online offline hybrid"`
+	Batch                *int              `json:"batch,omitempty"`
+	BatchStartDate       *string           `json:"batch_start_date,omitempty"`
+	BatchEndDate         *string           `json:"batch_end_date,omitempty"`
+	Location             *string           `json:"location,omitempty"`
+	MinAmount            *float64          `json:"min_amount,omitempty"`
+	MaxAmount            *float64          `json:"max_amount,omitempty"`
+	InterestRate         *float64          `json:"interest_rate,omitempty"`
+	InterestMethod       *string           `json:"interest_method,omitempty"`
+	MinAttendancePercent *int              `json:"min_attendance_percent,omitempty"`
+	MaxTenureMonths      *int              `json:"max_tenure_months,omitempty"`
+	Capacity             *int              `json:"capacity,omitempty"`
+	FundPool             *float64          `json:"fund_pool,omitempty"`
+	EligibilityRules     *EligibilityRules `json:"eligibility_rules,omitempty"`
+	ApplicationDeadline  string            `json:"application_deadline" validate:"required"`
+	IsActive             bool              `json:"is_active"`
+	CreatedBy            int               `json:"created_by,omitempty"`
+	CreatedByName        string            `json:"created_by_name,omitempty"`
+	CreatedAt            string            `json:"created_at,omitempty"`
+	UpdatedAt            string            `json:"updated_at,omitempty"`
+	Benefits             []string          `json:"benefits,omitempty"`
+	Requirements         []string          `json:"requirements,omitempty"`
+}
+
+// EligibilityRules are the structured conditions an applicant must meet. Empty
+// fields impose no condition.
+type EligibilityRules struct {
+	KartuTypes        []string `json:"kartu_types,omitempty"`
+	ProvinceIDs       []int    `json:"province_ids,omitempty"`
+	CityIDs           []int    `json:"city_ids,omitempty"`
+	Genders           []string `json:"genders,omitempty"`
+	MinYearsOperating *int     `json:"min_years_operating,omitempty"`
+	MinMonthlyRevenue *float64 `json:"min_monthly_revenue,omitempty"`
+	MaxMonthlyRevenue *float64 `json:"max_monthly_revenue,omitempty"`
+	MinAge            *int     `json:"min_age,omitempty"`
+	MaxAge            *int     `json:"max_age,omitempty"`
+	RequiredDocuments []string `json:"required_documents,omitempty"`
+}
+
+// EligibilityCheck is the outcome of one eligibility rule. Pending rules depend on
+// the application form and are only decided at submission.
+type EligibilityCheck struct {
+	Rule    string `json:"rule"`
+	Met     bool   `json:"met"`
+	Pending bool   `json:"pending,omitempty"`
+	Message string `json:"message"`
+}
+
+// EligibilityResult is the outcome of a program's eligibility rules for one applicant
+type EligibilityResult struct {
+	Eligible    bool               `json:"eligible"`
+	Checks      []EligibilityCheck `json:"checks"`
+	UnmetRules  []EligibilityCheck `json:"unmet_rules"`
+	EvaluatedAt string             `json:"evaluated_at"`
+}
+
+// ProgramUsage is the share of a program's capacity taken by approved applications.
+type ProgramUsage struct {
+	ProgramID      int     `json:"program_id"`
+	ApprovedCount  int64   `json:"approved_count"`
+	ApprovedAmount float64 `json:"approved_amount"`
 }
 
 type ProgramBenefits struct {
@@ -1,15 +1,51 @@
 package dto
 
 type SLA struct {
-	ID          int    `json:"id,omitempty"`
-	Status      string `json:"status" validate:"required,oneof=screening final"`
-	MaxDays     int    `json:"max_days" validate:"required,min=1"`
-	Description string `json:"description,omitempty"`
-	CreatedAt   string `json:"created_at,omitempty"`
-	UpdatedAt   string `json:"updated_at,omitempty"`
+	ID              int    `json:"id,omitempty"`
+	Status          string `json:"status" validate:"required,oneof=screening final"`
+	ApplicationType string `json:"application_type,omitempty" validate:"omitempty,oneof=training certification funding"`
+	ProgramID       *int   `json:"program_id,omitempty"`
+	MaxDays         int    `json:"max_days" validate:"required,min=1"`
+	DayType         string `json:"day_type" validate:"omitempty,oneof=calendar working"`
+	EffectiveFrom   string `json:"effective_from,omitempty"` // YYYY-MM-DD HH:mm:ss, defaults to now
+	Description     string `json:"description,omitempty"`
+	CreatedBy       *int   `json:"created_by,omitempty"`
+	CreatedAt       string `json:"created_at,omitempty"`
+	UpdatedAt       string `json:"updated_at,omitempty"`
+}
+
+type SLAChangeLog struct {
+	ID            int            `json:"id"`
+	SLAID         int            `json:"sla_id"`
+	Action        string         `json:"action"`
+	OldValue      map[string]any `json:"old_value"`
+	NewValue      map[string]any `json:"new_value"`
+	ChangedBy     *int           `json:"changed_by"`
+	ChangedByName string         `json:"changed_by_name"`
+	CreatedAt     string         `json:"created_at"`
 }
 
 type ExportRequest struct {
 	FileType        string `json:"file_type" validate:"required,oneof=pdf excel"`
 	ApplicationType string `json:"application_type" validate:"required,oneof=all funding training certification"`
 }
+
+type SLABreachReport struct {
+	Policy    string   `json:"policy"`
+	CheckedAt string   `json:"checked_at"`
+	Breached  int      `json:"breached"`
+	Escalated int      `json:"escalated"`
+	Rejected  int      `json:"rejected"`
+	Failed    int      `json:"failed"`
+	Errors    []string `json:"errors,omitempty"`
+}
+
+type Holiday struct {
+	ID                int    `json:"id,omitempty"`
+	Date              string `json:"date" validate:"required"` // YYYY-MM-DD
+	Name              string `json:"name" validate:"required"`
+	IsCollectiveLeave bool   `json:"is_collective_leave"`
+	Description       string `json:"description,omitempty"`
+	CreatedAt         string `json:"created_at,omitempty"`
+	UpdatedAt         string `json:"updated_at,omitempty"`
+}
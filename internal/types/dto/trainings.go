@@ -0,0 +1,106 @@
+package dto
+
+// TrainingSession is one scheduled meeting of a training program. Times are
+// "YYYY-MM-DD HH:mm" local time.
+type TrainingSession struct {
+	ID           int     `json:"id"`
+	ProgramID    int     `json:"program_id"`
+	Title        string  `json:"title"`
+	Mode         string  `json:"mode"`
+	StartsAt     string  `json:"starts_at"`
+	EndsAt       string  `json:"ends_at"`
+	Location     *string `json:"location,omitempty"`
+	MeetingURL   *string `json:"meeting_url,omitempty"`
+	PresentCount int     `json:"present_count"`
+}
+
+type TrainingSessionRequest struct {
+	Title      string  `json:"title" validate:"required"`
+	Mode       string  `json:"mode"` // online or offline; defaults to the program's training type
+	StartsAt   string  `json:"starts_at" validate:"required"`
+	EndsAt     string  `json:"ends_at" validate:"required"`
+	Location   *string `json:"location,omitempty"`
+	MeetingURL *string `json:"meeting_url,omitempty"`
+}
+
+type SessionAttendance struct {
+	Session      TrainingSession         `json:"session"`
+	Participants []ParticipantAttendance `json:"participants"`
+}
+
+// ParticipantAttendance is a participant's record for one session. Status is
+// empty until attendance is recorded.
+type ParticipantAttendance struct {
+	ApplicationID int    `json:"application_id"`
+	BusinessName  string `json:"business_name"`
+	OwnerName     string `json:"owner_name"`
+	Status        string `json:"status"`
+	CheckedInAt   string `json:"checked_in_at,omitempty"`
+	Notes         string `json:"notes,omitempty"`
+}
+
+type AttendanceRequest struct {
+	Records []AttendanceRecord `json:"records" validate:"required"`
+}
+
+type AttendanceRecord struct {
+	ApplicationID int    `json:"application_id" validate:"required"`
+	Status        string `json:"status" validate:"required"` // present, absent or excused
+	Notes         string `json:"notes,omitempty"`
+}
+
+// CheckInRequest carries what was scanned from the QR code on a UMKM card.
+type CheckInRequest struct {
+	QRData string `json:"qr_data" validate:"required"`
+}
+
+type CheckInResult struct {
+	SessionID        int    `json:"session_id"`
+	ApplicationID    int    `json:"application_id"`
+	BusinessName     string `json:"business_name"`
+	OwnerName        string `json:"owner_name"`
+	CheckedInAt      string `json:"checked_in_at"`
+	AlreadyCheckedIn bool   `json:"already_checked_in"`
+}
+
+// TrainingParticipant is the attendance progress of an approved training
+// application. Completed is set once every session is recorded and the
+// attendance reaches the program minimum.
+type TrainingParticipant struct {
+	ApplicationID        int                  `json:"application_id"`
+	BusinessName         string               `json:"business_name"`
+	OwnerName            string               `json:"owner_name"`
+	SessionsTotal        int                  `json:"sessions_total"`
+	SessionsRecorded     int                  `json:"sessions_recorded"`
+	SessionsPresent      int                  `json:"sessions_present"`
+	AttendancePercent    float64              `json:"attendance_percent"`
+	MinAttendancePercent int                  `json:"min_attendance_percent"`
+	Completed            bool                 `json:"completed"`
+	Certificate          *TrainingCertificate `json:"certificate,omitempty"`
+}
+
+type TrainingCertificate struct {
+	ID                int     `json:"id"`
+	ApplicationID     int     `json:"application_id"`
+	ProgramID         int     `json:"program_id"`
+	ProgramTitle      string  `json:"program_title"`
+	Provider          string  `json:"provider"`
+	RecipientName     string  `json:"recipient_name"`
+	BusinessName      string  `json:"business_name"`
+	CertificateNumber string  `json:"certificate_number"`
+	VerificationCode  string  `json:"verification_code"`
+	AttendancePercent float64 `json:"attendance_percent"`
+	FileURL           string  `json:"file_url"`
+	IssuedAt          string  `json:"issued_at"`
+}
+
+// CertificateVerification is the public answer for a verification code.
+type CertificateVerification struct {
+	Valid             bool   `json:"valid"`
+	CertificateNumber string `json:"certificate_number"`
+	RecipientName     string `json:"recipient_name"`
+	BusinessName      string `json:"business_name"`
+	ProgramTitle      string `json:"program_title"`
+	Provider          string `json:"provider"`
+	IssuedAt          string `json:"issued_at"`
+}
@@ -0,0 +1,19 @@
+package model
+
+import "time"
+
+type AdminNotification struct {
+	ID            int        `json:"id" gorm:"primary_key"`
+	UserID        int        `json:"user_id" gorm:"not null"`
+	ApplicationID *int       `json:"application_id"`
+	Type          string     `json:"type" gorm:"type:varchar(50);not null"`
+	Title         string     `json:"title" gorm:"type:varchar(255);not null"`
+	Message       string     `json:"message" gorm:"type:text;not null"`
+	IsRead        bool       `json:"is_read" gorm:"default:false"`
+	ReadAt        *time.Time `json:"read_at"`
+	Metadata      string     `json:"metadata" gorm:"type:jsonb"` // Store as JSON string
+	Base
+
+	User        User         `json:"user" gorm:"foreignKey:UserID"`
+	Application *Application `json:"application" gorm:"foreignKey:ApplicationID"`
+}
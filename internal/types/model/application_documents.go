@@ -1,9 +1,21 @@
 package model
 
+import "time"
+
 type ApplicationDocument struct {
-	ID            int    `json:"id" gorm:"primary_key"`
-	ApplicationID int    `json:"application_id" gorm:"not null"`
-	Type          string `json:"type" gorm:"type:document_type;not null"`
-	File          string `json:"file" gorm:"type:text;not null"`
+	ID            int        `json:"id" gorm:"primary_key"`
+	ApplicationID int        `json:"application_id" gorm:"not null"`
+	Type          string     `json:"type" gorm:"type:document_type;not null"`
+	File          string     `json:"file" gorm:"type:text;not null"`
+	ReviewStatus  string     `json:"review_status" gorm:"type:document_review_status;not null;default:'pending'"`
+	ReviewReason  string     `json:"review_reason" gorm:"type:text"`
+	ReviewedBy    *int       `json:"reviewed_by"`
+	ReviewedAt    *time.Time `json:"reviewed_at"`
+	// Every upload is a new version; the one replaced is kept and marked superseded
+	Version       int        `json:"version" gorm:"not null;default:1"`
+	PreviousID    *int       `json:"previous_id"`
+	RevisionRound int        `json:"revision_round" gorm:"not null;default:0"`
+	SupersededAt  *time.Time `json:"superseded_at"`
+	PurgedAt      *time.Time `json:"purged_at"`
 	Base
 }
@@ -9,6 +9,7 @@ type ApplicationHistory struct {
 	Notes         string    `json:"notes" gorm:"type:text"`
 	ActionedAt    time.Time `json:"actioned_at" gorm:"default:NOW()"`
 	ActionedBy    *int      `json:"actioned_by" gorm:"not null"`
+	Changes       *string   `json:"changes" gorm:"type:jsonb"`
 	Base
 
 	User User `json:"user" gorm:"foreignKey:ActionedBy"`
@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+// ApplicationScore is a reviewer's score of an application on one rubric criterion.
+type ApplicationScore struct {
+	ID            int       `json:"id" gorm:"primary_key"`
+	ApplicationID int       `json:"application_id" gorm:"not null"`
+	CriterionID   int       `json:"criterion_id" gorm:"not null"`
+	Score         float64   `json:"score" gorm:"type:numeric(7,2);not null"`
+	Notes         string    `json:"notes" gorm:"type:text"`
+	ScoredBy      int       `json:"scored_by" gorm:"not null"`
+	ScoredAt      time.Time `json:"scored_at" gorm:"not null;default:NOW()"`
+	Base
+
+	User User `json:"user" gorm:"foreignKey:ScoredBy"`
+}
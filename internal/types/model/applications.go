@@ -3,17 +3,32 @@ package model
 import "time"
 
 type Application struct {
-	ID          int       `json:"id" gorm:"primary_key"`
-	UMKMID      int       `json:"umkm_id" gorm:"not null"`
-	ProgramID   int       `json:"program_id" gorm:"not null"`
-	Type        string    `json:"type" gorm:"type:application_type;not null"`
-	Status      string    `json:"status" gorm:"type:application_status;not null;default:'screening'"`
-	SubmittedAt time.Time `json:"submitted_at" gorm:"not null;default:NOW()"`
-	ExpiredAt   time.Time `json:"expired_at" gorm:"not null"`
+	ID          int        `json:"id" gorm:"primary_key"`
+	UMKMID      int        `json:"umkm_id" gorm:"not null"`
+	ProgramID   int        `json:"program_id" gorm:"not null"`
+	Type        string     `json:"type" gorm:"type:application_type;not null"`
+	Status      string     `json:"status" gorm:"type:application_status;not null;default:'screening'"`
+	SubmittedAt time.Time  `json:"submitted_at" gorm:"not null;default:NOW()"`
+	ExpiredAt   time.Time  `json:"expired_at" gorm:"not null"`
+	IsOverdue   bool       `json:"is_overdue" gorm:"not null;default:false"`
+	OverdueAt   *time.Time `json:"overdue_at"`
+	EscalatedAt *time.Time `json:"escalated_at"`
+	Version     int        `json:"version" gorm:"not null;default:1"`
+	// RevisionFields lists the form fields flagged by the last revision request, comma separated
+	RevisionFields string `json:"revision_fields" gorm:"type:text;not null;default:''"`
+	// RevisionRound counts the resubmissions after a revision request
+	RevisionRound int `json:"revision_round" gorm:"not null;default:0"`
+	// WaitlistedAt orders the program's waitlist
+	WaitlistedAt *time.Time `json:"waitlisted_at"`
+	// Eligibility is the JSON-encoded dto.EligibilityResult of the last submission
+	Eligibility *string `json:"eligibility" gorm:"type:jsonb"`
+	// ScoreTotal is the weighted rubric score out of 100, set once every criterion is scored
+	ScoreTotal *float64 `json:"score_total" gorm:"type:numeric(5,2)"`
 	Base
 
 	Documents                []ApplicationDocument     `json:"documents" gorm:"foreignKey:ApplicationID"`
 	Histories                []ApplicationHistory      `json:"histories" gorm:"foreignKey:ApplicationID"`
+	Scores                   []ApplicationScore        `json:"scores" gorm:"foreignKey:ApplicationID"`
 	Program                  Program                   `json:"program" gorm:"foreignKey:ProgramID"`
 	UMKM                     UMKM                      `json:"umkm" gorm:"foreignKey:UMKMID"`
 	TrainingApplication      *TrainingApplication      `json:"training_application,omitempty" gorm:"foreignKey:ApplicationID"`
@@ -0,0 +1,23 @@
+package model
+
+import "time"
+
+// Certification is the certificate a UMKM earned through an approved
+// certification application, recorded once the provider has issued it.
+type Certification struct {
+	ID                         int        `json:"id" gorm:"primary_key"`
+	CertificationApplicationID int        `json:"certification_application_id" gorm:"not null;unique"`
+	CertificateNumber          string     `json:"certificate_number" gorm:"type:varchar(100);not null;unique"`
+	IssuingBody                string     `json:"issuing_body" gorm:"type:varchar(255);not null"`
+	IssuedDate                 time.Time  `json:"issued_date" gorm:"type:date;not null"`
+	ExpiryDate                 *time.Time `json:"expiry_date" gorm:"type:date"`
+	DocumentURL                string     `json:"document_url" gorm:"type:varchar(255);not null"`
+	VerificationCode           string     `json:"verification_code" gorm:"type:varchar(32);not null;unique"`
+	QRCode                     string     `json:"qr_code" gorm:"type:varchar(255)"`
+	// RemindedDays is the last expiry reminder sent, in days before expiry
+	RemindedDays *int `json:"reminded_days"`
+	RecordedBy   int  `json:"recorded_by" gorm:"not null"`
+	Base
+
+	CertificationApplication CertificationApplication `json:"certification_application" gorm:"foreignKey:CertificationApplicationID"`
+}
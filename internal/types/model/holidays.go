@@ -0,0 +1,12 @@
+package model
+
+import "time"
+
+type Holiday struct {
+	ID                int       `json:"id" gorm:"primary_key"`
+	Date              time.Time `json:"date" gorm:"type:date;not null"`
+	Name              string    `json:"name" gorm:"type:varchar(255);not null"`
+	IsCollectiveLeave bool      `json:"is_collective_leave" gorm:"default:false"` // cuti bersama
+	Description       string    `json:"description" gorm:"type:text"`
+	Base
+}
@@ -0,0 +1,37 @@
+package model
+
+import "time"
+
+// KartuHistory records a change to a UMKM's kartu after it was issued.
+type KartuHistory struct {
+	ID             int        `json:"id" gorm:"primary_key"`
+	UMKMID         int        `json:"umkm_id" gorm:"not null"`
+	Action         string     `json:"action" gorm:"type:kartu_history_action;not null"`
+	FromType       string     `json:"from_type" gorm:"type:card_type"`
+	ToType         string     `json:"to_type" gorm:"type:card_type"`
+	FromStatus     string     `json:"from_status" gorm:"type:kartu_status"`
+	ToStatus       string     `json:"to_status" gorm:"type:kartu_status"`
+	FromExpiryDate *time.Time `json:"from_expiry_date" gorm:"type:date"`
+	ToExpiryDate   *time.Time `json:"to_expiry_date" gorm:"type:date"`
+	Notes          string     `json:"notes" gorm:"type:text"`
+	// ActionedBy is nil for changes made by the system, e.g. expiry
+	ActionedBy *int      `json:"actioned_by"`
+	ActionedAt time.Time `json:"actioned_at" gorm:"default:NOW()"`
+	Base
+
+	User *User `json:"user" gorm:"foreignKey:ActionedBy"`
+}
+
+// KartuRenewal is a UMKM's request to extend its kartu, reviewed by an admin.
+type KartuRenewal struct {
+	ID          int        `json:"id" gorm:"primary_key"`
+	UMKMID      int        `json:"umkm_id" gorm:"not null"`
+	Status      string     `json:"status" gorm:"type:kartu_renewal_status;not null;default:'pending'"`
+	Notes       string     `json:"notes" gorm:"type:text"`
+	ReviewNotes string     `json:"review_notes" gorm:"type:text"`
+	ReviewedBy  *int       `json:"reviewed_by"`
+	ReviewedAt  *time.Time `json:"reviewed_at"`
+	Base
+
+	UMKM UMKM `json:"umkm" gorm:"foreignKey:UMKMID"`
+}
@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+type LoanInstallment struct {
+	ID         int        `json:"id" gorm:"primary_key"`
+	LoanID     int        `json:"loan_id" gorm:"not null"`
+	Number     int        `json:"number" gorm:"not null"`
+	DueDate    time.Time  `json:"due_date" gorm:"type:date;not null"`
+	Principal  float64    `json:"principal" gorm:"type:numeric(15,2);not null"`
+	Interest   float64    `json:"interest" gorm:"type:numeric(15,2);not null"`
+	Amount     float64    `json:"amount" gorm:"type:numeric(15,2);not null"`
+	PaidAmount float64    `json:"paid_amount" gorm:"type:numeric(15,2);not null;default:0"`
+	Status     string     `json:"status" gorm:"type:loan_installment_status;not null;default:'unpaid'"`
+	PaidAt     *time.Time `json:"paid_at"`
+	Base
+}
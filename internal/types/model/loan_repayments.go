@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+type LoanRepayment struct {
+	ID            int       `json:"id" gorm:"primary_key"`
+	LoanID        int       `json:"loan_id" gorm:"not null"`
+	InstallmentID int       `json:"installment_id" gorm:"not null"`
+	Amount        float64   `json:"amount" gorm:"type:numeric(15,2);not null"`
+	PaidAt        time.Time `json:"paid_at" gorm:"not null"`
+	Reference     string    `json:"reference" gorm:"type:varchar(100)"`
+	Notes         string    `json:"notes" gorm:"type:text"`
+	RecordedBy    int       `json:"recorded_by" gorm:"not null"`
+	Base
+
+	User User `json:"user" gorm:"foreignKey:RecordedBy"`
+}
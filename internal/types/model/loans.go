@@ -0,0 +1,24 @@
+package model
+
+import "time"
+
+// Loan is opened when a funding application is approved. The terms are copied
+// from the application and its program so later program edits leave it alone.
+type Loan struct {
+	ID             int       `json:"id" gorm:"primary_key"`
+	ApplicationID  int       `json:"application_id" gorm:"not null;unique"`
+	Principal      float64   `json:"principal" gorm:"type:numeric(15,2);not null"`
+	InterestRate   float64   `json:"interest_rate" gorm:"type:numeric(5,2);not null;default:0"`
+	InterestMethod string    `json:"interest_method" gorm:"type:varchar(20);not null"`
+	TenureMonths   int       `json:"tenure_months" gorm:"not null"`
+	TotalInterest  float64   `json:"total_interest" gorm:"type:numeric(15,2);not null"`
+	TotalPayable   float64   `json:"total_payable" gorm:"type:numeric(15,2);not null"`
+	PaidAmount     float64   `json:"paid_amount" gorm:"type:numeric(15,2);not null;default:0"`
+	Status         string    `json:"status" gorm:"type:loan_status;not null;default:'active'"`
+	StartDate      time.Time `json:"start_date" gorm:"type:date;not null"`
+	Base
+
+	Application  Application       `json:"application" gorm:"foreignKey:ApplicationID"`
+	Installments []LoanInstallment `json:"installments" gorm:"foreignKey:LoanID"`
+	Repayments   []LoanRepayment   `json:"repayments" gorm:"foreignKey:LoanID"`
+}
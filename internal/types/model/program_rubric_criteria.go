@@ -0,0 +1,18 @@
+package model
+
+// ProgramRubricCriterion is one criterion of a program's scoring rubric.
+type ProgramRubricCriterion struct {
+	ID          int     `json:"id" gorm:"primary_key"`
+	ProgramID   int     `json:"program_id" gorm:"not null"`
+	Name        string  `json:"name" gorm:"type:varchar(255);not null"`
+	Description string  `json:"description" gorm:"type:text"`
+	Weight      float64 `json:"weight" gorm:"type:numeric(5,2);not null"`
+	MinScore    float64 `json:"min_score" gorm:"type:numeric(7,2);not null;default:0"`
+	MaxScore    float64 `json:"max_score" gorm:"type:numeric(7,2);not null"`
+	SortOrder   int     `json:"sort_order" gorm:"not null;default:0"`
+	Base
+}
+
+func (ProgramRubricCriterion) TableName() string {
+	return "program_rubric_criteria"
+}
@@ -1,9 +1,32 @@
 package model
 
+import "time"
+
+// SLA is one versioned turnaround rule for a review stage. A rule without
+// ApplicationType and ProgramID is the stage default; ApplicationType narrows it
+// to a program type and ProgramID to a single program.
 type SLA struct {
-	ID          int    `json:"id" gorm:"primary_key"`
-	Status      string `json:"status" gorm:"type:varchar(50);not null;unique"`
-	MaxDays     int    `json:"max_days" gorm:"not null"`
-	Description string `json:"description" gorm:"type:text"`
+	ID              int       `json:"id" gorm:"primary_key"`
+	Status          string    `json:"status" gorm:"type:varchar(50);not null"`
+	ApplicationType *string   `json:"application_type" gorm:"type:program_type"`
+	ProgramID       *int      `json:"program_id"`
+	MaxDays         int       `json:"max_days" gorm:"not null"`
+	DayType         string    `json:"day_type" gorm:"type:varchar(20);not null;default:'calendar'"`
+	EffectiveFrom   time.Time `json:"effective_from" gorm:"not null"`
+	Description     string    `json:"description" gorm:"type:text"`
+	CreatedBy       *int      `json:"created_by"`
 	Base
 }
+
+type SLAChangeLog struct {
+	ID        int    `json:"id" gorm:"primary_key"`
+	SLAID     int    `json:"sla_id" gorm:"column:sla_id;not null"`
+	Action    string `json:"action" gorm:"type:varchar(20);not null"`
+	OldValue  string `json:"old_value" gorm:"type:jsonb"` // Store as JSON string
+	NewValue  string `json:"new_value" gorm:"type:jsonb"` // Store as JSON string
+	ChangedBy *int   `json:"changed_by"`
+	Base
+
+	SLA  SLA   `json:"sla" gorm:"foreignKey:SLAID"`
+	User *User `json:"user" gorm:"foreignKey:ChangedBy"`
+}
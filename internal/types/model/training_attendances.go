@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+type TrainingAttendance struct {
+	ID            int        `json:"id" gorm:"primary_key"`
+	SessionID     int        `json:"session_id" gorm:"not null"`
+	ApplicationID int        `json:"application_id" gorm:"not null"`
+	Status        string     `json:"status" gorm:"type:attendance_status;not null"`
+	CheckedInAt   *time.Time `json:"checked_in_at"`
+	Notes         string     `json:"notes" gorm:"type:text"`
+	RecordedBy    *int       `json:"recorded_by"`
+	Base
+
+	Session TrainingSession `json:"session" gorm:"foreignKey:SessionID"`
+}
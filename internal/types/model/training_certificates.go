@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+type TrainingCertificate struct {
+	ID                int       `json:"id" gorm:"primary_key"`
+	ApplicationID     int       `json:"application_id" gorm:"not null;unique"`
+	CertificateNumber string    `json:"certificate_number" gorm:"type:varchar(50);not null;unique"`
+	VerificationCode  string    `json:"verification_code" gorm:"type:varchar(32);not null;unique"`
+	AttendancePercent float64   `json:"attendance_percent" gorm:"type:numeric(5,2);not null"`
+	FileURL           string    `json:"file_url" gorm:"type:varchar(255)"`
+	IssuedAt          time.Time `json:"issued_at" gorm:"not null"`
+	Base
+
+	Application Application `json:"application" gorm:"foreignKey:ApplicationID"`
+}
@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+type TrainingSession struct {
+	ID         int       `json:"id" gorm:"primary_key"`
+	ProgramID  int       `json:"program_id" gorm:"not null"`
+	Title      string    `json:"title" gorm:"type:varchar(255);not null"`
+	Mode       string    `json:"mode" gorm:"type:training_type;not null"`
+	StartsAt   time.Time `json:"starts_at" gorm:"not null"`
+	EndsAt     time.Time `json:"ends_at" gorm:"not null"`
+	Location   *string   `json:"location" gorm:"type:varchar(255)"`
+	MeetingURL *string   `json:"meeting_url" gorm:"type:varchar(255)"`
+	Base
+
+	Program Program `json:"program" gorm:"foreignKey:ProgramID"`
+}
@@ -24,6 +24,12 @@ type UMKM struct {
 	KartuNumber    string    `json:"kartu_number" gorm:"type:text"`
 	Photo          string    `json:"photo" gorm:"type:text"`
 	QRCode         string    `json:"qr_code" gorm:"type:text"`
+	QRTokenVersion int       `json:"qr_token_version" gorm:"not null;default:0"`
+
+	// Kartu lifecycle; an active kartu past its expiry date counts as expired
+	KartuStatus     string     `json:"kartu_status" gorm:"type:kartu_status;not null;default:'active'"`
+	KartuIssuedDate *time.Time `json:"kartu_issued_date" gorm:"type:date"`
+	KartuExpiryDate *time.Time `json:"kartu_expiry_date" gorm:"type:date"`
 	Base
 
 	User         User          `json:"user" gorm:"foreignKey:UserID"`
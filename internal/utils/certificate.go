@@ -0,0 +1,166 @@
+package utils
+
+import (
+	"bytes"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/skip2/go-qrcode"
+)
+
+// ~ CertificateLine is one centered line of text on a certificate
+type CertificateLine struct {
+	Text string
+	Size float64
+	Bold bool
+	Gap  float64 // space above the line, in points
+}
+
+// ~ CertificatePDF is the content of a one-page landscape A4 certificate
+type CertificatePDF struct {
+	Lines  []CertificateLine
+	Footer []string // printed small at the bottom left
+	QRData string   // printed as a QR code at the bottom right when set
+}
+
+const (
+	certificatePageWidth  = 842.0
+	certificatePageHeight = 595.0
+)
+
+// ~ GenerateCertificatePDF renders a certificate as a PDF document using the standard Helvetica fonts
+// ~ Text outside Latin-1 is replaced with '?', since the standard fonts only cover WinAnsiEncoding.
+func GenerateCertificatePDF(cert CertificatePDF) ([]byte, error) {
+	var content bytes.Buffer
+
+	// Double border
+	content.WriteString("0.12 0.29 0.49 RG 3 w 24 24 794 547 re S 1 w 32 32 778 531 re S 0 0 0 RG\n")
+
+	y := certificatePageHeight - 80
+	for _, line := range cert.Lines {
+		y -= line.Gap + line.Size
+		font := "F1"
+		if line.Bold {
+			font = "F2"
+		}
+		x := (certificatePageWidth - textWidth(line.Text, line.Size, line.Bold)) / 2
+		fmt.Fprintf(&content, "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", font, line.Size, x, y, pdfText(line.Text))
+	}
+
+	footerY := 60.0 + float64(len(cert.Footer)-1)*14
+	for _, text := range cert.Footer {
+		fmt.Fprintf(&content, "BT /F1 10 Tf 60 %.2f Td (%s) Tj ET\n", footerY, pdfText(text))
+		footerY -= 14
+	}
+
+	if cert.QRData != "" {
+		qr, err := qrcode.New(cert.QRData, qrcode.Medium)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate QR code: %w", err)
+		}
+		qr.DisableBorder = true
+		bitmap := qr.Bitmap()
+
+		// Dark modules are drawn as filled squares from the top-left corner
+		const size, left, bottom = 100.0, 682.0, 50.0
+		module := size / float64(len(bitmap))
+		for row, cells := range bitmap {
+			for col, dark := range cells {
+				if dark {
+					fmt.Fprintf(&content, "%.3f %.3f %.3f %.3f re\n", left+float64(col)*module, bottom+size-float64(row+1)*module, module, module)
+				}
+			}
+		}
+		content.WriteString("f\n")
+	}
+
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] /Resources << /Font << /F1 5 0 R /F2 6 0 R >> >> /Contents 4 0 R >>", certificatePageWidth, certificatePageHeight),
+		fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", content.Len(), content.String()),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>",
+	}
+
+	var document bytes.Buffer
+	document.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = document.Len()
+		fmt.Fprintf(&document, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+
+	xref := document.Len()
+	fmt.Fprintf(&document, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&document, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&document, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+
+	return document.Bytes(), nil
+}
+
+// ~ pdfText encodes a string as Latin-1 and escapes it for a PDF literal string
+func pdfText(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < 32:
+			b.WriteByte(' ')
+		case r > 255:
+			b.WriteByte('?')
+		case r > 127:
+			fmt.Fprintf(&b, "\\%03o", r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// ~ textWidth estimates the printed width of a string in Helvetica, close enough to center a line
+func textWidth(text string, size float64, bold bool) float64 {
+	var units float64
+	for _, r := range text {
+		switch {
+		case r == ' ' || r == 'i' || r == 'l' || r == 'j' || r == '.' || r == ',' || r == ':' || r == '\'':
+			units += 278
+		case r == 'f' || r == 't' || r == 'r' || r == 'I' || r == '-' || r == '(' || r == ')' || r == '/':
+			units += 333
+		case r == 'm' || r == 'M' || r == 'W':
+			units += 833
+		case r == 'w':
+			units += 722
+		case r >= 'A' && r <= 'Z':
+			units += 667
+		default:
+			units += 556
+		}
+	}
+	if bold {
+		units *= 1.05
+	}
+	return units * size / 1000
+}
+
+// ~ GenerateVerificationCode creates a random code from characters that are hard to misread
+// ~ It uses crypto/rand since the code is what proves a certificate is genuine.
+func GenerateVerificationCode(length int) (string, error) {
+	const charset = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+	b := make([]byte, length)
+	for i := range b {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(charset))))
+		if err != nil {
+			return "", fmt.Errorf("failed to generate verification code: %w", err)
+		}
+		b[i] = charset[n.Int64()]
+	}
+	return string(b), nil
+}
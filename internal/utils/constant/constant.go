@@ -17,46 +17,179 @@ const (
 	OTPStatusActive = "active"
 	OTPStatusUsed   = "used"
 
-	ApplicationStatusScreening = "screening"
-	ApplicationStatusRevised   = "revised"
-	ApplicationStatusFinal     = "final"
-	ApplicationStatusApproved  = "approved"
-	ApplicationStatusRejected  = "rejected"
-
-	NotificationSubmitted        = "application_submitted"
-	NotificationApproved         = "screening_approved"
-	NotificationRejected         = "screening_rejected"
-	NotificationRevised          = "screening_revised"
-	NotificationFinalApproved    = "final_approved"
-	NotificationFinalRejected    = "final_rejected"
-	NotificationProgramReminder  = "program_reminder"
-	NotificationDocumentRequired = "document_required"
-	NotificationGeneralInfo      = "general_info"
-
-	NotificationTitleSubmitted        = "Pengajuan Dikirim"
-	NotificationTitleResubmitted      = "Pengajuan Dikirim Ulang"
-	NotificationTitleApproved         = "Pengajuan Disetujui pada Tahap Screening"
-	NotificationTitleRejected         = "Pengajuan Ditolak pada Tahap Screening"
-	NotificationTitleRevised          = "Pengajuan Direvisi pada Tahap Screening"
-	NotificationTitleFinalApproved    = "Pengajuan Disetujui pada Tahap Final"
-	NotificationTitleFinalRejected    = "Pengajuan Ditolak pada Tahap Final"
-	NotificationTitleProgramReminder  = "Pengingat Program"
-	NotificationTitleDocumentRequired = "Dokumen Diperlukan"
-	NotificationTitleGeneralInfo      = "Informasi Umum"
-
-	NotificationMessageSubmitted        = "Pengajuan Anda telah berhasil dikirim. Silakan tunggu proses screening."
-	NotificationMessageResubmitted      = "Pengajuan ulang Anda telah berhasil dikirim. Silakan tunggu proses screening."
-	NotificationMessageApproved         = "Pengajuan Anda telah disetujui pada tahap screening. Silakan menunggu lanjut ke tahap final."
-	NotificationMessageRejected         = "Pengajuan Anda telah ditolak pada tahap screening. Karena %s. Silakan periksa kembali data yang Anda kirim."
-	NotificationMessageRevised          = "Pengajuan Anda perlu direvisi pada tahap screening. Karena %s. Silakan periksa kembali data yang Anda kirim."
-	NotificationMessageFinalApproved    = "Pengajuan Anda telah disetujui pada tahap final. Selamat!"
-	NotificationMessageFinalRejected    = "Pengajuan Anda telah ditolak pada tahap final. Karena %s. Silakan periksa kembali data yang Anda kirim."
-	NotificationMessageProgramReminder  = "Ingatkan program yang akan datang."
-	NotificationMessageDocumentRequired = "Dokumen tambahan diperlukan untuk melanjutkan proses pengajuan."
-	NotificationMessageGeneralInfo      = "Informasi umum terkait program atau aplikasi."
+	ApplicationStatusDraft      = "draft"
+	ApplicationStatusScreening  = "screening"
+	ApplicationStatusRevised    = "revised"
+	ApplicationStatusFinal      = "final"
+	ApplicationStatusApproved   = "approved"
+	ApplicationStatusRejected   = "rejected"
+	ApplicationStatusWithdrawn  = "withdrawn"
+	ApplicationStatusWaitlisted = "waitlisted"
+
+	ApplicationActionSubmit                  = "submit"
+	ApplicationActionRevise                  = "revise"
+	ApplicationActionApproveByAdminScreening = "approve_by_admin_screening"
+	ApplicationActionRejectByAdminScreening  = "reject_by_admin_screening"
+	ApplicationActionApproveByAdminVendor    = "approve_by_admin_vendor"
+	ApplicationActionRejectByAdminVendor     = "reject_by_admin_vendor"
+	ApplicationActionSLABreached             = "sla_breached"
+	ApplicationActionEscalate                = "escalate"
+	ApplicationActionAutoReject              = "auto_reject"
+	ApplicationActionWithdraw                = "withdraw"
+	ApplicationActionWaitlist                = "waitlist"
+	ApplicationActionPromoteFromWaitlist     = "promote_from_waitlist"
+
+	DocumentReviewPending  = "pending"
+	DocumentReviewAccepted = "accepted"
+	DocumentReviewRejected = "rejected"
+
+	SLABreachPolicyNotify   = "notify"
+	SLABreachPolicyEscalate = "escalate"
+	SLABreachPolicyReject   = "reject"
+
+	SLADayTypeCalendar = "calendar"
+	SLADayTypeWorking  = "working"
+
+	SLAChangeActionCreate = "create"
+	SLAChangeActionUpdate = "update"
+	SLAChangeActionDelete = "delete"
+
+	ReviewAssignmentRoundRobin  = "round_robin"
+	ReviewAssignmentLeastLoaded = "least_loaded"
+
+	NotificationSubmitted             = "application_submitted"
+	NotificationApproved              = "screening_approved"
+	NotificationRejected              = "screening_rejected"
+	NotificationRevised               = "screening_revised"
+	NotificationFinalApproved         = "final_approved"
+	NotificationFinalRejected         = "final_rejected"
+	NotificationProgramReminder       = "program_reminder"
+	NotificationDocumentRequired      = "document_required"
+	NotificationGeneralInfo           = "general_info"
+	NotificationAutoRejected          = "auto_rejected"
+	NotificationWithdrawn             = "application_withdrawn"
+	NotificationWaitlisted            = "application_waitlisted"
+	NotificationCertificateIssued     = "certificate_issued"
+	NotificationCertificationRecorded = "certification_recorded"
+	NotificationCertificationExpiring = "certification_expiring"
+	NotificationKartuRenewed          = "kartu_renewed"
+	NotificationKartuRenewalRejected  = "kartu_renewal_rejected"
+	NotificationKartuSuspended        = "kartu_suspended"
+	NotificationKartuReactivated      = "kartu_reactivated"
+	NotificationKartuUpgraded         = "kartu_upgraded"
+	NotificationKartuExpired          = "kartu_expired"
+
+	NotificationTitleSubmitted             = "Pengajuan Dikirim"
+	NotificationTitleResubmitted           = "Pengajuan Dikirim Ulang"
+	NotificationTitleApproved              = "Pengajuan Disetujui pada Tahap Screening"
+	NotificationTitleRejected              = "Pengajuan Ditolak pada Tahap Screening"
+	NotificationTitleRevised               = "Pengajuan Direvisi pada Tahap Screening"
+	NotificationTitleFinalApproved         = "Pengajuan Disetujui pada Tahap Final"
+	NotificationTitleFinalRejected         = "Pengajuan Ditolak pada Tahap Final"
+	NotificationTitleProgramReminder       = "Pengingat Program"
+	NotificationTitleDocumentRequired      = "Dokumen Diperlukan"
+	NotificationTitleGeneralInfo           = "Informasi Umum"
+	NotificationTitleAutoRejected          = "Pengajuan Ditolak Otomatis"
+	NotificationTitleWithdrawn             = "Pengajuan Dibatalkan"
+	NotificationTitleWaitlisted            = "Pengajuan Masuk Daftar Tunggu"
+	NotificationTitlePromoted              = "Pengajuan Disetujui dari Daftar Tunggu"
+	NotificationTitleCertificateIssued     = "Sertifikat Pelatihan Terbit"
+	NotificationTitleCertificationRecorded = "Sertifikat Tercatat"
+	NotificationTitleCertificationExpiring = "Sertifikat Akan Berakhir"
+	NotificationTitleKartuRenewed          = "Kartu Diperpanjang"
+	NotificationTitleKartuRenewalRejected  = "Perpanjangan Kartu Ditolak"
+	NotificationTitleKartuSuspended        = "Kartu Dibekukan"
+	NotificationTitleKartuReactivated      = "Kartu Diaktifkan Kembali"
+	NotificationTitleKartuUpgraded         = "Kartu Ditingkatkan"
+	NotificationTitleKartuExpired          = "Kartu Berakhir"
+
+	NotificationMessageSubmitted             = "Pengajuan Anda telah berhasil dikirim. Silakan tunggu proses screening."
+	NotificationMessageResubmitted           = "Pengajuan ulang Anda telah berhasil dikirim. Silakan tunggu proses screening."
+	NotificationMessageApproved              = "Pengajuan Anda telah disetujui pada tahap screening. Silakan menunggu lanjut ke tahap final."
+	NotificationMessageRejected              = "Pengajuan Anda telah ditolak pada tahap screening. Karena %s. Silakan periksa kembali data yang Anda kirim."
+	NotificationMessageRevised               = "Pengajuan Anda perlu direvisi pada tahap screening. Karena %s. Silakan periksa kembali data yang Anda kirim."
+	NotificationMessageFinalApproved         = "Pengajuan Anda telah disetujui pada tahap final. Selamat!"
+	NotificationMessageFinalRejected         = "Pengajuan Anda telah ditolak pada tahap final. Karena %s. Silakan periksa kembali data yang Anda kirim."
+	NotificationMessageProgramReminder       = "Ingatkan program yang akan datang."
+	NotificationMessageDocumentRequired      = "Dokumen tambahan diperlukan untuk melanjutkan proses pengajuan."
+	NotificationMessageGeneralInfo           = "Informasi umum terkait program atau aplikasi."
+	NotificationMessageAutoRejected          = "Pengajuan Anda ditolak secara otomatis karena melewati batas waktu proses (SLA)."
+	NotificationMessageWithdrawn             = "Pengajuan Anda telah dibatalkan. Anda dapat mengajukan kembali program ini selama pendaftaran masih dibuka."
+	NotificationMessageWaitlisted            = "Pengajuan Anda telah disetujui, namun kuota program sudah penuh. Anda masuk daftar tunggu dan akan disetujui otomatis saat kuota tersedia."
+	NotificationMessagePromoted              = "Kuota program telah tersedia dan pengajuan Anda dari daftar tunggu telah disetujui. Selamat!"
+	NotificationMessageCertificateIssued     = "Selamat, Anda telah menyelesaikan pelatihan %s dengan kehadiran %.0f%%. Sertifikat Anda dapat diunduh di menu Sertifikat Saya."
+	NotificationMessageCertificationRecorded = "Sertifikat %s Anda dengan nomor %s dari %s telah tercatat dan dapat diverifikasi publik."
+	NotificationMessageCertificationExpiring = "Sertifikat %s Anda dengan nomor %s akan berakhir pada %s (%d hari lagi). Segera ajukan perpanjangan kepada %s."
+	NotificationMessageKartuRenewed          = "Kartu %s Anda telah diperpanjang dan berlaku hingga %s."
+	NotificationMessageKartuRenewalRejected  = "Permintaan perpanjangan kartu Anda ditolak. Karena %s."
+	NotificationMessageKartuSuspended        = "Kartu %s Anda dibekukan sementara. Karena %s. Selama dibekukan Anda tidak dapat mengajukan program."
+	NotificationMessageKartuReactivated      = "Kartu %s Anda telah diaktifkan kembali dan dapat digunakan untuk mengajukan program."
+	NotificationMessageKartuUpgraded         = "Selamat, kartu Anda telah ditingkatkan dari Kartu %s menjadi Kartu %s."
+	NotificationMessageKartuExpired          = "Kartu %s Anda telah berakhir pada %s. Ajukan perpanjangan melalui menu Kartu agar tetap dapat mengajukan program."
+
+	AdminNotificationSLABreached    = "sla_breached"
+	AdminNotificationSLAEscalated   = "sla_escalated"
+	AdminNotificationReviewAssigned = "review_assigned"
+	AdminNotificationWithdrawn      = "application_withdrawn"
+
+	AdminNotificationTitleSLABreached    = "Pengajuan Melewati Batas SLA"
+	AdminNotificationTitleSLAEscalated   = "Eskalasi Pengajuan Melewati SLA"
+	AdminNotificationTitleReviewAssigned = "Pengajuan Ditugaskan kepada Anda"
+	AdminNotificationTitleWithdrawn      = "Pengajuan Dibatalkan oleh Pelaku Usaha"
+
+	AdminNotificationMessageSLABreached    = "Pengajuan #%d (%s) pada tahap %s telah melewati batas waktu %s. Segera lakukan peninjauan."
+	AdminNotificationMessageSLAEscalated   = "Pengajuan #%d (%s) pada tahap %s belum ditinjau hingga batas waktu %s dan dieskalasi ke Anda."
+	AdminNotificationMessageReviewAssigned = "Pengajuan #%d (%s) pada tahap %s ditugaskan kepada Anda dengan batas waktu %s."
+	AdminNotificationMessageWithdrawn      = "Pengajuan #%d (%s) pada tahap %s dibatalkan oleh pelaku usaha. Alasan: %s"
 
 	DocumentTypeNib            = "nib"
 	DocumentTypeNPWP           = "npwp"
 	DocumentTypeRevenueRecord  = "revenue_record"
 	DocumentTypeBusinessPermit = "business_permit"
+
+	ProgramClosedDeleted        = "program_deleted"
+	ProgramClosedInactive       = "program_inactive"
+	ProgramClosedDeadlinePassed = "deadline_passed"
+	ProgramClosedBatchStarted   = "batch_started"
+
+	InterestMethodFlat    = "flat"
+	InterestMethodAnnuity = "annuity"
+
+	LoanStatusActive    = "active"
+	LoanStatusPaidOff   = "paid_off"
+	LoanStatusCancelled = "cancelled"
+
+	InstallmentStatusUnpaid  = "unpaid"
+	InstallmentStatusPartial = "partial"
+	InstallmentStatusPaid    = "paid"
+	// InstallmentStatusOverdue is reported, never stored: an unpaid installment past its due date
+	InstallmentStatusOverdue = "overdue"
+
+	SessionModeOnline  = "online"
+	SessionModeOffline = "offline"
+
+	AttendanceStatusPresent = "present"
+	AttendanceStatusAbsent  = "absent"
+	AttendanceStatusExcused = "excused"
+
+	CertificationStatusValid    = "valid"
+	CertificationStatusExpiring = "expiring"
+	CertificationStatusExpired  = "expired"
+
+	KartuTypeProduktif = "produktif"
+	KartuTypeAfirmatif = "afirmatif"
+
+	KartuStatusActive    = "active"
+	KartuStatusSuspended = "suspended"
+	KartuStatusExpired   = "expired"
+
+	KartuActionRenewed     = "renewed"
+	KartuActionSuspended   = "suspended"
+	KartuActionReactivated = "reactivated"
+	KartuActionUpgraded    = "upgraded"
+	KartuActionExpired     = "expired"
+
+	KartuRenewalStatusPending  = "pending"
+	KartuRenewalStatusApproved = "approved"
+	KartuRenewalStatusRejected = "rejected"
 )
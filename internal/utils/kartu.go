@@ -0,0 +1,54 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"UMKMGo-backend/config/env"
+)
+
+// kartuSignatureSize is the number of HMAC-SHA256 bytes kept in a kartu token,
+// truncated so the token still fits a low density QR code.
+const kartuSignatureSize = 16
+
+// ~ GenerateKartuToken signs the UMKM ID and QR version printed in a kartu QR code
+// ~ The token reads "<umkm id>.<version>.<signature>"; bumping the UMKM's QR version revokes it.
+func GenerateKartuToken(umkmID, version int) string {
+	payload := fmt.Sprintf("%d.%d", umkmID, version)
+	return payload + "." + kartuSignature(payload)
+}
+
+// ~ VerifyKartuToken checks the signature of a kartu token and returns the UMKM ID and QR version it holds
+func VerifyKartuToken(token string) (int, int, error) {
+	parts := strings.Split(strings.TrimSpace(token), ".")
+	if len(parts) != 3 {
+		return 0, 0, errors.New("invalid kartu QR code")
+	}
+
+	payload := parts[0] + "." + parts[1]
+	if !hmac.Equal([]byte(parts[2]), []byte(kartuSignature(payload))) {
+		return 0, 0, errors.New("invalid kartu QR code")
+	}
+
+	umkmID, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, errors.New("invalid kartu QR code")
+	}
+	version, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, errors.New("invalid kartu QR code")
+	}
+
+	return umkmID, version, nil
+}
+
+func kartuSignature(payload string) string {
+	mac := hmac.New(sha256.New, []byte(env.Cfg.Kartu.QRSecret))
+	mac.Write([]byte("kartu:" + payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil)[:kartuSignatureSize])
+}